@@ -14,6 +14,7 @@ import (
 	"github.com/tjanas94/vibefeeder/internal/shared/config"
 	"github.com/tjanas94/vibefeeder/internal/shared/database"
 	"github.com/tjanas94/vibefeeder/internal/shared/logger"
+	"github.com/tjanas94/vibefeeder/internal/shared/tracing"
 )
 
 func main() {
@@ -27,6 +28,13 @@ func main() {
 	// Initialize logger
 	log := logger.New(cfg)
 
+	// Initialize tracing (no-op unless TRACING_EXPORTER is set)
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.Error("Failed to initialize tracing", "error", err)
+		os.Exit(1)
+	}
+
 	// Initialize database client
 	db, err := database.New(cfg)
 	if err != nil {
@@ -64,8 +72,12 @@ func main() {
 	go c.FeedFetcher.Start()
 	log.Info("Feed fetcher service started")
 
+	// Start article retention job in background
+	go c.RetentionJob.Start()
+	log.Info("Article retention job started")
+
 	// Channel to capture server errors
-	serverErrors := make(chan error, 1)
+	serverErrors := make(chan error, 2)
 
 	// Start HTTP server in a goroutine
 	go func() {
@@ -74,6 +86,13 @@ func main() {
 		}
 	}()
 
+	// Start metrics server (only when METRICS_ADDRESS is set)
+	go func() {
+		if err := application.StartMetrics(); err != nil && err != http.ErrServerClosed {
+			serverErrors <- err
+		}
+	}()
+
 	// Wait for interrupt signal or server error
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
@@ -102,5 +121,10 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Flush spans still buffered by the exporter
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		log.Error("Failed to flush traces", "error", err)
+	}
+
 	log.Info("Application exited cleanly")
 }
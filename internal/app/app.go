@@ -14,6 +14,7 @@ import (
 // App holds the Echo server and container
 type App struct {
 	Echo      *echo.Echo
+	Metrics   *echo.Echo // separate metrics server, nil unless METRICS_ADDRESS is set
 	Container *container.Container
 }
 
@@ -52,6 +53,9 @@ func New(c *container.Container) (*App, error) {
 	// Setup routes
 	app.setupRoutes()
 
+	// Setup metrics endpoint (main server or separate listener)
+	app.setupMetrics()
+
 	return app, nil
 }
 
@@ -61,8 +65,25 @@ func (a *App) Start() error {
 	return a.Echo.Start(a.Container.Config.Server.Address)
 }
 
+// StartMetrics starts the separate metrics server
+// Returns nil immediately when metrics are served by the main server or disabled
+func (a *App) StartMetrics() error {
+	if a.Metrics == nil {
+		return nil
+	}
+
+	a.Container.Logger.Info("Starting metrics server", "address", a.Container.Config.Metrics.Address)
+	return a.Metrics.Start(a.Container.Config.Metrics.Address)
+}
+
 // Shutdown gracefully shuts down the application
 func (a *App) Shutdown(ctx context.Context) error {
+	if a.Metrics != nil {
+		if err := a.Metrics.Shutdown(ctx); err != nil {
+			a.Container.Logger.Error("Error shutting down metrics server", "error", err)
+		}
+	}
+
 	a.Container.Logger.Info("Shutting down HTTP server...")
 
 	// Shutdown Echo server
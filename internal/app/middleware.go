@@ -2,15 +2,32 @@ package app
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/tjanas94/vibefeeder/internal/shared/csrf"
 	"github.com/tjanas94/vibefeeder/internal/shared/logger"
+	"github.com/tjanas94/vibefeeder/internal/shared/metrics"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
 )
 
+// contentSecurityPolicy restricts scripts and styles to the application itself
+// Images and media are loaded from the sites of the feeds: lead images, audio/video enclosures,
+// images of article content and of saved snapshots (media URLs of feeds are http or https)
+const contentSecurityPolicy = "default-src 'self'; script-src 'self' 'unsafe-eval' 'unsafe-inline'; style-src 'self' 'unsafe-inline'; " +
+	"img-src 'self' data: http: https:; media-src http: https:"
+
 // setupMiddleware configures all application middleware
 func (a *App) setupMiddleware() {
+	// Metrics first, so request latency covers the whole chain
+	if a.Container.Config.Metrics.Enabled() {
+		a.Echo.Use(metrics.Middleware())
+	}
+
+	// Tracing before the request logger, so logged requests carry the trace ID
+	a.Echo.Use(otelecho.Middleware(a.Container.Config.Tracing.ServiceName, otelecho.WithSkipper(skipTracing)))
+
 	// Core middleware
 	a.Echo.Use(logger.RequestLoggerConfig(a.Container.Logger))
 	a.Echo.Use(middleware.Recover())
@@ -21,7 +38,7 @@ func (a *App) setupMiddleware() {
 		XSSProtection:         "1; mode=block",
 		ContentTypeNosniff:    "nosniff",
 		XFrameOptions:         "DENY",
-		ContentSecurityPolicy: "default-src 'self'; script-src 'self' 'unsafe-eval' 'unsafe-inline'; style-src 'self' 'unsafe-inline'; img-src 'self' data:",
+		ContentSecurityPolicy: contentSecurityPolicy,
 		ReferrerPolicy:        "strict-origin-when-cross-origin",
 	}))
 
@@ -34,6 +51,16 @@ func (a *App) setupMiddleware() {
 	})
 }
 
+// skipTracing excludes static assets, probes and metrics scrapes from tracing
+func skipTracing(c echo.Context) bool {
+	switch path := c.Request().URL.Path; path {
+	case "/livez", "/readyz", "/healthz", "/metrics":
+		return true
+	default:
+		return strings.HasPrefix(path, "/static/")
+	}
+}
+
 // csrfMiddleware returns configured CSRF protection middleware
 func (a *App) csrfMiddleware() echo.MiddlewareFunc {
 	return middleware.CSRFWithConfig(middleware.CSRFConfig{
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// cspSources returns the sources of a directive, falling back to default-src like browsers do
+func cspSources(policy, directive string) []string {
+	directives := make(map[string][]string)
+	for _, part := range strings.Split(policy, ";") {
+		fields := strings.Fields(part)
+		if len(fields) > 0 {
+			directives[fields[0]] = fields[1:]
+		}
+	}
+	if sources, ok := directives[directive]; ok {
+		return sources
+	}
+	return directives["default-src"]
+}
+
+// cspAllows reports whether a directive allows loading the URL (self stands for a relative URL)
+func cspAllows(policy, directive, rawURL string) bool {
+	sources := cspSources(policy, directive)
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	if parsed.Scheme == "" {
+		return slices.Contains(sources, "'self'")
+	}
+	return slices.Contains(sources, parsed.Scheme+":")
+}
+
+// TestContentSecurityPolicyAllowsFeedMedia tests that the policy allows the media rendered by the templates
+func TestContentSecurityPolicyAllowsFeedMedia(t *testing.T) {
+	tests := []struct {
+		name      string
+		directive string
+		url       string
+	}{
+		{name: "lead image of an article", directive: "img-src", url: "https://cdn.example.com/lead.jpg"},
+		{name: "image of a plain http feed", directive: "img-src", url: "http://blog.example.com/photo.png"},
+		{name: "image of a saved snapshot", directive: "img-src", url: "https://example.com/snapshot.webp"},
+		{name: "cached feed favicon", directive: "img-src", url: "/feeds/1/favicon"},
+		{name: "inline image", directive: "img-src", url: "data:image/png;base64,AAAA"},
+		{name: "audio enclosure", directive: "media-src", url: "https://podcast.example.com/episode.mp3"},
+		{name: "video enclosure", directive: "media-src", url: "http://video.example.com/clip.mp4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.True(t, cspAllows(contentSecurityPolicy, tt.directive, tt.url))
+		})
+	}
+}
+
+// TestContentSecurityPolicyRestrictsScripts tests that scripts and other resources stay limited to the application
+func TestContentSecurityPolicyRestrictsScripts(t *testing.T) {
+	assert.False(t, cspAllows(contentSecurityPolicy, "script-src", "https://cdn.example.com/script.js"))
+	assert.False(t, cspAllows(contentSecurityPolicy, "connect-src", "https://api.example.com/"))
+	assert.False(t, cspAllows(contentSecurityPolicy, "frame-src", "https://example.com/embed"))
+	assert.True(t, cspAllows(contentSecurityPolicy, "script-src", "/static/app.js"))
+}
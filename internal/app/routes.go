@@ -6,14 +6,17 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/tjanas94/vibefeeder/internal/shared/auth"
+	"github.com/tjanas94/vibefeeder/internal/shared/metrics"
 )
 
 // setupRoutes configures all application routes
 func (a *App) setupRoutes() {
 	c := a.Container
 
-	// Health check endpoint (public)
-	a.Echo.GET("/healthz", a.healthCheck)
+	// Health probes (public)
+	a.Echo.GET("/livez", c.HealthHandler.Livez)
+	a.Echo.GET("/readyz", c.HealthHandler.Readyz)
+	a.Echo.GET("/healthz", c.HealthHandler.Readyz) // Kept for existing deployments, same as /readyz
 
 	// Public routes (no authentication required)
 	publicGroup := a.Echo.Group("/auth")
@@ -27,6 +30,10 @@ func (a *App) setupRoutes() {
 	publicGroup.GET("/reset-password", c.AuthHandler.ShowResetPasswordPage)
 	publicGroup.POST("/reset-password", c.AuthHandler.HandleResetPassword)
 
+	// WebSub hub callbacks (public, authenticated by subscription secret)
+	a.Echo.GET("/websub/:feedId", c.FetcherHandler.HandleWebSubVerification)
+	a.Echo.POST("/websub/:feedId", c.FetcherHandler.HandleWebSubNotification)
+
 	// Protected routes (authentication required)
 	protectedGroup := a.Echo.Group("")
 	protectedGroup.Use(auth.AuthMiddleware(c.AuthService, c.SessionManager, c.Logger))
@@ -47,13 +54,44 @@ func (a *App) setupRoutes() {
 	protectedGroup.GET("/feeds", c.FeedHandler.ListFeeds)
 	protectedGroup.GET("/feeds/new", c.FeedHandler.HandleFeedAddForm)
 	protectedGroup.POST("/feeds", c.FeedHandler.CreateFeed)
+	protectedGroup.GET("/feeds/import", c.FeedHandler.HandleImportForm)
+	protectedGroup.POST("/feeds/import", c.FeedHandler.ImportFeeds)
+	protectedGroup.GET("/feeds/export", c.FeedHandler.ExportFeeds)
 	protectedGroup.GET("/feeds/:id/edit", c.FeedHandler.HandleFeedEditForm)
 	protectedGroup.PATCH("/feeds/:id", c.FeedHandler.HandleUpdate)
+	protectedGroup.GET("/feeds/:id/history", c.FeedHandler.HandleFeedHistory)
+	protectedGroup.GET("/feeds/:id/favicon", c.FeedHandler.HandleFavicon)
 	protectedGroup.GET("/feeds/:id/delete", c.FeedHandler.HandleDeleteConfirmation)
 	protectedGroup.DELETE("/feeds/:id", c.FeedHandler.DeleteFeed)
 
-	// Summary routes with rate limiting
+	// Article timeline routes
+	protectedGroup.GET("/timeline", c.ArticleHandler.ShowTimeline)
+	protectedGroup.GET("/articles", c.ArticleHandler.ListArticles)
+	protectedGroup.POST("/articles/read", c.ArticleHandler.MarkArticlesRead)
+	protectedGroup.GET("/articles/:id/open", c.ArticleHandler.OpenArticle)
+	protectedGroup.GET("/articles/:id/content", c.ArticleHandler.ReadArticle)
+	protectedGroup.POST("/articles/:id/star", c.ArticleHandler.StarArticle)
+	protectedGroup.DELETE("/articles/:id/star", c.ArticleHandler.UnstarArticle)
+
+	// Saved article routes
+	protectedGroup.GET("/saved", c.ArticleHandler.ShowSaved)
+	protectedGroup.GET("/articles/saved", c.ArticleHandler.ListSavedArticles)
+	protectedGroup.DELETE("/articles/saved/:id", c.ArticleHandler.DeleteSavedArticle)
+
+	// Settings and mute rule routes
+	protectedGroup.GET("/settings", c.SettingsHandler.ShowSettings)
+	protectedGroup.GET("/settings/retention", c.SettingsHandler.GetRetentionForm)
+	protectedGroup.PUT("/settings/retention", c.SettingsHandler.UpdateRetention)
+	protectedGroup.GET("/mute-rules", c.MuteHandler.ListRules)
+	protectedGroup.POST("/mute-rules", c.MuteHandler.CreateRule)
+	protectedGroup.DELETE("/mute-rules/:id", c.MuteHandler.DeleteRule)
+
+	// Summary and summary archive routes, generation is rate limited
 	protectedGroup.GET("/summaries/latest", c.SummaryHandler.GetLatestSummary)
+	protectedGroup.GET("/summaries", c.SummaryHandler.ShowArchive)
+	protectedGroup.GET("/summaries/list", c.SummaryHandler.ListSummaries)
+	protectedGroup.GET("/summaries/compare", c.SummaryHandler.CompareSummaries)
+	protectedGroup.GET("/summaries/:id", c.SummaryHandler.ShowSummary)
 	protectedGroup.POST("/summaries", c.SummaryHandler.GenerateSummary, middleware.RateLimiterWithConfig(middleware.RateLimiterConfig{
 		Store: c.RateLimiterStore,
 		IdentifierExtractor: func(ctx echo.Context) (string, error) {
@@ -63,16 +101,28 @@ func (a *App) setupRoutes() {
 	}))
 }
 
-// healthCheck handler checks application and database health
-func (a *App) healthCheck(c echo.Context) error {
-	if err := a.Container.DB.Health(); err != nil {
-		return c.JSON(http.StatusServiceUnavailable, map[string]string{
-			"status": "unhealthy",
-			"error":  err.Error(),
-		})
+// setupMetrics exposes Prometheus metrics at /metrics
+// A separate listen address keeps the endpoint off the public server; otherwise a bearer token is required
+func (a *App) setupMetrics() {
+	cfg := a.Container.Config.Metrics
+	if !cfg.Enabled() {
+		return
 	}
 
-	return c.JSON(http.StatusOK, map[string]string{
-		"status": "healthy",
-	})
+	var middlewares []echo.MiddlewareFunc
+	if cfg.Token != "" {
+		middlewares = append(middlewares, metrics.TokenAuth(cfg.Token))
+	}
+
+	handler := echo.WrapHandler(metrics.Handler())
+	if cfg.Address == "" {
+		a.Echo.GET("/metrics", handler, middlewares...)
+		return
+	}
+
+	a.Metrics = echo.New()
+	a.Metrics.HideBanner = true
+	a.Metrics.HidePort = true
+	a.Metrics.Use(middleware.Recover())
+	a.Metrics.GET("/metrics", handler, middlewares...)
 }
@@ -0,0 +1,7 @@
+package article
+
+// pageSize defines how many articles are shown per page of the timeline.
+// Used by:
+//   - repository.go (offset calculation, Range query)
+//   - service.go (BuildPagination call)
+const pageSize = 20
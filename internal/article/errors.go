@@ -0,0 +1,26 @@
+package article
+
+import (
+	"net/http"
+
+	sharederrors "github.com/tjanas94/vibefeeder/internal/shared/errors"
+)
+
+// NewArticleNotFoundError creates a ServiceError when an article is not found or doesn't belong to the user
+// Returns 404 Not Found
+func NewArticleNotFoundError() *sharederrors.ServiceError {
+	return sharederrors.NewServiceError(
+		http.StatusNotFound,
+		"Article not found",
+	)
+}
+
+// NewDatabaseError creates a ServiceError for database operation failures
+// Returns 500 Internal Server Error
+func NewDatabaseError(err error) *sharederrors.ServiceError {
+	return sharederrors.NewServiceErrorWithCause(
+		http.StatusInternalServerError,
+		"Database operation failed",
+		err,
+	)
+}
@@ -0,0 +1,307 @@
+package article
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/tjanas94/vibefeeder/internal/article/models"
+	"github.com/tjanas94/vibefeeder/internal/article/view"
+	"github.com/tjanas94/vibefeeder/internal/shared/auth"
+	sharederrors "github.com/tjanas94/vibefeeder/internal/shared/errors"
+	sharedmodels "github.com/tjanas94/vibefeeder/internal/shared/models"
+	sharedview "github.com/tjanas94/vibefeeder/internal/shared/view/components"
+)
+
+// Handler handles HTTP requests for the article timeline
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new article handler
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// ShowTimeline handles GET /timeline endpoint
+// Renders the timeline page with its filters; the articles are loaded by htmx from GET /articles
+func (h *Handler) ShowTimeline(c echo.Context) error {
+	// Bind and sanitize query parameters
+	query := new(models.ListArticlesQuery)
+	_ = c.Bind(query) // Ignore bind errors for query parameters
+
+	// Sanitize and set defaults for invalid/missing values
+	query.SetDefaults()
+
+	// Set user ID from authenticated session
+	query.UserID = auth.GetUserID(c)
+
+	// Load the options of the feed and tag filters
+	vm, err := h.service.GetTimeline(c.Request().Context(), *query)
+	if err != nil {
+		// Errors of a full page are rendered by the global error handler
+		return err
+	}
+
+	vm.Title = "Articles - VibeFeeder"
+	vm.UserEmail = auth.GetUserEmail(c)
+
+	return c.Render(http.StatusOK, "", view.Timeline(*vm))
+}
+
+// ListArticles handles GET /articles endpoint
+// Returns a page of the timeline as an HTML fragment
+func (h *Handler) ListArticles(c echo.Context) error {
+	// Bind and sanitize query parameters
+	query := new(models.ListArticlesQuery)
+	_ = c.Bind(query) // Ignore bind errors for query parameters
+
+	// Sanitize and set defaults for invalid/missing values
+	query.SetDefaults()
+
+	// Set user ID from authenticated session
+	query.UserID = auth.GetUserID(c)
+
+	// Call service to get articles
+	vm, err := h.service.ListArticles(c.Request().Context(), *query)
+	if err != nil {
+		// Path 3: Handle business errors (ServiceError)
+		var serviceErr *sharederrors.ServiceError
+		if errors.As(err, &serviceErr) {
+			errVM := models.ArticleListViewModel{
+				Articles:     []models.ArticleItemViewModel{},
+				ErrorMessage: serviceErr.Message,
+				Pagination:   sharedmodels.PaginationViewModel{},
+			}
+			return c.Render(serviceErr.Code, "", view.List(errVM))
+		}
+
+		// Path 4: Unexpected error - delegate to global error handler
+		return err
+	}
+
+	// Build URL for HX-Push-Url header to update browser history
+	c.Response().Header().Set("HX-Push-Url", buildTimelineURL(*query))
+
+	// Success - render list view with view model
+	return c.Render(http.StatusOK, "", view.List(*vm))
+}
+
+// OpenArticle handles GET /articles/:id/open endpoint
+// Marks the article as read and redirects to the original, so following a timeline link counts as reading it
+func (h *Handler) OpenArticle(c echo.Context) error {
+	// Path 2: Handle validation errors (malformed article ID)
+	articleID := c.Param("id")
+	if uuid.Validate(articleID) != nil {
+		return NewArticleNotFoundError()
+	}
+
+	// Path 3 & 4: errors of a full page are rendered by the global error handler
+	articleURL, err := h.service.OpenArticle(c.Request().Context(), auth.GetUserID(c), articleID)
+	if err != nil {
+		return err
+	}
+
+	return c.Redirect(http.StatusSeeOther, articleURL)
+}
+
+// ReadArticle handles GET /articles/:id/content endpoint
+// Returns the sanitized content of the article as an HTML fragment and marks the article as read
+func (h *Handler) ReadArticle(c echo.Context) error {
+	// Path 2: Handle validation errors (malformed article ID)
+	articleID := c.Param("id")
+	if uuid.Validate(articleID) != nil {
+		return h.renderToast(c, http.StatusNotFound, "error", NewArticleNotFoundError().Message)
+	}
+
+	vm, err := h.service.ReadArticle(c.Request().Context(), auth.GetUserID(c), articleID)
+	if err != nil {
+		// Path 3: Handle business errors (ServiceError)
+		var serviceErr *sharederrors.ServiceError
+		if errors.As(err, &serviceErr) {
+			return h.renderToast(c, serviceErr.Code, "error", serviceErr.Message)
+		}
+
+		// Path 4: Unexpected error - delegate to global error handler
+		return err
+	}
+
+	return c.Render(http.StatusOK, "", view.ArticleContent(*vm))
+}
+
+// MarkArticlesRead handles POST /articles/read endpoint
+// Marks all articles matching the timeline filters (feed, tag, date range) as read
+func (h *Handler) MarkArticlesRead(c echo.Context) error {
+	cmd := new(models.MarkArticlesReadCommand)
+	// Path 1: Handle bind errors (malformed form data)
+	if err := c.Bind(cmd); err != nil {
+		return h.renderToast(c, http.StatusBadRequest, "error", "Invalid form data")
+	}
+
+	// Path 2: Handle validation errors (malformed filters)
+	if err := c.Validate(cmd); err != nil {
+		return h.renderToast(c, http.StatusBadRequest, "error", "Invalid article filters")
+	}
+	cmd.SetDefaults()
+
+	// Get user ID from authenticated session
+	cmd.UserID = auth.GetUserID(c)
+
+	count, err := h.service.MarkArticlesRead(c.Request().Context(), *cmd)
+	if err != nil {
+		// Path 3: Handle business errors (ServiceError)
+		var serviceErr *sharederrors.ServiceError
+		if errors.As(err, &serviceErr) {
+			return h.renderToast(c, serviceErr.Code, "error", serviceErr.Message)
+		}
+
+		// Path 4: Unexpected error - delegate to global error handler
+		return err
+	}
+
+	// Success - refresh the timeline and show toast
+	c.Response().Header().Set("HX-Trigger", `{"refreshArticles": null}`)
+	return h.renderToast(c, http.StatusOK, "success", markedReadMessage(count))
+}
+
+// StarArticle handles POST /articles/:id/star endpoint
+// Saves the article and returns the toggled star button
+func (h *Handler) StarArticle(c echo.Context) error {
+	return h.toggleStar(c, true)
+}
+
+// UnstarArticle handles DELETE /articles/:id/star endpoint
+// Removes the saved article and returns the toggled star button
+func (h *Handler) UnstarArticle(c echo.Context) error {
+	return h.toggleStar(c, false)
+}
+
+// toggleStar stars or unstars the article from the path and renders its star button
+func (h *Handler) toggleStar(c echo.Context, star bool) error {
+	// Path 2: Handle validation errors (malformed article ID)
+	articleID := c.Param("id")
+	if uuid.Validate(articleID) != nil {
+		return h.renderToast(c, http.StatusNotFound, "error", NewArticleNotFoundError().Message)
+	}
+
+	userID := auth.GetUserID(c)
+	var err error
+	if star {
+		err = h.service.StarArticle(c.Request().Context(), userID, articleID)
+	} else {
+		err = h.service.UnstarArticle(c.Request().Context(), userID, articleID)
+	}
+	if err != nil {
+		// Path 3: Handle business errors (ServiceError)
+		var serviceErr *sharederrors.ServiceError
+		if errors.As(err, &serviceErr) {
+			return h.renderToast(c, serviceErr.Code, "error", serviceErr.Message)
+		}
+
+		// Path 4: Unexpected error - delegate to global error handler
+		return err
+	}
+
+	// Success - replace the button with its toggled state
+	return c.Render(http.StatusOK, "", view.StarButton(articleID, star))
+}
+
+// ShowSaved handles GET /saved endpoint
+// Renders the saved articles page; the articles are loaded by htmx from GET /articles/saved
+func (h *Handler) ShowSaved(c echo.Context) error {
+	// Bind and sanitize query parameters
+	query := new(models.ListSavedArticlesQuery)
+	_ = c.Bind(query) // Ignore bind errors for query parameters
+	query.SetDefaults()
+
+	vm := models.SavedPageViewModel{
+		Title:     "Saved articles - VibeFeeder",
+		UserEmail: auth.GetUserEmail(c),
+		Query:     query,
+	}
+
+	return c.Render(http.StatusOK, "", view.Saved(vm))
+}
+
+// ListSavedArticles handles GET /articles/saved endpoint
+// Returns a page of saved articles as an HTML fragment
+func (h *Handler) ListSavedArticles(c echo.Context) error {
+	// Bind and sanitize query parameters
+	query := new(models.ListSavedArticlesQuery)
+	_ = c.Bind(query) // Ignore bind errors for query parameters
+	query.SetDefaults()
+
+	// Set user ID from authenticated session
+	query.UserID = auth.GetUserID(c)
+
+	vm, err := h.service.ListSavedArticles(c.Request().Context(), *query)
+	if err != nil {
+		// Path 3: Handle business errors (ServiceError)
+		var serviceErr *sharederrors.ServiceError
+		if errors.As(err, &serviceErr) {
+			errVM := models.SavedListViewModel{
+				Articles:     []models.SavedArticleViewModel{},
+				ErrorMessage: serviceErr.Message,
+				Pagination:   sharedmodels.PaginationViewModel{},
+			}
+			return c.Render(serviceErr.Code, "", view.SavedList(errVM))
+		}
+
+		// Path 4: Unexpected error - delegate to global error handler
+		return err
+	}
+
+	// Build URL for HX-Push-Url header to update browser history
+	c.Response().Header().Set("HX-Push-Url", sharedmodels.BuildPageURL("/saved", query.Page))
+
+	return c.Render(http.StatusOK, "", view.SavedList(*vm))
+}
+
+// DeleteSavedArticle handles DELETE /articles/saved/:id endpoint
+// Removes a saved article by its own ID, so snapshots of deleted articles can be removed too
+func (h *Handler) DeleteSavedArticle(c echo.Context) error {
+	// Path 2: Handle validation errors (malformed ID) - nothing to delete
+	savedID := c.Param("id")
+	if uuid.Validate(savedID) != nil {
+		return h.renderToast(c, http.StatusNotFound, "error", "Saved article not found")
+	}
+
+	if err := h.service.DeleteSavedArticle(c.Request().Context(), auth.GetUserID(c), savedID); err != nil {
+		// Path 3: Handle business errors (ServiceError)
+		var serviceErr *sharederrors.ServiceError
+		if errors.As(err, &serviceErr) {
+			return h.renderToast(c, serviceErr.Code, "error", serviceErr.Message)
+		}
+
+		// Path 4: Unexpected error - delegate to global error handler
+		return err
+	}
+
+	// Success - refresh the saved list and show toast
+	c.Response().Header().Set("HX-Trigger", `{"refreshSavedArticles": null}`)
+	return h.renderToast(c, http.StatusOK, "success", "Article was removed from saved")
+}
+
+// renderToast renders a toast out of band, leaving the target of the request untouched
+func (h *Handler) renderToast(c echo.Context, statusCode int, toastType, message string) error {
+	c.Response().Header().Set("HX-Reswap", "none")
+	return c.Render(statusCode, "", sharedview.Toast(sharedview.ToastProps{
+		Type:    toastType,
+		Message: message,
+		UseOOB:  true,
+	}))
+}
+
+// markedReadMessage describes the result of marking articles as read
+func markedReadMessage(count int) string {
+	switch count {
+	case 0:
+		return "All articles were already read"
+	case 1:
+		return "1 article was marked as read"
+	default:
+		return fmt.Sprintf("%d articles were marked as read", count)
+	}
+}
@@ -0,0 +1,43 @@
+package article
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/tjanas94/vibefeeder/internal/article/models"
+)
+
+// buildTimelineURL builds the timeline URL with query parameters based on the article list query.
+// This is a pure function that constructs a URL string from the query parameters.
+func buildTimelineURL(query models.ListArticlesQuery) string {
+	pushURL := "/timeline"
+	params := make(url.Values)
+
+	if query.Search != "" {
+		params.Set("search", query.Search)
+	}
+	if query.Feed != "" {
+		params.Set("feed", query.Feed)
+	}
+	if query.Tag != "" {
+		params.Set("tag", query.Tag)
+	}
+	if query.From != "" {
+		params.Set("from", query.From)
+	}
+	if query.To != "" {
+		params.Set("to", query.To)
+	}
+	if query.Unread {
+		params.Set("unread", "true")
+	}
+	if query.Page > 1 {
+		params.Set("page", fmt.Sprintf("%d", query.Page))
+	}
+
+	if len(params) > 0 {
+		pushURL += "?" + params.Encode()
+	}
+
+	return pushURL
+}
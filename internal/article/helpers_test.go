@@ -0,0 +1,46 @@
+package article
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tjanas94/vibefeeder/internal/article/models"
+)
+
+func TestBuildTimelineURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    models.ListArticlesQuery
+		expected string
+	}{
+		{
+			name:     "empty query returns base URL",
+			query:    models.ListArticlesQuery{},
+			expected: "/timeline",
+		},
+		{
+			name:     "page 1 is omitted from URL",
+			query:    models.ListArticlesQuery{Page: 1},
+			expected: "/timeline",
+		},
+		{
+			name: "all filters",
+			query: models.ListArticlesQuery{
+				Search: "go generics",
+				Feed:   "feed-1",
+				Tag:    "tag-1",
+				From:   "2025-01-01",
+				To:     "2025-01-31",
+				Unread: true,
+				Page:   2,
+			},
+			expected: "/timeline?feed=feed-1&from=2025-01-01&page=2&search=go+generics&tag=tag-1&to=2025-01-31&unread=true",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, buildTimelineURL(tt.query))
+		})
+	}
+}
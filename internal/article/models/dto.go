@@ -0,0 +1,283 @@
+package models
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	feedmodels "github.com/tjanas94/vibefeeder/internal/feed/models"
+	"github.com/tjanas94/vibefeeder/internal/shared/database"
+	sharedmodels "github.com/tjanas94/vibefeeder/internal/shared/models"
+)
+
+// excerptLength is the maximum number of characters of article content shown in the timeline.
+const excerptLength = 280
+
+// TimelineViewModel contains the data needed to render the timeline page.
+// Articles are loaded by htmx, the page only renders the filters.
+// Used by: GET /timeline
+type TimelineViewModel struct {
+	Title     string
+	UserEmail string
+	Query     *ListArticlesQuery        // Query params for article filtering (feed, tag, dates, page)
+	Feeds     []FeedOptionViewModel     // Options of the feed filter
+	Tags      []feedmodels.TagViewModel // Options of the tag filter
+}
+
+// FeedOptionViewModel represents a feed in the feed filter of the timeline.
+type FeedOptionViewModel struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// NewFeedOptionsFromDB creates FeedOptionViewModels from database.PublicFeedsSelect rows.
+func NewFeedOptionsFromDB(dbFeeds []database.PublicFeedsSelect) []FeedOptionViewModel {
+	vms := make([]FeedOptionViewModel, len(dbFeeds))
+	for i, dbFeed := range dbFeeds {
+		vms[i] = FeedOptionViewModel{ID: dbFeed.Id, Name: dbFeed.Name}
+	}
+	return vms
+}
+
+// ArticleListViewModel represents a page of the timeline with empty state support.
+// Used by: GET /articles
+type ArticleListViewModel struct {
+	Articles       []ArticleItemViewModel           `json:"articles"`
+	ShowEmptyState bool                             `json:"show_empty_state"` // No articles at all (no filters applied)
+	ErrorMessage   string                           `json:"error_message,omitempty"`
+	Pagination     sharedmodels.PaginationViewModel `json:"pagination"`
+}
+
+// ArticleItemViewModel represents a single article of the timeline.
+// Derived from database.PublicArticlesSelect and the feed it belongs to.
+type ArticleItemViewModel struct {
+	ID             string               `json:"id"`
+	Title          string               `json:"title"`
+	URL            string               `json:"url"`
+	FeedID         string               `json:"feed_id"`
+	FeedName       string               `json:"feed_name"`
+	FeedFaviconURL string               `json:"feed_favicon_url"` // Cached site icon of the feed (empty if none)
+	PublishedAt    time.Time            `json:"published_at"`
+	Excerpt        string               `json:"excerpt"`     // Beginning of the plain text content
+	HasContent     bool                 `json:"has_content"` // Sanitized HTML is available to read inside the timeline
+	Authors        []string             `json:"authors"`
+	Categories     []string             `json:"categories"`
+	ImageURL       string               `json:"image_url"`
+	Enclosures     []EnclosureViewModel `json:"enclosures"`
+	Read           bool                 `json:"read"`    // The user has opened or marked the article as read
+	Starred        bool                 `json:"starred"` // The user has saved the article
+	// Search results only: title and content fragments with the matching words marked
+	TitleHighlight   []HighlightSegment `json:"title_highlight,omitempty"`
+	ExcerptHighlight []HighlightSegment `json:"excerpt_highlight,omitempty"`
+}
+
+// ArticleContentViewModel represents the content of an article read inside the timeline.
+// Used by: GET /articles/:id/content
+type ArticleContentViewModel struct {
+	ID   string `json:"id"`
+	URL  string `json:"url"`
+	HTML string `json:"html"` // Allow-list sanitized at fetch time, rendered as is (empty if the feed has no content)
+}
+
+// HighlightSegment is a part of a highlighted search result text.
+// Matching segments are rendered marked, the text is escaped like any other content.
+type HighlightSegment struct {
+	Text  string `json:"text"`
+	Match bool   `json:"match"`
+}
+
+// Delimiters of the matches in the highlights of search_articles (private use characters, never shown)
+const (
+	highlightStart = "\ue000"
+	highlightStop  = "\ue001"
+)
+
+// ParseHighlight splits a highlight of search_articles into segments.
+// Whitespace is collapsed, content fragments keep the line breaks of the article otherwise.
+func ParseHighlight(highlight string) []HighlightSegment {
+	var segments []HighlightSegment
+	text := strings.Join(strings.Fields(highlight), " ")
+	for text != "" {
+		start := strings.Index(text, highlightStart)
+		if start < 0 {
+			segments = append(segments, HighlightSegment{Text: text})
+			break
+		}
+		if start > 0 {
+			segments = append(segments, HighlightSegment{Text: text[:start]})
+		}
+		text = text[start+len(highlightStart):]
+
+		stop := strings.Index(text, highlightStop)
+		if stop < 0 {
+			// Unterminated match, mark the rest
+			stop = len(text)
+		}
+		if stop > 0 {
+			segments = append(segments, HighlightSegment{Text: text[:stop], Match: true})
+		}
+		text = strings.TrimPrefix(text[stop:], highlightStop)
+	}
+	return segments
+}
+
+// SavedPageViewModel contains the data needed to render the saved articles page.
+// Used by: GET /saved
+type SavedPageViewModel struct {
+	Title     string
+	UserEmail string
+	Query     *ListSavedArticlesQuery
+}
+
+// SavedListViewModel represents a page of saved articles with empty state support.
+// Used by: GET /articles/saved
+type SavedListViewModel struct {
+	Articles       []SavedArticleViewModel          `json:"articles"`
+	ShowEmptyState bool                             `json:"show_empty_state"`
+	ErrorMessage   string                           `json:"error_message,omitempty"`
+	Pagination     sharedmodels.PaginationViewModel `json:"pagination"`
+}
+
+// SavedArticleViewModel represents the snapshot of a saved article.
+// Derived from database.PublicSavedArticlesSelect, available after the article itself is deleted.
+type SavedArticleViewModel struct {
+	ID            string    `json:"id"`
+	ArticleID     string    `json:"article_id"` // Empty once the article (or its feed) is deleted
+	Title         string    `json:"title"`
+	URL           string    `json:"url"`
+	FeedName      string    `json:"feed_name"`
+	PublishedAt   time.Time `json:"published_at"`
+	SavedAt       time.Time `json:"saved_at"`
+	Excerpt       string    `json:"excerpt"`
+	Content       string    `json:"content"` // Full plain text snapshot
+	Authors       []string  `json:"authors"`
+	ImageURL      string    `json:"image_url"`
+	SourceDeleted bool      `json:"source_deleted"` // Computed: ArticleID is null
+}
+
+// NewSavedArticleFromDB creates a SavedArticleViewModel from database.PublicSavedArticlesSelect.
+func NewSavedArticleFromDB(dbSaved database.PublicSavedArticlesSelect) SavedArticleViewModel {
+	vm := SavedArticleViewModel{
+		ID:            dbSaved.Id,
+		Title:         dbSaved.Title,
+		URL:           dbSaved.Url,
+		FeedName:      dbSaved.FeedName,
+		Authors:       dbSaved.Authors,
+		SourceDeleted: dbSaved.ArticleId == nil,
+	}
+
+	if dbSaved.ArticleId != nil {
+		vm.ArticleID = *dbSaved.ArticleId
+	}
+
+	if dbSaved.Content != nil {
+		vm.Content = strings.TrimSpace(*dbSaved.Content)
+		vm.Excerpt = excerpt(vm.Content, excerptLength)
+	}
+
+	if dbSaved.ImageUrl != nil {
+		vm.ImageURL = *dbSaved.ImageUrl
+	}
+
+	if publishedAt, err := time.Parse(time.RFC3339, dbSaved.PublishedAt); err == nil {
+		vm.PublishedAt = publishedAt
+	}
+	if savedAt, err := time.Parse(time.RFC3339, dbSaved.SavedAt); err == nil {
+		vm.SavedAt = savedAt
+	}
+
+	return vm
+}
+
+// Enclosure is a media file attached to an article, as stored in articles.enclosures
+type Enclosure struct {
+	URL    string `json:"url"`
+	Type   string `json:"type,omitempty"`
+	Length int64  `json:"length,omitempty"`
+}
+
+// Enclosure kinds, decide how an enclosure is rendered
+const (
+	EnclosureKindAudio = "audio"
+	EnclosureKindVideo = "video"
+	EnclosureKindFile  = "file"
+)
+
+// EnclosureViewModel represents a media file attached to an article.
+type EnclosureViewModel struct {
+	URL  string `json:"url"`
+	Type string `json:"type"`
+	Kind string `json:"kind"` // Computed from Type: audio, video or file
+}
+
+// NewArticleItemFromDB creates an ArticleItemViewModel from an article and its feed.
+// Builds the excerpt from the plain text content and parses the published timestamp.
+func NewArticleItemFromDB(dbArticle database.PublicArticlesSelect, dbFeed database.PublicFeedsSelect) ArticleItemViewModel {
+	vm := ArticleItemViewModel{
+		ID:             dbArticle.Id,
+		Title:          dbArticle.Title,
+		URL:            dbArticle.Url,
+		FeedID:         dbFeed.Id,
+		FeedName:       dbFeed.Name,
+		FeedFaviconURL: feedmodels.FaviconURL(dbFeed),
+		Authors:        dbArticle.Authors,
+		Categories:     dbArticle.Categories,
+	}
+
+	if dbArticle.Content != nil {
+		vm.Excerpt = excerpt(*dbArticle.Content, excerptLength)
+	}
+
+	if dbArticle.ImageUrl != nil {
+		vm.ImageURL = *dbArticle.ImageUrl
+	}
+
+	if publishedAt, err := time.Parse(time.RFC3339, dbArticle.PublishedAt); err == nil {
+		vm.PublishedAt = publishedAt
+	}
+
+	return vm
+}
+
+// NewEnclosuresFromDB creates EnclosureViewModels, skipping enclosures without a URL.
+func NewEnclosuresFromDB(enclosures []Enclosure) []EnclosureViewModel {
+	vms := make([]EnclosureViewModel, 0, len(enclosures))
+	for _, enclosure := range enclosures {
+		if enclosure.URL == "" {
+			continue
+		}
+		vms = append(vms, EnclosureViewModel{
+			URL:  enclosure.URL,
+			Type: enclosure.Type,
+			Kind: enclosureKind(enclosure.Type),
+		})
+	}
+	return vms
+}
+
+// enclosureKind maps a MIME type to the way the enclosure is rendered
+func enclosureKind(mimeType string) string {
+	switch {
+	case strings.HasPrefix(mimeType, "audio/"):
+		return EnclosureKindAudio
+	case strings.HasPrefix(mimeType, "video/"):
+		return EnclosureKindVideo
+	default:
+		return EnclosureKindFile
+	}
+}
+
+// excerpt collapses whitespace of the content and cuts it to maxLen characters at a word boundary
+func excerpt(content string, maxLen int) string {
+	text := strings.Join(strings.Fields(content), " ")
+	if utf8.RuneCountInString(text) <= maxLen {
+		return text
+	}
+
+	runes := []rune(text)
+	cut := string(runes[:maxLen])
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " .,;:-") + "…"
+}
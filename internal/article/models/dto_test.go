@@ -0,0 +1,165 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tjanas94/vibefeeder/internal/shared/database"
+)
+
+func TestNewArticleItemFromDB(t *testing.T) {
+	content := "  First line.\n\n  Second   line.  "
+	imageURL := "https://example.com/image.jpg"
+	checkedAt := "2025-01-02T03:04:05Z"
+
+	vm := NewArticleItemFromDB(
+		database.PublicArticlesSelect{
+			Id:          "article-1",
+			Title:       "Hello",
+			Url:         "https://example.com/hello",
+			Content:     &content,
+			Authors:     []string{"Jane"},
+			Categories:  []string{"Go"},
+			ImageUrl:    &imageURL,
+			PublishedAt: "2025-01-01T10:00:00Z",
+		},
+		database.PublicFeedsSelect{
+			Id:               "feed-1",
+			Name:             "Example",
+			HasFavicon:       true,
+			FaviconCheckedAt: &checkedAt,
+		},
+	)
+
+	assert.Equal(t, "article-1", vm.ID)
+	assert.Equal(t, "Hello", vm.Title)
+	assert.Equal(t, "https://example.com/hello", vm.URL)
+	assert.Equal(t, "feed-1", vm.FeedID)
+	assert.Equal(t, "Example", vm.FeedName)
+	assert.Equal(t, "/feeds/feed-1/favicon?v=1735787045", vm.FeedFaviconURL)
+	assert.Equal(t, time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC), vm.PublishedAt.UTC())
+	assert.Equal(t, "First line. Second line.", vm.Excerpt)
+	assert.Equal(t, []string{"Jane"}, vm.Authors)
+	assert.Equal(t, []string{"Go"}, vm.Categories)
+	assert.Equal(t, imageURL, vm.ImageURL)
+}
+
+func TestNewArticleItemFromDB_MissingOptionalFields(t *testing.T) {
+	vm := NewArticleItemFromDB(
+		database.PublicArticlesSelect{Id: "article-1", PublishedAt: "invalid"},
+		database.PublicFeedsSelect{Id: "feed-1"},
+	)
+
+	assert.Empty(t, vm.Excerpt)
+	assert.Empty(t, vm.ImageURL)
+	assert.Empty(t, vm.FeedFaviconURL)
+	assert.True(t, vm.PublishedAt.IsZero())
+}
+
+func TestNewEnclosuresFromDB(t *testing.T) {
+	vms := NewEnclosuresFromDB([]Enclosure{
+		{URL: "https://example.com/episode.mp3", Type: "audio/mpeg", Length: 1024},
+		{URL: "https://example.com/clip.mp4", Type: "video/mp4"},
+		{URL: "https://example.com/slides.pdf", Type: "application/pdf"},
+		{URL: "", Type: "audio/mpeg"},
+	})
+
+	assert.Equal(t, []EnclosureViewModel{
+		{URL: "https://example.com/episode.mp3", Type: "audio/mpeg", Kind: EnclosureKindAudio},
+		{URL: "https://example.com/clip.mp4", Type: "video/mp4", Kind: EnclosureKindVideo},
+		{URL: "https://example.com/slides.pdf", Type: "application/pdf", Kind: EnclosureKindFile},
+	}, vms)
+}
+
+func TestExcerpt(t *testing.T) {
+	t.Run("short content is kept", func(t *testing.T) {
+		assert.Equal(t, "Short text", excerpt("Short text", 20))
+	})
+
+	t.Run("long content is cut at a word boundary", func(t *testing.T) {
+		assert.Equal(t, "The quick brown…", excerpt("The quick brown fox jumps", 18))
+	})
+
+	t.Run("counts characters, not bytes", func(t *testing.T) {
+		text := strings.Repeat("ż", 10)
+		assert.Equal(t, text, excerpt(text, 10))
+		assert.Equal(t, strings.Repeat("ż", 5)+"…", excerpt(text, 5))
+	})
+}
+
+func TestNewSavedArticleFromDB(t *testing.T) {
+	articleID := "article-1"
+	content := "  Snapshot of the article content.  "
+	vm := NewSavedArticleFromDB(database.PublicSavedArticlesSelect{
+		Id:          "saved-1",
+		ArticleId:   &articleID,
+		Title:       "Saved",
+		Url:         "https://example.com/saved",
+		FeedName:    "Blog",
+		Content:     &content,
+		PublishedAt: "2025-01-01T10:00:00Z",
+		SavedAt:     "2025-01-02T10:00:00Z",
+	})
+
+	assert.Equal(t, "article-1", vm.ArticleID)
+	assert.False(t, vm.SourceDeleted)
+	assert.Equal(t, "Snapshot of the article content.", vm.Content)
+	assert.Equal(t, vm.Content, vm.Excerpt)
+	assert.Equal(t, time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC), vm.SavedAt)
+}
+
+func TestNewSavedArticleFromDB_SourceDeleted(t *testing.T) {
+	vm := NewSavedArticleFromDB(database.PublicSavedArticlesSelect{Id: "saved-1", Title: "Saved"})
+
+	assert.Empty(t, vm.ArticleID)
+	assert.True(t, vm.SourceDeleted)
+	assert.Empty(t, vm.Content)
+	assert.True(t, vm.PublishedAt.IsZero())
+}
+
+func TestParseHighlight(t *testing.T) {
+	tests := []struct {
+		name      string
+		highlight string
+		expected  []HighlightSegment
+	}{
+		{
+			name:      "empty",
+			highlight: "",
+			expected:  nil,
+		},
+		{
+			name:      "no matches",
+			highlight: "Plain text",
+			expected:  []HighlightSegment{{Text: "Plain text"}},
+		},
+		{
+			name:      "matches inside text",
+			highlight: "Using \ue000Postgres\ue001 for\n full-text \ue000search\ue001",
+			expected: []HighlightSegment{
+				{Text: "Using "},
+				{Text: "Postgres", Match: true},
+				{Text: " for full-text "},
+				{Text: "search", Match: true},
+			},
+		},
+		{
+			name:      "unterminated match",
+			highlight: "\ue000Postgres",
+			expected:  []HighlightSegment{{Text: "Postgres", Match: true}},
+		},
+		{
+			name:      "markup is kept as text",
+			highlight: "<b>\ue000bold\ue001</b>",
+			expected:  []HighlightSegment{{Text: "<b>"}, {Text: "bold", Match: true}, {Text: "</b>"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseHighlight(tt.highlight))
+		})
+	}
+}
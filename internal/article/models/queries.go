@@ -0,0 +1,129 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DateFormat is the format of the date range parameters (as sent by date inputs)
+const DateFormat = "2006-01-02"
+
+// MaxSearchLength limits the length of the search phrase (in characters)
+const MaxSearchLength = 200
+
+// ListArticlesQuery represents the input parameters for listing articles in the timeline.
+// Used by: GET /timeline, GET /articles
+type ListArticlesQuery struct {
+	UserID string `query:"-"`      // Required: User ID from authenticated session (set by handler)
+	Search string `query:"search"` // Optional: Full-text search phrase, results are ranked instead of newest first
+	Feed   string `query:"feed"`   // Optional: Filter by feed ID
+	Tag    string `query:"tag"`    // Optional: Filter by tag ID of the feed
+	From   string `query:"from"`   // Optional: Only articles published on or after this date (YYYY-MM-DD)
+	To     string `query:"to"`     // Optional: Only articles published on or before this date (YYYY-MM-DD)
+	Unread bool   `query:"unread"` // Optional: Only articles the user has not read
+	Page   int    `query:"page"`   // Optional: Page number (1-indexed), default: 1
+}
+
+// SetDefaults sets default values for optional query parameters
+// and sanitizes invalid values
+func (q *ListArticlesQuery) SetDefaults() {
+	q.Search = sanitizeSearch(q.Search)
+
+	// Sanitize feed and tag - must be IDs, anything else would fail the database query
+	if q.Feed != "" && uuid.Validate(q.Feed) != nil {
+		q.Feed = ""
+	}
+	if q.Tag != "" && uuid.Validate(q.Tag) != nil {
+		q.Tag = ""
+	}
+
+	// Sanitize date range - drop dates that can't be parsed
+	if _, err := time.Parse(DateFormat, q.From); err != nil {
+		q.From = ""
+	}
+	if _, err := time.Parse(DateFormat, q.To); err != nil {
+		q.To = ""
+	}
+
+	// Swap a reversed range instead of returning nothing
+	if q.From != "" && q.To != "" && q.From > q.To {
+		q.From, q.To = q.To, q.From
+	}
+
+	// Sanitize page - must be >= 1
+	if q.Page < 1 {
+		q.Page = 1
+	}
+}
+
+// HasFilters reports whether any filter narrows down the timeline
+func (q *ListArticlesQuery) HasFilters() bool {
+	return q.Search != "" || q.Feed != "" || q.Tag != "" || q.From != "" || q.To != "" || q.Unread
+}
+
+// PublishedRange returns the bounds of the published_at filter in UTC.
+// The end is exclusive (the day after To), so the whole To day is included.
+// A zero time means the bound is not set.
+func (q *ListArticlesQuery) PublishedRange() (from, to time.Time) {
+	return publishedRange(q.From, q.To)
+}
+
+// ListSavedArticlesQuery represents the input parameters for listing saved (starred) articles.
+// Used by: GET /saved, GET /articles/saved
+type ListSavedArticlesQuery struct {
+	UserID string `query:"-"`    // Required: User ID from authenticated session (set by handler)
+	Page   int    `query:"page"` // Optional: Page number (1-indexed), default: 1
+}
+
+// SetDefaults sanitizes the page number
+func (q *ListSavedArticlesQuery) SetDefaults() {
+	if q.Page < 1 {
+		q.Page = 1
+	}
+}
+
+// MarkArticlesReadCommand represents the input for marking all articles matching the timeline filters as read.
+// Without filters every article of the user is marked as read.
+// Used by: POST /articles/read
+type MarkArticlesReadCommand struct {
+	UserID string `form:"-"`                                             // Set from authenticated session
+	Search string `form:"search"`                                        // Optional: only articles matching the search
+	Feed   string `form:"feed" validate:"omitempty,uuid"`                // Optional: only articles of this feed
+	Tag    string `form:"tag" validate:"omitempty,uuid"`                 // Optional: only articles of feeds with this tag
+	From   string `form:"from" validate:"omitempty,datetime=2006-01-02"` // Optional: published on or after this date
+	To     string `form:"to" validate:"omitempty,datetime=2006-01-02"`   // Optional: published on or before this date
+}
+
+// SetDefaults sanitizes the search phrase like ListArticlesQuery.SetDefaults, so the same articles are marked
+func (c *MarkArticlesReadCommand) SetDefaults() {
+	c.Search = sanitizeSearch(c.Search)
+}
+
+// PublishedRange returns the bounds of the published_at filter in UTC, like ListArticlesQuery.PublishedRange.
+func (c *MarkArticlesReadCommand) PublishedRange() (from, to time.Time) {
+	return publishedRange(c.From, c.To)
+}
+
+// sanitizeSearch trims the search phrase and cuts it to MaxSearchLength characters
+func sanitizeSearch(search string) string {
+	search = strings.TrimSpace(search)
+	if runes := []rune(search); len(runes) > MaxSearchLength {
+		search = strings.TrimSpace(string(runes[:MaxSearchLength]))
+	}
+	return search
+}
+
+// publishedRange parses a date range into published_at bounds, the end is the day after toDate
+func publishedRange(fromDate, toDate string) (from, to time.Time) {
+	if fromDate != "" {
+		from, _ = time.Parse(DateFormat, fromDate)
+	}
+	if toDate != "" {
+		if day, err := time.Parse(DateFormat, toDate); err == nil {
+			to = day.AddDate(0, 0, 1)
+		}
+	}
+	return from, to
+}
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestListArticlesQuery_SetDefaults tests the SetDefaults method
+func TestListArticlesQuery_SetDefaults(t *testing.T) {
+	const feedID = "0b7c6e5a-3d1f-4e2a-9c8b-1a2b3c4d5e6f"
+	const tagID = "5f8d2c1b-7a6e-4b3c-8d9e-0f1a2b3c4d5e"
+
+	tests := []struct {
+		name     string
+		initial  ListArticlesQuery
+		expected ListArticlesQuery
+	}{
+		{
+			name:     "all fields empty",
+			initial:  ListArticlesQuery{},
+			expected: ListArticlesQuery{Page: 1},
+		},
+		{
+			name:     "valid filters are kept",
+			initial:  ListArticlesQuery{Feed: feedID, Tag: tagID, From: "2025-01-01", To: "2025-01-31", Page: 3},
+			expected: ListArticlesQuery{Feed: feedID, Tag: tagID, From: "2025-01-01", To: "2025-01-31", Page: 3},
+		},
+		{
+			name:     "invalid IDs are dropped",
+			initial:  ListArticlesQuery{Feed: "not-a-uuid", Tag: "1; drop table"},
+			expected: ListArticlesQuery{Page: 1},
+		},
+		{
+			name:     "invalid dates are dropped",
+			initial:  ListArticlesQuery{From: "yesterday", To: "2025-02-30"},
+			expected: ListArticlesQuery{Page: 1},
+		},
+		{
+			name:     "reversed range is swapped",
+			initial:  ListArticlesQuery{From: "2025-03-01", To: "2025-01-01"},
+			expected: ListArticlesQuery{From: "2025-01-01", To: "2025-03-01", Page: 1},
+		},
+		{
+			name:     "search is trimmed",
+			initial:  ListArticlesQuery{Search: "  postgres  "},
+			expected: ListArticlesQuery{Search: "postgres", Page: 1},
+		},
+		{
+			name:     "long search is cut",
+			initial:  ListArticlesQuery{Search: strings.Repeat("ą", MaxSearchLength+10)},
+			expected: ListArticlesQuery{Search: strings.Repeat("ą", MaxSearchLength), Page: 1},
+		},
+		{
+			name:     "negative page is reset",
+			initial:  ListArticlesQuery{Page: -2},
+			expected: ListArticlesQuery{Page: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.initial
+			q.SetDefaults()
+			assert.Equal(t, tt.expected, q)
+		})
+	}
+}
+
+func TestListArticlesQuery_HasFilters(t *testing.T) {
+	assert.False(t, (&ListArticlesQuery{Page: 2}).HasFilters())
+	assert.True(t, (&ListArticlesQuery{Feed: "feed-1"}).HasFilters())
+	assert.True(t, (&ListArticlesQuery{Tag: "tag-1"}).HasFilters())
+	assert.True(t, (&ListArticlesQuery{From: "2025-01-01"}).HasFilters())
+	assert.True(t, (&ListArticlesQuery{To: "2025-01-01"}).HasFilters())
+	assert.True(t, (&ListArticlesQuery{Unread: true}).HasFilters())
+}
+
+func TestListArticlesQuery_PublishedRange(t *testing.T) {
+	t.Run("no dates", func(t *testing.T) {
+		from, to := (&ListArticlesQuery{}).PublishedRange()
+		assert.True(t, from.IsZero())
+		assert.True(t, to.IsZero())
+	})
+
+	t.Run("end of range includes the whole day", func(t *testing.T) {
+		from, to := (&ListArticlesQuery{From: "2025-01-01", To: "2025-01-31"}).PublishedRange()
+		assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), from)
+		assert.Equal(t, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), to)
+	})
+}
+
+func TestMarkArticlesReadCommand_PublishedRange(t *testing.T) {
+	from, to := (&MarkArticlesReadCommand{To: "2025-01-31"}).PublishedRange()
+	assert.True(t, from.IsZero())
+	assert.Equal(t, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), to)
+}
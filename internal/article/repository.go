@@ -0,0 +1,429 @@
+package article
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/supabase-community/postgrest-go"
+	"github.com/tjanas94/vibefeeder/internal/article/models"
+	"github.com/tjanas94/vibefeeder/internal/shared/database"
+	"github.com/tjanas94/vibefeeder/internal/shared/tracing"
+)
+
+// Repository handles data access for the article timeline
+type Repository struct {
+	db *database.Client
+}
+
+// Ensure Repository implements ArticleRepository interface at compile time
+var _ ArticleRepository = (*Repository)(nil)
+
+// NewRepository creates a new article repository
+func NewRepository(db *database.Client) *Repository {
+	return &Repository{db: db}
+}
+
+// ArticleWithFeed is an article row with its feed embedded by PostgREST
+type ArticleWithFeed struct {
+	database.PublicArticlesSelect
+	Enclosures []models.Enclosure                   `json:"enclosures"`       // Shadows the untyped column of the generated type
+	HasHTML    bool                                 `json:"has_content_html"` // Computed field, the sanitized HTML itself is loaded on read
+	Feed       database.PublicFeedsSelect           `json:"feeds"`
+	Reads      []database.PublicArticleReadsSelect  `json:"article_reads"`  // Read state of the user, empty while unread (RLS)
+	Saved      []database.PublicSavedArticlesSelect `json:"saved_articles"` // Star of the user, empty while not starred (RLS)
+}
+
+// ListArticlesResult contains the result of listing articles from the database
+type ListArticlesResult struct {
+	Articles   []ArticleWithFeed
+	TotalCount int
+}
+
+// articleColumns are the article columns shown in the timeline; full content and HTML are left out,
+// has_content_html tells whether the article can be read inside the timeline
+const articleColumns = "id,feed_id,title,url,content,authors,categories,image_url,enclosures,published_at,has_content_html"
+
+// feedColumns are the columns of the feed embedded in every timeline article
+const feedColumns = "id,name,user_id,has_favicon,favicon_checked_at"
+
+// stateColumns embed the read state and star without a join filter, RLS limits them to the rows of the user
+const stateColumns = "article_reads(read_at),saved_articles(id)"
+
+// ListArticles retrieves a page of the user's articles, newest first, with filtering
+func (r *Repository) ListArticles(ctx context.Context, query models.ListArticlesQuery) (_ *ListArticlesResult, err error) {
+	_, span := tracing.Start(ctx, "article.Repository.ListArticles")
+	defer func() { tracing.End(span, err) }()
+
+	// Get authenticated client for RLS
+	client, err := r.db.NewAuthenticatedClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate offset for pagination
+	offset := (query.Page - 1) * pageSize
+
+	// Embed the feed of every article; the tag filter joins the tags of the feed
+	embeddedFeed := feedColumns
+	if query.Tag != "" {
+		embeddedFeed += ",feed_tags!inner(tag_id)"
+	}
+	articleQuery := client.From("articles").
+		Select(articleColumns+",feeds!inner("+embeddedFeed+"),"+stateColumns, "exact", false)
+
+	// Apply user_id filter on the feed (required for security)
+	articleQuery = articleQuery.Eq("feeds.user_id", query.UserID)
+
+	// Hide articles matching a mute rule of the user (computed field)
+	// Evaluated per row, also for the exact count; see the performance note of create_mute_rules
+	articleQuery = articleQuery.Is("muted", "false")
+
+	// Apply feed and tag filters if provided
+	if query.Feed != "" {
+		articleQuery = articleQuery.Eq("feed_id", query.Feed)
+	}
+	if query.Tag != "" {
+		articleQuery = articleQuery.Eq("feeds.feed_tags.tag_id", query.Tag)
+	}
+
+	// Apply unread filter (anti-join: articles without a read row)
+	if query.Unread {
+		articleQuery = articleQuery.Is("article_reads", "null")
+	}
+
+	// Apply date range filter if provided
+	from, to := query.PublishedRange()
+	if !from.IsZero() {
+		articleQuery = articleQuery.Gte("published_at", from.Format(time.RFC3339))
+	}
+	if !to.IsZero() {
+		articleQuery = articleQuery.Lt("published_at", to.Format(time.RFC3339))
+	}
+
+	// Execute query with pagination and ordering
+	// ID breaks ties, so articles published at the same time keep their page
+	var articles []ArticleWithFeed
+	count, err := articleQuery.
+		Order("published_at", &postgrest.OrderOpts{Ascending: false}).
+		Order("id", &postgrest.OrderOpts{Ascending: false}).
+		Range(offset, offset+pageSize-1, "").
+		ExecuteTo(&articles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch articles: %w", err)
+	}
+
+	return &ListArticlesResult{
+		Articles:   articles,
+		TotalCount: int(count),
+	}, nil
+}
+
+// SearchHit is a search result of the search_articles function
+type SearchHit struct {
+	ID               string  `json:"id"`
+	Rank             float64 `json:"rank"`
+	TitleHighlight   string  `json:"title_highlight"`   // Title with the matches marked
+	ContentHighlight string  `json:"content_highlight"` // Fragments of the content with the matches marked
+	TotalCount       int     `json:"total_count"`       // Number of all matches, the same in every hit
+}
+
+// SearchArticles runs a ranked full-text search over the user's articles with the timeline filters
+// Returns one page of hits, best match first
+func (r *Repository) SearchArticles(ctx context.Context, query models.ListArticlesQuery) (_ []SearchHit, err error) {
+	_, span := tracing.Start(ctx, "article.Repository.SearchArticles")
+	defer func() { tracing.End(span, err) }()
+
+	// Empty filters are sent as null, so the function ignores them
+	params := map[string]any{
+		"p_query":   query.Search,
+		"p_feed_id": nil,
+		"p_tag_id":  nil,
+		"p_from":    nil,
+		"p_to":      nil,
+		"p_unread":  query.Unread,
+		"p_limit":   pageSize,
+		"p_offset":  (query.Page - 1) * pageSize,
+	}
+	if query.Feed != "" {
+		params["p_feed_id"] = query.Feed
+	}
+	if query.Tag != "" {
+		params["p_tag_id"] = query.Tag
+	}
+	from, to := query.PublishedRange()
+	if !from.IsZero() {
+		params["p_from"] = from.Format(time.RFC3339)
+	}
+	if !to.IsZero() {
+		params["p_to"] = to.Format(time.RFC3339)
+	}
+
+	var hits []SearchHit
+	if err = r.db.CallAuthenticatedRPC(ctx, "search_articles", params, &hits); err != nil {
+		return nil, fmt.Errorf("failed to search articles: %w", err)
+	}
+
+	return hits, nil
+}
+
+// FindArticles retrieves the user's articles with the given IDs, as shown in the timeline (in no particular order)
+func (r *Repository) FindArticles(ctx context.Context, articleIDs []string) (_ []ArticleWithFeed, err error) {
+	_, span := tracing.Start(ctx, "article.Repository.FindArticles")
+	defer func() { tracing.End(span, err) }()
+
+	// Get authenticated client for RLS
+	client, err := r.db.NewAuthenticatedClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var articles []ArticleWithFeed
+	_, err = client.From("articles").
+		Select(articleColumns+",feeds!inner("+feedColumns+"),"+stateColumns, "", false).
+		In("id", articleIDs).
+		ExecuteTo(&articles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch articles: %w", err)
+	}
+
+	return articles, nil
+}
+
+// FindArticleURL retrieves the URL of an article visible to the user (RLS limits it to the user's feeds)
+func (r *Repository) FindArticleURL(ctx context.Context, articleID string) (_ string, err error) {
+	_, span := tracing.Start(ctx, "article.Repository.FindArticleURL")
+	defer func() { tracing.End(span, err) }()
+
+	// Get authenticated client for RLS
+	client, err := r.db.NewAuthenticatedClient(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var article database.PublicArticlesSelect
+	_, err = client.From("articles").
+		Select("url", "", false).
+		Eq("id", articleID).
+		Single().
+		ExecuteTo(&article)
+	if err != nil {
+		return "", fmt.Errorf("failed to find article: %w", err)
+	}
+
+	return article.Url, nil
+}
+
+// FindArticleContent retrieves the sanitized HTML content of an article visible to the user
+func (r *Repository) FindArticleContent(ctx context.Context, articleID string) (_ *database.PublicArticlesSelect, err error) {
+	_, span := tracing.Start(ctx, "article.Repository.FindArticleContent")
+	defer func() { tracing.End(span, err) }()
+
+	// Get authenticated client for RLS
+	client, err := r.db.NewAuthenticatedClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var article database.PublicArticlesSelect
+	_, err = client.From("articles").
+		Select("id,url,content_html", "", false).
+		Eq("id", articleID).
+		Single().
+		ExecuteTo(&article)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find article content: %w", err)
+	}
+
+	return &article, nil
+}
+
+// MarkArticleRead marks a single article as read by the user, keeping the first read time
+func (r *Repository) MarkArticleRead(ctx context.Context, userID, articleID string) (err error) {
+	_, span := tracing.Start(ctx, "article.Repository.MarkArticleRead")
+	defer func() { tracing.End(span, err) }()
+
+	// Get authenticated client for RLS
+	client, err := r.db.NewAuthenticatedClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Upsert of the key columns only, an article read before keeps its read_at
+	_, _, err = client.From("article_reads").
+		Upsert(database.PublicArticleReadsInsert{UserId: userID, ArticleId: articleID}, "user_id,article_id", "minimal", "").
+		Execute()
+	if err != nil {
+		return fmt.Errorf("failed to mark article as read: %w", err)
+	}
+
+	return nil
+}
+
+// MarkArticlesRead marks all articles of the user matching the filters as read
+// Returns the number of articles that were unread before
+func (r *Repository) MarkArticlesRead(ctx context.Context, cmd models.MarkArticlesReadCommand) (_ int, err error) {
+	_, span := tracing.Start(ctx, "article.Repository.MarkArticlesRead")
+	defer func() { tracing.End(span, err) }()
+
+	// Empty filters are sent as null, so the function ignores them
+	params := map[string]any{
+		"p_feed_id": nil,
+		"p_tag_id":  nil,
+		"p_from":    nil,
+		"p_to":      nil,
+		"p_query":   nil,
+	}
+	if cmd.Feed != "" {
+		params["p_feed_id"] = cmd.Feed
+	}
+	if cmd.Tag != "" {
+		params["p_tag_id"] = cmd.Tag
+	}
+	if cmd.Search != "" {
+		params["p_query"] = cmd.Search
+	}
+	from, to := cmd.PublishedRange()
+	if !from.IsZero() {
+		params["p_from"] = from.Format(time.RFC3339)
+	}
+	if !to.IsZero() {
+		params["p_to"] = to.Format(time.RFC3339)
+	}
+
+	var count int
+	if err = r.db.CallAuthenticatedRPC(ctx, "mark_articles_read", params, &count); err != nil {
+		return 0, fmt.Errorf("failed to mark articles as read: %w", err)
+	}
+
+	return count, nil
+}
+
+// ListFeeds retrieves the IDs and names of the user's feeds ordered by name
+func (r *Repository) ListFeeds(ctx context.Context, userID string) (_ []database.PublicFeedsSelect, err error) {
+	_, span := tracing.Start(ctx, "article.Repository.ListFeeds")
+	defer func() { tracing.End(span, err) }()
+
+	// Get authenticated client for RLS
+	client, err := r.db.NewAuthenticatedClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var feeds []database.PublicFeedsSelect
+	_, err = client.From("feeds").
+		Select("id,name", "", false).
+		Eq("user_id", userID).
+		Order("name", &postgrest.OrderOpts{Ascending: true}).
+		ExecuteTo(&feeds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch feeds: %w", err)
+	}
+
+	return feeds, nil
+}
+
+// StarArticle saves a snapshot of an article of the user, keeping an existing one
+// Returns nil when the article doesn't exist or belongs to another user
+func (r *Repository) StarArticle(ctx context.Context, articleID string) (_ *database.PublicSavedArticlesSelect, err error) {
+	_, span := tracing.Start(ctx, "article.Repository.StarArticle")
+	defer func() { tracing.End(span, err) }()
+
+	params := map[string]any{
+		"p_article_id": articleID,
+	}
+
+	var saved []database.PublicSavedArticlesSelect
+	if err = r.db.CallAuthenticatedRPC(ctx, "star_article", params, &saved); err != nil {
+		return nil, fmt.Errorf("failed to star article: %w", err)
+	}
+
+	if len(saved) == 0 {
+		return nil, nil
+	}
+	return &saved[0], nil
+}
+
+// UnstarArticle removes the star (and the snapshot) of an article of the user
+func (r *Repository) UnstarArticle(ctx context.Context, userID, articleID string) (err error) {
+	_, span := tracing.Start(ctx, "article.Repository.UnstarArticle")
+	defer func() { tracing.End(span, err) }()
+
+	// Get authenticated client for RLS
+	client, err := r.db.NewAuthenticatedClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = client.From("saved_articles").
+		Delete("minimal", "").
+		Eq("user_id", userID).
+		Eq("article_id", articleID).
+		Execute()
+	if err != nil {
+		return fmt.Errorf("failed to unstar article: %w", err)
+	}
+
+	return nil
+}
+
+// ListSavedArticlesResult contains the result of listing saved articles from the database
+type ListSavedArticlesResult struct {
+	Articles   []database.PublicSavedArticlesSelect
+	TotalCount int
+}
+
+// ListSavedArticles retrieves a page of the user's saved articles, most recently saved first
+func (r *Repository) ListSavedArticles(ctx context.Context, query models.ListSavedArticlesQuery) (_ *ListSavedArticlesResult, err error) {
+	_, span := tracing.Start(ctx, "article.Repository.ListSavedArticles")
+	defer func() { tracing.End(span, err) }()
+
+	// Get authenticated client for RLS
+	client, err := r.db.NewAuthenticatedClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate offset for pagination
+	offset := (query.Page - 1) * pageSize
+
+	var articles []database.PublicSavedArticlesSelect
+	count, err := client.From("saved_articles").
+		Select("*", "exact", false).
+		Eq("user_id", query.UserID).
+		Order("saved_at", &postgrest.OrderOpts{Ascending: false}).
+		Order("id", &postgrest.OrderOpts{Ascending: false}).
+		Range(offset, offset+pageSize-1, "").
+		ExecuteTo(&articles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch saved articles: %w", err)
+	}
+
+	return &ListSavedArticlesResult{
+		Articles:   articles,
+		TotalCount: int(count),
+	}, nil
+}
+
+// DeleteSavedArticle removes a saved article of the user by its own ID (works after the article is gone)
+func (r *Repository) DeleteSavedArticle(ctx context.Context, userID, savedID string) (err error) {
+	_, span := tracing.Start(ctx, "article.Repository.DeleteSavedArticle")
+	defer func() { tracing.End(span, err) }()
+
+	// Get authenticated client for RLS
+	client, err := r.db.NewAuthenticatedClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = client.From("saved_articles").
+		Delete("minimal", "").
+		Eq("id", savedID).
+		Eq("user_id", userID).
+		Execute()
+	if err != nil {
+		return fmt.Errorf("failed to delete saved article: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,260 @@
+package article
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/tjanas94/vibefeeder/internal/article/models"
+	feedmodels "github.com/tjanas94/vibefeeder/internal/feed/models"
+	"github.com/tjanas94/vibefeeder/internal/shared/database"
+	sharedmodels "github.com/tjanas94/vibefeeder/internal/shared/models"
+	"github.com/tjanas94/vibefeeder/internal/shared/tags"
+)
+
+// ArticleRepository defines the interface for article data access
+type ArticleRepository interface {
+	ListArticles(ctx context.Context, query models.ListArticlesQuery) (*ListArticlesResult, error)
+	ListFeeds(ctx context.Context, userID string) ([]database.PublicFeedsSelect, error)
+	FindArticleURL(ctx context.Context, articleID string) (string, error)
+	FindArticleContent(ctx context.Context, articleID string) (*database.PublicArticlesSelect, error)
+	MarkArticleRead(ctx context.Context, userID, articleID string) error
+	MarkArticlesRead(ctx context.Context, cmd models.MarkArticlesReadCommand) (int, error)
+	SearchArticles(ctx context.Context, query models.ListArticlesQuery) ([]SearchHit, error)
+	FindArticles(ctx context.Context, articleIDs []string) ([]ArticleWithFeed, error)
+	StarArticle(ctx context.Context, articleID string) (*database.PublicSavedArticlesSelect, error)
+	UnstarArticle(ctx context.Context, userID, articleID string) error
+	ListSavedArticles(ctx context.Context, query models.ListSavedArticlesQuery) (*ListSavedArticlesResult, error)
+	DeleteSavedArticle(ctx context.Context, userID, savedID string) error
+}
+
+// Service handles business logic for the article timeline
+type Service struct {
+	repo    ArticleRepository
+	tagRepo tags.TagRepository
+	logger  *slog.Logger
+}
+
+// NewService creates a new article service
+func NewService(repo ArticleRepository, tagRepo tags.TagRepository, logger *slog.Logger) *Service {
+	return &Service{
+		repo:    repo,
+		tagRepo: tagRepo,
+		logger:  logger,
+	}
+}
+
+// GetTimeline retrieves the options of the timeline filters
+func (s *Service) GetTimeline(ctx context.Context, query models.ListArticlesQuery) (*models.TimelineViewModel, error) {
+	dbFeeds, err := s.repo.ListFeeds(ctx, query.UserID)
+	if err != nil {
+		s.logger.Error("failed to list feeds", "user_id", query.UserID, "error", err)
+		return nil, NewDatabaseError(err)
+	}
+
+	dbTags, err := s.tagRepo.ListTags(ctx, query.UserID)
+	if err != nil {
+		s.logger.Error("failed to list tags", "user_id", query.UserID, "error", err)
+		return nil, NewDatabaseError(err)
+	}
+
+	return &models.TimelineViewModel{
+		Query: &query,
+		Feeds: models.NewFeedOptionsFromDB(dbFeeds),
+		Tags:  feedmodels.NewTagsFromDB(dbTags),
+	}, nil
+}
+
+// ListArticles retrieves and transforms a page of the timeline for display
+// With a search phrase the page holds the best matches instead of the newest articles
+func (s *Service) ListArticles(ctx context.Context, query models.ListArticlesQuery) (*models.ArticleListViewModel, error) {
+	if query.Search != "" {
+		return s.searchArticles(ctx, query)
+	}
+
+	result, err := s.repo.ListArticles(ctx, query)
+	if err != nil {
+		s.logger.Error("failed to list articles", "user_id", query.UserID, "error", err)
+		return nil, NewDatabaseError(err)
+	}
+
+	// Build view model using pure function
+	viewModel := buildArticleListViewModel(result, query)
+	return &viewModel, nil
+}
+
+// searchArticles runs the full-text search and loads the found articles as shown in the timeline
+func (s *Service) searchArticles(ctx context.Context, query models.ListArticlesQuery) (*models.ArticleListViewModel, error) {
+	hits, err := s.repo.SearchArticles(ctx, query)
+	if err != nil {
+		s.logger.Error("failed to search articles", "user_id", query.UserID, "error", err)
+		return nil, NewDatabaseError(err)
+	}
+
+	var dbArticles []ArticleWithFeed
+	if len(hits) > 0 {
+		ids := make([]string, len(hits))
+		for i, hit := range hits {
+			ids[i] = hit.ID
+		}
+		dbArticles, err = s.repo.FindArticles(ctx, ids)
+		if err != nil {
+			s.logger.Error("failed to fetch found articles", "user_id", query.UserID, "error", err)
+			return nil, NewDatabaseError(err)
+		}
+	}
+
+	viewModel := buildSearchResultViewModel(hits, dbArticles, query)
+	return &viewModel, nil
+}
+
+// OpenArticle marks an article as read and returns its URL
+// Failing to store the read state doesn't keep the user from reading the article
+func (s *Service) OpenArticle(ctx context.Context, userID, articleID string) (string, error) {
+	articleURL, err := s.repo.FindArticleURL(ctx, articleID)
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return "", NewArticleNotFoundError()
+		}
+		s.logger.Error("failed to find article", "user_id", userID, "article_id", articleID, "error", err)
+		return "", NewDatabaseError(err)
+	}
+
+	if err := s.repo.MarkArticleRead(ctx, userID, articleID); err != nil {
+		s.logger.Warn("failed to mark article as read", "user_id", userID, "article_id", articleID, "error", err)
+	}
+
+	return articleURL, nil
+}
+
+// ReadArticle marks an article as read and returns its sanitized content
+// Reading an article inside the timeline counts as reading it, like opening the original
+func (s *Service) ReadArticle(ctx context.Context, userID, articleID string) (*models.ArticleContentViewModel, error) {
+	article, err := s.repo.FindArticleContent(ctx, articleID)
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil, NewArticleNotFoundError()
+		}
+		s.logger.Error("failed to find article content", "user_id", userID, "article_id", articleID, "error", err)
+		return nil, NewDatabaseError(err)
+	}
+
+	if err := s.repo.MarkArticleRead(ctx, userID, articleID); err != nil {
+		s.logger.Warn("failed to mark article as read", "user_id", userID, "article_id", articleID, "error", err)
+	}
+
+	vm := &models.ArticleContentViewModel{ID: article.Id, URL: article.Url}
+	if article.ContentHtml != nil {
+		vm.HTML = *article.ContentHtml
+	}
+	return vm, nil
+}
+
+// MarkArticlesRead marks all articles matching the filters as read and returns how many were unread
+func (s *Service) MarkArticlesRead(ctx context.Context, cmd models.MarkArticlesReadCommand) (int, error) {
+	count, err := s.repo.MarkArticlesRead(ctx, cmd)
+	if err != nil {
+		s.logger.Error("failed to mark articles as read", "user_id", cmd.UserID, "error", err)
+		return 0, NewDatabaseError(err)
+	}
+	return count, nil
+}
+
+// StarArticle saves a snapshot of an article for the user
+func (s *Service) StarArticle(ctx context.Context, userID, articleID string) error {
+	saved, err := s.repo.StarArticle(ctx, articleID)
+	if err != nil {
+		s.logger.Error("failed to star article", "user_id", userID, "article_id", articleID, "error", err)
+		return NewDatabaseError(err)
+	}
+	if saved == nil {
+		return NewArticleNotFoundError()
+	}
+	return nil
+}
+
+// UnstarArticle removes the star and the snapshot of an article
+func (s *Service) UnstarArticle(ctx context.Context, userID, articleID string) error {
+	if err := s.repo.UnstarArticle(ctx, userID, articleID); err != nil {
+		s.logger.Error("failed to unstar article", "user_id", userID, "article_id", articleID, "error", err)
+		return NewDatabaseError(err)
+	}
+	return nil
+}
+
+// ListSavedArticles retrieves and transforms a page of saved articles for display
+func (s *Service) ListSavedArticles(ctx context.Context, query models.ListSavedArticlesQuery) (*models.SavedListViewModel, error) {
+	result, err := s.repo.ListSavedArticles(ctx, query)
+	if err != nil {
+		s.logger.Error("failed to list saved articles", "user_id", query.UserID, "error", err)
+		return nil, NewDatabaseError(err)
+	}
+
+	articles := make([]models.SavedArticleViewModel, len(result.Articles))
+	for i, dbSaved := range result.Articles {
+		articles[i] = models.NewSavedArticleFromDB(dbSaved)
+	}
+
+	return &models.SavedListViewModel{
+		Articles:       articles,
+		ShowEmptyState: result.TotalCount == 0,
+		Pagination:     sharedmodels.BuildPagination(result.TotalCount, query.Page, pageSize),
+	}, nil
+}
+
+// DeleteSavedArticle removes a saved article, also when the article itself is already gone
+func (s *Service) DeleteSavedArticle(ctx context.Context, userID, savedID string) error {
+	if err := s.repo.DeleteSavedArticle(ctx, userID, savedID); err != nil {
+		s.logger.Error("failed to delete saved article", "user_id", userID, "saved_id", savedID, "error", err)
+		return NewDatabaseError(err)
+	}
+	return nil
+}
+
+// buildSearchResultViewModel is a pure function that transforms search hits and the found articles to view model
+// Articles keep the order of the hits; hits of articles deleted in the meantime are skipped
+func buildSearchResultViewModel(hits []SearchHit, dbArticles []ArticleWithFeed, query models.ListArticlesQuery) models.ArticleListViewModel {
+	articlesByID := make(map[string]ArticleWithFeed, len(dbArticles))
+	for _, dbArticle := range dbArticles {
+		articlesByID[dbArticle.Id] = dbArticle
+	}
+
+	result := &ListArticlesResult{Articles: make([]ArticleWithFeed, 0, len(hits))}
+	found := make([]SearchHit, 0, len(hits))
+	for _, hit := range hits {
+		if dbArticle, ok := articlesByID[hit.ID]; ok {
+			result.Articles = append(result.Articles, dbArticle)
+			found = append(found, hit)
+		}
+	}
+	if len(hits) > 0 {
+		result.TotalCount = hits[0].TotalCount
+	}
+
+	viewModel := buildArticleListViewModel(result, query)
+	for i, hit := range found {
+		viewModel.Articles[i].TitleHighlight = models.ParseHighlight(hit.TitleHighlight)
+		viewModel.Articles[i].ExcerptHighlight = models.ParseHighlight(hit.ContentHighlight)
+	}
+	return viewModel
+}
+
+// buildArticleListViewModel transforms a page of articles into the timeline view model
+func buildArticleListViewModel(result *ListArticlesResult, query models.ListArticlesQuery) models.ArticleListViewModel {
+	articleItems := make([]models.ArticleItemViewModel, len(result.Articles))
+	for i, dbArticle := range result.Articles {
+		articleItems[i] = models.NewArticleItemFromDB(dbArticle.PublicArticlesSelect, dbArticle.Feed)
+		articleItems[i].Enclosures = models.NewEnclosuresFromDB(dbArticle.Enclosures)
+		articleItems[i].HasContent = dbArticle.HasHTML
+		articleItems[i].Read = len(dbArticle.Reads) > 0
+		articleItems[i].Starred = len(dbArticle.Saved) > 0
+	}
+
+	// Show empty state only when there are no articles at all (no filters applied)
+	showEmptyState := result.TotalCount == 0 && !query.HasFilters()
+
+	return models.ArticleListViewModel{
+		Articles:       articleItems,
+		ShowEmptyState: showEmptyState,
+		Pagination:     sharedmodels.BuildPagination(result.TotalCount, query.Page, pageSize),
+	}
+}
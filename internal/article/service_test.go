@@ -0,0 +1,580 @@
+package article
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tjanas94/vibefeeder/internal/article/models"
+	"github.com/tjanas94/vibefeeder/internal/shared/database"
+	sharederrors "github.com/tjanas94/vibefeeder/internal/shared/errors"
+)
+
+// MockArticleRepository is a mock implementation of ArticleRepository
+type MockArticleRepository struct {
+	mock.Mock
+}
+
+func (m *MockArticleRepository) ListArticles(ctx context.Context, query models.ListArticlesQuery) (*ListArticlesResult, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ListArticlesResult), args.Error(1)
+}
+
+func (m *MockArticleRepository) ListFeeds(ctx context.Context, userID string) ([]database.PublicFeedsSelect, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]database.PublicFeedsSelect), args.Error(1)
+}
+
+func (m *MockArticleRepository) ListTags(ctx context.Context, userID string) ([]database.PublicTagsSelect, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]database.PublicTagsSelect), args.Error(1)
+}
+
+func (m *MockArticleRepository) FindArticleURL(ctx context.Context, articleID string) (string, error) {
+	args := m.Called(ctx, articleID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockArticleRepository) FindArticleContent(ctx context.Context, articleID string) (*database.PublicArticlesSelect, error) {
+	args := m.Called(ctx, articleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*database.PublicArticlesSelect), args.Error(1)
+}
+
+func (m *MockArticleRepository) MarkArticleRead(ctx context.Context, userID, articleID string) error {
+	args := m.Called(ctx, userID, articleID)
+	return args.Error(0)
+}
+
+func (m *MockArticleRepository) MarkArticlesRead(ctx context.Context, cmd models.MarkArticlesReadCommand) (int, error) {
+	args := m.Called(ctx, cmd)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockArticleRepository) SearchArticles(ctx context.Context, query models.ListArticlesQuery) ([]SearchHit, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]SearchHit), args.Error(1)
+}
+
+func (m *MockArticleRepository) FindArticles(ctx context.Context, articleIDs []string) ([]ArticleWithFeed, error) {
+	args := m.Called(ctx, articleIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]ArticleWithFeed), args.Error(1)
+}
+
+func (m *MockArticleRepository) StarArticle(ctx context.Context, articleID string) (*database.PublicSavedArticlesSelect, error) {
+	args := m.Called(ctx, articleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*database.PublicSavedArticlesSelect), args.Error(1)
+}
+
+func (m *MockArticleRepository) UnstarArticle(ctx context.Context, userID, articleID string) error {
+	args := m.Called(ctx, userID, articleID)
+	return args.Error(0)
+}
+
+func (m *MockArticleRepository) ListSavedArticles(ctx context.Context, query models.ListSavedArticlesQuery) (*ListSavedArticlesResult, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ListSavedArticlesResult), args.Error(1)
+}
+
+func (m *MockArticleRepository) DeleteSavedArticle(ctx context.Context, userID, savedID string) error {
+	args := m.Called(ctx, userID, savedID)
+	return args.Error(0)
+}
+
+func newTestLogger() *slog.Logger {
+	// Use io.Discard to suppress log output during tests
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func newTestArticle(id, feedID, feedName, title string) ArticleWithFeed {
+	return ArticleWithFeed{
+		PublicArticlesSelect: database.PublicArticlesSelect{
+			Id:          id,
+			FeedId:      feedID,
+			Title:       title,
+			Url:         "https://example.com/" + id,
+			PublishedAt: "2025-01-01T10:00:00Z",
+		},
+		Feed: database.PublicFeedsSelect{Id: feedID, Name: feedName},
+	}
+}
+
+func TestGetTimeline_Success(t *testing.T) {
+	mockRepo := new(MockArticleRepository)
+	service := NewService(mockRepo, mockRepo, newTestLogger())
+
+	ctx := context.Background()
+	query := models.ListArticlesQuery{UserID: "user-123", Tag: "tag-1", Page: 1}
+
+	mockRepo.On("ListFeeds", ctx, "user-123").Return([]database.PublicFeedsSelect{{Id: "feed-1", Name: "Example"}}, nil)
+	mockRepo.On("ListTags", ctx, "user-123").Return([]database.PublicTagsSelect{{Id: "tag-1", Name: "Go"}}, nil)
+
+	result, err := service.GetTimeline(ctx, query)
+
+	require.NoError(t, err)
+	assert.Equal(t, []models.FeedOptionViewModel{{ID: "feed-1", Name: "Example"}}, result.Feeds)
+	require.Len(t, result.Tags, 1)
+	assert.Equal(t, "Go", result.Tags[0].Name)
+	assert.Equal(t, "tag-1", result.Query.Tag)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetTimeline_DatabaseError(t *testing.T) {
+	mockRepo := new(MockArticleRepository)
+	service := NewService(mockRepo, mockRepo, newTestLogger())
+
+	ctx := context.Background()
+	query := models.ListArticlesQuery{UserID: "user-123", Page: 1}
+
+	mockRepo.On("ListFeeds", ctx, "user-123").Return(nil, errors.New("connection refused"))
+
+	result, err := service.GetTimeline(ctx, query)
+
+	assert.Nil(t, result)
+	var serviceErr *sharederrors.ServiceError
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, http.StatusInternalServerError, serviceErr.Code)
+	mockRepo.AssertNotCalled(t, "ListTags", mock.Anything, mock.Anything)
+}
+
+func TestListArticles_Success(t *testing.T) {
+	mockRepo := new(MockArticleRepository)
+	service := NewService(mockRepo, mockRepo, newTestLogger())
+
+	ctx := context.Background()
+	query := models.ListArticlesQuery{UserID: "user-123", Page: 1}
+
+	podcast := newTestArticle("article-2", "feed-2", "Podcast", "Episode 1")
+	podcast.Enclosures = []models.Enclosure{{URL: "https://example.com/episode.mp3", Type: "audio/mpeg"}}
+	podcast.Reads = []database.PublicArticleReadsSelect{{ArticleId: "article-2", ReadAt: "2025-01-01T12:00:00Z"}}
+	podcast.Saved = []database.PublicSavedArticlesSelect{{Id: "saved-1"}}
+	podcast.HasHTML = true
+
+	mockRepo.On("ListArticles", ctx, query).Return(&ListArticlesResult{
+		Articles:   []ArticleWithFeed{newTestArticle("article-1", "feed-1", "Blog", "Hello"), podcast},
+		TotalCount: 45,
+	}, nil)
+
+	result, err := service.ListArticles(ctx, query)
+
+	require.NoError(t, err)
+	require.Len(t, result.Articles, 2)
+	assert.Equal(t, "Hello", result.Articles[0].Title)
+	assert.Equal(t, "Blog", result.Articles[0].FeedName)
+	assert.Empty(t, result.Articles[0].Enclosures)
+	assert.False(t, result.Articles[0].Read)
+	assert.True(t, result.Articles[1].Read)
+	assert.False(t, result.Articles[0].Starred)
+	assert.True(t, result.Articles[1].Starred)
+	assert.False(t, result.Articles[0].HasContent)
+	assert.True(t, result.Articles[1].HasContent)
+	require.Len(t, result.Articles[1].Enclosures, 1)
+	assert.Equal(t, models.EnclosureKindAudio, result.Articles[1].Enclosures[0].Kind)
+	assert.False(t, result.ShowEmptyState)
+	assert.Equal(t, 3, result.Pagination.TotalPages)
+	assert.Equal(t, 45, result.Pagination.TotalItems)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListArticles_EmptyState(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          models.ListArticlesQuery
+		showEmptyState bool
+	}{
+		{
+			name:           "no articles at all",
+			query:          models.ListArticlesQuery{UserID: "user-123", Page: 1},
+			showEmptyState: true,
+		},
+		{
+			name:           "no articles matching the filters",
+			query:          models.ListArticlesQuery{UserID: "user-123", From: "2025-01-01", Page: 1},
+			showEmptyState: false,
+		},
+		{
+			name:           "no unread articles",
+			query:          models.ListArticlesQuery{UserID: "user-123", Unread: true, Page: 1},
+			showEmptyState: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockArticleRepository)
+			service := NewService(mockRepo, mockRepo, newTestLogger())
+			ctx := context.Background()
+
+			mockRepo.On("ListArticles", ctx, tt.query).Return(&ListArticlesResult{Articles: []ArticleWithFeed{}}, nil)
+
+			result, err := service.ListArticles(ctx, tt.query)
+
+			require.NoError(t, err)
+			assert.Empty(t, result.Articles)
+			assert.Equal(t, tt.showEmptyState, result.ShowEmptyState)
+		})
+	}
+}
+
+func TestListArticles_DatabaseError(t *testing.T) {
+	mockRepo := new(MockArticleRepository)
+	service := NewService(mockRepo, mockRepo, newTestLogger())
+
+	ctx := context.Background()
+	query := models.ListArticlesQuery{UserID: "user-123", Page: 1}
+
+	mockRepo.On("ListArticles", ctx, query).Return(nil, errors.New("connection refused"))
+
+	result, err := service.ListArticles(ctx, query)
+
+	assert.Nil(t, result)
+	var serviceErr *sharederrors.ServiceError
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, http.StatusInternalServerError, serviceErr.Code)
+	assert.Equal(t, "Database operation failed", serviceErr.Message)
+}
+
+func TestListArticles_Search(t *testing.T) {
+	mockRepo := new(MockArticleRepository)
+	service := NewService(mockRepo, mockRepo, newTestLogger())
+
+	ctx := context.Background()
+	query := models.ListArticlesQuery{UserID: "user-123", Search: "postgres", Page: 1}
+
+	mockRepo.On("SearchArticles", ctx, query).Return([]SearchHit{
+		{ID: "article-2", Rank: 0.9, TitleHighlight: "\ue000Postgres\ue001 tips", ContentHighlight: "Tuning \ue000Postgres\ue001", TotalCount: 3},
+		{ID: "article-deleted", Rank: 0.5, TotalCount: 3},
+		{ID: "article-1", Rank: 0.1, TitleHighlight: "Databases", ContentHighlight: "about \ue000postgres\ue001", TotalCount: 3},
+	}, nil)
+	// The articles are returned in no particular order
+	mockRepo.On("FindArticles", ctx, []string{"article-2", "article-deleted", "article-1"}).Return([]ArticleWithFeed{
+		newTestArticle("article-1", "feed-1", "Blog", "Databases"),
+		newTestArticle("article-2", "feed-1", "Blog", "Postgres tips"),
+	}, nil)
+
+	result, err := service.ListArticles(ctx, query)
+
+	require.NoError(t, err)
+	require.Len(t, result.Articles, 2)
+	// Ranked order is kept, the article deleted after the search is skipped
+	assert.Equal(t, "article-2", result.Articles[0].ID)
+	assert.Equal(t, []models.HighlightSegment{{Text: "Postgres", Match: true}, {Text: " tips"}}, result.Articles[0].TitleHighlight)
+	assert.Equal(t, "article-1", result.Articles[1].ID)
+	assert.Equal(t, []models.HighlightSegment{{Text: "about "}, {Text: "postgres", Match: true}}, result.Articles[1].ExcerptHighlight)
+	assert.Equal(t, 3, result.Pagination.TotalItems)
+	assert.False(t, result.ShowEmptyState)
+	mockRepo.AssertNotCalled(t, "ListArticles", mock.Anything, mock.Anything)
+}
+
+func TestListArticles_SearchWithoutResults(t *testing.T) {
+	mockRepo := new(MockArticleRepository)
+	service := NewService(mockRepo, mockRepo, newTestLogger())
+
+	ctx := context.Background()
+	query := models.ListArticlesQuery{UserID: "user-123", Search: "nothing", Page: 1}
+
+	mockRepo.On("SearchArticles", ctx, query).Return([]SearchHit{}, nil)
+
+	result, err := service.ListArticles(ctx, query)
+
+	require.NoError(t, err)
+	assert.Empty(t, result.Articles)
+	assert.False(t, result.ShowEmptyState)
+	mockRepo.AssertNotCalled(t, "FindArticles", mock.Anything, mock.Anything)
+}
+
+func TestListArticles_SearchError(t *testing.T) {
+	mockRepo := new(MockArticleRepository)
+	service := NewService(mockRepo, mockRepo, newTestLogger())
+
+	ctx := context.Background()
+	query := models.ListArticlesQuery{UserID: "user-123", Search: "postgres", Page: 1}
+
+	mockRepo.On("SearchArticles", ctx, query).Return(nil, errors.New("rpc error"))
+
+	result, err := service.ListArticles(ctx, query)
+
+	assert.Nil(t, result)
+	var serviceErr *sharederrors.ServiceError
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, http.StatusInternalServerError, serviceErr.Code)
+}
+
+func TestOpenArticle_MarksRead(t *testing.T) {
+	mockRepo := new(MockArticleRepository)
+	service := NewService(mockRepo, mockRepo, newTestLogger())
+	ctx := context.Background()
+
+	mockRepo.On("FindArticleURL", ctx, "article-1").Return("https://example.com/article-1", nil)
+	mockRepo.On("MarkArticleRead", ctx, "user-123", "article-1").Return(nil)
+
+	articleURL, err := service.OpenArticle(ctx, "user-123", "article-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/article-1", articleURL)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOpenArticle_MarkReadFailureStillOpens(t *testing.T) {
+	mockRepo := new(MockArticleRepository)
+	service := NewService(mockRepo, mockRepo, newTestLogger())
+	ctx := context.Background()
+
+	mockRepo.On("FindArticleURL", ctx, "article-1").Return("https://example.com/article-1", nil)
+	mockRepo.On("MarkArticleRead", ctx, "user-123", "article-1").Return(errors.New("connection refused"))
+
+	articleURL, err := service.OpenArticle(ctx, "user-123", "article-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/article-1", articleURL)
+}
+
+func TestOpenArticle_NotFound(t *testing.T) {
+	mockRepo := new(MockArticleRepository)
+	service := NewService(mockRepo, mockRepo, newTestLogger())
+	ctx := context.Background()
+
+	// Simulate not found error from database
+	mockRepo.On("FindArticleURL", ctx, "article-1").Return("", errors.New("no rows"))
+
+	articleURL, err := service.OpenArticle(ctx, "user-123", "article-1")
+
+	assert.Empty(t, articleURL)
+	var serviceErr *sharederrors.ServiceError
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, http.StatusNotFound, serviceErr.Code)
+	mockRepo.AssertNotCalled(t, "MarkArticleRead", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReadArticle_MarksRead(t *testing.T) {
+	mockRepo := new(MockArticleRepository)
+	service := NewService(mockRepo, mockRepo, newTestLogger())
+	ctx := context.Background()
+
+	contentHTML := "<p>Hello</p>"
+	mockRepo.On("FindArticleContent", ctx, "article-1").Return(&database.PublicArticlesSelect{
+		Id: "article-1", Url: "https://example.com/article-1", ContentHtml: &contentHTML,
+	}, nil)
+	mockRepo.On("MarkArticleRead", ctx, "user-123", "article-1").Return(nil)
+
+	vm, err := service.ReadArticle(ctx, "user-123", "article-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "article-1", vm.ID)
+	assert.Equal(t, "https://example.com/article-1", vm.URL)
+	assert.Equal(t, "<p>Hello</p>", vm.HTML)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestReadArticle_WithoutContent(t *testing.T) {
+	mockRepo := new(MockArticleRepository)
+	service := NewService(mockRepo, mockRepo, newTestLogger())
+	ctx := context.Background()
+
+	mockRepo.On("FindArticleContent", ctx, "article-1").Return(&database.PublicArticlesSelect{
+		Id: "article-1", Url: "https://example.com/article-1",
+	}, nil)
+	mockRepo.On("MarkArticleRead", ctx, "user-123", "article-1").Return(nil)
+
+	vm, err := service.ReadArticle(ctx, "user-123", "article-1")
+
+	require.NoError(t, err)
+	assert.Empty(t, vm.HTML)
+}
+
+func TestReadArticle_NotFound(t *testing.T) {
+	mockRepo := new(MockArticleRepository)
+	service := NewService(mockRepo, mockRepo, newTestLogger())
+	ctx := context.Background()
+
+	// Simulate not found error from database
+	mockRepo.On("FindArticleContent", ctx, "article-1").Return(nil, errors.New("no rows"))
+
+	vm, err := service.ReadArticle(ctx, "user-123", "article-1")
+
+	assert.Nil(t, vm)
+	var serviceErr *sharederrors.ServiceError
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, http.StatusNotFound, serviceErr.Code)
+	mockRepo.AssertNotCalled(t, "MarkArticleRead", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMarkArticlesRead(t *testing.T) {
+	mockRepo := new(MockArticleRepository)
+	service := NewService(mockRepo, mockRepo, newTestLogger())
+	ctx := context.Background()
+	cmd := models.MarkArticlesReadCommand{UserID: "user-123", Tag: "tag-1"}
+
+	mockRepo.On("MarkArticlesRead", ctx, cmd).Return(12, nil)
+
+	count, err := service.MarkArticlesRead(ctx, cmd)
+
+	require.NoError(t, err)
+	assert.Equal(t, 12, count)
+}
+
+func TestMarkArticlesRead_DatabaseError(t *testing.T) {
+	mockRepo := new(MockArticleRepository)
+	service := NewService(mockRepo, mockRepo, newTestLogger())
+	ctx := context.Background()
+	cmd := models.MarkArticlesReadCommand{UserID: "user-123"}
+
+	mockRepo.On("MarkArticlesRead", ctx, cmd).Return(0, errors.New("connection refused"))
+
+	_, err := service.MarkArticlesRead(ctx, cmd)
+
+	var serviceErr *sharederrors.ServiceError
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, http.StatusInternalServerError, serviceErr.Code)
+}
+
+func TestStarArticle(t *testing.T) {
+	mockRepo := new(MockArticleRepository)
+	service := NewService(mockRepo, mockRepo, newTestLogger())
+	ctx := context.Background()
+
+	articleID := "article-1"
+	mockRepo.On("StarArticle", ctx, articleID).Return(&database.PublicSavedArticlesSelect{Id: "saved-1", ArticleId: &articleID}, nil)
+
+	err := service.StarArticle(ctx, "user-123", articleID)
+
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestStarArticle_NotFound(t *testing.T) {
+	mockRepo := new(MockArticleRepository)
+	service := NewService(mockRepo, mockRepo, newTestLogger())
+	ctx := context.Background()
+
+	// No row is returned for missing articles and articles of other users
+	mockRepo.On("StarArticle", ctx, "article-1").Return(nil, nil)
+
+	err := service.StarArticle(ctx, "user-123", "article-1")
+
+	var serviceErr *sharederrors.ServiceError
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, http.StatusNotFound, serviceErr.Code)
+}
+
+func TestUnstarArticle_DatabaseError(t *testing.T) {
+	mockRepo := new(MockArticleRepository)
+	service := NewService(mockRepo, mockRepo, newTestLogger())
+	ctx := context.Background()
+
+	mockRepo.On("UnstarArticle", ctx, "user-123", "article-1").Return(errors.New("connection refused"))
+
+	err := service.UnstarArticle(ctx, "user-123", "article-1")
+
+	var serviceErr *sharederrors.ServiceError
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, http.StatusInternalServerError, serviceErr.Code)
+}
+
+func TestListSavedArticles(t *testing.T) {
+	mockRepo := new(MockArticleRepository)
+	service := NewService(mockRepo, mockRepo, newTestLogger())
+	ctx := context.Background()
+	query := models.ListSavedArticlesQuery{UserID: "user-123", Page: 1}
+
+	articleID := "article-1"
+	mockRepo.On("ListSavedArticles", ctx, query).Return(&ListSavedArticlesResult{
+		Articles: []database.PublicSavedArticlesSelect{
+			{Id: "saved-1", ArticleId: &articleID, Title: "Kept", FeedName: "Blog"},
+			{Id: "saved-2", Title: "Feed was deleted", FeedName: "Gone"},
+		},
+		TotalCount: 25,
+	}, nil)
+
+	result, err := service.ListSavedArticles(ctx, query)
+
+	require.NoError(t, err)
+	require.Len(t, result.Articles, 2)
+	assert.False(t, result.Articles[0].SourceDeleted)
+	assert.True(t, result.Articles[1].SourceDeleted)
+	assert.False(t, result.ShowEmptyState)
+	assert.Equal(t, 2, result.Pagination.TotalPages)
+}
+
+func TestListSavedArticles_EmptyState(t *testing.T) {
+	mockRepo := new(MockArticleRepository)
+	service := NewService(mockRepo, mockRepo, newTestLogger())
+	ctx := context.Background()
+	query := models.ListSavedArticlesQuery{UserID: "user-123", Page: 1}
+
+	mockRepo.On("ListSavedArticles", ctx, query).Return(&ListSavedArticlesResult{}, nil)
+
+	result, err := service.ListSavedArticles(ctx, query)
+
+	require.NoError(t, err)
+	assert.Empty(t, result.Articles)
+	assert.True(t, result.ShowEmptyState)
+}
+
+func TestDeleteSavedArticle_DatabaseError(t *testing.T) {
+	mockRepo := new(MockArticleRepository)
+	service := NewService(mockRepo, mockRepo, newTestLogger())
+	ctx := context.Background()
+
+	mockRepo.On("DeleteSavedArticle", ctx, "user-123", "saved-1").Return(errors.New("connection refused"))
+
+	err := service.DeleteSavedArticle(ctx, "user-123", "saved-1")
+
+	var serviceErr *sharederrors.ServiceError
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, http.StatusInternalServerError, serviceErr.Code)
+}
+
+func TestMarkedReadMessage(t *testing.T) {
+	assert.Equal(t, "All articles were already read", markedReadMessage(0))
+	assert.Equal(t, "1 article was marked as read", markedReadMessage(1))
+	assert.Equal(t, "5 articles were marked as read", markedReadMessage(5))
+}
+
+func TestArticleWithFeed_DecodesEnclosures(t *testing.T) {
+	var article ArticleWithFeed
+	err := json.Unmarshal([]byte(`{
+		"id": "article-1",
+		"enclosures": [{"url": "https://example.com/a.mp3", "type": "audio/mpeg", "length": 10}],
+		"feeds": {"id": "feed-1", "name": "Podcast"},
+		"article_reads": [],
+		"has_content_html": true
+	}`), &article)
+
+	require.NoError(t, err)
+	assert.Equal(t, "article-1", article.Id)
+	assert.Equal(t, []models.Enclosure{{URL: "https://example.com/a.mp3", Type: "audio/mpeg", Length: 10}}, article.Enclosures)
+	assert.Equal(t, "Podcast", article.Feed.Name)
+	assert.Empty(t, article.Reads)
+	assert.True(t, article.HasHTML)
+}
@@ -0,0 +1,36 @@
+package view
+
+import (
+	"path"
+	"strings"
+
+	"github.com/tjanas94/vibefeeder/internal/article/models"
+)
+
+// articleTitle returns the title of an article, falling back to its URL for untitled entries
+func articleTitle(article models.ArticleItemViewModel) string {
+	if strings.TrimSpace(article.Title) == "" {
+		return article.URL
+	}
+	return article.Title
+}
+
+// enclosureLabel returns the file name of an attachment with its type
+func enclosureLabel(enclosure models.EnclosureViewModel) string {
+	name := path.Base(strings.SplitN(enclosure.URL, "?", 2)[0])
+	if name == "" || name == "." || name == "/" {
+		name = "Attachment"
+	}
+	if enclosure.Type != "" {
+		return name + " (" + enclosure.Type + ")"
+	}
+	return name
+}
+
+// savedTitle returns the title of a saved article, falling back to its URL for untitled entries
+func savedTitle(article models.SavedArticleViewModel) string {
+	if strings.TrimSpace(article.Title) == "" {
+		return article.URL
+	}
+	return article.Title
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/tjanas94/vibefeeder/internal/shared/config"
 	"github.com/tjanas94/vibefeeder/internal/shared/database"
 	"github.com/tjanas94/vibefeeder/internal/shared/events"
+	"github.com/tjanas94/vibefeeder/internal/shared/tracing"
 )
 
 // Service handles authentication business logic
@@ -96,7 +97,10 @@ func (s *Service) Login(ctx context.Context, req models.LoginRequest) (*sharedAu
 }
 
 // RefreshSession refreshes an expired access token using refresh token
-func (s *Service) RefreshSession(ctx context.Context, refreshToken string) (*sharedAuth.UserSession, error) {
+func (s *Service) RefreshSession(ctx context.Context, refreshToken string) (_ *sharedAuth.UserSession, err error) {
+	_, span := tracing.Start(ctx, "auth.Service.RefreshSession")
+	defer func() { tracing.End(span, err) }()
+
 	resp, err := s.authClient.RefreshToken(refreshToken)
 	if err != nil {
 		s.logger.Debug("Token refresh failed", "error", err)
@@ -115,7 +119,10 @@ func (s *Service) RefreshSession(ctx context.Context, refreshToken string) (*sha
 }
 
 // GetUserByToken retrieves user information from an access token
-func (s *Service) GetUserByToken(ctx context.Context, accessToken string) (*sharedAuth.UserSession, error) {
+func (s *Service) GetUserByToken(ctx context.Context, accessToken string) (_ *sharedAuth.UserSession, err error) {
+	_, span := tracing.Start(ctx, "auth.Service.GetUserByToken")
+	defer func() { tracing.End(span, err) }()
+
 	// Create a client with the access token
 	client := s.authClient.WithToken(accessToken)
 
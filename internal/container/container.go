@@ -9,15 +9,21 @@ import (
 
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/supabase-community/gotrue-go"
+	"github.com/tjanas94/vibefeeder/internal/article"
 	authModule "github.com/tjanas94/vibefeeder/internal/auth"
 	"github.com/tjanas94/vibefeeder/internal/dashboard"
 	"github.com/tjanas94/vibefeeder/internal/feed"
 	"github.com/tjanas94/vibefeeder/internal/fetcher"
+	"github.com/tjanas94/vibefeeder/internal/health"
+	"github.com/tjanas94/vibefeeder/internal/mute"
+	"github.com/tjanas94/vibefeeder/internal/settings"
 	"github.com/tjanas94/vibefeeder/internal/shared/ai"
 	sharedAuth "github.com/tjanas94/vibefeeder/internal/shared/auth"
 	"github.com/tjanas94/vibefeeder/internal/shared/config"
+	"github.com/tjanas94/vibefeeder/internal/shared/credentials"
 	"github.com/tjanas94/vibefeeder/internal/shared/database"
 	"github.com/tjanas94/vibefeeder/internal/shared/events"
+	"github.com/tjanas94/vibefeeder/internal/shared/tags"
 	"github.com/tjanas94/vibefeeder/internal/summary"
 	"golang.org/x/time/rate"
 )
@@ -31,23 +37,38 @@ type Container struct {
 	Ctx    context.Context
 
 	// Repositories
-	EventsRepo  *events.Repository
-	FeedRepo    *feed.Repository
-	SummaryRepo *summary.Repository
-	FetcherRepo *fetcher.Repository
+	EventsRepo   *events.Repository
+	TagsRepo     *tags.Repository
+	FeedRepo     *feed.Repository
+	SummaryRepo  *summary.Repository
+	FetcherRepo  *fetcher.Repository
+	HealthRepo   *health.Repository
+	ArticleRepo  *article.Repository
+	MuteRepo     *mute.Repository
+	SettingsRepo *settings.Repository
 
 	// Services
-	AuthService    *authModule.Service
-	FeedService    *feed.Service
-	SummaryService *summary.Service
-	AIService      *ai.OpenRouterService
-	FeedFetcher    *fetcher.FeedFetcherService
+	AuthService     *authModule.Service
+	FeedService     *feed.Service
+	SummaryService  *summary.Service
+	AIService       *ai.OpenRouterService
+	FeedFetcher     *fetcher.FeedFetcherService
+	RetentionJob    *fetcher.RetentionJob
+	HealthService   *health.Service
+	ArticleService  *article.Service
+	MuteService     *mute.Service
+	SettingsService *settings.Service
 
 	// Handlers
 	AuthHandler      *authModule.Handler
 	DashboardHandler *dashboard.Handler
 	FeedHandler      *feed.Handler
 	SummaryHandler   *summary.Handler
+	FetcherHandler   *fetcher.Handler
+	HealthHandler    *health.Handler
+	ArticleHandler   *article.Handler
+	MuteHandler      *mute.Handler
+	SettingsHandler  *settings.Handler
 
 	// Middleware and utilities
 	SessionManager   sharedAuth.SessionManager
@@ -89,9 +110,14 @@ func New(cfg *config.Config, db *database.Client, logger *slog.Logger, ctx conte
 // initRepositories initializes all repository instances
 func (c *Container) initRepositories() error {
 	c.EventsRepo = events.NewRepository(c.DB)
+	c.TagsRepo = tags.NewRepository(c.DB)
 	c.FeedRepo = feed.NewRepository(c.DB)
 	c.SummaryRepo = summary.NewRepository(c.DB)
 	c.FetcherRepo = fetcher.NewRepository(c.DB)
+	c.HealthRepo = health.NewRepository(c.DB)
+	c.ArticleRepo = article.NewRepository(c.DB)
+	c.MuteRepo = mute.NewRepository(c.DB)
+	c.SettingsRepo = settings.NewRepository(c.DB)
 
 	return nil
 }
@@ -104,8 +130,27 @@ func (c *Container) initServices() error {
 	authAdapter := sharedAuth.NewGoTrueClientAdapter(goTrueClient)
 	c.AuthService = authModule.NewService(authAdapter, c.EventsRepo, &c.Config.Auth, c.Logger)
 
+	// Initialize feed credentials cipher (optional - credentials are disabled without a key)
+	var credentialCipher *credentials.Cipher
+	if c.Config.Credentials.EncryptionKey != "" {
+		cipher, err := credentials.NewCipher(c.Config.Credentials.EncryptionKey)
+		if err != nil {
+			return fmt.Errorf("invalid CREDENTIALS_ENCRYPTION_KEY: %w", err)
+		}
+		credentialCipher = cipher
+	}
+
 	// Initialize feed service
-	c.FeedService = feed.NewService(c.FeedRepo, c.EventsRepo, c.Logger)
+	c.FeedService = feed.NewService(c.FeedRepo, c.TagsRepo, c.EventsRepo, credentialCipher, c.Logger)
+
+	// Initialize article service
+	c.ArticleService = article.NewService(c.ArticleRepo, c.TagsRepo, c.Logger)
+
+	// Initialize mute rule service
+	c.MuteService = mute.NewService(c.MuteRepo, c.Logger)
+
+	// Initialize settings service (per-user article retention)
+	c.SettingsService = settings.NewService(c.SettingsRepo, c.Config.Retention.MaxAgeDays, c.Logger)
 
 	// Initialize AI service
 	httpClient := &http.Client{
@@ -119,7 +164,7 @@ func (c *Container) initServices() error {
 	c.AIService = aiService
 
 	// Initialize summary service
-	c.SummaryService = summary.NewService(c.SummaryRepo, c.AIService, c.Logger, c.EventsRepo)
+	c.SummaryService = summary.NewService(c.SummaryRepo, c.TagsRepo, c.AIService, c.Logger, c.EventsRepo)
 
 	// Initialize feed fetcher service
 	fetcherHTTPClient := fetcher.NewHTTPClient(fetcher.HTTPClientConfig{
@@ -129,12 +174,36 @@ func (c *Container) initServices() error {
 	})
 	c.FeedFetcher = fetcher.NewFeedFetcherService(
 		c.FetcherRepo,
+		c.FetcherRepo,
+		fetcherHTTPClient,
 		fetcherHTTPClient,
+		credentialCipher,
 		c.Logger,
 		c.Config.Fetcher,
 		c.Ctx,
 	)
 
+	// Initialize article retention job (keeps the articles the fetcher would save again)
+	c.RetentionJob = fetcher.NewRetentionJob(
+		c.FetcherRepo,
+		c.Logger,
+		c.Config.Retention,
+		c.Config.Fetcher.MaxArticlesPerFeed,
+		c.Ctx,
+	)
+
+	// Initialize health service (readiness checks of Supabase, fetcher and AI)
+	c.HealthService = health.NewService(
+		c.HealthRepo,
+		goTrueClient.WithClient(http.Client{Timeout: 5 * time.Second}),
+		c.FeedFetcher,
+		health.Config{
+			FetchInterval: c.Config.Fetcher.FetchInterval,
+			AIConfigured:  c.Config.OpenRouter.APIKey != "",
+		},
+		c.Logger,
+	)
+
 	return nil
 }
 
@@ -166,8 +235,21 @@ func (c *Container) initHandlers() error {
 	// Initialize feed handler
 	c.FeedHandler = feed.NewHandler(c.FeedService, c.FeedFetcher)
 
+	// Initialize article handler (timeline)
+	c.ArticleHandler = article.NewHandler(c.ArticleService)
+
+	// Initialize settings and mute rule handlers
+	c.SettingsHandler = settings.NewHandler(c.SettingsService)
+	c.MuteHandler = mute.NewHandler(c.MuteService)
+
 	// Initialize summary handler
 	c.SummaryHandler = summary.NewHandler(c.SummaryService)
 
+	// Initialize fetcher handler (WebSub callbacks)
+	c.FetcherHandler = fetcher.NewHandler(c.FeedFetcher)
+
+	// Initialize health handler (liveness and readiness probes)
+	c.HealthHandler = health.NewHandler(c.HealthService)
+
 	return nil
 }
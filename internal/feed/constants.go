@@ -1,8 +1,33 @@
 package feed
 
+import "time"
+
 // pageSize defines how many feed records are returned per page for list endpoints.
 // Single source of truth: adjust here if pagination size needs to change.
 // Used by:
 //   - repository.go (offset calculation, Range query)
 //   - service.go (BuildPagination call)
 const pageSize = 20
+
+// historySize defines how many recent fetch attempts are shown in the feed history view.
+const historySize = 50
+
+// exportPageSize defines how many feeds are read from the database at a time while streaming an export.
+const exportPageSize = 500
+
+// maxFeedNameLength matches the max validation tag of the feed name.
+const maxFeedNameLength = 255
+
+// OPML import limits. The upload is read into memory, so both are kept small.
+// maxImportFeeds bounds the number of sequential inserts done in a single request.
+const (
+	maxOPMLSize    = 1 << 20 // 1 MB
+	maxImportFeeds = 500
+)
+
+// Imported feeds are first fetched after importFetchDelay (like feeds added by hand),
+// each next one importFetchSpacing later, so a large import doesn't land in a single fetcher batch.
+const (
+	importFetchDelay   = 5 * time.Minute
+	importFetchSpacing = 10 * time.Second
+)
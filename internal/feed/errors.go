@@ -18,6 +18,18 @@ func NewFeedAlreadyExistsError() *sharederrors.ServiceError {
 	)
 }
 
+// NewFeedURLNotAFeedError creates a ServiceError when an added URL is neither a feed nor a page linking to one
+// Returns 422 Unprocessable Entity with field error
+func NewFeedURLNotAFeedError(message string) *sharederrors.ServiceError {
+	return sharederrors.NewServiceErrorWithFields(
+		http.StatusUnprocessableEntity,
+		"",
+		map[string]string{
+			"URL": message,
+		},
+	)
+}
+
 // NewFeedNotFoundError creates a ServiceError when a feed is not found or doesn't belong to the user
 // Returns 404 Not Found
 func NewFeedNotFoundError() *sharederrors.ServiceError {
@@ -36,3 +48,25 @@ func NewDatabaseError(err error) *sharederrors.ServiceError {
 		err,
 	)
 }
+
+// NewCredentialsDisabledError creates a ServiceError when feed credentials are submitted
+// but no encryption key is configured on the server
+// Returns 422 Unprocessable Entity
+func NewCredentialsDisabledError() *sharederrors.ServiceError {
+	return sharederrors.NewServiceError(
+		http.StatusUnprocessableEntity,
+		"Feed credentials are not enabled on this server",
+	)
+}
+
+// NewAuthTokenRequiredError creates a ServiceError when bearer authorization is selected without a token
+// Returns 422 Unprocessable Entity with field error
+func NewAuthTokenRequiredError() *sharederrors.ServiceError {
+	return sharederrors.NewServiceErrorWithFields(
+		http.StatusUnprocessableEntity,
+		"",
+		map[string]string{
+			"AuthToken": "This field is required",
+		},
+	)
+}
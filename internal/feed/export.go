@@ -0,0 +1,187 @@
+package feed
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/tjanas94/vibefeeder/internal/feed/models"
+)
+
+// exportTitle is the title of exported OPML documents
+const exportTitle = "VibeFeeder subscriptions"
+
+// opmlWriter streams feeds as an OPML 2.0 document
+type opmlWriter struct {
+	enc *xml.Encoder
+	w   io.Writer
+}
+
+// Ensure opmlWriter implements GroupedFeedExportWriter interface at compile time
+var _ GroupedFeedExportWriter = (*opmlWriter)(nil)
+
+// newOPMLWriter creates a writer of an OPML 2.0 document
+func newOPMLWriter(w io.Writer) *opmlWriter {
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return &opmlWriter{enc: enc, w: w}
+}
+
+// opmlHead is the head element of an exported OPML document
+type opmlHead struct {
+	Title       string `xml:"title"`
+	DateCreated string `xml:"dateCreated"` // RFC 822 date, as required by OPML 2.0
+}
+
+// opmlFeedOutline is a subscription outline of an exported OPML document
+type opmlFeedOutline struct {
+	Type        string `xml:"type,attr"`
+	Text        string `xml:"text,attr"`
+	Title       string `xml:"title,attr"`
+	XMLURL      string `xml:"xmlUrl,attr"`
+	HTMLURL     string `xml:"htmlUrl,attr,omitempty"`
+	Description string `xml:"description,attr,omitempty"`
+}
+
+// Begin writes the XML declaration, the head and opens the body
+func (o *opmlWriter) Begin(exportedAt time.Time) error {
+	if _, err := io.WriteString(o.w, xml.Header); err != nil {
+		return err
+	}
+
+	opml := xml.StartElement{
+		Name: xml.Name{Local: "opml"},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "version"}, Value: "2.0"}},
+	}
+	if err := o.enc.EncodeToken(opml); err != nil {
+		return err
+	}
+
+	head := opmlHead{Title: exportTitle, DateCreated: exportedAt.UTC().Format(time.RFC1123Z)}
+	if err := o.enc.EncodeElement(head, xml.StartElement{Name: xml.Name{Local: "head"}}); err != nil {
+		return err
+	}
+
+	return o.enc.EncodeToken(xml.StartElement{Name: xml.Name{Local: "body"}})
+}
+
+// WriteFeed writes a subscription outline
+func (o *opmlWriter) WriteFeed(feed models.FeedExportItem) error {
+	outline := opmlFeedOutline{
+		Type:        "rss",
+		Text:        feed.Name,
+		Title:       feed.Name,
+		XMLURL:      feed.URL,
+		HTMLURL:     feed.SiteURL,
+		Description: feed.Description,
+	}
+	return o.enc.EncodeElement(outline, xml.StartElement{Name: xml.Name{Local: "outline"}})
+}
+
+// BeginGroup opens a folder outline, the following feeds are nested in it
+func (o *opmlWriter) BeginGroup(name string) error {
+	folder := xml.StartElement{
+		Name: xml.Name{Local: "outline"},
+		Attr: []xml.Attr{
+			{Name: xml.Name{Local: "text"}, Value: name},
+			{Name: xml.Name{Local: "title"}, Value: name},
+		},
+	}
+	return o.enc.EncodeToken(folder)
+}
+
+// EndGroup closes the folder outline
+func (o *opmlWriter) EndGroup() error {
+	return o.enc.EncodeToken(xml.EndElement{Name: xml.Name{Local: "outline"}})
+}
+
+// Flush sends the encoded outlines to the client
+func (o *opmlWriter) Flush() error {
+	if err := o.enc.Flush(); err != nil {
+		return err
+	}
+	flushResponse(o.w)
+	return nil
+}
+
+// End closes the body and the document
+func (o *opmlWriter) End() error {
+	if err := o.enc.EncodeToken(xml.EndElement{Name: xml.Name{Local: "body"}}); err != nil {
+		return err
+	}
+	if err := o.enc.EncodeToken(xml.EndElement{Name: xml.Name{Local: "opml"}}); err != nil {
+		return err
+	}
+	if err := o.enc.Close(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(o.w, "\n")
+	return err
+}
+
+// jsonWriter streams feeds as a JSON document with an array of feeds
+// Written piece by piece, so the whole list is never held in memory
+type jsonWriter struct {
+	w     io.Writer
+	count int
+}
+
+// Ensure jsonWriter implements FeedExportWriter interface at compile time
+var _ FeedExportWriter = (*jsonWriter)(nil)
+
+// newJSONWriter creates a writer of a JSON export
+func newJSONWriter(w io.Writer) *jsonWriter {
+	return &jsonWriter{w: w}
+}
+
+// Begin writes the export metadata and opens the feeds array
+func (j *jsonWriter) Begin(exportedAt time.Time) error {
+	exportedAtJSON, err := json.Marshal(exportedAt.UTC())
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(j.w, "{\"version\":1,\"exported_at\":%s,\"feeds\":[", exportedAtJSON)
+	return err
+}
+
+// WriteFeed writes a feed as the next element of the array
+func (j *jsonWriter) WriteFeed(feed models.FeedExportItem) error {
+	data, err := json.Marshal(feed)
+	if err != nil {
+		return err
+	}
+
+	separator := ",\n"
+	if j.count == 0 {
+		separator = "\n"
+	}
+	j.count++
+
+	if _, err := io.WriteString(j.w, separator); err != nil {
+		return err
+	}
+	_, err = j.w.Write(data)
+	return err
+}
+
+// Flush sends the written feeds to the client
+func (j *jsonWriter) Flush() error {
+	flushResponse(j.w)
+	return nil
+}
+
+// End closes the feeds array and the document
+func (j *jsonWriter) End() error {
+	_, err := io.WriteString(j.w, "\n]}\n")
+	return err
+}
+
+// flushResponse sends the written part of an export to the client when w is a streamed HTTP response
+func flushResponse(w io.Writer) {
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,115 @@
+package feed
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tjanas94/vibefeeder/internal/feed/models"
+)
+
+var testExportFeeds = []models.FeedExportItem{
+	{
+		Name:        "The Go Blog",
+		URL:         "https://go.dev/blog/feed.atom",
+		SiteURL:     "https://go.dev/blog",
+		Description: "News & updates <from> the Go team",
+	},
+	{
+		Name:            "Hacker News",
+		URL:             "https://news.ycombinator.com/rss?q=a&b=c",
+		LastFetchStatus: "temporary_error",
+		LastFetchError:  "HTTP 503",
+	},
+}
+
+func writeTestExport(t *testing.T, w FeedExportWriter, feeds []models.FeedExportItem) {
+	t.Helper()
+	require.NoError(t, w.Begin(time.Date(2025, 11, 12, 12, 0, 0, 0, time.UTC)))
+	for _, feed := range feeds {
+		require.NoError(t, w.WriteFeed(feed))
+	}
+	require.NoError(t, w.Flush())
+	require.NoError(t, w.End())
+}
+
+func TestOPMLWriter_RoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	writeTestExport(t, newOPMLWriter(&buf), testExportFeeds)
+
+	assert.Contains(t, buf.String(), `<opml version="2.0">`)
+	assert.Contains(t, buf.String(), "<dateCreated>Wed, 12 Nov 2025 12:00:00 +0000</dateCreated>")
+	assert.Contains(t, buf.String(), `htmlUrl="https://go.dev/blog"`)
+
+	// The export can be imported again
+	rows, err := parseOPML(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, []models.ImportFeedRow{
+		{Position: 1, Name: "The Go Blog", URL: "https://go.dev/blog/feed.atom"},
+		{Position: 2, Name: "Hacker News", URL: "https://news.ycombinator.com/rss?q=a&b=c"},
+	}, rows)
+}
+
+func TestOPMLWriter_GroupsBecomeFolders(t *testing.T) {
+	var buf bytes.Buffer
+	w := newOPMLWriter(&buf)
+
+	require.NoError(t, w.Begin(time.Date(2025, 11, 12, 12, 0, 0, 0, time.UTC)))
+	require.NoError(t, w.BeginGroup("Go"))
+	require.NoError(t, w.WriteFeed(testExportFeeds[0]))
+	require.NoError(t, w.EndGroup())
+	require.NoError(t, w.WriteFeed(testExportFeeds[1]))
+	require.NoError(t, w.Flush())
+	require.NoError(t, w.End())
+
+	// Folders are imported back as tags
+	rows, err := parseOPML(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, []models.ImportFeedRow{
+		{Position: 1, Name: "The Go Blog", URL: "https://go.dev/blog/feed.atom", Folder: "Go", Tags: []string{"Go"}},
+		{Position: 2, Name: "Hacker News", URL: "https://news.ycombinator.com/rss?q=a&b=c"},
+	}, rows)
+}
+
+func TestOPMLWriter_NoFeeds(t *testing.T) {
+	var buf bytes.Buffer
+	writeTestExport(t, newOPMLWriter(&buf), nil)
+
+	rows, err := parseOPML(&buf)
+	require.NoError(t, err)
+	assert.Empty(t, rows)
+}
+
+func TestJSONWriter(t *testing.T) {
+	tests := []struct {
+		name  string
+		feeds []models.FeedExportItem
+	}{
+		{name: "with feeds", feeds: testExportFeeds},
+		{name: "no feeds", feeds: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			writeTestExport(t, newJSONWriter(&buf), tt.feeds)
+
+			var export struct {
+				Version    int                     `json:"version"`
+				ExportedAt time.Time               `json:"exported_at"`
+				Feeds      []models.FeedExportItem `json:"feeds"`
+			}
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &export), buf.String())
+			assert.Equal(t, 1, export.Version)
+			assert.True(t, export.ExportedAt.Equal(time.Date(2025, 11, 12, 12, 0, 0, 0, time.UTC)))
+			assert.Len(t, export.Feeds, len(tt.feeds))
+			for i, feed := range tt.feeds {
+				assert.Equal(t, feed.URL, export.Feeds[i].URL)
+				assert.Equal(t, feed.LastFetchStatus, export.Feeds[i].LastFetchStatus)
+			}
+		})
+	}
+}
@@ -1,24 +1,29 @@
 package feed
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/tjanas94/vibefeeder/internal/feed/models"
 	"github.com/tjanas94/vibefeeder/internal/feed/view"
 	"github.com/tjanas94/vibefeeder/internal/shared/auth"
+	"github.com/tjanas94/vibefeeder/internal/shared/credentials"
 	sharederrors "github.com/tjanas94/vibefeeder/internal/shared/errors"
 	sharedmodels "github.com/tjanas94/vibefeeder/internal/shared/models"
 	"github.com/tjanas94/vibefeeder/internal/shared/validator"
 	sharedview "github.com/tjanas94/vibefeeder/internal/shared/view/components"
 )
 
-// FeedFetcher is an interface for triggering immediate feed fetches
+// FeedFetcher is an interface for triggering immediate feed fetches and checking new feed URLs
 type FeedFetcher interface {
 	FetchFeedNow(feedID string)
+	ResolveFeedURL(ctx context.Context, rawURL string, creds *credentials.FeedCredentials) (string, error)
 }
 
 // Handler handles HTTP requests for feed operations
@@ -102,8 +107,9 @@ func (h *Handler) CreateFeed(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid form data")
 	}
 
-	// Sanitize URL input
+	// Sanitize URL and name input
 	cmd.URL = strings.TrimSpace(cmd.URL)
+	cmd.Name = strings.TrimSpace(cmd.Name)
 
 	// Get user ID from authenticated session
 	cmd.UserID = auth.GetUserID(c)
@@ -113,20 +119,32 @@ func (h *Handler) CreateFeed(c echo.Context) error {
 		// Parse validation errors into view model
 		fieldErrors := validator.ParseFieldErrors(err)
 		errorVM := models.NewFeedFormErrorFromFieldErrors(fieldErrors)
-		vm := models.NewFeedFormWithErrors("add", "", cmd.Name, cmd.URL, errorVM)
-		return c.Render(http.StatusUnprocessableEntity, "", view.FeedForm(vm))
+		return c.Render(http.StatusUnprocessableEntity, "", view.FeedForm(cmd.ToFormViewModel(errorVM)))
+	}
+
+	// Path 3: Handle URLs without a feed - website pages are resolved to the feed linked from them
+	if h.feedFetcher != nil {
+		var creds *credentials.FeedCredentials
+		if formCreds := cmd.ToCredentials(nil); !formCreds.IsEmpty() {
+			creds = &formCreds
+		}
+		feedURL, err := h.feedFetcher.ResolveFeedURL(c.Request().Context(), cmd.URL, creds)
+		if err != nil {
+			return h.renderFormServiceError(c, NewFeedURLNotAFeedError(err.Error()), cmd.ToFormViewModel(models.FeedFormErrorViewModel{}))
+		}
+		cmd.URL = feedURL
 	}
 
 	// Call service to create feed
 	feedID, err := h.service.CreateFeed(c.Request().Context(), *cmd)
 	if err != nil {
-		// Path 3: Handle business errors (ServiceError)
+		// Path 4: Handle business errors (ServiceError)
 		var serviceErr *sharederrors.ServiceError
 		if errors.As(err, &serviceErr) {
-			return h.renderFormServiceError(c, serviceErr, "add", "", cmd.Name, cmd.URL)
+			return h.renderFormServiceError(c, serviceErr, cmd.ToFormViewModel(models.FeedFormErrorViewModel{}))
 		}
 
-		// Path 4: Unexpected error - delegate to global error handler
+		// Path 5: Unexpected error - delegate to global error handler
 		return err
 	}
 
@@ -139,6 +157,110 @@ func (h *Handler) CreateFeed(c echo.Context) error {
 	return h.renderSuccessToast(c, "Feed was added")
 }
 
+// HandleImportForm handles GET /feeds/import endpoint
+// Returns the OPML upload form in a modal
+func (h *Handler) HandleImportForm(c echo.Context) error {
+	// Success - add HX-Trigger header to open modal and render upload form
+	c.Response().Header().Set("HX-Trigger", `{"openModal": {"modal": "import"}}`)
+	return c.Render(http.StatusOK, "", view.FeedImportForm(models.FeedImportFormViewModel{}))
+}
+
+// ImportFeeds handles POST /feeds/import endpoint
+// Adds the subscriptions of an uploaded OPML file and renders the result of every row
+func (h *Handler) ImportFeeds(c echo.Context) error {
+	// Path 1: Handle missing or unreadable upload
+	file, err := c.FormFile("opml")
+	if err != nil {
+		return h.renderImportFormError(c, "Choose an OPML file to import")
+	}
+	if file.Size > maxOPMLSize {
+		return h.renderImportFormError(c, fmt.Sprintf("The file is too large (max %d KB)", maxOPMLSize/1024))
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return h.renderImportFormError(c, "The file could not be read")
+	}
+	defer func() { _ = src.Close() }()
+
+	// Path 2: Handle validation errors (not an OPML document, no feeds, too many feeds)
+	rows, err := parseOPML(io.LimitReader(src, maxOPMLSize))
+	if err != nil {
+		return h.renderImportFormError(c, "The file is not a valid OPML document")
+	}
+	if len(rows) == 0 {
+		return h.renderImportFormError(c, "No feeds were found in the file")
+	}
+	if len(rows) > maxImportFeeds {
+		return h.renderImportFormError(c, fmt.Sprintf("The file contains %d feeds, at most %d can be imported at once", len(rows), maxImportFeeds))
+	}
+
+	// Validate every row with the rules of the add feed form; invalid rows are reported, not rejected
+	userID := auth.GetUserID(c)
+	for i := range rows {
+		if err := c.Validate(rows[i].ToCreateCommand(userID)); err != nil {
+			rows[i].Error = importRowError(validator.ParseFieldErrors(err))
+		}
+	}
+
+	result := h.service.ImportFeeds(c.Request().Context(), models.ImportFeedsCommand{
+		UserID: userID,
+		Rows:   rows,
+	})
+
+	// Success - refresh feed list and show the results in the modal
+	// Imported feeds are not fetched immediately, their first fetches are spread by the service
+	c.Response().Header().Set("HX-Trigger", `{"refreshFeedList": null}`)
+	return c.Render(http.StatusOK, "", view.FeedImportResult(result))
+}
+
+// ExportFeeds handles GET /feeds/export endpoint
+// Streams all feeds of the authenticated user as an OPML 2.0 or JSON download
+func (h *Handler) ExportFeeds(c echo.Context) error {
+	query := new(models.ExportFeedsQuery)
+	// Path 1: Handle bind errors (invalid query parameters)
+	if err := c.Bind(query); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid query parameters")
+	}
+
+	// Path 2: Handle validation errors (unknown format)
+	if err := c.Validate(query); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Unsupported export format")
+	}
+	query.SetDefaults()
+	query.UserID = auth.GetUserID(c)
+
+	res := c.Response()
+	filename := fmt.Sprintf("vibefeeder-feeds-%s.%s", time.Now().Format("2006-01-02"), query.Format)
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	res.Header().Set("Cache-Control", "no-store")
+
+	// JSON lists the tags of every feed, OPML nests feeds in a folder per tag
+	var err error
+	switch query.Format {
+	case models.ExportFormatJSON:
+		res.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		err = h.service.ExportFeeds(c.Request().Context(), query.UserID, newJSONWriter(res))
+	default:
+		res.Header().Set(echo.HeaderContentType, "text/x-opml; charset=utf-8")
+		err = h.service.ExportFeedsGrouped(c.Request().Context(), query.UserID, newOPMLWriter(res))
+	}
+
+	if err != nil {
+		// Nothing was sent yet - drop the download headers and let the error handler respond
+		if !res.Committed {
+			res.Header().Del(echo.HeaderContentDisposition)
+			res.Header().Del(echo.HeaderContentType)
+		}
+
+		// Path 3 & 4: Business and unexpected errors - delegate to global error handler
+		// Once streaming has started the error handler can only log, the client gets a truncated file
+		return err
+	}
+
+	return nil
+}
+
 // HandleFeedEditForm handles GET /feeds/:id/edit endpoint
 // Returns an HTML form pre-filled with the feed's current data for editing
 func (h *Handler) HandleFeedEditForm(c echo.Context) error {
@@ -175,8 +297,9 @@ func (h *Handler) HandleUpdate(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid form data")
 	}
 
-	// Sanitize URL input
+	// Sanitize URL and name input
 	cmd.URL = strings.TrimSpace(cmd.URL)
+	cmd.Name = strings.TrimSpace(cmd.Name)
 
 	// Get user ID from authenticated session
 	cmd.UserID = auth.GetUserID(c)
@@ -186,12 +309,11 @@ func (h *Handler) HandleUpdate(c echo.Context) error {
 		// Parse validation errors into view model
 		fieldErrors := validator.ParseFieldErrors(err)
 		errorVM := models.NewFeedFormErrorFromFieldErrors(fieldErrors)
-		vm := models.NewFeedFormWithErrors("edit", cmd.ID, cmd.Name, cmd.URL, errorVM)
-		return c.Render(http.StatusUnprocessableEntity, "", view.FeedForm(vm))
+		return c.Render(http.StatusUnprocessableEntity, "", view.FeedForm(cmd.ToFormViewModel(errorVM)))
 	}
 
 	// Call service to update feed
-	urlChanged, err := h.service.UpdateFeed(c.Request().Context(), *cmd)
+	refetch, err := h.service.UpdateFeed(c.Request().Context(), *cmd)
 	if err != nil {
 		// Path 3: Handle business errors (ServiceError)
 		var serviceErr *sharederrors.ServiceError
@@ -202,15 +324,15 @@ func (h *Handler) HandleUpdate(c echo.Context) error {
 			}
 
 			// For other errors, show form with errors
-			return h.renderFormServiceError(c, serviceErr, "edit", cmd.ID, cmd.Name, cmd.URL)
+			return h.renderFormServiceError(c, serviceErr, cmd.ToFormViewModel(models.FeedFormErrorViewModel{}))
 		}
 
 		// Path 4: Unexpected error - delegate to global error handler
 		return err
 	}
 
-	// Trigger immediate fetch if URL changed
-	if urlChanged && h.feedFetcher != nil {
+	// Trigger immediate fetch if URL or credentials changed
+	if refetch && h.feedFetcher != nil {
 		h.feedFetcher.FetchFeedNow(cmd.ID)
 	}
 
@@ -251,6 +373,55 @@ func (h *Handler) HandleDeleteConfirmation(c echo.Context) error {
 	return c.Render(http.StatusOK, "", view.DeleteConfirmation(vm))
 }
 
+// HandleFeedHistory handles GET /feeds/:id/history endpoint
+// Renders recent fetch attempts of the feed in a modal
+func (h *Handler) HandleFeedHistory(c echo.Context) error {
+	// Get user ID from authenticated session
+	userID := auth.GetUserID(c)
+
+	// Get feed ID from path parameter
+	feedID := c.Param("id")
+
+	vm, err := h.service.GetFeedHistory(c.Request().Context(), feedID, userID)
+	if err != nil {
+		// Path 3: Handle business errors (ServiceError)
+		var serviceErr *sharederrors.ServiceError
+		if errors.As(err, &serviceErr) {
+			return h.renderErrorToast(c, serviceErr.Code, serviceErr.Message)
+		}
+
+		// Path 4: Unexpected error - delegate to global error handler
+		return err
+	}
+
+	// Success - add HX-Trigger header to open modal and render history
+	c.Response().Header().Set("HX-Trigger", `{"openModal": {"modal": "history"}}`)
+	return c.Render(http.StatusOK, "", view.FeedHistory(*vm))
+}
+
+// HandleFavicon handles GET /feeds/:id/favicon endpoint
+// Serves the cached site icon of the feed; the list links it with a version parameter, so it can be cached long
+func (h *Handler) HandleFavicon(c echo.Context) error {
+	// Get feed ID from path parameter
+	feedID := c.Param("id")
+
+	favicon, err := h.service.GetFavicon(c.Request().Context(), feedID)
+	if err != nil {
+		// Path 3: Handle business errors (ServiceError) - images have no error view
+		var serviceErr *sharederrors.ServiceError
+		if errors.As(err, &serviceErr) {
+			return c.NoContent(serviceErr.Code)
+		}
+
+		// Path 4: Unexpected error - delegate to global error handler
+		return err
+	}
+
+	c.Response().Header().Set("Cache-Control", "private, max-age=604800")
+	c.Response().Header().Set("X-Content-Type-Options", "nosniff")
+	return c.Blob(http.StatusOK, favicon.ContentType, favicon.Data)
+}
+
 // DeleteFeed handles DELETE /feeds/:id endpoint
 // Deletes a feed for the authenticated user
 func (h *Handler) DeleteFeed(c echo.Context) error {
@@ -301,15 +472,30 @@ func (h *Handler) renderSuccessToast(c echo.Context, message string) error {
 func (h *Handler) renderFormServiceError(
 	c echo.Context,
 	serviceErr *sharederrors.ServiceError,
-	mode string, // "add" or "edit"
-	feedID string,
-	name string,
-	url string,
+	vm models.FeedFormViewModel,
 ) error {
-	errorVM := models.FeedFormErrorViewModel{
-		GeneralError: serviceErr.Message,
-		URLError:     serviceErr.FieldErrors["URL"],
+	vm.Errors = models.FeedFormErrorViewModel{
+		GeneralError:   serviceErr.Message,
+		URLError:       serviceErr.FieldErrors["URL"],
+		AuthTokenError: serviceErr.FieldErrors["AuthToken"],
 	}
-	vm := models.NewFeedFormWithErrors(mode, feedID, name, url, errorVM)
 	return c.Render(serviceErr.Code, "", view.FeedForm(vm))
 }
+
+// renderImportFormError renders the OPML upload form with a file-level error
+func (h *Handler) renderImportFormError(c echo.Context, message string) error {
+	return c.Render(http.StatusUnprocessableEntity, "", view.FeedImportForm(models.FeedImportFormViewModel{
+		Error: message,
+	}))
+}
+
+// importRowError picks the message reported for an invalid import row, the feed URL being the usual culprit
+func importRowError(fieldErrors map[string]string) string {
+	if msg, ok := fieldErrors["URL"]; ok {
+		return "URL: " + msg
+	}
+	for field, msg := range fieldErrors {
+		return field + ": " + msg
+	}
+	return "Invalid feed"
+}
@@ -19,6 +19,9 @@ func buildDashboardURL(query models.ListFeedsQuery) string {
 	if query.Status != "" && query.Status != "all" {
 		params.Set("status", query.Status)
 	}
+	if query.Tag != "" {
+		params.Set("tag", query.Tag)
+	}
 	if query.Page > 1 {
 		params.Set("page", fmt.Sprintf("%d", query.Page))
 	}
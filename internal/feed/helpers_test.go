@@ -165,6 +165,15 @@ func TestBuildDashboardURL(t *testing.T) {
 			},
 			expected: "/dashboard",
 		},
+		{
+			name: "tag filter is included",
+			query: models.ListFeedsQuery{
+				Status: "all",
+				Tag:    "7c9e6679-7425-40de-944b-e07fc1f90ae7",
+				Page:   1,
+			},
+			expected: "/dashboard?tag=7c9e6679-7425-40de-944b-e07fc1f90ae7",
+		},
 		{
 			name: "UserID is ignored in URL building",
 			query: models.ListFeedsQuery{
@@ -1,67 +1,218 @@
 package models
 
 import (
+	"net/url"
+	"strings"
 	"time"
 
+	"github.com/tjanas94/vibefeeder/internal/shared/credentials"
 	"github.com/tjanas94/vibefeeder/internal/shared/database"
+	"github.com/tjanas94/vibefeeder/internal/shared/tags"
 )
 
+// FeedAuthFields holds the optional authorization settings of the feed form.
+// Secrets are write-only: saved passwords, tokens and header values are never rendered back.
+// Embedded in CreateFeedCommand and UpdateFeedCommand.
+type FeedAuthFields struct {
+	AuthType      string `form:"auth_type" json:"auth_type" validate:"omitempty,oneof=none basic bearer"`
+	AuthUsername  string `form:"auth_username" json:"auth_username" validate:"required_if=AuthType basic,max=255"`
+	AuthPassword  string `form:"auth_password" json:"-" validate:"max=1024"`
+	AuthToken     string `form:"auth_token" json:"-" validate:"max=4096"`
+	CustomHeaders string `form:"custom_headers" json:"-" validate:"max=4096,httpheaders"`
+}
+
 // CreateFeedCommand represents the input for creating a new feed.
 // Maps to database.PublicFeedsInsert.
 // Used by: POST /feeds
 type CreateFeedCommand struct {
-	Name   string `form:"name" json:"name" validate:"required,max=255"`
-	URL    string `form:"url" json:"url" validate:"required,http_url"`
-	UserID string `param:"-"`
+	Name             string `form:"name" json:"name" validate:"max=255"` // Empty: named after the channel title once fetched
+	URL              string `form:"url" json:"url" validate:"required,http_url"`
+	FetchFullContent bool   `form:"fetch_full_content" json:"fetch_full_content"`
+	Tags             string `form:"tags" json:"tags" validate:"tagnames"` // Comma-separated tag names
+	UserID           string `param:"-"`
+	FeedAuthFields
+}
+
+// ImportFeedsCommand represents the subscriptions read from an uploaded OPML file.
+// Used by: POST /feeds/import
+type ImportFeedsCommand struct {
+	UserID string
+	Rows   []ImportFeedRow
+}
+
+// ImportFeedRow is a single subscription of an OPML file.
+// Error is set when the row fails the validation rules of CreateFeedCommand.
+type ImportFeedRow struct {
+	Position int      // 1-based position of the subscription in the file
+	Name     string   // Outline title, empty to name the feed automatically
+	URL      string   // Outline xmlUrl
+	Folder   string   // Names of the enclosing outlines, e.g. "Tech / Go"
+	Tags     []string // Tag for every enclosing outline
+	Error    string
 }
 
 // UpdateFeedCommand represents the input for updating an existing feed.
 // Maps to database.PublicFeedsUpdate (subset of fields).
 // Used by: PATCH /feeds/{id}
 type UpdateFeedCommand struct {
-	ID     string `param:"id"`
-	UserID string `param:"-"`
-	Name   string `form:"name" json:"name" validate:"required,max=255"`
-	URL    string `form:"url" json:"url" validate:"required,http_url"`
+	ID               string `param:"id"`
+	UserID           string `param:"-"`
+	Name             string `form:"name" json:"name" validate:"max=255"` // Empty: named after the channel title
+	URL              string `form:"url" json:"url" validate:"required,http_url"`
+	FetchFullContent bool   `form:"fetch_full_content" json:"fetch_full_content"`
+	Tags             string `form:"tags" json:"tags" validate:"tagnames"`       // Comma-separated tag names, replace the current tags
+	ClearCredentials bool   `form:"clear_credentials" json:"clear_credentials"` // Remove saved credentials before applying the form
+	FeedAuthFields
+}
+
+// ToCredentials builds feed credentials from the form.
+// Empty password, token and headers keep the values of the saved credentials (nil when there are none).
+func (f FeedAuthFields) ToCredentials(saved *credentials.FeedCredentials) credentials.FeedCredentials {
+	var creds credentials.FeedCredentials
+
+	switch f.AuthType {
+	case credentials.AuthTypeBasic:
+		creds.Username = f.AuthUsername
+		creds.Password = f.AuthPassword
+		if creds.Password == "" && saved != nil && saved.AuthType() == credentials.AuthTypeBasic {
+			creds.Password = saved.Password
+		}
+	case credentials.AuthTypeBearer:
+		creds.Token = f.AuthToken
+		if creds.Token == "" && saved != nil {
+			creds.Token = saved.Token
+		}
+	}
+
+	// Headers are checked by the httpheaders validation tag before the command reaches this point
+	creds.Headers, _ = credentials.ParseHeaders(f.CustomHeaders)
+	if creds.Headers == nil && saved != nil {
+		creds.Headers = saved.Headers
+	}
+
+	return creds
 }
 
 // ToInsert converts CreateFeedCommand to database.PublicFeedsInsert.
 // UserID is automatically bound from authenticated session via custom binder.
 // Sets fetch_after to NOW() + 5 minutes to prevent race conditions with background job.
+// Without a name the feed is named after the URL host until the fetcher stores the channel title.
 func (c CreateFeedCommand) ToInsert() database.PublicFeedsInsert {
-	fetchAfter := time.Now().Add(5 * time.Minute).Format(time.RFC3339)
+	return c.ToInsertFetchedAfter(time.Now().Add(5 * time.Minute))
+}
+
+// ToInsertFetchedAfter converts CreateFeedCommand to database.PublicFeedsInsert with the given first fetch time.
+// Used by the OPML import to spread the first fetches of many feeds.
+func (c CreateFeedCommand) ToInsertFetchedAfter(fetchAt time.Time) database.PublicFeedsInsert {
+	fetchAfter := fetchAt.Format(time.RFC3339)
+
+	name := c.Name
+	nameIsAuto := name == ""
+	if nameIsAuto {
+		name = DefaultFeedName(c.URL)
+	}
 
 	return database.PublicFeedsInsert{
-		Name:       c.Name,
-		Url:        c.URL,
-		UserId:     c.UserID,
-		FetchAfter: &fetchAfter,
+		Name:             name,
+		NameIsAuto:       &nameIsAuto,
+		Url:              c.URL,
+		UserId:           c.UserID,
+		FetchAfter:       &fetchAfter,
+		FetchFullContent: &c.FetchFullContent,
 		// CreatedAt, UpdatedAt, Id will be set by database
 		// LastFetchStatus, LastFetchError will be set by background job
 	}
 }
 
+// ToCreateCommand converts the row to CreateFeedCommand, so imported feeds pass the same validation as added ones.
+func (r ImportFeedRow) ToCreateCommand(userID string) CreateFeedCommand {
+	return CreateFeedCommand{
+		Name:   r.Name,
+		URL:    r.URL,
+		UserID: userID,
+	}
+}
+
+// TagNames returns the tags entered in the form.
+// The list is checked by the tagnames validation tag before the command reaches the service.
+func (c CreateFeedCommand) TagNames() []string {
+	names, _ := tags.Parse(c.Tags)
+	return names
+}
+
+// TagNames returns the tags entered in the form; an empty list removes all tags of the feed.
+func (c UpdateFeedCommand) TagNames() []string {
+	names, _ := tags.Parse(c.Tags)
+	return names
+}
+
 // ToUpdate converts UpdateFeedCommand to database.PublicFeedsUpdate.
-// Only updates Name field; other fields remain unchanged.
+// Only updates user-editable settings; fetch-related fields remain unchanged.
+// An empty name switches the feed to automatic naming; the service picks the name in that case.
 func (c UpdateFeedCommand) ToUpdate() database.PublicFeedsUpdate {
-	return database.PublicFeedsUpdate{
-		Name: &c.Name,
+	nameIsAuto := c.Name == ""
+
+	update := database.PublicFeedsUpdate{
+		NameIsAuto:       &nameIsAuto,
+		FetchFullContent: &c.FetchFullContent,
 		// Other fields intentionally nil to avoid updating them
 	}
+	if !nameIsAuto {
+		update.Name = &c.Name
+	}
+
+	return update
 }
 
 // ToUpdateWithURLChange converts UpdateFeedCommand to database.PublicFeedsUpdate when URL has changed.
 // Resets fetch-related fields
 func (c UpdateFeedCommand) ToUpdateWithURLChange() database.PublicFeedsUpdate {
 	fetchAfter := time.Now().Add(5 * time.Minute).Format(time.RFC3339)
+	nameIsAuto := c.Name == ""
 
-	return database.PublicFeedsUpdate{
-		Name:            &c.Name,
-		Url:             &c.URL,
-		LastFetchStatus: nil, // Reset status
-		LastFetchError:  nil, // Reset error
-		LastModified:    nil, // Reset Last-Modified header
-		Etag:            nil, // Reset ETag header
-		FetchAfter:      &fetchAfter,
+	update := database.PublicFeedsUpdate{
+		NameIsAuto:       &nameIsAuto,
+		Url:              &c.URL,
+		FetchFullContent: &c.FetchFullContent,
+		LastFetchStatus:  nil, // Reset status
+		LastFetchError:   nil, // Reset error
+		LastModified:     nil, // Reset Last-Modified header
+		Etag:             nil, // Reset ETag header
+		FetchAfter:       &fetchAfter,
 	}
+	if !nameIsAuto {
+		update.Name = &c.Name
+	}
+
+	return update
+}
+
+// DefaultFeedName returns the name of a feed without a user-defined name before its first fetch
+// Uses the URL host without the "www." prefix, or the URL itself if it has no host
+func DefaultFeedName(feedURL string) string {
+	parsed, err := url.Parse(feedURL)
+	if err != nil || parsed.Hostname() == "" {
+		return feedURL
+	}
+	return strings.TrimPrefix(parsed.Hostname(), "www.")
+}
+
+// ToFormViewModel converts CreateFeedCommand back to the add form, e.g. to show validation errors.
+// Secrets are not rendered back and have to be entered again.
+func (c CreateFeedCommand) ToFormViewModel(errors FeedFormErrorViewModel) FeedFormViewModel {
+	vm := NewFeedFormWithErrors("add", "", c.Name, c.URL, errors)
+	vm.FetchFullContent = c.FetchFullContent
+	vm.Tags = c.Tags
+	vm.setAuthFields(c.FeedAuthFields)
+	return vm
+}
+
+// ToFormViewModel converts UpdateFeedCommand back to the edit form, e.g. to show validation errors.
+// Secrets are not rendered back; empty secret fields keep the saved values.
+func (c UpdateFeedCommand) ToFormViewModel(errors FeedFormErrorViewModel) FeedFormViewModel {
+	vm := NewFeedFormWithErrors("edit", c.ID, c.Name, c.URL, errors)
+	vm.FetchFullContent = c.FetchFullContent
+	vm.Tags = c.Tags
+	vm.setAuthFields(c.FeedAuthFields)
+	return vm
 }
@@ -1,8 +1,10 @@
 package models
 
 import (
+	"strconv"
 	"time"
 
+	"github.com/tjanas94/vibefeeder/internal/shared/credentials"
 	"github.com/tjanas94/vibefeeder/internal/shared/database"
 	sharedmodels "github.com/tjanas94/vibefeeder/internal/shared/models"
 )
@@ -11,6 +13,8 @@ import (
 // Used by: GET /feeds
 type FeedListViewModel struct {
 	Feeds          []FeedItemViewModel              `json:"feeds"`
+	Tags           []TagViewModel                   `json:"tags"`         // All tags of the user, options of the tag filter
+	SelectedTag    string                           `json:"selected_tag"` // ID of the tag the list is filtered by
 	ShowEmptyState bool                             `json:"show_empty_state"`
 	ErrorMessage   string                           `json:"error_message,omitempty"`
 	Pagination     sharedmodels.PaginationViewModel `json:"pagination"`
@@ -20,32 +24,88 @@ type FeedListViewModel struct {
 // Derived from database.PublicFeedsSelect with computed HasError field.
 // Used by: GET /feeds, POST /feeds, PATCH /feeds/{id}, GET /dashboard
 type FeedItemViewModel struct {
-	ID            string    `json:"id"`
-	Name          string    `json:"name"`
-	URL           string    `json:"url"`
-	HasError      bool      `json:"has_error"`     // Computed: LastFetchStatus is 'permanent_error' or 'temporary_error'
-	ErrorMessage  string    `json:"error_message"` // From last_fetch_error
-	LastFetchedAt time.Time `json:"last_fetched_at"`
+	ID            string         `json:"id"`
+	Name          string         `json:"name"`
+	URL           string         `json:"url"`
+	SiteURL       string         `json:"site_url"`      // Website of the feed (empty until fetched)
+	FaviconURL    string         `json:"favicon_url"`   // Cached site icon served by the application (empty if none)
+	HasError      bool           `json:"has_error"`     // Computed: LastFetchStatus is 'permanent_error', 'temporary_error', 'unauthorized' or 'too_large'
+	ErrorMessage  string         `json:"error_message"` // From last_fetch_error
+	LastFetchedAt time.Time      `json:"last_fetched_at"`
+	Tags          []TagViewModel `json:"tags"`
+	UnreadCount   int            `json:"unread_count"` // Articles of the feed the user has not read
+}
+
+// TagViewModel represents a tag of the user.
+// Used by: GET /feeds (tag filter and feed tags)
+type TagViewModel struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// NewTagsFromDB creates TagViewModels from database.PublicTagsSelect rows.
+func NewTagsFromDB(dbTags []database.PublicTagsSelect) []TagViewModel {
+	vms := make([]TagViewModel, len(dbTags))
+	for i, dbTag := range dbTags {
+		vms[i] = TagViewModel{ID: dbTag.Id, Name: dbTag.Name}
+	}
+	return vms
+}
+
+// TagNamesFromDB returns the names of database.PublicTagsSelect rows.
+func TagNamesFromDB(dbTags []database.PublicTagsSelect) []string {
+	names := make([]string, len(dbTags))
+	for i, dbTag := range dbTags {
+		names[i] = dbTag.Name
+	}
+	return names
 }
 
 // FeedFormViewModel represents the unified form for adding or editing feeds.
 // Used by: GET /feeds/new, GET /feeds/{id}/edit
 type FeedFormViewModel struct {
-	Mode         string                 `json:"mode"`           // "add" or "edit"
-	PostURL      string                 `json:"post_url"`       // "/feeds" or "/feeds/{id}"
-	FormTargetID string                 `json:"form_target_id"` // "feed-add-form-errors" or "feed-edit-form-errors-{id}"
-	FeedID       string                 `json:"feed_id"`        // ID of the feed being edited (optional)
-	Name         string                 `json:"name"`           // Current name
-	URL          string                 `json:"url"`            // Current URL
-	Errors       FeedFormErrorViewModel `json:"errors"`         // Validation errors
+	Mode             string                 `json:"mode"`               // "add" or "edit"
+	PostURL          string                 `json:"post_url"`           // "/feeds" or "/feeds/{id}"
+	FormTargetID     string                 `json:"form_target_id"`     // "feed-add-form-errors" or "feed-edit-form-errors-{id}"
+	FeedID           string                 `json:"feed_id"`            // ID of the feed being edited (optional)
+	Name             string                 `json:"name"`               // Current name (empty for automatically named feeds)
+	NamePlaceholder  string                 `json:"name_placeholder"`   // Automatic name shown when the name is left empty
+	URL              string                 `json:"url"`                // Current URL
+	FetchFullContent bool                   `json:"fetch_full_content"` // Whether full article text is downloaded
+	Tags             string                 `json:"tags"`               // Comma-separated tag names
+	AuthType         string                 `json:"auth_type"`          // "none", "basic" or "bearer"
+	AuthUsername     string                 `json:"auth_username"`      // Basic auth username
+	HasCredentials   bool                   `json:"has_credentials"`    // Whether credentials are saved for the feed
+	SavedHeaderNames []string               `json:"saved_header_names"` // Names of saved custom headers (values are never shown)
+	Errors           FeedFormErrorViewModel `json:"errors"`             // Validation errors
 }
 
 // FeedFormErrorViewModel represents validation errors for feed forms.
 // Used by: POST /feeds, PATCH /feeds/{id}
 type FeedFormErrorViewModel struct {
-	NameError    string `json:"name_error,omitempty"`
-	URLError     string `json:"url_error,omitempty"`
-	GeneralError string `json:"general_error,omitempty"`
+	NameError          string `json:"name_error,omitempty"`
+	URLError           string `json:"url_error,omitempty"`
+	TagsError          string `json:"tags_error,omitempty"`
+	AuthUsernameError  string `json:"auth_username_error,omitempty"`
+	AuthTokenError     string `json:"auth_token_error,omitempty"`
+	CustomHeadersError string `json:"custom_headers_error,omitempty"`
+	GeneralError       string `json:"general_error,omitempty"`
+}
+
+// FaviconURL returns the URL of the cached site icon of a feed, or an empty string if it has none.
+// The URL is versioned so browsers pick up refreshed icons despite caching.
+func FaviconURL(dbFeed database.PublicFeedsSelect) string {
+	if !dbFeed.HasFavicon {
+		return ""
+	}
+
+	faviconURL := "/feeds/" + dbFeed.Id + "/favicon"
+	if dbFeed.FaviconCheckedAt != nil {
+		if checkedAt, err := time.Parse(time.RFC3339, *dbFeed.FaviconCheckedAt); err == nil {
+			faviconURL += "?v=" + strconv.FormatInt(checkedAt.Unix(), 10)
+		}
+	}
+	return faviconURL
 }
 
 // NewFeedItemFromDB creates a FeedItemViewModel from database.PublicFeedsSelect.
@@ -57,10 +117,16 @@ func NewFeedItemFromDB(dbFeed database.PublicFeedsSelect) FeedItemViewModel {
 		URL:  dbFeed.Url,
 	}
 
+	if dbFeed.SiteUrl != nil {
+		vm.SiteURL = *dbFeed.SiteUrl
+	}
+
+	vm.FaviconURL = FaviconURL(dbFeed)
+
 	// Compute HasError from last_fetch_status
 	if dbFeed.LastFetchStatus != nil {
 		status := *dbFeed.LastFetchStatus
-		if status == "permanent_error" || status == "temporary_error" {
+		if status == "permanent_error" || status == "temporary_error" || status == "unauthorized" || status == "too_large" {
 			vm.HasError = true
 			if dbFeed.LastFetchError != nil {
 				vm.ErrorMessage = *dbFeed.LastFetchError
@@ -96,6 +162,18 @@ func NewFeedFormErrorFromFieldErrors(fieldErrors map[string]string) FeedFormErro
 	if urlErr, ok := fieldErrors["URL"]; ok {
 		vm.URLError = urlErr
 	}
+	if tagsErr, ok := fieldErrors["Tags"]; ok {
+		vm.TagsError = tagsErr
+	}
+	if usernameErr, ok := fieldErrors["AuthUsername"]; ok {
+		vm.AuthUsernameError = usernameErr
+	}
+	if tokenErr, ok := fieldErrors["AuthToken"]; ok {
+		vm.AuthTokenError = tokenErr
+	}
+	if headersErr, ok := fieldErrors["CustomHeaders"]; ok {
+		vm.CustomHeadersError = headersErr
+	}
 
 	return vm
 }
@@ -106,21 +184,49 @@ func NewFeedFormForAdd() FeedFormViewModel {
 		Mode:         "add",
 		PostURL:      "/feeds",
 		FormTargetID: "feed-add-form-errors",
+		AuthType:     credentials.AuthTypeNone,
 		Errors:       FeedFormErrorViewModel{},
 	}
 }
 
 // NewFeedFormForEdit creates a FeedFormViewModel for editing an existing feed.
+// The name of automatically named feeds is shown as a placeholder, so saving keeps it automatic.
 func NewFeedFormForEdit(dbFeed database.PublicFeedsSelect) FeedFormViewModel {
-	return FeedFormViewModel{
-		Mode:         "edit",
-		PostURL:      "/feeds/" + dbFeed.Id,
-		FormTargetID: "feed-edit-form-errors-" + dbFeed.Id,
-		FeedID:       dbFeed.Id,
-		Name:         dbFeed.Name,
-		URL:          dbFeed.Url,
-		Errors:       FeedFormErrorViewModel{},
+	vm := FeedFormViewModel{
+		Mode:             "edit",
+		PostURL:          "/feeds/" + dbFeed.Id,
+		FormTargetID:     "feed-edit-form-errors-" + dbFeed.Id,
+		FeedID:           dbFeed.Id,
+		Name:             dbFeed.Name,
+		URL:              dbFeed.Url,
+		FetchFullContent: dbFeed.FetchFullContent,
+		AuthType:         credentials.AuthTypeNone,
+		HasCredentials:   dbFeed.CredentialsEncrypted != nil,
+		Errors:           FeedFormErrorViewModel{},
 	}
+
+	if dbFeed.NameIsAuto {
+		vm.Name = ""
+		vm.NamePlaceholder = dbFeed.Name
+	}
+
+	return vm
+}
+
+// SetSavedCredentials shows the non-secret parts of saved credentials in the edit form.
+func (vm *FeedFormViewModel) SetSavedCredentials(creds credentials.FeedCredentials) {
+	vm.AuthType = creds.AuthType()
+	vm.AuthUsername = creds.Username
+	vm.SavedHeaderNames = creds.HeaderNames()
+}
+
+// setAuthFields keeps the submitted non-secret authorization settings when the form is re-rendered.
+func (vm *FeedFormViewModel) setAuthFields(fields FeedAuthFields) {
+	vm.AuthType = fields.AuthType
+	if vm.AuthType == "" {
+		vm.AuthType = credentials.AuthTypeNone
+	}
+	vm.AuthUsername = fields.AuthUsername
 }
 
 // NewFeedFormWithErrors creates a FeedFormViewModel with validation errors.
@@ -145,6 +251,169 @@ func NewFeedFormWithErrors(mode, feedID, name, url string, errors FeedFormErrorV
 	return vm
 }
 
+// FeedHistoryViewModel holds the recent fetch attempts of a feed.
+// Used by: GET /feeds/{id}/history
+type FeedHistoryViewModel struct {
+	FeedID   string                   `json:"feed_id"`
+	FeedName string                   `json:"feed_name"`
+	Entries  []FetchLogEntryViewModel `json:"entries"`
+}
+
+// FetchLogEntryViewModel represents a single fetch attempt for display.
+// Derived from database.PublicFeedFetchLogSelect with computed HasError field.
+type FetchLogEntryViewModel struct {
+	FetchedAt     time.Time     `json:"fetched_at"`
+	Status        string        `json:"status"`
+	HasError      bool          `json:"has_error"`   // Computed: status is not 'success'
+	HTTPStatus    int           `json:"http_status"` // 0 if no response was received
+	ErrorMessage  string        `json:"error_message"`
+	Duration      time.Duration `json:"duration"`
+	BytesRead     int64         `json:"bytes_read"`
+	NewArticles   int           `json:"new_articles"`
+	NotModified   bool          `json:"not_modified"`
+	RedirectChain []string      `json:"redirect_chain"`
+}
+
+// NewFetchLogEntryFromDB creates FetchLogEntryViewModel from database model.
+func NewFetchLogEntryFromDB(dbEntry database.PublicFeedFetchLogSelect) FetchLogEntryViewModel {
+	vm := FetchLogEntryViewModel{
+		Status:        dbEntry.Status,
+		HasError:      dbEntry.Status != "success",
+		Duration:      time.Duration(dbEntry.DurationMs) * time.Millisecond,
+		BytesRead:     dbEntry.BytesRead,
+		NewArticles:   dbEntry.NewArticles,
+		NotModified:   dbEntry.NotModified,
+		RedirectChain: dbEntry.RedirectChain,
+	}
+
+	if dbEntry.HttpStatus != nil {
+		vm.HTTPStatus = *dbEntry.HttpStatus
+	}
+	if dbEntry.ErrorMessage != nil {
+		vm.ErrorMessage = *dbEntry.ErrorMessage
+	}
+	if fetchedAt, err := time.Parse(time.RFC3339, dbEntry.CreatedAt); err == nil {
+		vm.FetchedAt = fetchedAt
+	}
+
+	return vm
+}
+
+// FeedFavicon holds a cached site icon of a feed.
+// Used by: GET /feeds/{id}/favicon
+type FeedFavicon struct {
+	ContentType string
+	Data        []byte
+}
+
+// Results of a single row of an OPML import.
+const (
+	ImportStatusAdded     = "added"
+	ImportStatusDuplicate = "duplicate" // rejected by the unique_user_feed constraint
+	ImportStatusInvalid   = "invalid"   // failed validation, not sent to the database
+	ImportStatusFailed    = "failed"    // unexpected database error, importing the file again retries the row
+)
+
+// FeedImportFormViewModel holds the state of the OPML upload form.
+// Used by: GET /feeds/import, POST /feeds/import (on errors)
+type FeedImportFormViewModel struct {
+	Error string `json:"error,omitempty"` // Unreadable, oversized or empty file
+}
+
+// FeedImportResultViewModel reports the result of every row of an OPML import.
+// Used by: POST /feeds/import
+type FeedImportResultViewModel struct {
+	Rows       []FeedImportRowViewModel `json:"rows"`
+	Added      int                      `json:"added"`
+	Duplicates int                      `json:"duplicates"`
+	Invalid    int                      `json:"invalid"`
+	Failed     int                      `json:"failed"`
+}
+
+// FeedImportRowViewModel represents the result of importing a single subscription.
+type FeedImportRowViewModel struct {
+	Position int    `json:"position"`
+	Name     string `json:"name"`
+	URL      string `json:"url"`
+	Folder   string `json:"folder"`
+	Status   string `json:"status"`            // ImportStatusAdded, ImportStatusDuplicate, ImportStatusInvalid or ImportStatusFailed
+	Message  string `json:"message,omitempty"` // Why the row was not added
+}
+
+// AddRow appends the row and counts it by status.
+func (vm *FeedImportResultViewModel) AddRow(row FeedImportRowViewModel) {
+	switch row.Status {
+	case ImportStatusAdded:
+		vm.Added++
+	case ImportStatusDuplicate:
+		vm.Duplicates++
+	case ImportStatusInvalid:
+		vm.Invalid++
+	case ImportStatusFailed:
+		vm.Failed++
+	}
+	vm.Rows = append(vm.Rows, row)
+}
+
+// FeedExportItem is a single feed in an export.
+// The OPML export uses the subscription fields, the JSON export includes the fetch status as well.
+// Used by: GET /feeds/export
+type FeedExportItem struct {
+	Name             string     `json:"name"`
+	URL              string     `json:"url"`
+	SiteURL          string     `json:"site_url,omitempty"`
+	Description      string     `json:"description,omitempty"`
+	FetchFullContent bool       `json:"fetch_full_content"`
+	Tags             []string   `json:"tags"`
+	CreatedAt        time.Time  `json:"created_at"`
+	LastFetchStatus  string     `json:"last_fetch_status,omitempty"` // Empty until the first fetch
+	LastFetchError   string     `json:"last_fetch_error,omitempty"`
+	LastFetchedAt    *time.Time `json:"last_fetched_at,omitempty"`
+	NextFetchAt      *time.Time `json:"next_fetch_at,omitempty"`
+}
+
+// NewFeedExportItemFromDB creates a FeedExportItem from database.PublicFeedsSelect and the tags of the feed.
+func NewFeedExportItemFromDB(dbFeed database.PublicFeedsSelect, dbTags []database.PublicTagsSelect) FeedExportItem {
+	item := FeedExportItem{
+		Name:             dbFeed.Name,
+		URL:              dbFeed.Url,
+		FetchFullContent: dbFeed.FetchFullContent,
+		Tags:             TagNamesFromDB(dbTags),
+		LastFetchedAt:    parseOptionalTime(dbFeed.LastFetchedAt),
+		NextFetchAt:      parseOptionalTime(dbFeed.FetchAfter),
+	}
+
+	if dbFeed.SiteUrl != nil {
+		item.SiteURL = *dbFeed.SiteUrl
+	}
+	if dbFeed.SiteDescription != nil {
+		item.Description = *dbFeed.SiteDescription
+	}
+	if dbFeed.LastFetchStatus != nil {
+		item.LastFetchStatus = *dbFeed.LastFetchStatus
+	}
+	if dbFeed.LastFetchError != nil {
+		item.LastFetchError = *dbFeed.LastFetchError
+	}
+	if createdAt, err := time.Parse(time.RFC3339, dbFeed.CreatedAt); err == nil {
+		item.CreatedAt = createdAt
+	}
+
+	return item
+}
+
+// parseOptionalTime parses a nullable timestamp column, returning nil when it's empty or invalid
+func parseOptionalTime(value *string) *time.Time {
+	if value == nil {
+		return nil
+	}
+	parsed, err := time.Parse(time.RFC3339, *value)
+	if err != nil {
+		return nil
+	}
+	return &parsed
+}
+
 // DeleteConfirmationViewModel holds data for the delete confirmation modal.
 // Used by: GET /feeds/{id}/delete
 type DeleteConfirmationViewModel struct {
@@ -81,10 +81,12 @@ func TestNewFeedItemFromDB(t *testing.T) {
 				Name:            "Protected Feed",
 				Url:             "https://protected.example.com/feed",
 				LastFetchStatus: &unauthorizedStatus,
+				LastFetchError:  &errorMessage,
 			},
 			validate: func(t *testing.T, vm FeedItemViewModel) {
 				assert.Equal(t, "feed-4", vm.ID)
-				assert.False(t, vm.HasError, "unauthorized should not set HasError")
+				assert.True(t, vm.HasError, "unauthorized should set HasError")
+				assert.Equal(t, errorMessage, vm.ErrorMessage)
 			},
 		},
 		{
@@ -160,6 +162,63 @@ func TestNewFeedItemFromDB(t *testing.T) {
 	}
 }
 
+// TestNewFetchLogEntryFromDB tests the NewFetchLogEntryFromDB function
+func TestNewFetchLogEntryFromDB(t *testing.T) {
+	httpStatus := 301
+	errorMessage := "connection reset"
+	createdAt := "2025-11-06T10:00:00.123456+00:00"
+
+	tests := []struct {
+		name     string
+		dbEntry  database.PublicFeedFetchLogSelect
+		validate func(t *testing.T, vm FetchLogEntryViewModel)
+	}{
+		{
+			name: "successful fetch with redirects",
+			dbEntry: database.PublicFeedFetchLogSelect{
+				Status:        "success",
+				HttpStatus:    &httpStatus,
+				DurationMs:    250,
+				BytesRead:     4096,
+				NewArticles:   2,
+				RedirectChain: []string{"http://example.com/feed", "https://example.com/feed"},
+				CreatedAt:     createdAt,
+			},
+			validate: func(t *testing.T, vm FetchLogEntryViewModel) {
+				assert.False(t, vm.HasError)
+				assert.Equal(t, 301, vm.HTTPStatus)
+				assert.Equal(t, 250*time.Millisecond, vm.Duration)
+				assert.Equal(t, int64(4096), vm.BytesRead)
+				assert.Equal(t, 2, vm.NewArticles)
+				assert.Len(t, vm.RedirectChain, 2)
+				expectedTime, _ := time.Parse(time.RFC3339, createdAt)
+				assert.Equal(t, expectedTime, vm.FetchedAt)
+			},
+		},
+		{
+			name: "failed fetch without response",
+			dbEntry: database.PublicFeedFetchLogSelect{
+				Status:       "temporary_error",
+				ErrorMessage: &errorMessage,
+				CreatedAt:    "invalid",
+			},
+			validate: func(t *testing.T, vm FetchLogEntryViewModel) {
+				assert.True(t, vm.HasError)
+				assert.Equal(t, 0, vm.HTTPStatus)
+				assert.Equal(t, errorMessage, vm.ErrorMessage)
+				assert.True(t, vm.FetchedAt.IsZero())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := NewFetchLogEntryFromDB(tt.dbEntry)
+			tt.validate(t, vm)
+		})
+	}
+}
+
 // TestNewFeedFormErrorFromFieldErrors tests the NewFeedFormErrorFromFieldErrors function
 func TestNewFeedFormErrorFromFieldErrors(t *testing.T) {
 	tests := []struct {
@@ -248,6 +307,8 @@ func TestNewFeedFormForEdit(t *testing.T) {
 		Id:   "feed-123",
 		Name: "My Feed",
 		Url:  "https://example.com/feed",
+
+		FetchFullContent: true,
 	}
 
 	vm := NewFeedFormForEdit(dbFeed)
@@ -258,9 +319,49 @@ func TestNewFeedFormForEdit(t *testing.T) {
 	assert.Equal(t, "feed-123", vm.FeedID)
 	assert.Equal(t, "My Feed", vm.Name)
 	assert.Equal(t, "https://example.com/feed", vm.URL)
+	assert.True(t, vm.FetchFullContent)
 	assert.Equal(t, FeedFormErrorViewModel{}, vm.Errors)
 }
 
+// TestNewFeedFormForEdit_AutoName tests the edit form of an automatically named feed
+func TestNewFeedFormForEdit_AutoName(t *testing.T) {
+	vm := NewFeedFormForEdit(database.PublicFeedsSelect{
+		Id:         "feed-123",
+		Name:       "Example Blog",
+		Url:        "https://example.com/feed",
+		NameIsAuto: true,
+	})
+
+	assert.Empty(t, vm.Name, "automatic name is not submitted back as a user-defined name")
+	assert.Equal(t, "Example Blog", vm.NamePlaceholder)
+}
+
+// TestNewFeedItemFromDB_SiteAndFavicon tests the site link and versioned favicon URL
+func TestNewFeedItemFromDB_SiteAndFavicon(t *testing.T) {
+	siteURL := "https://example.com/"
+	checkedAt := "2025-11-10T12:00:00Z"
+
+	vm := NewFeedItemFromDB(database.PublicFeedsSelect{
+		Id:               "feed-1",
+		SiteUrl:          &siteURL,
+		HasFavicon:       true,
+		FaviconCheckedAt: &checkedAt,
+	})
+
+	assert.Equal(t, siteURL, vm.SiteURL)
+	assert.Equal(t, "/feeds/feed-1/favicon?v=1762776000", vm.FaviconURL)
+
+	vm = NewFeedItemFromDB(database.PublicFeedsSelect{Id: "feed-1", FaviconCheckedAt: &checkedAt})
+	assert.Empty(t, vm.FaviconURL, "no icon link when none is cached")
+}
+
+// TestDefaultFeedName tests the name of feeds added without a name
+func TestDefaultFeedName(t *testing.T) {
+	assert.Equal(t, "example.com", DefaultFeedName("https://www.example.com/feed.xml"))
+	assert.Equal(t, "blog.example.com", DefaultFeedName("https://blog.example.com:8443/rss"))
+	assert.Equal(t, "not a url", DefaultFeedName("not a url"))
+}
+
 // TestNewFeedFormWithErrors tests the NewFeedFormWithErrors function
 func TestNewFeedFormWithErrors(t *testing.T) {
 	errors := FeedFormErrorViewModel{
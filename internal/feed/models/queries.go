@@ -1,5 +1,7 @@
 package models
 
+import "github.com/google/uuid"
+
 // CheckURLTakenQuery represents the input parameters for checking if a URL is already in use.
 // Used by: Feed creation and update validation
 type CheckURLTakenQuery struct {
@@ -14,6 +16,7 @@ type ListFeedsQuery struct {
 	UserID string `query:"-"`      // Required: User ID from authenticated session (set by handler)
 	Search string `query:"search"` // Optional: Search phrase for feed names (case-insensitive)
 	Status string `query:"status"` // Optional: Filter by last fetch status (all, working, error, pending)
+	Tag    string `query:"tag"`    // Optional: Filter by tag ID
 	Page   int    `query:"page"`   // Optional: Page number (1-indexed), default: 1
 }
 
@@ -29,12 +32,47 @@ func (q *ListFeedsQuery) SetDefaults() {
 		q.Status = "all"
 	}
 
+	// Sanitize tag - must be a tag ID, anything else would fail the database query
+	if q.Tag != "" && uuid.Validate(q.Tag) != nil {
+		q.Tag = ""
+	}
+
 	// Sanitize page - must be >= 1
 	if q.Page < 1 {
 		q.Page = 1
 	}
 }
 
+// Feed export formats
+const (
+	ExportFormatOPML = "opml"
+	ExportFormatJSON = "json" // includes fetch status metadata
+)
+
+// ExportFeedsQuery represents the input parameters for exporting feeds.
+// Used by: GET /feeds/export
+type ExportFeedsQuery struct {
+	UserID string `query:"-"`                                           // Required: User ID from authenticated session (set by handler)
+	Format string `query:"format" validate:"omitempty,oneof=opml json"` // Optional: "opml" (default) or "json"
+}
+
+// SetDefaults sets the default export format
+func (q *ExportFeedsQuery) SetDefaults() {
+	if q.Format == "" {
+		q.Format = ExportFormatOPML
+	}
+}
+
+// ExportFeedsPageQuery represents a page of feeds read while streaming an export.
+// Without TagID and Untagged all feeds of the user are read.
+type ExportFeedsPageQuery struct {
+	UserID   string
+	TagID    string // Optional: only feeds with this tag
+	Untagged bool   // Optional: only feeds without tags
+	Offset   int
+	Limit    int
+}
+
 // StatusFilter describes a filter operation for feed statuses
 type StatusFilter struct {
 	FilterType string   // e.g., "IN", "IS_NULL"
@@ -56,7 +94,7 @@ func (q *ListFeedsQuery) GetStatusFilter() (*StatusFilter, bool) {
 		return &StatusFilter{
 			FilterType: "IN",
 			Column:     "last_fetch_status",
-			Values:     []string{"temporary_error", "permanent_error", "unauthorized"},
+			Values:     []string{"temporary_error", "permanent_error", "unauthorized", "too_large"},
 		}, true
 	case "pending":
 		return &StatusFilter{
@@ -115,7 +115,7 @@ func TestListFeedsQuery_GetStatusFilter(t *testing.T) {
 			expectedFilter: &StatusFilter{
 				FilterType: "IN",
 				Column:     "last_fetch_status",
-				Values:     []string{"temporary_error", "permanent_error", "unauthorized"},
+				Values:     []string{"temporary_error", "permanent_error", "unauthorized", "too_large"},
 			},
 		},
 		{
@@ -185,10 +185,11 @@ func TestStatusFilter_Values(t *testing.T) {
 		query := ListFeedsQuery{Status: "error"}
 		filter, ok := query.GetStatusFilter()
 		require.True(t, ok)
-		assert.Len(t, filter.Values, 3)
+		assert.Len(t, filter.Values, 4)
 		assert.Contains(t, filter.Values, "temporary_error")
 		assert.Contains(t, filter.Values, "permanent_error")
 		assert.Contains(t, filter.Values, "unauthorized")
+		assert.Contains(t, filter.Values, "too_large")
 	})
 
 	t.Run("pending filter has no values (IS_NULL)", func(t *testing.T) {
@@ -255,7 +256,7 @@ func TestListFeedsQuery_Complete(t *testing.T) {
 		filter, ok := query.GetStatusFilter()
 		require.True(t, ok)
 		assert.Equal(t, "IN", filter.FilterType)
-		assert.Len(t, filter.Values, 3)
+		assert.Len(t, filter.Values, 4)
 	})
 
 	t.Run("query for pending feeds", func(t *testing.T) {
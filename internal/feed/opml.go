@@ -0,0 +1,118 @@
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/tjanas94/vibefeeder/internal/feed/models"
+	"github.com/tjanas94/vibefeeder/internal/shared/tags"
+	"golang.org/x/net/html/charset"
+)
+
+// folderSeparator joins the names of nested OPML folders in the import results
+const folderSeparator = " / "
+
+// opmlDocument is the part of an OPML 1.0/2.0 document read by the import
+type opmlDocument struct {
+	XMLName xml.Name `xml:"opml"`
+	Body    struct {
+		Outlines []opmlOutline `xml:"outline"`
+	} `xml:"body"`
+}
+
+// opmlOutline is a feed subscription (has xmlUrl) or a folder of nested outlines
+// Attributes are matched case-insensitively, exporters disagree on "xmlUrl" vs "xmlurl"
+type opmlOutline struct {
+	Attrs    []xml.Attr    `xml:",any,attr"`
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+// attr returns the value of the named attribute and whether it is present
+func (o opmlOutline) attr(name string) (string, bool) {
+	for _, a := range o.Attrs {
+		if strings.EqualFold(a.Name.Local, name) {
+			return strings.TrimSpace(a.Value), true
+		}
+	}
+	return "", false
+}
+
+// title returns the display name of the outline, preferring title over text
+func (o opmlOutline) title() string {
+	if title, _ := o.attr("title"); title != "" {
+		return title
+	}
+	text, _ := o.attr("text")
+	return text
+}
+
+// parseOPML reads the feed subscriptions of an OPML document in document order
+// Outlines with an xmlUrl attribute become rows (an empty xmlUrl is kept to be reported as invalid),
+// outlines without one are folders whose names are recorded on the nested rows and become their tags
+func parseOPML(r io.Reader) ([]models.ImportFeedRow, error) {
+	decoder := xml.NewDecoder(r)
+	decoder.CharsetReader = charset.NewReaderLabel
+
+	var doc opmlDocument
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OPML: %w", err)
+	}
+
+	var rows []models.ImportFeedRow
+	collectOutlines(doc.Body.Outlines, nil, &rows)
+	return rows, nil
+}
+
+// collectOutlines walks nested outlines depth-first, appending a row for every subscription
+func collectOutlines(outlines []opmlOutline, folders []string, rows *[]models.ImportFeedRow) {
+	for _, outline := range outlines {
+		if feedURL, ok := outline.attr("xmlUrl"); ok {
+			*rows = append(*rows, models.ImportFeedRow{
+				Position: len(*rows) + 1,
+				Name:     truncateRunes(outline.title(), maxFeedNameLength),
+				URL:      feedURL,
+				Folder:   strings.Join(folders, folderSeparator),
+				Tags:     folderTags(folders),
+			})
+			continue
+		}
+
+		nested := folders
+		if name := outline.title(); name != "" {
+			nested = append(folders[:len(folders):len(folders)], name)
+		}
+		collectOutlines(outline.Outlines, nested, rows)
+	}
+}
+
+// folderTags turns the enclosing folders of a subscription into tag names, one per nesting level
+// Names repeated in the path are kept once and deep paths are cut at tags.MaxTags
+func folderTags(folders []string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, folder := range folders {
+		name := tags.Sanitize(folder)
+		key := strings.ToLower(name)
+		if name == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		names = append(names, name)
+		if len(names) == tags.MaxTags {
+			break
+		}
+	}
+	return names
+}
+
+// truncateRunes shortens s to at most n characters
+// Long titles are cut instead of failing validation, the name can be edited after the import
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return strings.TrimSpace(string(runes[:n]))
+}
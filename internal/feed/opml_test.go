@@ -0,0 +1,120 @@
+package feed
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tjanas94/vibefeeder/internal/feed/models"
+	"github.com/tjanas94/vibefeeder/internal/shared/tags"
+)
+
+func TestParseOPML_NestedFolders(t *testing.T) {
+	opml := `<?xml version="1.0" encoding="UTF-8"?>
+<opml version="2.0">
+	<head><title>Subscriptions</title></head>
+	<body>
+		<outline text="Go Blog" title="The Go Blog" type="rss" xmlUrl="https://go.dev/blog/feed.atom" htmlUrl="https://go.dev/blog"/>
+		<outline text="Tech">
+			<outline text="Hacker News" xmlurl="https://news.ycombinator.com/rss"/>
+			<outline title="Languages">
+				<outline text="Rust" xmlUrl=" https://blog.rust-lang.org/feed.xml "/>
+			</outline>
+		</outline>
+		<outline text="Bookmarks only" htmlUrl="https://example.com"/>
+		<outline text="Broken" xmlUrl=""/>
+	</body>
+</opml>`
+
+	rows, err := parseOPML(strings.NewReader(opml))
+
+	require.NoError(t, err)
+	assert.Equal(t, []models.ImportFeedRow{
+		{Position: 1, Name: "The Go Blog", URL: "https://go.dev/blog/feed.atom"},
+		{Position: 2, Name: "Hacker News", URL: "https://news.ycombinator.com/rss", Folder: "Tech", Tags: []string{"Tech"}},
+		{Position: 3, Name: "Rust", URL: "https://blog.rust-lang.org/feed.xml", Folder: "Tech / Languages", Tags: []string{"Tech", "Languages"}},
+		{Position: 4, Name: "Broken", URL: ""},
+	}, rows)
+}
+
+func TestParseOPML_SiblingFoldersDoNotShareParent(t *testing.T) {
+	opml := `<opml version="1.0"><body>
+		<outline text="A">
+			<outline text="B"><outline text="one" xmlUrl="https://example.com/1"/></outline>
+			<outline text="C"><outline text="two" xmlUrl="https://example.com/2"/></outline>
+		</outline>
+	</body></opml>`
+
+	rows, err := parseOPML(strings.NewReader(opml))
+
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, "A / B", rows[0].Folder)
+	assert.Equal(t, "A / C", rows[1].Folder)
+}
+
+func TestFolderTags(t *testing.T) {
+	deep := make([]string, tags.MaxTags+2)
+	for i := range deep {
+		deep[i] = fmt.Sprintf("Level %d", i)
+	}
+
+	tests := []struct {
+		name     string
+		folders  []string
+		expected []string
+	}{
+		{name: "no folders", folders: nil, expected: nil},
+		{name: "one tag per level", folders: []string{"Tech", "Go"}, expected: []string{"Tech", "Go"}},
+		{name: "repeated names are kept once", folders: []string{"News", "Tech", "news"}, expected: []string{"News", "Tech"}},
+		{name: "commas are not separators", folders: []string{"Science, Nature"}, expected: []string{"Science Nature"}},
+		{name: "deep paths are cut", folders: deep, expected: deep[:tags.MaxTags]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, folderTags(tt.folders))
+		})
+	}
+}
+
+func TestParseOPML_NonUTF8Encoding(t *testing.T) {
+	opml := "<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?>" +
+		"<opml version=\"1.0\"><body><outline text=\"Caf\xe9\" xmlUrl=\"https://example.com/feed\"/></body></opml>"
+
+	rows, err := parseOPML(strings.NewReader(opml))
+
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "Café", rows[0].Name)
+}
+
+func TestParseOPML_TruncatesLongTitles(t *testing.T) {
+	opml := `<opml version="2.0"><body><outline text="` + strings.Repeat("ż", 300) + `" xmlUrl="https://example.com/feed"/></body></opml>`
+
+	rows, err := parseOPML(strings.NewReader(opml))
+
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, maxFeedNameLength, len([]rune(rows[0].Name)))
+}
+
+func TestParseOPML_InvalidDocument(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "not XML", input: "name,url\nGo,https://go.dev/blog/feed.atom"},
+		{name: "other XML document", input: `<rss version="2.0"><channel></channel></rss>`},
+		{name: "empty file", input: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseOPML(strings.NewReader(tt.input))
+			assert.Error(t, err)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/supabase-community/postgrest-go"
 	"github.com/tjanas94/vibefeeder/internal/feed/models"
 	"github.com/tjanas94/vibefeeder/internal/shared/database"
+	"github.com/tjanas94/vibefeeder/internal/shared/tracing"
 )
 
 // Repository handles data access for feeds
@@ -22,14 +23,23 @@ func NewRepository(db *database.Client) *Repository {
 	return &Repository{db: db}
 }
 
+// FeedWithTags is a feed row with its tags embedded by PostgREST
+type FeedWithTags struct {
+	database.PublicFeedsSelect
+	Tags []database.PublicTagsSelect `json:"tags"`
+}
+
 // ListFeedsResult contains the feeds and total count for pagination
 type ListFeedsResult struct {
-	Feeds      []database.PublicFeedsSelect
+	Feeds      []FeedWithTags
 	TotalCount int
 }
 
 // ListFeeds retrieves feeds with filtering and pagination
-func (r *Repository) ListFeeds(ctx context.Context, query models.ListFeedsQuery) (*ListFeedsResult, error) {
+func (r *Repository) ListFeeds(ctx context.Context, query models.ListFeedsQuery) (_ *ListFeedsResult, err error) {
+	_, span := tracing.Start(ctx, "feed.Repository.ListFeeds")
+	defer func() { tracing.End(span, err) }()
+
 	// Get authenticated client for RLS
 	client, err := r.db.NewAuthenticatedClient(ctx)
 	if err != nil {
@@ -39,12 +49,22 @@ func (r *Repository) ListFeeds(ctx context.Context, query models.ListFeedsQuery)
 	// Calculate offset for pagination
 	offset := (query.Page - 1) * pageSize
 
-	// Build query with exact count and RLS token
-	feedQuery := client.From("feeds").Select("*", "exact", false)
+	// Build query with exact count and RLS token, embedding the tags of every feed
+	columns := "*,tags(id,name)"
+	if query.Tag != "" {
+		// Inner join on a separate embedding, so the filter does not hide the other tags of the feed
+		columns += ",filter_tags:feed_tags!inner(tag_id)"
+	}
+	feedQuery := client.From("feeds").Select(columns, "exact", false)
 
 	// Apply user_id filter (required for security)
 	feedQuery = feedQuery.Eq("user_id", query.UserID)
 
+	// Apply tag filter if provided
+	if query.Tag != "" {
+		feedQuery = feedQuery.Eq("filter_tags.tag_id", query.Tag)
+	}
+
 	// Apply search filter if provided
 	if query.Search != "" {
 		// Use ILIKE for case-insensitive search
@@ -63,9 +83,10 @@ func (r *Repository) ListFeeds(ctx context.Context, query models.ListFeedsQuery)
 
 	// Execute query with pagination and ordering
 	// The count is returned in Content-Range header by PostgREST when using "exact"
-	var feeds []database.PublicFeedsSelect
+	var feeds []FeedWithTags
 	count, err := feedQuery.
 		Order("created_at", &postgrest.OrderOpts{Ascending: false}).
+		Order("name", &postgrest.OrderOpts{Ascending: true, ForeignTable: "tags"}).
 		Range(offset, offset+pageSize-1, "").
 		ExecuteTo(&feeds)
 	if err != nil {
@@ -78,8 +99,164 @@ func (r *Repository) ListFeeds(ctx context.Context, query models.ListFeedsQuery)
 	}, nil
 }
 
+// exportColumns are the feed columns included in exports; credentials are never exported
+const exportColumns = "id,name,url,site_url,site_description,fetch_full_content,created_at," +
+	"last_fetch_status,last_fetch_error,last_fetched_at,fetch_after"
+
+// ListFeedsForExport retrieves a page of the user's feeds for export, with the names of their tags
+// Ordered by name and ID, so pages stay stable while the export is streamed
+func (r *Repository) ListFeedsForExport(ctx context.Context, query models.ExportFeedsPageQuery) (_ []FeedWithTags, err error) {
+	_, span := tracing.Start(ctx, "feed.Repository.ListFeedsForExport")
+	defer func() { tracing.End(span, err) }()
+
+	// Get authenticated client for RLS
+	client, err := r.db.NewAuthenticatedClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := exportColumns + ",tags(name)"
+	switch {
+	case query.TagID != "":
+		columns += ",filter_tags:feed_tags!inner(tag_id)"
+	case query.Untagged:
+		columns += ",filter_tags:feed_tags(tag_id)"
+	}
+
+	feedQuery := client.From("feeds").
+		Select(columns, "", false).
+		Eq("user_id", query.UserID)
+
+	switch {
+	case query.TagID != "":
+		feedQuery = feedQuery.Eq("filter_tags.tag_id", query.TagID)
+	case query.Untagged:
+		// Anti-join: keep feeds without any feed_tags row
+		feedQuery = feedQuery.Is("filter_tags", "null")
+	}
+
+	var feeds []FeedWithTags
+	_, err = feedQuery.
+		Order("name", &postgrest.OrderOpts{Ascending: true}).
+		Order("id", &postgrest.OrderOpts{Ascending: true}).
+		Order("name", &postgrest.OrderOpts{Ascending: true, ForeignTable: "tags"}).
+		Range(query.Offset, query.Offset+query.Limit-1, "").
+		ExecuteTo(&feeds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch feeds for export: %w", err)
+	}
+
+	return feeds, nil
+}
+
+// unreadCount is a row returned by the count_unread_articles function
+type unreadCount struct {
+	FeedID      string `json:"feed_id"`
+	UnreadCount int    `json:"unread_count"`
+}
+
+// CountUnreadArticles counts the articles of the given feeds the user has not read
+// Feeds without unread articles are missing from the result
+func (r *Repository) CountUnreadArticles(ctx context.Context, feedIDs []string) (_ map[string]int, err error) {
+	_, span := tracing.Start(ctx, "feed.Repository.CountUnreadArticles")
+	defer func() { tracing.End(span, err) }()
+
+	params := map[string]any{
+		"p_feed_ids": feedIDs,
+	}
+
+	var rows []unreadCount
+	if err = r.db.CallAuthenticatedRPC(ctx, "count_unread_articles", params, &rows); err != nil {
+		return nil, fmt.Errorf("failed to count unread articles: %w", err)
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.FeedID] = row.UnreadCount
+	}
+	return counts, nil
+}
+
+// ListFeedTags retrieves the tags of a feed ordered by name
+func (r *Repository) ListFeedTags(ctx context.Context, feedID string) (_ []database.PublicTagsSelect, err error) {
+	_, span := tracing.Start(ctx, "feed.Repository.ListFeedTags")
+	defer func() { tracing.End(span, err) }()
+
+	// Get authenticated client for RLS
+	client, err := r.db.NewAuthenticatedClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []database.PublicTagsSelect
+	_, err = client.From("tags").
+		Select("id,name,feed_tags!inner(feed_id)", "", false).
+		Eq("feed_tags.feed_id", feedID).
+		Order("name", &postgrest.OrderOpts{Ascending: true}).
+		ExecuteTo(&tags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch feed tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// SetFeedTags assigns tags to a feed by name, creating the tags the user does not have yet
+// With replace the feed loses the tags missing from names; returns the resulting tags of the feed
+func (r *Repository) SetFeedTags(ctx context.Context, feedID string, names []string, replace bool) (_ []database.PublicTagsSelect, err error) {
+	_, span := tracing.Start(ctx, "feed.Repository.SetFeedTags")
+	defer func() { tracing.End(span, err) }()
+
+	// An empty array instead of null, so replacing with no names removes every tag
+	if names == nil {
+		names = []string{}
+	}
+
+	params := map[string]any{
+		"p_feed_id":   feedID,
+		"p_tag_names": names,
+		"p_replace":   replace,
+	}
+
+	var tags []database.PublicTagsSelect
+	if err = r.db.CallAuthenticatedRPC(ctx, "set_feed_tags", params, &tags); err != nil {
+		return nil, fmt.Errorf("failed to set feed tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// FindFeedIDByURL retrieves the ID of the user's feed with the given URL
+// Returns error that can be checked with database.IsNotFoundError if the user has no such feed
+func (r *Repository) FindFeedIDByURL(ctx context.Context, userID, url string) (_ string, err error) {
+	_, span := tracing.Start(ctx, "feed.Repository.FindFeedIDByURL")
+	defer func() { tracing.End(span, err) }()
+
+	// Get authenticated client for RLS
+	client, err := r.db.NewAuthenticatedClient(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var feed database.PublicFeedsSelect
+	_, err = client.From("feeds").
+		Select("id", "", false).
+		Eq("user_id", userID).
+		Eq("url", url).
+		Single().
+		ExecuteTo(&feed)
+	if err != nil {
+		return "", fmt.Errorf("failed to find feed by URL: %w", err)
+	}
+
+	return feed.Id, nil
+}
+
 // InsertFeed creates a new feed in the database and returns the created feed ID
-func (r *Repository) InsertFeed(ctx context.Context, feed database.PublicFeedsInsert) (string, error) {
+func (r *Repository) InsertFeed(ctx context.Context, feed database.PublicFeedsInsert) (_ string, err error) {
+	_, span := tracing.Start(ctx, "feed.Repository.InsertFeed")
+	defer func() { tracing.End(span, err) }()
+
 	// Get authenticated client for RLS
 	client, err := r.db.NewAuthenticatedClient(ctx)
 	if err != nil {
@@ -98,7 +275,10 @@ func (r *Repository) InsertFeed(ctx context.Context, feed database.PublicFeedsIn
 // FindFeedByIDAndUser retrieves a single feed by ID and user ID
 // Returns error if feed is not found or doesn't belong to the user
 // Used for: edit form display, update operations
-func (r *Repository) FindFeedByIDAndUser(ctx context.Context, feedID, userID string) (*database.PublicFeedsSelect, error) {
+func (r *Repository) FindFeedByIDAndUser(ctx context.Context, feedID, userID string) (_ *database.PublicFeedsSelect, err error) {
+	_, span := tracing.Start(ctx, "feed.Repository.FindFeedByIDAndUser")
+	defer func() { tracing.End(span, err) }()
+
 	// Get authenticated client for RLS
 	client, err := r.db.NewAuthenticatedClient(ctx)
 	if err != nil {
@@ -122,7 +302,10 @@ func (r *Repository) FindFeedByIDAndUser(ctx context.Context, feedID, userID str
 
 // IsURLTaken checks if a URL is already in use by another feed for the same user,
 // excluding the specified feed ID from the check (used during updates)
-func (r *Repository) IsURLTaken(ctx context.Context, query models.CheckURLTakenQuery) (bool, error) {
+func (r *Repository) IsURLTaken(ctx context.Context, query models.CheckURLTakenQuery) (_ bool, err error) {
+	_, span := tracing.Start(ctx, "feed.Repository.IsURLTaken")
+	defer func() { tracing.End(span, err) }()
+
 	// Get authenticated client for RLS
 	client, err := r.db.NewAuthenticatedClient(ctx)
 	if err != nil {
@@ -145,7 +328,10 @@ func (r *Repository) IsURLTaken(ctx context.Context, query models.CheckURLTakenQ
 }
 
 // UpdateFeed updates an existing feed in the database
-func (r *Repository) UpdateFeed(ctx context.Context, feedID string, update database.PublicFeedsUpdate) error {
+func (r *Repository) UpdateFeed(ctx context.Context, feedID string, update database.PublicFeedsUpdate) (err error) {
+	_, span := tracing.Start(ctx, "feed.Repository.UpdateFeed")
+	defer func() { tracing.End(span, err) }()
+
 	// Get authenticated client for RLS
 	client, err := r.db.NewAuthenticatedClient(ctx)
 	if err != nil {
@@ -166,9 +352,36 @@ func (r *Repository) UpdateFeed(ctx context.Context, feedID string, update datab
 	return nil
 }
 
+// ClearFeedCredentials removes the saved credentials of a feed
+// Separate from UpdateFeed because PublicFeedsUpdate cannot set a column to null
+func (r *Repository) ClearFeedCredentials(ctx context.Context, feedID string) (err error) {
+	_, span := tracing.Start(ctx, "feed.Repository.ClearFeedCredentials")
+	defer func() { tracing.End(span, err) }()
+
+	// Get authenticated client for RLS
+	client, err := r.db.NewAuthenticatedClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = client.From("feeds").
+		Update(map[string]any{"credentials_encrypted": nil}, "minimal", "").
+		Eq("id", feedID).
+		Execute()
+
+	if err != nil {
+		return fmt.Errorf("failed to clear feed credentials: %w", err)
+	}
+
+	return nil
+}
+
 // DeleteFeed deletes a feed from the database by ID and user ID
 // Returns error that can be checked with database.IsNotFoundError if feed doesn't exist or doesn't belong to user
-func (r *Repository) DeleteFeed(ctx context.Context, feedID, userID string) error {
+func (r *Repository) DeleteFeed(ctx context.Context, feedID, userID string) (err error) {
+	_, span := tracing.Start(ctx, "feed.Repository.DeleteFeed")
+	defer func() { tracing.End(span, err) }()
+
 	// Get authenticated client for RLS
 	client, err := r.db.NewAuthenticatedClient(ctx)
 	if err != nil {
@@ -189,3 +402,57 @@ func (r *Repository) DeleteFeed(ctx context.Context, feedID, userID string) erro
 
 	return nil
 }
+
+// FindFetchLog retrieves the most recent fetch attempts of a feed, newest first
+// Ownership is enforced by RLS on feed_fetch_log
+func (r *Repository) FindFetchLog(ctx context.Context, feedID string, limit int) (_ []database.PublicFeedFetchLogSelect, err error) {
+	_, span := tracing.Start(ctx, "feed.Repository.FindFetchLog")
+	defer func() { tracing.End(span, err) }()
+
+	// Get authenticated client for RLS
+	client, err := r.db.NewAuthenticatedClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []database.PublicFeedFetchLogSelect
+	_, err = client.From("feed_fetch_log").
+		Select("*", "", false).
+		Eq("feed_id", feedID).
+		Order("created_at", &postgrest.OrderOpts{Ascending: false}).
+		Limit(limit, "").
+		ExecuteTo(&entries)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find fetch log: %w", err)
+	}
+
+	return entries, nil
+}
+
+// FindFavicon retrieves the cached site icon of a feed
+// Ownership is enforced by RLS on feed_favicons
+// Returns error that can be checked with database.IsNotFoundError if no icon is cached
+func (r *Repository) FindFavicon(ctx context.Context, feedID string) (_ *database.PublicFeedFaviconsSelect, err error) {
+	_, span := tracing.Start(ctx, "feed.Repository.FindFavicon")
+	defer func() { tracing.End(span, err) }()
+
+	// Get authenticated client for RLS
+	client, err := r.db.NewAuthenticatedClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var favicon database.PublicFeedFaviconsSelect
+	_, err = client.From("feed_favicons").
+		Select("*", "", false).
+		Eq("feed_id", feedID).
+		Single().
+		ExecuteTo(&favicon)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find feed favicon: %w", err)
+	}
+
+	return &favicon, nil
+}
@@ -2,13 +2,17 @@ package feed
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/tjanas94/vibefeeder/internal/feed/models"
+	"github.com/tjanas94/vibefeeder/internal/shared/credentials"
 	"github.com/tjanas94/vibefeeder/internal/shared/database"
 	"github.com/tjanas94/vibefeeder/internal/shared/events"
 	sharedmodels "github.com/tjanas94/vibefeeder/internal/shared/models"
+	"github.com/tjanas94/vibefeeder/internal/shared/tags"
 )
 
 // FeedRepository defines the interface for feed data access
@@ -18,22 +22,50 @@ type FeedRepository interface {
 	FindFeedByIDAndUser(ctx context.Context, feedID, userID string) (*database.PublicFeedsSelect, error)
 	IsURLTaken(ctx context.Context, query models.CheckURLTakenQuery) (bool, error)
 	UpdateFeed(ctx context.Context, feedID string, update database.PublicFeedsUpdate) error
+	ClearFeedCredentials(ctx context.Context, feedID string) error
 	DeleteFeed(ctx context.Context, id, userID string) error
+	FindFetchLog(ctx context.Context, feedID string, limit int) ([]database.PublicFeedFetchLogSelect, error)
+	FindFavicon(ctx context.Context, feedID string) (*database.PublicFeedFaviconsSelect, error)
+	ListFeedsForExport(ctx context.Context, query models.ExportFeedsPageQuery) ([]FeedWithTags, error)
+	ListFeedTags(ctx context.Context, feedID string) ([]database.PublicTagsSelect, error)
+	SetFeedTags(ctx context.Context, feedID string, names []string, replace bool) ([]database.PublicTagsSelect, error)
+	FindFeedIDByURL(ctx context.Context, userID, url string) (string, error)
+	CountUnreadArticles(ctx context.Context, feedIDs []string) (map[string]int, error)
+}
+
+// FeedExportWriter serializes a streamed feed export
+// Begin is called once the first page of feeds is read, so database errors can still be reported as an error response
+type FeedExportWriter interface {
+	Begin(exportedAt time.Time) error
+	WriteFeed(feed models.FeedExportItem) error
+	Flush() error
+	End() error
+}
+
+// GroupedFeedExportWriter is a FeedExportWriter that nests feeds in named groups (OPML folders)
+type GroupedFeedExportWriter interface {
+	FeedExportWriter
+	BeginGroup(name string) error
+	EndGroup() error
 }
 
 // Service handles business logic for feeds
 type Service struct {
-	repo      FeedRepository
-	eventRepo events.EventRepository
-	logger    *slog.Logger
+	repo        FeedRepository
+	tagRepo     tags.TagRepository
+	eventRepo   events.EventRepository
+	credentials *credentials.Cipher // nil when feed credentials are disabled
+	logger      *slog.Logger
 }
 
 // NewService creates a new feed service
-func NewService(repo FeedRepository, eventRepo events.EventRepository, logger *slog.Logger) *Service {
+func NewService(repo FeedRepository, tagRepo tags.TagRepository, eventRepo events.EventRepository, credentialCipher *credentials.Cipher, logger *slog.Logger) *Service {
 	return &Service{
-		repo:      repo,
-		eventRepo: eventRepo,
-		logger:    logger,
+		repo:        repo,
+		tagRepo:     tagRepo,
+		eventRepo:   eventRepo,
+		credentials: credentialCipher,
+		logger:      logger,
 	}
 }
 
@@ -46,8 +78,29 @@ func (s *Service) ListFeeds(ctx context.Context, query models.ListFeedsQuery) (*
 		return nil, NewDatabaseError(err)
 	}
 
+	// Fetch all tags for the tag filter
+	dbTags, err := s.tagRepo.ListTags(ctx, query.UserID)
+	if err != nil {
+		s.logger.Error("failed to list tags", "user_id", query.UserID, "error", err)
+		return nil, NewDatabaseError(err)
+	}
+
+	// Count unread articles of the listed feeds
+	unreadCounts := map[string]int{}
+	if len(result.Feeds) > 0 {
+		feedIDs := make([]string, len(result.Feeds))
+		for i, dbFeed := range result.Feeds {
+			feedIDs[i] = dbFeed.Id
+		}
+		unreadCounts, err = s.repo.CountUnreadArticles(ctx, feedIDs)
+		if err != nil {
+			s.logger.Error("failed to count unread articles", "user_id", query.UserID, "error", err)
+			return nil, NewDatabaseError(err)
+		}
+	}
+
 	// Build view model using pure function
-	viewModel := buildFeedListViewModel(result, query)
+	viewModel := buildFeedListViewModel(result, dbTags, unreadCounts, query)
 	return &viewModel, nil
 }
 
@@ -56,6 +109,19 @@ func (s *Service) CreateFeed(ctx context.Context, cmd models.CreateFeedCommand)
 	// Convert command to insert model (UserID is already set via custom binder)
 	feedInsert := cmd.ToInsert()
 
+	// Encrypt optional credentials for private feeds
+	creds := cmd.ToCredentials(nil)
+	if err := validateCredentials(cmd.AuthType, creds); err != nil {
+		return "", err
+	}
+	if !creds.IsEmpty() {
+		sealed, err := s.sealCredentials(creds)
+		if err != nil {
+			return "", err
+		}
+		feedInsert.CredentialsEncrypted = &sealed
+	}
+
 	// Insert feed into database
 	feedID, err := s.repo.InsertFeed(ctx, feedInsert)
 	if err != nil {
@@ -66,19 +132,197 @@ func (s *Service) CreateFeed(ctx context.Context, cmd models.CreateFeedCommand)
 		return "", fmt.Errorf("failed to create feed %w", err)
 	}
 
+	// Assign tags, creating the ones the user does not have yet
+	if names := cmd.TagNames(); len(names) > 0 {
+		if _, err := s.repo.SetFeedTags(ctx, feedID, names, false); err != nil {
+			return "", fmt.Errorf("failed to tag feed %w", err)
+		}
+	}
+
 	// Log feed_added event
+	s.recordFeedAdded(ctx, cmd.UserID, map[string]any{
+		"feed_name": feedInsert.Name,
+		"feed_url":  cmd.URL,
+	})
+
+	return feedID, nil
+}
+
+// ImportFeeds adds the subscriptions of an OPML file and reports the result of every row
+// Rows are inserted one by one, so a duplicate or a failed row doesn't abort the rest of the import
+// First fetches are spread by importFetchSpacing to avoid flooding the fetcher with a large import
+func (s *Service) ImportFeeds(ctx context.Context, cmd models.ImportFeedsCommand) models.FeedImportResultViewModel {
+	result := models.FeedImportResultViewModel{
+		Rows: make([]models.FeedImportRowViewModel, 0, len(cmd.Rows)),
+	}
+	fetchAt := time.Now().Add(importFetchDelay)
+
+	for _, row := range cmd.Rows {
+		rowVM := models.FeedImportRowViewModel{
+			Position: row.Position,
+			Name:     row.Name,
+			URL:      row.URL,
+			Folder:   row.Folder,
+		}
+
+		if row.Error != "" {
+			rowVM.Status = models.ImportStatusInvalid
+			rowVM.Message = row.Error
+			result.AddRow(rowVM)
+			continue
+		}
+
+		feedInsert := row.ToCreateCommand(cmd.UserID).ToInsertFetchedAfter(fetchAt)
+		rowVM.Name = feedInsert.Name
+
+		feedID, err := s.repo.InsertFeed(ctx, feedInsert)
+		switch {
+		case err == nil:
+			rowVM.Status = models.ImportStatusAdded
+			fetchAt = fetchAt.Add(importFetchSpacing)
+			s.recordFeedAdded(ctx, cmd.UserID, map[string]any{
+				"feed_name": feedInsert.Name,
+				"feed_url":  row.URL,
+				"source":    "opml",
+			})
+			if !s.tagImportedFeed(ctx, cmd.UserID, feedID, row) {
+				rowVM.Message = "Added without its folder tags"
+			}
+		case database.IsUniqueViolationError(err):
+			rowVM.Status = models.ImportStatusDuplicate
+			rowVM.Message = NewFeedAlreadyExistsError().FieldErrors["URL"]
+			// The existing feed still joins the folders of the file, e.g. a feed listed in two folders
+			if len(row.Tags) > 0 {
+				if feedID, err = s.repo.FindFeedIDByURL(ctx, cmd.UserID, row.URL); err != nil {
+					s.logger.Warn("Failed to find imported feed", "user_id", cmd.UserID, "feed_url", row.URL, "error", err)
+				} else {
+					s.tagImportedFeed(ctx, cmd.UserID, feedID, row)
+				}
+			}
+		default:
+			s.logger.Error("Failed to import feed", "user_id", cmd.UserID, "feed_url", row.URL, "error", err)
+			rowVM.Status = models.ImportStatusFailed
+			rowVM.Message = "Could not be saved, try importing the file again"
+		}
+		result.AddRow(rowVM)
+	}
+
+	s.logger.Info("Imported feeds from OPML",
+		"user_id", cmd.UserID,
+		"added", result.Added,
+		"duplicates", result.Duplicates,
+		"invalid", result.Invalid,
+		"failed", result.Failed,
+	)
+
+	return result
+}
+
+// tagImportedFeed adds the folder tags of an imported row to the feed, keeping its other tags
+// Returns false if the tags could not be saved; the failure is only logged, the feed is already saved
+func (s *Service) tagImportedFeed(ctx context.Context, userID, feedID string, row models.ImportFeedRow) bool {
+	if len(row.Tags) == 0 {
+		return true
+	}
+	if _, err := s.repo.SetFeedTags(ctx, feedID, row.Tags, false); err != nil {
+		s.logger.Warn("Failed to tag imported feed", "user_id", userID, "feed_id", feedID, "error", err)
+		return false
+	}
+	return true
+}
+
+// ExportFeeds streams all feeds of the user to the export writer, one page at a time
+func (s *Service) ExportFeeds(ctx context.Context, userID string, w FeedExportWriter) error {
+	query := models.ExportFeedsPageQuery{UserID: userID}
+	begin := func() error { return w.Begin(time.Now()) }
+	if err := s.exportFeedPages(ctx, query, w, begin); err != nil {
+		return err
+	}
+
+	if err := w.End(); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+	return nil
+}
+
+// ExportFeedsGrouped streams the feeds of the user grouped by tag, followed by the feeds without tags
+// A feed with several tags is written in each of its groups
+func (s *Service) ExportFeedsGrouped(ctx context.Context, userID string, w GroupedFeedExportWriter) error {
+	dbTags, err := s.tagRepo.ListTags(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to export tags", "user_id", userID, "error", err)
+		return NewDatabaseError(err)
+	}
+
+	if err := w.Begin(time.Now()); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+
+	for _, tag := range dbTags {
+		if err := w.BeginGroup(tag.Name); err != nil {
+			return fmt.Errorf("failed to write export: %w", err)
+		}
+		query := models.ExportFeedsPageQuery{UserID: userID, TagID: tag.Id}
+		if err := s.exportFeedPages(ctx, query, w, nil); err != nil {
+			return err
+		}
+		if err := w.EndGroup(); err != nil {
+			return fmt.Errorf("failed to write export: %w", err)
+		}
+	}
+
+	query := models.ExportFeedsPageQuery{UserID: userID, Untagged: true}
+	if err := s.exportFeedPages(ctx, query, w, nil); err != nil {
+		return err
+	}
+
+	if err := w.End(); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+	return nil
+}
+
+// exportFeedPages writes the feeds matching the query one page at a time
+// beforeFirstPage (optional) runs once the first page is read, before any feed is written
+func (s *Service) exportFeedPages(ctx context.Context, query models.ExportFeedsPageQuery, w FeedExportWriter, beforeFirstPage func() error) error {
+	query.Limit = exportPageSize
+	for query.Offset = 0; ; query.Offset += exportPageSize {
+		feeds, err := s.repo.ListFeedsForExport(ctx, query)
+		if err != nil {
+			s.logger.Error("failed to export feeds", "user_id", query.UserID, "offset", query.Offset, "error", err)
+			return NewDatabaseError(err)
+		}
+
+		if query.Offset == 0 && beforeFirstPage != nil {
+			if err := beforeFirstPage(); err != nil {
+				return fmt.Errorf("failed to write export: %w", err)
+			}
+		}
+
+		for _, feed := range feeds {
+			if err := w.WriteFeed(models.NewFeedExportItemFromDB(feed.PublicFeedsSelect, feed.Tags)); err != nil {
+				return fmt.Errorf("failed to write export: %w", err)
+			}
+		}
+		if err := w.Flush(); err != nil {
+			return fmt.Errorf("failed to write export: %w", err)
+		}
+
+		if len(feeds) < exportPageSize {
+			return nil
+		}
+	}
+}
+
+// recordFeedAdded logs the feed_added event; a failure is only logged, the feed is already saved
+func (s *Service) recordFeedAdded(ctx context.Context, userID string, metadata map[string]any) {
 	if err := s.eventRepo.RecordEvent(ctx, database.PublicEventsInsert{
 		EventType: events.EventFeedAdded,
-		UserId:    &cmd.UserID,
-		Metadata: map[string]any{
-			"feed_name": cmd.Name,
-			"feed_url":  cmd.URL,
-		},
+		UserId:    &userID,
+		Metadata:  metadata,
 	}); err != nil {
-		s.logger.Warn("Failed to log event", "event_type", events.EventFeedAdded, "error", err, "user_id", cmd.UserID)
+		s.logger.Warn("Failed to log event", "event_type", events.EventFeedAdded, "error", err, "user_id", userID)
 	}
-
-	return feedID, nil
 }
 
 // GetFeedForEdit retrieves a feed for editing
@@ -93,13 +337,22 @@ func (s *Service) GetFeedForEdit(ctx context.Context, feedID, userID string) (*m
 		return nil, fmt.Errorf("failed to get feed for edit %w", err)
 	}
 
+	dbTags, err := s.repo.ListFeedTags(ctx, feedID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feed tags for edit %w", err)
+	}
+
 	// Map database model to view model
 	vm := models.NewFeedFormForEdit(*dbFeed)
+	vm.Tags = tags.Format(models.TagNamesFromDB(dbTags))
+	if saved := s.openCredentials(*dbFeed); saved != nil {
+		vm.SetSavedCredentials(*saved)
+	}
 	return &vm, nil
 }
 
 // UpdateFeed updates an existing feed with validation and conflict detection
-// Returns true if URL was changed, false otherwise
+// Returns true if the feed should be fetched again (URL or credentials changed), false otherwise
 func (s *Service) UpdateFeed(ctx context.Context, cmd models.UpdateFeedCommand) (bool, error) {
 	// Get existing feed to verify ownership (UserID and ID are already set via custom binder)
 	existingFeed, err := s.repo.FindFeedByIDAndUser(ctx, cmd.ID, cmd.UserID)
@@ -126,12 +379,70 @@ func (s *Service) UpdateFeed(ctx context.Context, cmd models.UpdateFeedCommand)
 		updateData = cmd.ToUpdateWithURLChange()
 	}
 
+	// Empty name switches the feed to automatic naming
+	if cmd.Name == "" {
+		name := autoFeedName(*existingFeed, cmd.URL)
+		updateData.Name = &name
+	}
+
+	// Merge submitted credentials with the saved ones (empty secret fields keep saved values)
+	saved := s.openCredentials(*existingFeed)
+	base := saved
+	if cmd.ClearCredentials {
+		base = nil
+	}
+	creds := cmd.ToCredentials(base)
+	if err := validateCredentials(cmd.AuthType, creds); err != nil {
+		return false, err
+	}
+
+	credentialsChanged := false
+	switch {
+	case !creds.IsEmpty():
+		credentialsChanged = saved == nil || !creds.Equal(*saved)
+	case existingFeed.CredentialsEncrypted != nil:
+		// Credentials that cannot be decrypted are only removed on request
+		credentialsChanged = saved != nil || cmd.ClearCredentials
+	}
+
+	clearCredentials := false
+	if credentialsChanged {
+		if creds.IsEmpty() {
+			clearCredentials = true
+		} else {
+			sealed, err := s.sealCredentials(creds)
+			if err != nil {
+				return false, err
+			}
+			updateData.CredentialsEncrypted = &sealed
+		}
+
+		// Retry right away with the new credentials instead of waiting for the authorization backoff
+		retryCount := 0
+		updateData.RetryCount = &retryCount
+		if updateData.FetchAfter == nil {
+			fetchAfter := time.Now().Add(5 * time.Minute).Format(time.RFC3339)
+			updateData.FetchAfter = &fetchAfter
+		}
+	}
+
 	// Perform update
 	if err := s.repo.UpdateFeed(ctx, cmd.ID, updateData); err != nil {
 		return false, fmt.Errorf("failed to update feed %w", err)
 	}
 
-	return urlChanged, nil
+	if clearCredentials {
+		if err := s.repo.ClearFeedCredentials(ctx, cmd.ID); err != nil {
+			return false, fmt.Errorf("failed to clear feed credentials %w", err)
+		}
+	}
+
+	// Replace the tags of the feed with the submitted ones
+	if _, err := s.repo.SetFeedTags(ctx, cmd.ID, cmd.TagNames(), true); err != nil {
+		return false, fmt.Errorf("failed to update feed tags %w", err)
+	}
+
+	return urlChanged || credentialsChanged, nil
 }
 
 // DeleteFeed deletes a feed for the authenticated user
@@ -148,6 +459,56 @@ func (s *Service) DeleteFeed(ctx context.Context, id, userID string) error {
 	return nil
 }
 
+// GetFeedHistory retrieves the recent fetch attempts of a feed for display
+func (s *Service) GetFeedHistory(ctx context.Context, feedID, userID string) (*models.FeedHistoryViewModel, error) {
+	// Verify ownership and get feed name
+	dbFeed, err := s.repo.FindFeedByIDAndUser(ctx, feedID, userID)
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil, NewFeedNotFoundError()
+		}
+		return nil, fmt.Errorf("failed to get feed for history %w", err)
+	}
+
+	entries, err := s.repo.FindFetchLog(ctx, feedID, historySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fetch history %w", err)
+	}
+
+	vm := models.FeedHistoryViewModel{
+		FeedID:   dbFeed.Id,
+		FeedName: dbFeed.Name,
+		Entries:  make([]models.FetchLogEntryViewModel, len(entries)),
+	}
+	for i, entry := range entries {
+		vm.Entries[i] = models.NewFetchLogEntryFromDB(entry)
+	}
+
+	return &vm, nil
+}
+
+// GetFavicon retrieves the cached site icon of a feed
+// Feeds of other users are reported as not found (RLS hides their icons)
+func (s *Service) GetFavicon(ctx context.Context, feedID string) (*models.FeedFavicon, error) {
+	dbFavicon, err := s.repo.FindFavicon(ctx, feedID)
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil, NewFeedNotFoundError()
+		}
+		return nil, fmt.Errorf("failed to get feed favicon %w", err)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(dbFavicon.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode feed favicon %w", err)
+	}
+
+	return &models.FeedFavicon{
+		ContentType: dbFavicon.ContentType,
+		Data:        data,
+	}, nil
+}
+
 // validateURLChange checks if new URL can be used
 func (s *Service) validateURLChange(ctx context.Context, userID, feedID, newURL string) error {
 	query := models.CheckURLTakenQuery{
@@ -168,19 +529,72 @@ func (s *Service) validateURLChange(ctx context.Context, userID, feedID, newURL
 	return nil
 }
 
+// sealCredentials encrypts feed credentials for storage
+func (s *Service) sealCredentials(creds credentials.FeedCredentials) (string, error) {
+	if s.credentials == nil {
+		return "", NewCredentialsDisabledError()
+	}
+
+	sealed, err := s.credentials.Seal(creds)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt feed credentials %w", err)
+	}
+
+	return sealed, nil
+}
+
+// openCredentials decrypts the saved credentials of a feed
+// Returns nil if the feed has no credentials or they cannot be decrypted (e.g. after a key change)
+func (s *Service) openCredentials(feed database.PublicFeedsSelect) *credentials.FeedCredentials {
+	if feed.CredentialsEncrypted == nil || s.credentials == nil {
+		return nil
+	}
+
+	creds, err := s.credentials.Open(*feed.CredentialsEncrypted)
+	if err != nil {
+		s.logger.Warn("Failed to decrypt feed credentials", "feed_id", feed.Id, "error", err)
+		return nil
+	}
+
+	return &creds
+}
+
+// autoFeedName returns the name of a feed switched to automatic naming
+// Uses the stored channel title unless the URL changed; the fetcher renames the feed after the next fetch
+// Until then the name is the host of the new URL (see models.DefaultFeedName)
+func autoFeedName(feed database.PublicFeedsSelect, newURL string) string {
+	if feed.Url == newURL && feed.SiteTitle != nil && *feed.SiteTitle != "" {
+		return *feed.SiteTitle
+	}
+	return models.DefaultFeedName(newURL)
+}
+
+// validateCredentials checks that the selected authorization type is complete
+// Returns NewAuthTokenRequiredError for the bearer type without a token
+func validateCredentials(authType string, creds credentials.FeedCredentials) error {
+	if authType == credentials.AuthTypeBearer && creds.Token == "" {
+		return NewAuthTokenRequiredError()
+	}
+	return nil
+}
+
 // buildFeedListViewModel is a pure function that transforms repository result to view model
-func buildFeedListViewModel(result *ListFeedsResult, query models.ListFeedsQuery) models.FeedListViewModel {
+func buildFeedListViewModel(result *ListFeedsResult, dbTags []database.PublicTagsSelect, unreadCounts map[string]int, query models.ListFeedsQuery) models.FeedListViewModel {
 	// Transform database models to view models
 	feedItems := make([]models.FeedItemViewModel, len(result.Feeds))
 	for i, dbFeed := range result.Feeds {
-		feedItems[i] = models.NewFeedItemFromDB(dbFeed)
+		feedItems[i] = models.NewFeedItemFromDB(dbFeed.PublicFeedsSelect)
+		feedItems[i].Tags = models.NewTagsFromDB(dbFeed.Tags)
+		feedItems[i].UnreadCount = unreadCounts[dbFeed.Id]
 	}
 
 	// Show empty state only when there are no feeds at all (no filters applied)
-	showEmptyState := result.TotalCount == 0 && query.Search == "" && query.Status == "all"
+	showEmptyState := result.TotalCount == 0 && query.Search == "" && query.Status == "all" && query.Tag == ""
 
 	return models.FeedListViewModel{
 		Feeds:          feedItems,
+		Tags:           models.NewTagsFromDB(dbTags),
+		SelectedTag:    query.Tag,
 		ShowEmptyState: showEmptyState,
 		Pagination:     sharedmodels.BuildPagination(result.TotalCount, query.Page, pageSize),
 	}
@@ -2,15 +2,20 @@ package feed
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/tjanas94/vibefeeder/internal/feed/models"
+	"github.com/tjanas94/vibefeeder/internal/shared/credentials"
 	"github.com/tjanas94/vibefeeder/internal/shared/database"
 	sharederrors "github.com/tjanas94/vibefeeder/internal/shared/errors"
 	"github.com/tjanas94/vibefeeder/internal/shared/events"
@@ -52,11 +57,77 @@ func (m *MockFeedRepository) UpdateFeed(ctx context.Context, feedID string, upda
 	return args.Error(0)
 }
 
+func (m *MockFeedRepository) ClearFeedCredentials(ctx context.Context, feedID string) error {
+	args := m.Called(ctx, feedID)
+	return args.Error(0)
+}
+
 func (m *MockFeedRepository) DeleteFeed(ctx context.Context, id, userID string) error {
 	args := m.Called(ctx, id, userID)
 	return args.Error(0)
 }
 
+func (m *MockFeedRepository) FindFetchLog(ctx context.Context, feedID string, limit int) ([]database.PublicFeedFetchLogSelect, error) {
+	args := m.Called(ctx, feedID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]database.PublicFeedFetchLogSelect), args.Error(1)
+}
+
+func (m *MockFeedRepository) FindFavicon(ctx context.Context, feedID string) (*database.PublicFeedFaviconsSelect, error) {
+	args := m.Called(ctx, feedID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*database.PublicFeedFaviconsSelect), args.Error(1)
+}
+
+func (m *MockFeedRepository) ListFeedsForExport(ctx context.Context, query models.ExportFeedsPageQuery) ([]FeedWithTags, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]FeedWithTags), args.Error(1)
+}
+
+func (m *MockFeedRepository) ListTags(ctx context.Context, userID string) ([]database.PublicTagsSelect, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]database.PublicTagsSelect), args.Error(1)
+}
+
+func (m *MockFeedRepository) ListFeedTags(ctx context.Context, feedID string) ([]database.PublicTagsSelect, error) {
+	args := m.Called(ctx, feedID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]database.PublicTagsSelect), args.Error(1)
+}
+
+func (m *MockFeedRepository) SetFeedTags(ctx context.Context, feedID string, names []string, replace bool) ([]database.PublicTagsSelect, error) {
+	args := m.Called(ctx, feedID, names, replace)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]database.PublicTagsSelect), args.Error(1)
+}
+
+func (m *MockFeedRepository) FindFeedIDByURL(ctx context.Context, userID, url string) (string, error) {
+	args := m.Called(ctx, userID, url)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockFeedRepository) CountUnreadArticles(ctx context.Context, feedIDs []string) (map[string]int, error) {
+	args := m.Called(ctx, feedIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
 // MockEventRepository is a mock implementation of events.EventRepository
 type MockEventRepository struct {
 	mock.Mock
@@ -77,6 +148,21 @@ func newTestFeed(id, userID, name, url string) *database.PublicFeedsSelect {
 	}
 }
 
+func newTestFeedWithTags(id, userID, name, url string, tagNames ...string) FeedWithTags {
+	feed := FeedWithTags{PublicFeedsSelect: *newTestFeed(id, userID, name, url)}
+	for i, tagName := range tagNames {
+		feed.Tags = append(feed.Tags, database.PublicTagsSelect{Id: fmt.Sprintf("tag-%d", i+1), Name: tagName})
+	}
+	return feed
+}
+
+func newTestCipher(t *testing.T) *credentials.Cipher {
+	t.Helper()
+	cipher, err := credentials.NewCipher(base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32))))
+	require.NoError(t, err)
+	return cipher
+}
+
 func newTestLogger() *slog.Logger {
 	// Use io.Discard to suppress log output during tests
 	return slog.New(slog.NewTextHandler(io.Discard, nil))
@@ -87,7 +173,7 @@ func TestListFeeds_Success(t *testing.T) {
 	mockRepo := new(MockFeedRepository)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockEventRepo, logger)
+	service := NewService(mockRepo, mockRepo, mockEventRepo, nil, logger)
 
 	ctx := context.Background()
 	query := models.ListFeedsQuery{
@@ -97,9 +183,9 @@ func TestListFeeds_Success(t *testing.T) {
 		Page:   1,
 	}
 
-	feeds := []database.PublicFeedsSelect{
-		*newTestFeed("feed-1", "user-123", "Test Feed 1", "https://example.com/feed1"),
-		*newTestFeed("feed-2", "user-123", "Test Feed 2", "https://example.com/feed2"),
+	feeds := []FeedWithTags{
+		newTestFeedWithTags("feed-1", "user-123", "Test Feed 1", "https://example.com/feed1", "Go"),
+		newTestFeedWithTags("feed-2", "user-123", "Test Feed 2", "https://example.com/feed2"),
 	}
 
 	expectedResult := &ListFeedsResult{
@@ -108,6 +194,8 @@ func TestListFeeds_Success(t *testing.T) {
 	}
 
 	mockRepo.On("ListFeeds", ctx, query).Return(expectedResult, nil)
+	mockRepo.On("ListTags", ctx, "user-123").Return([]database.PublicTagsSelect{{Id: "tag-1", Name: "Go"}}, nil)
+	mockRepo.On("CountUnreadArticles", ctx, []string{"feed-1", "feed-2"}).Return(map[string]int{"feed-2": 7}, nil)
 
 	result, err := service.ListFeeds(ctx, query)
 
@@ -115,6 +203,40 @@ func TestListFeeds_Success(t *testing.T) {
 	assert.NotNil(t, result)
 	assert.Len(t, result.Feeds, 2)
 	assert.False(t, result.ShowEmptyState)
+	assert.Equal(t, []models.TagViewModel{{ID: "tag-1", Name: "Go"}}, result.Tags)
+	assert.Equal(t, []models.TagViewModel{{ID: "tag-1", Name: "Go"}}, result.Feeds[0].Tags)
+	assert.Empty(t, result.Feeds[1].Tags)
+	assert.Equal(t, 0, result.Feeds[0].UnreadCount)
+	assert.Equal(t, 7, result.Feeds[1].UnreadCount)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListFeeds_UnreadCountError(t *testing.T) {
+	mockRepo := new(MockFeedRepository)
+	mockEventRepo := new(MockEventRepository)
+	logger := newTestLogger()
+	service := NewService(mockRepo, mockRepo, mockEventRepo, nil, logger)
+
+	ctx := context.Background()
+	query := models.ListFeedsQuery{
+		UserID: "user-123",
+		Status: "all",
+		Page:   1,
+	}
+
+	mockRepo.On("ListFeeds", ctx, query).Return(&ListFeedsResult{
+		Feeds:      []FeedWithTags{newTestFeedWithTags("feed-1", "user-123", "Test Feed 1", "https://example.com/feed1")},
+		TotalCount: 1,
+	}, nil)
+	mockRepo.On("ListTags", ctx, "user-123").Return([]database.PublicTagsSelect{}, nil)
+	mockRepo.On("CountUnreadArticles", ctx, []string{"feed-1"}).Return(nil, errors.New("rpc error"))
+
+	result, err := service.ListFeeds(ctx, query)
+
+	assert.Nil(t, result)
+	serviceErr, ok := sharederrors.AsServiceError(err)
+	assert.True(t, ok, "error should be a ServiceError")
+	assert.Equal(t, 500, serviceErr.Code)
 	mockRepo.AssertExpectations(t)
 }
 
@@ -122,7 +244,7 @@ func TestListFeeds_Empty(t *testing.T) {
 	mockRepo := new(MockFeedRepository)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockEventRepo, logger)
+	service := NewService(mockRepo, mockRepo, mockEventRepo, nil, logger)
 
 	ctx := context.Background()
 	query := models.ListFeedsQuery{
@@ -133,11 +255,12 @@ func TestListFeeds_Empty(t *testing.T) {
 	}
 
 	expectedResult := &ListFeedsResult{
-		Feeds:      []database.PublicFeedsSelect{},
+		Feeds:      []FeedWithTags{},
 		TotalCount: 0,
 	}
 
 	mockRepo.On("ListFeeds", ctx, query).Return(expectedResult, nil)
+	mockRepo.On("ListTags", ctx, "user-123").Return([]database.PublicTagsSelect{{Id: "tag-1", Name: "Go"}}, nil)
 
 	result, err := service.ListFeeds(ctx, query)
 
@@ -152,7 +275,7 @@ func TestListFeeds_WithSearchFilter(t *testing.T) {
 	mockRepo := new(MockFeedRepository)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockEventRepo, logger)
+	service := NewService(mockRepo, mockRepo, mockEventRepo, nil, logger)
 
 	ctx := context.Background()
 	query := models.ListFeedsQuery{
@@ -163,11 +286,12 @@ func TestListFeeds_WithSearchFilter(t *testing.T) {
 	}
 
 	expectedResult := &ListFeedsResult{
-		Feeds:      []database.PublicFeedsSelect{},
+		Feeds:      []FeedWithTags{},
 		TotalCount: 0,
 	}
 
 	mockRepo.On("ListFeeds", ctx, query).Return(expectedResult, nil)
+	mockRepo.On("ListTags", ctx, "user-123").Return([]database.PublicTagsSelect{{Id: "tag-1", Name: "Go"}}, nil)
 
 	result, err := service.ListFeeds(ctx, query)
 
@@ -181,7 +305,7 @@ func TestListFeeds_RepositoryError(t *testing.T) {
 	mockRepo := new(MockFeedRepository)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockEventRepo, logger)
+	service := NewService(mockRepo, mockRepo, mockEventRepo, nil, logger)
 
 	ctx := context.Background()
 	query := models.ListFeedsQuery{
@@ -207,7 +331,7 @@ func TestCreateFeed_Success(t *testing.T) {
 	mockRepo := new(MockFeedRepository)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockEventRepo, logger)
+	service := NewService(mockRepo, mockRepo, mockEventRepo, nil, logger)
 
 	ctx := context.Background()
 	userID := "user-123"
@@ -233,11 +357,37 @@ func TestCreateFeed_Success(t *testing.T) {
 	mockEventRepo.AssertExpectations(t)
 }
 
+func TestCreateFeed_WithoutName(t *testing.T) {
+	mockRepo := new(MockFeedRepository)
+	mockEventRepo := new(MockEventRepository)
+	logger := newTestLogger()
+	service := NewService(mockRepo, mockRepo, mockEventRepo, nil, logger)
+
+	ctx := context.Background()
+	cmd := models.CreateFeedCommand{
+		UserID: "user-123",
+		URL:    "https://www.example.com/feed",
+	}
+
+	mockRepo.On("InsertFeed", ctx, mock.MatchedBy(func(feed database.PublicFeedsInsert) bool {
+		return feed.Name == "example.com" && feed.NameIsAuto != nil && *feed.NameIsAuto
+	})).Return("feed-123", nil)
+	mockEventRepo.On("RecordEvent", ctx, mock.MatchedBy(func(event database.PublicEventsInsert) bool {
+		return event.Metadata.(map[string]any)["feed_name"] == "example.com"
+	})).Return(nil)
+
+	_, err := service.CreateFeed(ctx, cmd)
+
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockEventRepo.AssertExpectations(t)
+}
+
 func TestCreateFeed_URLAlreadyExists(t *testing.T) {
 	mockRepo := new(MockFeedRepository)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockEventRepo, logger)
+	service := NewService(mockRepo, mockRepo, mockEventRepo, nil, logger)
 
 	ctx := context.Background()
 	userID := "user-123"
@@ -266,7 +416,7 @@ func TestCreateFeed_RepositoryError(t *testing.T) {
 	mockRepo := new(MockFeedRepository)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockEventRepo, logger)
+	service := NewService(mockRepo, mockRepo, mockEventRepo, nil, logger)
 
 	ctx := context.Background()
 	userID := "user-123"
@@ -290,7 +440,7 @@ func TestCreateFeed_EventLogError(t *testing.T) {
 	mockRepo := new(MockFeedRepository)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockEventRepo, logger)
+	service := NewService(mockRepo, mockRepo, mockEventRepo, nil, logger)
 
 	ctx := context.Background()
 	userID := "user-123"
@@ -315,12 +465,299 @@ func TestCreateFeed_EventLogError(t *testing.T) {
 	mockEventRepo.AssertExpectations(t)
 }
 
+func TestCreateFeed_AssignsTags(t *testing.T) {
+	mockRepo := new(MockFeedRepository)
+	mockEventRepo := new(MockEventRepository)
+	service := NewService(mockRepo, mockRepo, mockEventRepo, nil, newTestLogger())
+
+	ctx := context.Background()
+	cmd := models.CreateFeedCommand{
+		UserID: "user-123",
+		URL:    "https://example.com/feed",
+		Tags:   "Tech, news, tech",
+	}
+
+	mockRepo.On("InsertFeed", ctx, mock.AnythingOfType("database.PublicFeedsInsert")).Return("feed-123", nil)
+	mockRepo.On("SetFeedTags", ctx, "feed-123", []string{"Tech", "news"}, false).Return([]database.PublicTagsSelect{}, nil)
+	mockEventRepo.On("RecordEvent", ctx, mock.AnythingOfType("database.PublicEventsInsert")).Return(nil)
+
+	feedID, err := service.CreateFeed(ctx, cmd)
+
+	require.NoError(t, err)
+	assert.Equal(t, "feed-123", feedID)
+	mockRepo.AssertExpectations(t)
+}
+
+// Tests for ImportFeeds
+func TestImportFeeds_ReportsEveryRow(t *testing.T) {
+	mockRepo := new(MockFeedRepository)
+	mockEventRepo := new(MockEventRepository)
+	service := NewService(mockRepo, mockRepo, mockEventRepo, nil, newTestLogger())
+
+	ctx := context.Background()
+	cmd := models.ImportFeedsCommand{
+		UserID: "user-123",
+		Rows: []models.ImportFeedRow{
+			{Position: 1, Name: "Go Blog", URL: "https://go.dev/blog/feed.atom", Folder: "Tech"},
+			{Position: 2, URL: "https://news.example.com/rss"},
+			{Position: 3, Name: "Existing", URL: "https://existing.example.com/feed"},
+			{Position: 4, Name: "Broken", URL: "not a url", Error: "URL: Must be a valid HTTP or HTTPS URL"},
+			{Position: 5, Name: "Unlucky", URL: "https://unlucky.example.com/feed"},
+		},
+	}
+
+	var fetchAfter []string
+	mockRepo.On("InsertFeed", ctx, mock.MatchedBy(func(feed database.PublicFeedsInsert) bool {
+		return feed.Url == "https://go.dev/blog/feed.atom" || feed.Url == "https://news.example.com/rss"
+	})).Run(func(args mock.Arguments) {
+		feed := args.Get(1).(database.PublicFeedsInsert)
+		assert.Equal(t, "user-123", feed.UserId)
+		fetchAfter = append(fetchAfter, *feed.FetchAfter)
+	}).Return("feed-id", nil)
+	mockRepo.On("InsertFeed", ctx, mock.MatchedBy(func(feed database.PublicFeedsInsert) bool {
+		return feed.Url == "https://existing.example.com/feed"
+	})).Return("", errors.New("duplicate key value violates unique constraint \"unique_user_feed\""))
+	mockRepo.On("InsertFeed", ctx, mock.MatchedBy(func(feed database.PublicFeedsInsert) bool {
+		return feed.Url == "https://unlucky.example.com/feed"
+	})).Return("", errors.New("connection reset"))
+	mockEventRepo.On("RecordEvent", ctx, mock.MatchedBy(func(event database.PublicEventsInsert) bool {
+		return event.EventType == events.EventFeedAdded && event.Metadata.(map[string]any)["source"] == "opml"
+	})).Return(nil).Times(2)
+
+	result := service.ImportFeeds(ctx, cmd)
+
+	assert.Equal(t, 2, result.Added)
+	assert.Equal(t, 1, result.Duplicates)
+	assert.Equal(t, 1, result.Invalid)
+	assert.Equal(t, 1, result.Failed)
+
+	require.Len(t, result.Rows, 5)
+	statuses := make([]string, len(result.Rows))
+	for i, row := range result.Rows {
+		statuses[i] = row.Status
+		assert.Equal(t, cmd.Rows[i].Position, row.Position)
+	}
+	assert.Equal(t, []string{
+		models.ImportStatusAdded,
+		models.ImportStatusAdded,
+		models.ImportStatusDuplicate,
+		models.ImportStatusInvalid,
+		models.ImportStatusFailed,
+	}, statuses)
+	assert.Equal(t, "Tech", result.Rows[0].Folder)
+	assert.Equal(t, "news.example.com", result.Rows[1].Name, "rows without a title are named automatically")
+	assert.Equal(t, "You have already added this feed", result.Rows[2].Message)
+	assert.Equal(t, cmd.Rows[3].Error, result.Rows[3].Message)
+
+	// First fetches of added feeds are spread apart
+	require.Len(t, fetchAfter, 2)
+	first, err := time.Parse(time.RFC3339, fetchAfter[0])
+	require.NoError(t, err)
+	second, err := time.Parse(time.RFC3339, fetchAfter[1])
+	require.NoError(t, err)
+	assert.Equal(t, importFetchSpacing, second.Sub(first))
+	assert.WithinDuration(t, time.Now().Add(importFetchDelay), first, 5*time.Second)
+
+	mockRepo.AssertNumberOfCalls(t, "InsertFeed", 4)
+	mockEventRepo.AssertExpectations(t)
+}
+
+func TestImportFeeds_TagsFeedsWithFolders(t *testing.T) {
+	mockRepo := new(MockFeedRepository)
+	mockEventRepo := new(MockEventRepository)
+	service := NewService(mockRepo, mockRepo, mockEventRepo, nil, newTestLogger())
+
+	ctx := context.Background()
+	cmd := models.ImportFeedsCommand{
+		UserID: "user-123",
+		Rows: []models.ImportFeedRow{
+			{Position: 1, Name: "Go Blog", URL: "https://go.dev/blog/feed.atom", Folder: "Tech / Go", Tags: []string{"Tech", "Go"}},
+			{Position: 2, Name: "Go Blog", URL: "https://go.dev/blog/feed.atom", Folder: "Favorites", Tags: []string{"Favorites"}},
+		},
+	}
+
+	mockRepo.On("InsertFeed", ctx, mock.AnythingOfType("database.PublicFeedsInsert")).Return("feed-1", nil).Once()
+	mockRepo.On("InsertFeed", ctx, mock.AnythingOfType("database.PublicFeedsInsert")).
+		Return("", errors.New("duplicate key value violates unique constraint \"unique_user_feed\"")).Once()
+	mockRepo.On("SetFeedTags", ctx, "feed-1", []string{"Tech", "Go"}, false).Return([]database.PublicTagsSelect{}, nil)
+	mockRepo.On("FindFeedIDByURL", ctx, "user-123", "https://go.dev/blog/feed.atom").Return("feed-1", nil)
+	mockRepo.On("SetFeedTags", ctx, "feed-1", []string{"Favorites"}, false).Return([]database.PublicTagsSelect{}, nil)
+	mockEventRepo.On("RecordEvent", ctx, mock.AnythingOfType("database.PublicEventsInsert")).Return(nil)
+
+	result := service.ImportFeeds(ctx, cmd)
+
+	assert.Equal(t, 1, result.Added)
+	assert.Equal(t, 1, result.Duplicates)
+	assert.Empty(t, result.Rows[0].Message)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestImportFeeds_TaggingFailureKeepsFeed(t *testing.T) {
+	mockRepo := new(MockFeedRepository)
+	mockEventRepo := new(MockEventRepository)
+	service := NewService(mockRepo, mockRepo, mockEventRepo, nil, newTestLogger())
+
+	ctx := context.Background()
+	cmd := models.ImportFeedsCommand{
+		UserID: "user-123",
+		Rows: []models.ImportFeedRow{
+			{Position: 1, URL: "https://go.dev/blog/feed.atom", Folder: "Tech", Tags: []string{"Tech"}},
+		},
+	}
+
+	mockRepo.On("InsertFeed", ctx, mock.AnythingOfType("database.PublicFeedsInsert")).Return("feed-1", nil)
+	mockRepo.On("SetFeedTags", ctx, "feed-1", []string{"Tech"}, false).Return(nil, errors.New("connection reset"))
+	mockEventRepo.On("RecordEvent", ctx, mock.AnythingOfType("database.PublicEventsInsert")).Return(nil)
+
+	result := service.ImportFeeds(ctx, cmd)
+
+	assert.Equal(t, 1, result.Added)
+	assert.Equal(t, models.ImportStatusAdded, result.Rows[0].Status)
+	assert.NotEmpty(t, result.Rows[0].Message)
+}
+
+// recordingExportWriter is a FeedExportWriter recording the calls of the export
+type recordingExportWriter struct {
+	calls []string
+	feeds []models.FeedExportItem
+}
+
+func (w *recordingExportWriter) Begin(exportedAt time.Time) error {
+	w.calls = append(w.calls, "begin")
+	return nil
+}
+
+func (w *recordingExportWriter) WriteFeed(feed models.FeedExportItem) error {
+	w.feeds = append(w.feeds, feed)
+	return nil
+}
+
+func (w *recordingExportWriter) Flush() error {
+	w.calls = append(w.calls, "flush")
+	return nil
+}
+
+func (w *recordingExportWriter) End() error {
+	w.calls = append(w.calls, "end")
+	return nil
+}
+
+func (w *recordingExportWriter) BeginGroup(name string) error {
+	w.calls = append(w.calls, "group "+name)
+	return nil
+}
+
+func (w *recordingExportWriter) EndGroup() error {
+	w.calls = append(w.calls, "end group")
+	return nil
+}
+
+// Tests for ExportFeeds
+func TestExportFeeds_StreamsAllPages(t *testing.T) {
+	mockRepo := new(MockFeedRepository)
+	service := NewService(mockRepo, mockRepo, new(MockEventRepository), nil, newTestLogger())
+
+	ctx := context.Background()
+	userID := "user-123"
+	firstPage := make([]FeedWithTags, exportPageSize)
+	for i := range firstPage {
+		firstPage[i] = newTestFeedWithTags(fmt.Sprintf("feed-%d", i), userID, fmt.Sprintf("Feed %d", i), fmt.Sprintf("https://example.com/%d", i))
+	}
+	secondPage := []FeedWithTags{newTestFeedWithTags("feed-last", userID, "Last", "https://example.com/last", "Go", "News")}
+
+	mockRepo.On("ListFeedsForExport", ctx, models.ExportFeedsPageQuery{UserID: userID, Offset: 0, Limit: exportPageSize}).Return(firstPage, nil)
+	mockRepo.On("ListFeedsForExport", ctx, models.ExportFeedsPageQuery{UserID: userID, Offset: exportPageSize, Limit: exportPageSize}).Return(secondPage, nil)
+
+	writer := &recordingExportWriter{}
+	err := service.ExportFeeds(ctx, userID, writer)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"begin", "flush", "flush", "end"}, writer.calls)
+	require.Len(t, writer.feeds, exportPageSize+1)
+	assert.Equal(t, "Last", writer.feeds[exportPageSize].Name)
+	assert.Equal(t, "https://example.com/last", writer.feeds[exportPageSize].URL)
+	assert.Equal(t, []string{"Go", "News"}, writer.feeds[exportPageSize].Tags)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestExportFeeds_NoFeeds(t *testing.T) {
+	mockRepo := new(MockFeedRepository)
+	service := NewService(mockRepo, mockRepo, new(MockEventRepository), nil, newTestLogger())
+
+	ctx := context.Background()
+	mockRepo.On("ListFeedsForExport", ctx, models.ExportFeedsPageQuery{UserID: "user-123", Limit: exportPageSize}).Return([]FeedWithTags{}, nil)
+
+	writer := &recordingExportWriter{}
+	err := service.ExportFeeds(ctx, "user-123", writer)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"begin", "flush", "end"}, writer.calls)
+	assert.Empty(t, writer.feeds)
+}
+
+func TestExportFeeds_RepositoryErrorBeforeBegin(t *testing.T) {
+	mockRepo := new(MockFeedRepository)
+	service := NewService(mockRepo, mockRepo, new(MockEventRepository), nil, newTestLogger())
+
+	ctx := context.Background()
+	mockRepo.On("ListFeedsForExport", ctx, models.ExportFeedsPageQuery{UserID: "user-123", Limit: exportPageSize}).Return(nil, errors.New("database error"))
+
+	writer := &recordingExportWriter{}
+	err := service.ExportFeeds(ctx, "user-123", writer)
+
+	require.Error(t, err)
+	serviceErr, ok := sharederrors.AsServiceError(err)
+	assert.True(t, ok, "error should be a ServiceError")
+	assert.Equal(t, 500, serviceErr.Code)
+	assert.Empty(t, writer.calls, "nothing is written before the first page is read")
+}
+
+func TestExportFeedsGrouped_GroupsByTag(t *testing.T) {
+	mockRepo := new(MockFeedRepository)
+	service := NewService(mockRepo, mockRepo, new(MockEventRepository), nil, newTestLogger())
+
+	ctx := context.Background()
+	userID := "user-123"
+	goBlog := newTestFeedWithTags("feed-1", userID, "Go Blog", "https://go.dev/blog/feed.atom", "Go", "Tech")
+	hn := newTestFeedWithTags("feed-2", userID, "Hacker News", "https://news.ycombinator.com/rss")
+
+	mockRepo.On("ListTags", ctx, userID).Return([]database.PublicTagsSelect{{Id: "tag-1", Name: "Go"}, {Id: "tag-2", Name: "Tech"}}, nil)
+	mockRepo.On("ListFeedsForExport", ctx, models.ExportFeedsPageQuery{UserID: userID, TagID: "tag-1", Limit: exportPageSize}).Return([]FeedWithTags{goBlog}, nil)
+	mockRepo.On("ListFeedsForExport", ctx, models.ExportFeedsPageQuery{UserID: userID, TagID: "tag-2", Limit: exportPageSize}).Return([]FeedWithTags{goBlog}, nil)
+	mockRepo.On("ListFeedsForExport", ctx, models.ExportFeedsPageQuery{UserID: userID, Untagged: true, Limit: exportPageSize}).Return([]FeedWithTags{hn}, nil)
+
+	writer := &recordingExportWriter{}
+	err := service.ExportFeedsGrouped(ctx, userID, writer)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"begin", "group Go", "flush", "end group", "group Tech", "flush", "end group", "flush", "end"}, writer.calls)
+	require.Len(t, writer.feeds, 3)
+	assert.Equal(t, "Hacker News", writer.feeds[2].Name)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestExportFeedsGrouped_TagsErrorBeforeBegin(t *testing.T) {
+	mockRepo := new(MockFeedRepository)
+	service := NewService(mockRepo, mockRepo, new(MockEventRepository), nil, newTestLogger())
+
+	ctx := context.Background()
+	mockRepo.On("ListTags", ctx, "user-123").Return(nil, errors.New("database error"))
+
+	writer := &recordingExportWriter{}
+	err := service.ExportFeedsGrouped(ctx, "user-123", writer)
+
+	require.Error(t, err)
+	_, ok := sharederrors.AsServiceError(err)
+	assert.True(t, ok, "error should be a ServiceError")
+	assert.Empty(t, writer.calls)
+}
+
 // Tests for GetFeedForEdit
 func TestGetFeedForEdit_Success(t *testing.T) {
 	mockRepo := new(MockFeedRepository)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockEventRepo, logger)
+	service := NewService(mockRepo, mockRepo, mockEventRepo, nil, logger)
 
 	ctx := context.Background()
 	feedID := "feed-123"
@@ -328,6 +765,7 @@ func TestGetFeedForEdit_Success(t *testing.T) {
 
 	dbFeed := newTestFeed(feedID, userID, "Test Feed", "https://example.com/feed")
 	mockRepo.On("FindFeedByIDAndUser", ctx, feedID, userID).Return(dbFeed, nil)
+	mockRepo.On("ListFeedTags", ctx, feedID).Return([]database.PublicTagsSelect{{Id: "tag-1", Name: "Go"}, {Id: "tag-2", Name: "News"}}, nil)
 
 	result, err := service.GetFeedForEdit(ctx, feedID, userID)
 
@@ -335,6 +773,7 @@ func TestGetFeedForEdit_Success(t *testing.T) {
 	assert.NotNil(t, result)
 	assert.Equal(t, "edit", result.Mode)
 	assert.Equal(t, dbFeed.Name, result.Name)
+	assert.Equal(t, "Go, News", result.Tags)
 	mockRepo.AssertExpectations(t)
 }
 
@@ -342,7 +781,7 @@ func TestGetFeedForEdit_NotFound(t *testing.T) {
 	mockRepo := new(MockFeedRepository)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockEventRepo, logger)
+	service := NewService(mockRepo, mockRepo, mockEventRepo, nil, logger)
 
 	ctx := context.Background()
 	feedID := "feed-123"
@@ -366,7 +805,7 @@ func TestGetFeedForEdit_RepositoryError(t *testing.T) {
 	mockRepo := new(MockFeedRepository)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockEventRepo, logger)
+	service := NewService(mockRepo, mockRepo, mockEventRepo, nil, logger)
 
 	ctx := context.Background()
 	feedID := "feed-123"
@@ -387,7 +826,7 @@ func TestUpdateFeed_NameOnlyUpdate(t *testing.T) {
 	mockRepo := new(MockFeedRepository)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockEventRepo, logger)
+	service := NewService(mockRepo, mockRepo, mockEventRepo, nil, logger)
 
 	ctx := context.Background()
 	feedID := "feed-123"
@@ -410,6 +849,7 @@ func TestUpdateFeed_NameOnlyUpdate(t *testing.T) {
 
 	mockRepo.On("FindFeedByIDAndUser", ctx, feedID, userID).Return(existingFeed, nil)
 	mockRepo.On("UpdateFeed", ctx, feedID, mock.AnythingOfType("database.PublicFeedsUpdate")).Return(nil)
+	mockRepo.On("SetFeedTags", ctx, feedID, []string(nil), true).Return([]database.PublicTagsSelect{}, nil)
 
 	urlChanged, err := service.UpdateFeed(ctx, cmd)
 
@@ -418,11 +858,47 @@ func TestUpdateFeed_NameOnlyUpdate(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestUpdateFeed_ClearedNameUsesChannelTitle(t *testing.T) {
+	mockRepo := new(MockFeedRepository)
+	mockEventRepo := new(MockEventRepository)
+	logger := newTestLogger()
+	service := NewService(mockRepo, mockRepo, mockEventRepo, nil, logger)
+
+	ctx := context.Background()
+	siteTitle := "Example Blog"
+	existingFeed := newTestFeed("feed-123", "user-123", "My name", "https://example.com/feed")
+	existingFeed.SiteTitle = &siteTitle
+
+	mockRepo.On("FindFeedByIDAndUser", ctx, "feed-123", "user-123").Return(existingFeed, nil)
+	mockRepo.On("UpdateFeed", ctx, "feed-123", mock.MatchedBy(func(update database.PublicFeedsUpdate) bool {
+		return update.Name != nil && *update.Name == siteTitle && update.NameIsAuto != nil && *update.NameIsAuto
+	})).Return(nil)
+	mockRepo.On("SetFeedTags", ctx, "feed-123", []string(nil), true).Return([]database.PublicTagsSelect{}, nil)
+
+	_, err := service.UpdateFeed(ctx, models.UpdateFeedCommand{
+		ID:     "feed-123",
+		UserID: "user-123",
+		URL:    "https://example.com/feed",
+	})
+
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAutoFeedName(t *testing.T) {
+	siteTitle := "Example Blog"
+	feed := database.PublicFeedsSelect{Url: "https://example.com/feed", SiteTitle: &siteTitle}
+
+	assert.Equal(t, "Example Blog", autoFeedName(feed, "https://example.com/feed"))
+	assert.Equal(t, "other.org", autoFeedName(feed, "https://www.other.org/rss"), "channel title of the old URL is not reused")
+	assert.Equal(t, "example.com", autoFeedName(database.PublicFeedsSelect{Url: "https://example.com/feed"}, "https://example.com/feed"))
+}
+
 func TestUpdateFeed_URLChange(t *testing.T) {
 	mockRepo := new(MockFeedRepository)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockEventRepo, logger)
+	service := NewService(mockRepo, mockRepo, mockEventRepo, nil, logger)
 
 	ctx := context.Background()
 	feedID := "feed-123"
@@ -451,6 +927,7 @@ func TestUpdateFeed_URLChange(t *testing.T) {
 		ExcludeFeedID: feedID,
 	}).Return(false, nil)
 	mockRepo.On("UpdateFeed", ctx, feedID, mock.AnythingOfType("database.PublicFeedsUpdate")).Return(nil)
+	mockRepo.On("SetFeedTags", ctx, feedID, []string(nil), true).Return([]database.PublicTagsSelect{}, nil)
 
 	urlChanged, err := service.UpdateFeed(ctx, cmd)
 
@@ -463,7 +940,7 @@ func TestUpdateFeed_URLConflict(t *testing.T) {
 	mockRepo := new(MockFeedRepository)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockEventRepo, logger)
+	service := NewService(mockRepo, mockRepo, mockEventRepo, nil, logger)
 
 	ctx := context.Background()
 	feedID := "feed-123"
@@ -507,7 +984,7 @@ func TestUpdateFeed_FeedNotFound(t *testing.T) {
 	mockRepo := new(MockFeedRepository)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockEventRepo, logger)
+	service := NewService(mockRepo, mockRepo, mockEventRepo, nil, logger)
 
 	ctx := context.Background()
 	feedID := "feed-123"
@@ -538,7 +1015,7 @@ func TestUpdateFeed_UpdateError(t *testing.T) {
 	mockRepo := new(MockFeedRepository)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockEventRepo, logger)
+	service := NewService(mockRepo, mockRepo, mockEventRepo, nil, logger)
 
 	ctx := context.Background()
 	feedID := "feed-123"
@@ -570,12 +1047,258 @@ func TestUpdateFeed_UpdateError(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to update feed")
 }
 
+// Tests for feed credentials
+func TestCreateFeed_EncryptsCredentials(t *testing.T) {
+	mockRepo := new(MockFeedRepository)
+	mockEventRepo := new(MockEventRepository)
+	cipher := newTestCipher(t)
+	service := NewService(mockRepo, mockRepo, mockEventRepo, cipher, newTestLogger())
+
+	ctx := context.Background()
+	cmd := models.CreateFeedCommand{
+		UserID: "user-123",
+		Name:   "Private Feed",
+		URL:    "https://gitlab.example.com/activity.atom",
+		FeedAuthFields: models.FeedAuthFields{
+			AuthType:      credentials.AuthTypeBasic,
+			AuthUsername:  "alice",
+			AuthPassword:  "s3cret",
+			CustomHeaders: "X-Api-Key: key",
+		},
+	}
+
+	var inserted database.PublicFeedsInsert
+	mockRepo.On("InsertFeed", ctx, mock.AnythingOfType("database.PublicFeedsInsert")).
+		Run(func(args mock.Arguments) { inserted = args.Get(1).(database.PublicFeedsInsert) }).
+		Return("feed-123", nil)
+	mockEventRepo.On("RecordEvent", ctx, mock.Anything).Return(nil)
+
+	_, err := service.CreateFeed(ctx, cmd)
+
+	require.NoError(t, err)
+	require.NotNil(t, inserted.CredentialsEncrypted)
+	assert.NotContains(t, *inserted.CredentialsEncrypted, "s3cret")
+
+	saved, err := cipher.Open(*inserted.CredentialsEncrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", saved.Username)
+	assert.Equal(t, "s3cret", saved.Password)
+	assert.Equal(t, map[string]string{"X-Api-Key": "key"}, saved.Headers)
+}
+
+func TestCreateFeed_CredentialsDisabled(t *testing.T) {
+	mockRepo := new(MockFeedRepository)
+	mockEventRepo := new(MockEventRepository)
+	service := NewService(mockRepo, mockRepo, mockEventRepo, nil, newTestLogger())
+
+	cmd := models.CreateFeedCommand{
+		UserID: "user-123",
+		Name:   "Private Feed",
+		URL:    "https://example.com/feed",
+		FeedAuthFields: models.FeedAuthFields{
+			AuthType:  credentials.AuthTypeBearer,
+			AuthToken: "token",
+		},
+	}
+
+	_, err := service.CreateFeed(context.Background(), cmd)
+
+	serviceErr, ok := sharederrors.AsServiceError(err)
+	require.True(t, ok, "error should be a ServiceError")
+	assert.Equal(t, 422, serviceErr.Code)
+	mockRepo.AssertNotCalled(t, "InsertFeed", mock.Anything, mock.Anything)
+}
+
+func TestCreateFeed_BearerWithoutToken(t *testing.T) {
+	mockRepo := new(MockFeedRepository)
+	mockEventRepo := new(MockEventRepository)
+	service := NewService(mockRepo, mockRepo, mockEventRepo, newTestCipher(t), newTestLogger())
+
+	cmd := models.CreateFeedCommand{
+		UserID:         "user-123",
+		Name:           "Private Feed",
+		URL:            "https://example.com/feed",
+		FeedAuthFields: models.FeedAuthFields{AuthType: credentials.AuthTypeBearer},
+	}
+
+	_, err := service.CreateFeed(context.Background(), cmd)
+
+	serviceErr, ok := sharederrors.AsServiceError(err)
+	require.True(t, ok, "error should be a ServiceError")
+	assert.Contains(t, serviceErr.FieldErrors, "AuthToken")
+}
+
+func TestUpdateFeed_KeepsSavedSecretsAndRefetches(t *testing.T) {
+	mockRepo := new(MockFeedRepository)
+	mockEventRepo := new(MockEventRepository)
+	cipher := newTestCipher(t)
+	service := NewService(mockRepo, mockRepo, mockEventRepo, cipher, newTestLogger())
+
+	ctx := context.Background()
+	sealed, err := cipher.Seal(credentials.FeedCredentials{Username: "alice", Password: "old-password"})
+	require.NoError(t, err)
+
+	existingFeed := newTestFeed("feed-123", "user-123", "Private Feed", "https://example.com/feed")
+	existingFeed.CredentialsEncrypted = &sealed
+	existingFeed.RetryCount = 4
+
+	cmd := models.UpdateFeedCommand{
+		ID:     "feed-123",
+		UserID: "user-123",
+		Name:   "Private Feed",
+		URL:    "https://example.com/feed",
+		FeedAuthFields: models.FeedAuthFields{
+			AuthType:     credentials.AuthTypeBasic,
+			AuthUsername: "bob", // Password left empty
+		},
+	}
+
+	var update database.PublicFeedsUpdate
+	mockRepo.On("FindFeedByIDAndUser", ctx, "feed-123", "user-123").Return(existingFeed, nil)
+	mockRepo.On("UpdateFeed", ctx, "feed-123", mock.AnythingOfType("database.PublicFeedsUpdate")).
+		Run(func(args mock.Arguments) { update = args.Get(2).(database.PublicFeedsUpdate) }).
+		Return(nil)
+	mockRepo.On("SetFeedTags", ctx, "feed-123", []string(nil), true).Return([]database.PublicTagsSelect{}, nil)
+
+	refetch, err := service.UpdateFeed(ctx, cmd)
+
+	require.NoError(t, err)
+	assert.True(t, refetch, "changed credentials should trigger a fetch")
+	require.NotNil(t, update.CredentialsEncrypted)
+	saved, err := cipher.Open(*update.CredentialsEncrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "bob", saved.Username)
+	assert.Equal(t, "old-password", saved.Password)
+	require.NotNil(t, update.RetryCount)
+	assert.Equal(t, 0, *update.RetryCount, "retry state should be reset")
+}
+
+func TestUpdateFeed_UnchangedCredentials(t *testing.T) {
+	mockRepo := new(MockFeedRepository)
+	mockEventRepo := new(MockEventRepository)
+	cipher := newTestCipher(t)
+	service := NewService(mockRepo, mockRepo, mockEventRepo, cipher, newTestLogger())
+
+	ctx := context.Background()
+	sealed, err := cipher.Seal(credentials.FeedCredentials{Token: "token"})
+	require.NoError(t, err)
+
+	existingFeed := newTestFeed("feed-123", "user-123", "Private Feed", "https://example.com/feed")
+	existingFeed.CredentialsEncrypted = &sealed
+
+	cmd := models.UpdateFeedCommand{
+		ID:             "feed-123",
+		UserID:         "user-123",
+		Name:           "Renamed Feed",
+		URL:            "https://example.com/feed",
+		FeedAuthFields: models.FeedAuthFields{AuthType: credentials.AuthTypeBearer},
+	}
+
+	mockRepo.On("FindFeedByIDAndUser", ctx, "feed-123", "user-123").Return(existingFeed, nil)
+	mockRepo.On("UpdateFeed", ctx, "feed-123", mock.MatchedBy(func(update database.PublicFeedsUpdate) bool {
+		return update.CredentialsEncrypted == nil && update.RetryCount == nil
+	})).Return(nil)
+	mockRepo.On("SetFeedTags", ctx, "feed-123", []string(nil), true).Return([]database.PublicTagsSelect{}, nil)
+
+	refetch, err := service.UpdateFeed(ctx, cmd)
+
+	require.NoError(t, err)
+	assert.False(t, refetch)
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "ClearFeedCredentials", mock.Anything, mock.Anything)
+}
+
+func TestUpdateFeed_ClearCredentials(t *testing.T) {
+	mockRepo := new(MockFeedRepository)
+	mockEventRepo := new(MockEventRepository)
+	cipher := newTestCipher(t)
+	service := NewService(mockRepo, mockRepo, mockEventRepo, cipher, newTestLogger())
+
+	ctx := context.Background()
+	sealed, err := cipher.Seal(credentials.FeedCredentials{Token: "token"})
+	require.NoError(t, err)
+
+	existingFeed := newTestFeed("feed-123", "user-123", "Private Feed", "https://example.com/feed")
+	existingFeed.CredentialsEncrypted = &sealed
+
+	cmd := models.UpdateFeedCommand{
+		ID:               "feed-123",
+		UserID:           "user-123",
+		Name:             "Private Feed",
+		URL:              "https://example.com/feed",
+		ClearCredentials: true,
+		FeedAuthFields:   models.FeedAuthFields{AuthType: credentials.AuthTypeNone},
+	}
+
+	mockRepo.On("FindFeedByIDAndUser", ctx, "feed-123", "user-123").Return(existingFeed, nil)
+	mockRepo.On("UpdateFeed", ctx, "feed-123", mock.AnythingOfType("database.PublicFeedsUpdate")).Return(nil)
+	mockRepo.On("SetFeedTags", ctx, "feed-123", []string(nil), true).Return([]database.PublicTagsSelect{}, nil)
+	mockRepo.On("ClearFeedCredentials", ctx, "feed-123").Return(nil)
+
+	refetch, err := service.UpdateFeed(ctx, cmd)
+
+	require.NoError(t, err)
+	assert.True(t, refetch)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetFeedForEdit_ShowsSavedCredentials(t *testing.T) {
+	mockRepo := new(MockFeedRepository)
+	mockEventRepo := new(MockEventRepository)
+	cipher := newTestCipher(t)
+	service := NewService(mockRepo, mockRepo, mockEventRepo, cipher, newTestLogger())
+
+	ctx := context.Background()
+	sealed, err := cipher.Seal(credentials.FeedCredentials{
+		Username: "alice",
+		Password: "s3cret",
+		Headers:  map[string]string{"X-Api-Key": "key", "Cookie": "a=b"},
+	})
+	require.NoError(t, err)
+
+	existingFeed := newTestFeed("feed-123", "user-123", "Private Feed", "https://example.com/feed")
+	existingFeed.CredentialsEncrypted = &sealed
+	mockRepo.On("FindFeedByIDAndUser", ctx, "feed-123", "user-123").Return(existingFeed, nil)
+	mockRepo.On("ListFeedTags", ctx, "feed-123").Return([]database.PublicTagsSelect{}, nil)
+
+	vm, err := service.GetFeedForEdit(ctx, "feed-123", "user-123")
+
+	require.NoError(t, err)
+	assert.True(t, vm.HasCredentials)
+	assert.Equal(t, credentials.AuthTypeBasic, vm.AuthType)
+	assert.Equal(t, "alice", vm.AuthUsername)
+	assert.Equal(t, []string{"Cookie", "X-Api-Key"}, vm.SavedHeaderNames)
+}
+
+func TestUpdateFeed_ReplacesTags(t *testing.T) {
+	mockRepo := new(MockFeedRepository)
+	service := NewService(mockRepo, mockRepo, new(MockEventRepository), nil, newTestLogger())
+
+	ctx := context.Background()
+	existingFeed := newTestFeed("feed-123", "user-123", "Feed", "https://example.com/feed")
+
+	mockRepo.On("FindFeedByIDAndUser", ctx, "feed-123", "user-123").Return(existingFeed, nil)
+	mockRepo.On("UpdateFeed", ctx, "feed-123", mock.AnythingOfType("database.PublicFeedsUpdate")).Return(nil)
+	mockRepo.On("SetFeedTags", ctx, "feed-123", []string{"Go"}, true).Return([]database.PublicTagsSelect{}, nil)
+
+	_, err := service.UpdateFeed(ctx, models.UpdateFeedCommand{
+		ID:     "feed-123",
+		UserID: "user-123",
+		Name:   "Feed",
+		URL:    "https://example.com/feed",
+		Tags:   " Go ,",
+	})
+
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
 // Tests for DeleteFeed
 func TestDeleteFeed_Success(t *testing.T) {
 	mockRepo := new(MockFeedRepository)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockEventRepo, logger)
+	service := NewService(mockRepo, mockRepo, mockEventRepo, nil, logger)
 
 	ctx := context.Background()
 	feedID := "feed-123"
@@ -593,7 +1316,7 @@ func TestDeleteFeed_NotFound(t *testing.T) {
 	mockRepo := new(MockFeedRepository)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockEventRepo, logger)
+	service := NewService(mockRepo, mockRepo, mockEventRepo, nil, logger)
 
 	ctx := context.Background()
 	feedID := "feed-123"
@@ -616,7 +1339,7 @@ func TestDeleteFeed_RepositoryError(t *testing.T) {
 	mockRepo := new(MockFeedRepository)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockEventRepo, logger)
+	service := NewService(mockRepo, mockRepo, mockEventRepo, nil, logger)
 
 	ctx := context.Background()
 	feedID := "feed-123"
@@ -631,11 +1354,135 @@ func TestDeleteFeed_RepositoryError(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to delete feed")
 }
 
+// Tests for GetFeedHistory
+func TestGetFeedHistory_Success(t *testing.T) {
+	mockRepo := new(MockFeedRepository)
+	mockEventRepo := new(MockEventRepository)
+	logger := newTestLogger()
+	service := NewService(mockRepo, mockRepo, mockEventRepo, nil, logger)
+
+	ctx := context.Background()
+	feedID := "feed-123"
+	userID := "user-123"
+	httpStatus := 200
+
+	mockRepo.On("FindFeedByIDAndUser", ctx, feedID, userID).
+		Return(newTestFeed(feedID, userID, "Test Feed", "https://example.com/feed"), nil)
+	mockRepo.On("FindFetchLog", ctx, feedID, historySize).Return([]database.PublicFeedFetchLogSelect{
+		{
+			Id:          "log-2",
+			FeedId:      feedID,
+			Status:      "success",
+			HttpStatus:  &httpStatus,
+			DurationMs:  120,
+			BytesRead:   2048,
+			NewArticles: 3,
+			CreatedAt:   "2025-11-06T10:00:00Z",
+		},
+		{
+			Id:         "log-1",
+			FeedId:     feedID,
+			Status:     "temporary_error",
+			DurationMs: 30000,
+			CreatedAt:  "2025-11-06T09:00:00Z",
+		},
+	}, nil)
+
+	vm, err := service.GetFeedHistory(ctx, feedID, userID)
+
+	require.NoError(t, err)
+	assert.Equal(t, feedID, vm.FeedID)
+	assert.Equal(t, "Test Feed", vm.FeedName)
+	require.Len(t, vm.Entries, 2)
+	assert.Equal(t, 200, vm.Entries[0].HTTPStatus)
+	assert.Equal(t, 3, vm.Entries[0].NewArticles)
+	assert.True(t, vm.Entries[1].HasError)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetFeedHistory_NotFound(t *testing.T) {
+	mockRepo := new(MockFeedRepository)
+	mockEventRepo := new(MockEventRepository)
+	logger := newTestLogger()
+	service := NewService(mockRepo, mockRepo, mockEventRepo, nil, logger)
+
+	ctx := context.Background()
+	feedID := "feed-123"
+	userID := "user-123"
+
+	mockRepo.On("FindFeedByIDAndUser", ctx, feedID, userID).
+		Return(nil, errors.New("404 not found"))
+
+	vm, err := service.GetFeedHistory(ctx, feedID, userID)
+
+	assert.Nil(t, vm)
+	serviceErr, ok := sharederrors.AsServiceError(err)
+	assert.True(t, ok, "error should be a ServiceError")
+	assert.Equal(t, 404, serviceErr.Code)
+	mockRepo.AssertNotCalled(t, "FindFetchLog", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetFeedHistory_RepositoryError(t *testing.T) {
+	mockRepo := new(MockFeedRepository)
+	mockEventRepo := new(MockEventRepository)
+	logger := newTestLogger()
+	service := NewService(mockRepo, mockRepo, mockEventRepo, nil, logger)
+
+	ctx := context.Background()
+	feedID := "feed-123"
+	userID := "user-123"
+
+	mockRepo.On("FindFeedByIDAndUser", ctx, feedID, userID).
+		Return(newTestFeed(feedID, userID, "Test Feed", "https://example.com/feed"), nil)
+	mockRepo.On("FindFetchLog", ctx, feedID, historySize).
+		Return(nil, errors.New("database error"))
+
+	vm, err := service.GetFeedHistory(ctx, feedID, userID)
+
+	assert.Nil(t, vm)
+	assert.Contains(t, err.Error(), "failed to get fetch history")
+}
+
+// Tests for GetFavicon
+func TestGetFavicon_Success(t *testing.T) {
+	mockRepo := new(MockFeedRepository)
+	service := NewService(mockRepo, mockRepo, new(MockEventRepository), nil, newTestLogger())
+
+	ctx := context.Background()
+	mockRepo.On("FindFavicon", ctx, "feed-123").Return(&database.PublicFeedFaviconsSelect{
+		FeedId:      "feed-123",
+		ContentType: "image/png",
+		Data:        base64.StdEncoding.EncodeToString([]byte("icon")),
+	}, nil)
+
+	favicon, err := service.GetFavicon(ctx, "feed-123")
+
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", favicon.ContentType)
+	assert.Equal(t, []byte("icon"), favicon.Data)
+}
+
+func TestGetFavicon_NotFound(t *testing.T) {
+	mockRepo := new(MockFeedRepository)
+	service := NewService(mockRepo, mockRepo, new(MockEventRepository), nil, newTestLogger())
+
+	ctx := context.Background()
+	mockRepo.On("FindFavicon", ctx, "feed-123").
+		Return(nil, errors.New("404 not found"))
+
+	favicon, err := service.GetFavicon(ctx, "feed-123")
+
+	assert.Nil(t, favicon)
+	serviceErr, ok := sharederrors.AsServiceError(err)
+	require.True(t, ok, "error should be a ServiceError")
+	assert.Equal(t, 404, serviceErr.Code)
+}
+
 // Tests for buildFeedListViewModel (pure function)
 func TestBuildFeedListViewModel_WithFeeds(t *testing.T) {
-	feeds := []database.PublicFeedsSelect{
-		*newTestFeed("feed-1", "user-123", "Feed 1", "https://example.com/feed1"),
-		*newTestFeed("feed-2", "user-123", "Feed 2", "https://example.com/feed2"),
+	feeds := []FeedWithTags{
+		newTestFeedWithTags("feed-1", "user-123", "Feed 1", "https://example.com/feed1"),
+		newTestFeedWithTags("feed-2", "user-123", "Feed 2", "https://example.com/feed2"),
 	}
 
 	result := &ListFeedsResult{
@@ -650,7 +1497,7 @@ func TestBuildFeedListViewModel_WithFeeds(t *testing.T) {
 		Page:   1,
 	}
 
-	viewModel := buildFeedListViewModel(result, query)
+	viewModel := buildFeedListViewModel(result, nil, nil, query)
 
 	assert.Len(t, viewModel.Feeds, 2)
 	assert.False(t, viewModel.ShowEmptyState)
@@ -659,7 +1506,7 @@ func TestBuildFeedListViewModel_WithFeeds(t *testing.T) {
 
 func TestBuildFeedListViewModel_EmptyWithFilters(t *testing.T) {
 	result := &ListFeedsResult{
-		Feeds:      []database.PublicFeedsSelect{},
+		Feeds:      []FeedWithTags{},
 		TotalCount: 0,
 	}
 
@@ -670,7 +1517,7 @@ func TestBuildFeedListViewModel_EmptyWithFilters(t *testing.T) {
 		Page:   1,
 	}
 
-	viewModel := buildFeedListViewModel(result, query)
+	viewModel := buildFeedListViewModel(result, nil, nil, query)
 
 	assert.Len(t, viewModel.Feeds, 0)
 	assert.False(t, viewModel.ShowEmptyState)
@@ -678,7 +1525,7 @@ func TestBuildFeedListViewModel_EmptyWithFilters(t *testing.T) {
 
 func TestBuildFeedListViewModel_EmptyNoFilters(t *testing.T) {
 	result := &ListFeedsResult{
-		Feeds:      []database.PublicFeedsSelect{},
+		Feeds:      []FeedWithTags{},
 		TotalCount: 0,
 	}
 
@@ -689,7 +1536,7 @@ func TestBuildFeedListViewModel_EmptyNoFilters(t *testing.T) {
 		Page:   1,
 	}
 
-	viewModel := buildFeedListViewModel(result, query)
+	viewModel := buildFeedListViewModel(result, nil, nil, query)
 
 	assert.Len(t, viewModel.Feeds, 0)
 	assert.True(t, viewModel.ShowEmptyState)
@@ -697,9 +1544,9 @@ func TestBuildFeedListViewModel_EmptyNoFilters(t *testing.T) {
 
 func TestBuildFeedListViewModel_Pagination(t *testing.T) {
 	// Simulate 100 total items with page size 20
-	feeds := make([]database.PublicFeedsSelect, 20)
+	feeds := make([]FeedWithTags, 20)
 	for i := 0; i < 20; i++ {
-		feeds[i] = *newTestFeed("feed-"+string(rune(i)), "user-123", "Feed", "https://example.com")
+		feeds[i] = newTestFeedWithTags("feed-"+string(rune(i)), "user-123", "Feed", "https://example.com")
 	}
 
 	result := &ListFeedsResult{
@@ -714,7 +1561,7 @@ func TestBuildFeedListViewModel_Pagination(t *testing.T) {
 		Page:   2,
 	}
 
-	viewModel := buildFeedListViewModel(result, query)
+	viewModel := buildFeedListViewModel(result, nil, nil, query)
 
 	assert.Equal(t, 2, viewModel.Pagination.CurrentPage)
 	assert.True(t, viewModel.Pagination.HasPrevious)
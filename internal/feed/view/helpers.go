@@ -0,0 +1,93 @@
+package view
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/tjanas94/vibefeeder/internal/feed/models"
+	"github.com/tjanas94/vibefeeder/internal/shared/view/components"
+)
+
+// fetchStatusBadge maps a fetch attempt to the badge shown in the history view
+func fetchStatusBadge(entry models.FetchLogEntryViewModel) components.BadgeProps {
+	switch {
+	case entry.NotModified:
+		return components.BadgeProps{Text: "Not modified", Type: "neutral", Size: "sm"}
+	case entry.Status == "success":
+		return components.BadgeProps{Text: "OK", Type: "success", Size: "sm"}
+	case entry.Status == "temporary_error":
+		return components.BadgeProps{Text: "Retrying", Type: "warning", Size: "sm"}
+	case entry.Status == "unauthorized":
+		return components.BadgeProps{Text: "Unauthorized", Type: "error", Size: "sm"}
+	case entry.Status == "too_large":
+		return components.BadgeProps{Text: "Too large", Type: "warning", Size: "sm"}
+	default:
+		return components.BadgeProps{Text: "Error", Type: "error", Size: "sm"}
+	}
+}
+
+// importStatusBadge maps the result of an imported row to its badge
+func importStatusBadge(status string) components.BadgeProps {
+	switch status {
+	case models.ImportStatusAdded:
+		return components.BadgeProps{Text: "Added", Type: "success", Size: "sm"}
+	case models.ImportStatusDuplicate:
+		return components.BadgeProps{Text: "Duplicate", Type: "neutral", Size: "sm"}
+	case models.ImportStatusInvalid:
+		return components.BadgeProps{Text: "Invalid", Type: "warning", Size: "sm"}
+	default:
+		return components.BadgeProps{Text: "Failed", Type: "error", Size: "sm"}
+	}
+}
+
+// formatBytes renders a byte count in human-readable units
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1024*1024))
+	case n >= 1024:
+		return fmt.Sprintf("%.1f KB", float64(n)/1024)
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
+
+// formatDuration renders a fetch duration with millisecond precision
+func formatDuration(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%d ms", d.Milliseconds())
+	}
+	return fmt.Sprintf("%.1f s", d.Seconds())
+}
+
+// hasAuthErrors reports whether any authorization field failed validation
+func hasAuthErrors(errors models.FeedFormErrorViewModel) bool {
+	return errors.AuthUsernameError != "" || errors.AuthTokenError != "" || errors.CustomHeadersError != ""
+}
+
+// namePlaceholder shows the automatic name of the feed, or explains that the name is optional
+func namePlaceholder(vm models.FeedFormViewModel) string {
+	if vm.NamePlaceholder != "" {
+		return vm.NamePlaceholder
+	}
+	return "Leave empty to use the feed title"
+}
+
+// siteHost returns the host of a website URL for display
+func siteHost(siteURL string) string {
+	parsed, err := url.Parse(siteURL)
+	if err != nil || parsed.Host == "" {
+		return siteURL
+	}
+	return strings.TrimPrefix(parsed.Host, "www.")
+}
+
+// secretPlaceholder explains that saved secrets are kept when the field is left empty
+func secretPlaceholder(mode string) string {
+	if mode == "edit" {
+		return "Leave empty to keep the saved value"
+	}
+	return ""
+}
@@ -1,7 +1,7 @@
 package view
 
 // FeedSearchFilterProps defines the properties required to render the feed
-// search + status filter form (see FeedSearchFilter templ component).
+// search, status and tag filter form (see FeedSearchFilter templ component).
 // These values typically originate from query parameters so that the form
 // reflects the current filter state after HTMX-driven updates.
 type FeedSearchFilterProps struct {
@@ -11,4 +11,7 @@ type FeedSearchFilterProps struct {
 	// Status is the selected status filter:
 	// "all", "working", "pending", "error"
 	Status string
+
+	// Tag is the ID of the selected tag, empty for all tags
+	Tag string
 }
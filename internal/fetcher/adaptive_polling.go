@@ -0,0 +1,185 @@
+package fetcher
+
+import (
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/tjanas94/vibefeeder/internal/shared/database"
+)
+
+const (
+	// unchangedRatioWeight is the weight of the latest fetch outcome in the unchanged ratio
+	unchangedRatioWeight = 0.2
+
+	// cadenceWeight is the weight of the latest observed cadence in the smoothed publish cadence
+	cadenceWeight = 0.3
+
+	// maxCadenceSamples limits how many of the most recent items are used to measure cadence
+	maxCadenceSamples = 20
+)
+
+// AdaptivePolling learns a per-feed polling interval from publishing cadence and fetch outcomes
+// Busy feeds are polled close to the minimum interval, dead feeds drift towards the maximum
+type AdaptivePolling struct {
+	defaultInterval time.Duration
+	minInterval     time.Duration
+	maxInterval     time.Duration
+	logger          *slog.Logger
+}
+
+// NewAdaptivePolling creates a new adaptive polling policy
+// defaultInterval is used until the feed's publishing cadence is known
+func NewAdaptivePolling(defaultInterval, minInterval, maxInterval time.Duration, logger *slog.Logger) *AdaptivePolling {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &AdaptivePolling{
+		defaultInterval: defaultInterval,
+		minInterval:     minInterval,
+		maxInterval:     maxInterval,
+		logger:          logger,
+	}
+}
+
+// Apply updates polling statistics for a successful fetch and reschedules the next fetch
+// Error decisions keep their backoff-based schedule
+func (ap *AdaptivePolling) Apply(feed database.PublicFeedsSelect, decision FetchDecision, now time.Time) FetchDecision {
+	if decision.Status != "success" {
+		return decision
+	}
+
+	unchanged := decision.NotModified || !hasNewArticles(decision.Articles, feed.LastFetchedAt)
+	outcome := 0.0
+	if unchanged {
+		outcome = 1.0
+	}
+	ratio := feed.UnchangedFetchRatio*(1-unchangedRatioWeight) + outcome*unchangedRatioWeight
+
+	cadence := secondsToDuration(feed.PublishCadenceSeconds)
+	if observed, ok := observedPublishCadence(decision.Articles, now); ok {
+		cadence = smoothCadence(cadence, observed)
+	}
+
+	interval := adaptiveInterval(cadence, ratio, ap.defaultInterval, ap.minInterval, ap.maxInterval)
+
+	// Never poll more often than the server allows
+	wait := max(interval, decision.CacheMaxAge)
+	decision.NextFetchTime = now.Add(wait)
+	decision.Polling = &PollingStats{
+		Interval:       interval,
+		PublishCadence: cadence,
+		UnchangedRatio: ratio,
+	}
+
+	ap.logger.Debug("Adaptive polling interval calculated",
+		"feed_id", feed.Id,
+		"interval", interval,
+		"unchanged_ratio", ratio,
+		"next_fetch", decision.NextFetchTime,
+	)
+
+	return decision
+}
+
+// hasNewArticles reports whether any article was published after the previous fetch
+// Every article is new for feeds that were never fetched
+func hasNewArticles(articles []Article, lastFetchedAt *string) bool {
+	if len(articles) == 0 {
+		return false
+	}
+	if lastFetchedAt == nil {
+		return true
+	}
+
+	since, err := time.Parse(time.RFC3339, *lastFetchedAt)
+	if err != nil {
+		return true
+	}
+
+	for _, article := range articles {
+		if article.PublishedAt.After(since) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// observedPublishCadence measures the median interval between the most recent published items
+// Silence since the newest item counts as a lower bound, so feeds that stopped publishing slow down
+// Returns false when fewer than two distinct publication dates are available
+func observedPublishCadence(articles []Article, now time.Time) (time.Duration, bool) {
+	dates := make([]time.Time, 0, len(articles))
+	for _, article := range articles {
+		if !article.PublishedAt.After(now) {
+			dates = append(dates, article.PublishedAt)
+		}
+	}
+
+	// Newest first
+	slices.SortFunc(dates, func(a, b time.Time) int { return b.Compare(a) })
+	if len(dates) > maxCadenceSamples {
+		dates = dates[:maxCadenceSamples]
+	}
+
+	gaps := make([]time.Duration, 0, len(dates))
+	for i := 1; i < len(dates); i++ {
+		// Items without a date share the fetch time; identical timestamps carry no signal
+		if gap := dates[i-1].Sub(dates[i]); gap > 0 {
+			gaps = append(gaps, gap)
+		}
+	}
+	if len(gaps) == 0 {
+		return 0, false
+	}
+
+	slices.Sort(gaps)
+	median := gaps[len(gaps)/2]
+
+	if silence := now.Sub(dates[0]); silence > median {
+		return silence, true
+	}
+
+	return median, true
+}
+
+// smoothCadence blends a newly observed cadence into the previous one
+func smoothCadence(previous *time.Duration, observed time.Duration) *time.Duration {
+	if previous == nil {
+		return &observed
+	}
+
+	smoothed := time.Duration(float64(*previous)*(1-cadenceWeight) + float64(observed)*cadenceWeight)
+	return &smoothed
+}
+
+// adaptiveInterval calculates the polling interval from cadence and unchanged ratio
+// Polls twice per publishing period, stretched up to 3x when fetches keep returning nothing new
+func adaptiveInterval(
+	cadence *time.Duration,
+	unchangedRatio float64,
+	defaultInterval time.Duration,
+	minInterval time.Duration,
+	maxInterval time.Duration,
+) time.Duration {
+	base := defaultInterval
+	if cadence != nil {
+		base = *cadence / 2
+	}
+
+	interval := time.Duration(float64(base) * (1 + 2*unchangedRatio))
+
+	return min(max(interval, minInterval), maxInterval)
+}
+
+// secondsToDuration converts a nullable seconds column to a duration pointer
+func secondsToDuration(seconds *int) *time.Duration {
+	if seconds == nil {
+		return nil
+	}
+
+	d := time.Duration(*seconds) * time.Second
+	return &d
+}
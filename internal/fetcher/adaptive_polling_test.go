@@ -0,0 +1,161 @@
+package fetcher
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tjanas94/vibefeeder/internal/shared/database"
+)
+
+func durationPtr(d time.Duration) *time.Duration {
+	return &d
+}
+
+// TestObservedPublishCadence tests cadence measurement from article dates
+func TestObservedPublishCadence(t *testing.T) {
+	now := time.Date(2025, 11, 2, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		published     []time.Time
+		expected      time.Duration
+		expectedFound bool
+	}{
+		{
+			name:          "hourly feed",
+			published:     []time.Time{now.Add(-30 * time.Minute), now.Add(-90 * time.Minute), now.Add(-150 * time.Minute), now.Add(-210 * time.Minute)},
+			expected:      time.Hour,
+			expectedFound: true,
+		},
+		{
+			name:          "unsorted input uses median gap",
+			published:     []time.Time{now.Add(-3 * time.Hour), now.Add(-time.Hour), now.Add(-2 * time.Hour), now.Add(-10 * time.Hour)},
+			expected:      time.Hour,
+			expectedFound: true,
+		},
+		{
+			name:          "silence since newest item dominates",
+			published:     []time.Time{now.Add(-90 * 24 * time.Hour), now.Add(-91 * 24 * time.Hour), now.Add(-92 * 24 * time.Hour)},
+			expected:      90 * 24 * time.Hour,
+			expectedFound: true,
+		},
+		{
+			name:          "items without dates carry no signal",
+			published:     []time.Time{now, now, now},
+			expectedFound: false,
+		},
+		{
+			name:          "single item",
+			published:     []time.Time{now.Add(-time.Hour)},
+			expectedFound: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			articles := make([]Article, 0, len(tt.published))
+			for _, p := range tt.published {
+				articles = append(articles, Article{PublishedAt: p})
+			}
+
+			cadence, found := observedPublishCadence(articles, now)
+
+			assert.Equal(t, tt.expectedFound, found)
+			if tt.expectedFound {
+				assert.Equal(t, tt.expected, cadence)
+			}
+		})
+	}
+}
+
+// TestAdaptiveInterval tests interval calculation and clamping
+func TestAdaptiveInterval(t *testing.T) {
+	tests := []struct {
+		name     string
+		cadence  *time.Duration
+		ratio    float64
+		expected time.Duration
+	}{
+		{name: "unknown cadence uses default", cadence: nil, ratio: 0, expected: time.Hour},
+		{name: "unknown cadence stretched by unchanged ratio", cadence: nil, ratio: 0.5, expected: 2 * time.Hour},
+		{name: "polls twice per publishing period", cadence: durationPtr(6 * time.Hour), ratio: 0, expected: 3 * time.Hour},
+		{name: "clamped to minimum", cadence: durationPtr(10 * time.Minute), ratio: 0, expected: 15 * time.Minute},
+		{name: "clamped to maximum", cadence: durationPtr(30 * 24 * time.Hour), ratio: 1, expected: 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, adaptiveInterval(tt.cadence, tt.ratio, time.Hour, 15*time.Minute, 24*time.Hour))
+		})
+	}
+}
+
+// TestHasNewArticles tests detection of articles published since the last fetch
+func TestHasNewArticles(t *testing.T) {
+	lastFetched := "2025-11-02T12:00:00Z"
+	before := time.Date(2025, 11, 2, 11, 0, 0, 0, time.UTC)
+	after := time.Date(2025, 11, 2, 13, 0, 0, 0, time.UTC)
+
+	assert.False(t, hasNewArticles(nil, &lastFetched), "no articles")
+	assert.True(t, hasNewArticles([]Article{{PublishedAt: before}}, nil), "never fetched")
+	assert.False(t, hasNewArticles([]Article{{PublishedAt: before}}, &lastFetched), "only old articles")
+	assert.True(t, hasNewArticles([]Article{{PublishedAt: before}, {PublishedAt: after}}, &lastFetched), "new article")
+}
+
+// TestAdaptivePollingApply tests rescheduling of successful fetches
+func TestAdaptivePollingApply(t *testing.T) {
+	now := time.Date(2025, 11, 2, 12, 0, 0, 0, time.UTC)
+	lastFetched := now.Add(-time.Hour).Format(time.RFC3339)
+	ap := NewAdaptivePolling(time.Hour, 15*time.Minute, 24*time.Hour, slog.Default())
+
+	t.Run("not modified raises unchanged ratio", func(t *testing.T) {
+		feed := database.PublicFeedsSelect{Id: "feed-1", LastFetchedAt: &lastFetched, UnchangedFetchRatio: 0.5}
+
+		decision := ap.Apply(feed, FetchDecision{Status: "success", NotModified: true}, now)
+
+		require.NotNil(t, decision.Polling)
+		assert.InDelta(t, 0.6, decision.Polling.UnchangedRatio, 0.0001)
+		assert.Nil(t, decision.Polling.PublishCadence)
+		assert.Equal(t, 2*time.Hour+12*time.Minute, decision.Polling.Interval)
+		assert.Equal(t, now.Add(decision.Polling.Interval), decision.NextFetchTime)
+	})
+
+	t.Run("new articles lower ratio and learn cadence", func(t *testing.T) {
+		cadence := 4 * 3600
+		feed := database.PublicFeedsSelect{Id: "feed-1", LastFetchedAt: &lastFetched, UnchangedFetchRatio: 0.5, PublishCadenceSeconds: &cadence}
+		articles := []Article{
+			{PublishedAt: now.Add(-10 * time.Minute)},
+			{PublishedAt: now.Add(-130 * time.Minute)},
+			{PublishedAt: now.Add(-250 * time.Minute)},
+		}
+
+		decision := ap.Apply(feed, FetchDecision{Status: "success", Articles: articles}, now)
+
+		require.NotNil(t, decision.Polling)
+		assert.InDelta(t, 0.4, decision.Polling.UnchangedRatio, 0.0001)
+		require.NotNil(t, decision.Polling.PublishCadence)
+		// 0.7 * 4h + 0.3 * 2h
+		assert.Equal(t, 3*time.Hour+24*time.Minute, *decision.Polling.PublishCadence)
+	})
+
+	t.Run("cache max-age wins over shorter interval", func(t *testing.T) {
+		feed := database.PublicFeedsSelect{Id: "feed-1"}
+
+		decision := ap.Apply(feed, FetchDecision{Status: "success", CacheMaxAge: 48 * time.Hour}, now)
+
+		assert.Equal(t, now.Add(48*time.Hour), decision.NextFetchTime)
+	})
+
+	t.Run("error decisions keep their schedule", func(t *testing.T) {
+		backoff := now.Add(15 * time.Minute)
+		feed := database.PublicFeedsSelect{Id: "feed-1"}
+
+		decision := ap.Apply(feed, FetchDecision{Status: "temporary_error", NextFetchTime: backoff}, now)
+
+		assert.Equal(t, backoff, decision.NextFetchTime)
+		assert.Nil(t, decision.Polling)
+	})
+}
@@ -0,0 +1,418 @@
+package fetcher
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/tjanas94/vibefeeder/internal/shared/database"
+	"github.com/tjanas94/vibefeeder/internal/shared/tracing"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	// minExtractedContentLength is the minimum length of extracted text considered a real article
+	minExtractedContentLength = 250
+
+	// maxExtractedContentLength caps the stored full text
+	maxExtractedContentLength = 100_000
+
+	// maxArticleRedirects limits redirects followed when downloading an article page
+	maxArticleRedirects = 5
+)
+
+// errNoExtractableContent is returned when a page has no recognizable main content
+var errNoExtractableContent = errors.New("no extractable content")
+
+// ContentExtractor downloads article pages and extracts their main text
+// Requests go through the SSRF-protected HTTP client and the per-domain rate limiter
+type ContentExtractor struct {
+	repo        FetcherRepository
+	httpClient  HTTPClientInterface
+	rateLimiter *RateLimiter
+	logger      *slog.Logger
+	maxBodySize int64
+	maxArticles int
+}
+
+// NewContentExtractor creates a new content extractor
+// maxArticles limits how many pages are downloaded per feed fetch
+func NewContentExtractor(
+	repo FetcherRepository,
+	httpClient HTTPClientInterface,
+	rateLimiter *RateLimiter,
+	logger *slog.Logger,
+	maxBodySize int64,
+	maxArticles int,
+) *ContentExtractor {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &ContentExtractor{
+		repo:        repo,
+		httpClient:  httpClient,
+		rateLimiter: rateLimiter,
+		logger:      logger,
+		maxBodySize: maxBodySize,
+		maxArticles: maxArticles,
+	}
+}
+
+// Enrich downloads articles not processed before and stores their extracted text on the articles
+// Failures are logged and leave the article with its feed excerpt only
+func (ce *ContentExtractor) Enrich(ctx context.Context, feed database.PublicFeedsSelect, articles []Article) {
+	if len(articles) == 0 || ce.maxArticles <= 0 {
+		return
+	}
+
+	ctx, span := tracing.Start(ctx, "fetcher.ContentExtractor.Enrich")
+	defer span.End()
+
+	urls := make([]string, 0, len(articles))
+	for _, article := range articles {
+		urls = append(urls, article.URL)
+	}
+
+	processed, err := ce.repo.FindArticleURLsWithFullContent(ctx, feed.Id, urls)
+	if err != nil {
+		ce.logger.Error("Failed to check articles for full content", "feed_id", feed.Id, "error", err)
+		return
+	}
+
+	attempts := 0
+	for i := range articles {
+		if attempts >= ce.maxArticles || ctx.Err() != nil {
+			break
+		}
+		if processed[articles[i].URL] {
+			continue
+		}
+		attempts++
+
+		content, err := ce.extract(ctx, articles[i].URL)
+		if err != nil && isTransientExtractionError(ctx, err) {
+			// Leave unmarked so the next fetch tries again
+			ce.logger.Debug("Full content download failed, will retry", "feed_id", feed.Id, "url", articles[i].URL, "error", err)
+			continue
+		}
+
+		fetchedAt := time.Now()
+		articles[i].FullContentFetchedAt = &fetchedAt
+		if err != nil {
+			ce.logger.Debug("No full content extracted", "feed_id", feed.Id, "url", articles[i].URL, "error", err)
+			continue
+		}
+		articles[i].FullContent = &content
+	}
+}
+
+// extract downloads an article page, following redirects, and returns its main text
+func (ce *ContentExtractor) extract(ctx context.Context, articleURL string) (string, error) {
+	currentURL, err := url.Parse(articleURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid article URL: %w", err)
+	}
+
+	for redirects := 0; redirects <= maxArticleRedirects; redirects++ {
+		if err := ce.rateLimiter.WaitIfNeeded(ctx, currentURL.String()); err != nil {
+			return "", err
+		}
+
+		resp, err := ce.httpClient.ExecuteRequest(ctx, ExecuteRequestParams{URL: currentURL.String()})
+		if err != nil {
+			return "", err
+		}
+
+		location := resp.Header.Get("Location")
+		body, readErr := ce.readHTML(resp)
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			ce.logger.Error("failed to close response body", "error", closeErr)
+		}
+
+		switch {
+		case resp.StatusCode >= 300 && resp.StatusCode < 400 && location != "":
+			if currentURL, err = ValidateURL(currentURL, location); err != nil {
+				return "", err
+			}
+			continue
+		case resp.StatusCode != http.StatusOK:
+			return "", &httpStatusError{statusCode: resp.StatusCode}
+		case readErr != nil:
+			return "", readErr
+		}
+
+		return extractMainContent(body)
+	}
+
+	return "", errors.New("too many redirects")
+}
+
+// readHTML reads a size-limited HTML response body
+func (ce *ContentExtractor) readHTML(resp *http.Response) ([]byte, error) {
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil
+	}
+
+	contentType := strings.ToLower(resp.Header.Get(HeaderContentType))
+	if contentType != "" && !strings.Contains(contentType, "html") {
+		return nil, fmt.Errorf("unsupported content type %q: %w", contentType, errNoExtractableContent)
+	}
+
+	body, err := readResponseBody(resp, ce.maxBodySize)
+	if err != nil {
+		return nil, err
+	}
+
+	return toUTF8(body, resp.Header.Get(HeaderContentType))
+}
+
+// httpStatusError represents a non-200 response for an article page
+type httpStatusError struct {
+	statusCode int
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("unexpected status %d", e.statusCode)
+}
+
+// isTransientExtractionError reports whether a failed download is worth retrying on a later fetch
+func isTransientExtractionError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return true
+	}
+
+	var statusErr *httpStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.statusCode == http.StatusTooManyRequests || statusErr.statusCode >= 500
+	}
+
+	return !errors.Is(err, errNoExtractableContent) && !isSSRFError(err)
+}
+
+var (
+	// positiveHint matches class/id values of elements likely to contain the article body
+	positiveHint = regexp.MustCompile(`(?i)article|body|content|entry|main|page|post|text|blog|story`)
+
+	// negativeHint matches class/id values of boilerplate elements
+	negativeHint = regexp.MustCompile(`(?i)comment|meta|footer|footnote|sidebar|sponsor|share|social|nav|menu|related|promo|banner|advert|widget|popup|cookie|subscribe`)
+)
+
+// skippedElements are removed before scoring since they never contain article text
+var skippedElements = map[atom.Atom]bool{
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Noscript: true,
+	atom.Nav:      true,
+	atom.Header:   true,
+	atom.Footer:   true,
+	atom.Aside:    true,
+	atom.Form:     true,
+	atom.Iframe:   true,
+	atom.Svg:      true,
+	atom.Button:   true,
+	atom.Template: true,
+}
+
+// textBlockElements are emitted as separate paragraphs in extracted text
+var textBlockElements = map[atom.Atom]bool{
+	atom.P:          true,
+	atom.H1:         true,
+	atom.H2:         true,
+	atom.H3:         true,
+	atom.H4:         true,
+	atom.H5:         true,
+	atom.H6:         true,
+	atom.Li:         true,
+	atom.Pre:        true,
+	atom.Blockquote: true,
+	atom.Figcaption: true,
+	atom.Dd:         true,
+	atom.Dt:         true,
+}
+
+// extractMainContent runs a readability-style extraction and returns the main text of a page
+// Paragraphs are scored by length and punctuation and credited to their ancestors;
+// the best scoring container adjusted for class/id hints and link density wins
+// Returns errNoExtractableContent when the winner is too short to be an article body
+func extractMainContent(body []byte) (string, error) {
+	doc, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	removeSkippedElements(doc)
+
+	scores := make(map[*html.Node]float64)
+	var scoreParagraphs func(*html.Node)
+	scoreParagraphs = func(n *html.Node) {
+		if n.Type == html.ElementNode && (n.DataAtom == atom.P || n.DataAtom == atom.Pre || n.DataAtom == atom.Blockquote) {
+			text := collapseWhitespace(nodeText(n))
+			if len(text) >= 25 {
+				score := 1 + float64(strings.Count(text, ",")) + min(float64(len(text))/100, 3)
+				if parent := n.Parent; parent != nil {
+					scores[parent] += score
+					if grandparent := parent.Parent; grandparent != nil {
+						scores[grandparent] += score / 2
+					}
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			scoreParagraphs(c)
+		}
+	}
+	scoreParagraphs(doc)
+
+	var best *html.Node
+	bestScore := 0.0
+	for node, score := range scores {
+		if node.Type != html.ElementNode {
+			continue
+		}
+		score += classWeight(node)
+		score *= 1 - linkDensity(node)
+		if score > bestScore {
+			best, bestScore = node, score
+		}
+	}
+
+	if best == nil {
+		return "", errNoExtractableContent
+	}
+
+	text := blockText(best)
+	if len(text) < minExtractedContentLength {
+		return "", errNoExtractableContent
+	}
+	if len(text) > maxExtractedContentLength {
+		text = strings.ToValidUTF8(text[:maxExtractedContentLength], "")
+	}
+
+	return text, nil
+}
+
+// removeSkippedElements detaches boilerplate elements from the document tree
+func removeSkippedElements(n *html.Node) {
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+		if c.Type == html.CommentNode || (c.Type == html.ElementNode && skippedElements[c.DataAtom]) {
+			n.RemoveChild(c)
+		} else {
+			removeSkippedElements(c)
+		}
+		c = next
+	}
+}
+
+// classWeight scores an element by its class and id attributes
+func classWeight(n *html.Node) float64 {
+	weight := 0.0
+	for _, attr := range n.Attr {
+		if attr.Key != "class" && attr.Key != "id" {
+			continue
+		}
+		if negativeHint.MatchString(attr.Val) {
+			weight -= 25
+		}
+		if positiveHint.MatchString(attr.Val) {
+			weight += 25
+		}
+	}
+	if n.DataAtom == atom.Article || n.DataAtom == atom.Main {
+		weight += 25
+	}
+	return weight
+}
+
+// linkDensity returns the share of an element's text that is inside links
+func linkDensity(n *html.Node) float64 {
+	textLength := len(collapseWhitespace(nodeText(n)))
+	if textLength == 0 {
+		return 1
+	}
+
+	linkLength := 0
+	var walk func(*html.Node)
+	walk = func(c *html.Node) {
+		if c.Type == html.ElementNode && c.DataAtom == atom.A {
+			linkLength += len(collapseWhitespace(nodeText(c)))
+			return
+		}
+		for child := c.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(n)
+
+	return min(float64(linkLength)/float64(textLength), 1)
+}
+
+// nodeText returns the concatenated text content of a node
+func nodeText(n *html.Node) string {
+	var sb strings.Builder
+	var walk func(*html.Node)
+	walk = func(c *html.Node) {
+		if c.Type == html.TextNode {
+			sb.WriteString(c.Data)
+			sb.WriteByte(' ')
+		}
+		for child := c.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(n)
+	return sb.String()
+}
+
+// blockText returns the text of a node with block elements separated by blank lines
+func blockText(n *html.Node) string {
+	var blocks []string
+	var inline strings.Builder
+
+	flush := func() {
+		if text := collapseWhitespace(inline.String()); text != "" {
+			blocks = append(blocks, text)
+		}
+		inline.Reset()
+	}
+
+	var walk func(*html.Node)
+	walk = func(c *html.Node) {
+		switch {
+		case c.Type == html.TextNode:
+			inline.WriteString(c.Data)
+		case c.Type == html.ElementNode && textBlockElements[c.DataAtom]:
+			flush()
+			if text := collapseWhitespace(nodeText(c)); text != "" {
+				blocks = append(blocks, text)
+			}
+			return
+		case c.Type == html.ElementNode && c.DataAtom == atom.Br:
+			inline.WriteByte(' ')
+		}
+		for child := c.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+		if c.Type == html.ElementNode && (c.DataAtom == atom.Div || c.DataAtom == atom.Section) {
+			flush()
+		}
+	}
+	walk(n)
+	flush()
+
+	return strings.Join(blocks, "\n\n")
+}
+
+// collapseWhitespace trims text and collapses runs of whitespace to single spaces
+func collapseWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
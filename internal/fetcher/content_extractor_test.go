@@ -0,0 +1,186 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tjanas94/vibefeeder/internal/shared/database"
+)
+
+const articlePage = `<!DOCTYPE html>
+<html>
+<head><title>Article</title><script>var tracking = "should not appear";</script></head>
+<body>
+  <header><nav><a href="/">Home</a> <a href="/about">About</a></nav></header>
+  <div class="sidebar">
+    <p>Subscribe to our newsletter, follow us on social media, and never miss an update again.</p>
+  </div>
+  <div class="post-content">
+    <h1>Why full text matters</h1>
+    <p>Many feeds only publish a short excerpt of each article, which makes summaries shallow and misleading.</p>
+    <p>Downloading the page and extracting the main text gives the summarizer the whole story, including details, quotes, and conclusions.</p>
+    <p>The extractor scores paragraphs by their length and punctuation, credits their containers, and picks the best one.</p>
+  </div>
+  <div class="comments">
+    <p>Great post, thanks for sharing, I learned a lot from it!</p>
+  </div>
+  <footer><p>Copyright 2024 Example Blog, all rights reserved, do not copy.</p></footer>
+</body>
+</html>`
+
+func htmlResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"text/html; charset=utf-8"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+// TestExtractMainContent tests readability-style extraction of article text
+func TestExtractMainContent(t *testing.T) {
+	text, err := extractMainContent([]byte(articlePage))
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(text, "Why full text matters\n\nMany feeds only publish"))
+	assert.Contains(t, text, "gives the summarizer the whole story")
+	assert.Contains(t, text, "picks the best one.")
+	assert.NotContains(t, text, "newsletter")
+	assert.NotContains(t, text, "Great post")
+	assert.NotContains(t, text, "Copyright")
+	assert.NotContains(t, text, "tracking")
+	assert.NotContains(t, text, "Home")
+}
+
+// TestExtractMainContentNoContent tests pages without article text
+func TestExtractMainContentNoContent(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "empty page", body: ""},
+		{name: "only navigation", body: `<html><body><nav><p>Home, About, Contact, Archive, Categories</p></nav></body></html>`},
+		{name: "too short", body: `<html><body><article><p>Just one short paragraph of text, nothing else.</p></article></body></html>`},
+		{name: "link list", body: `<html><body><div><p><a href="/a">A list of links that are long enough, to be scored as a paragraph</a></p></div></body></html>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := extractMainContent([]byte(tt.body))
+			assert.ErrorIs(t, err, errNoExtractableContent)
+		})
+	}
+}
+
+// TestIsTransientExtractionError tests which failures are retried on the next fetch
+func TestIsTransientExtractionError(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		err      error
+		expected bool
+	}{
+		{name: "network error", ctx: context.Background(), err: errors.New("connection reset"), expected: true},
+		{name: "server error", ctx: context.Background(), err: &httpStatusError{statusCode: 503}, expected: true},
+		{name: "too many requests", ctx: context.Background(), err: &httpStatusError{statusCode: 429}, expected: true},
+		{name: "not found", ctx: context.Background(), err: &httpStatusError{statusCode: 404}, expected: false},
+		{name: "no content", ctx: context.Background(), err: errNoExtractableContent, expected: false},
+		{name: "ssrf blocked", ctx: context.Background(), err: errors.New("security validation failed: private IP"), expected: false},
+		{name: "cancelled", ctx: cancelled, err: &httpStatusError{statusCode: 404}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isTransientExtractionError(tt.ctx, tt.err))
+		})
+	}
+}
+
+// TestContentExtractorEnrich tests full content download for feed articles
+func TestContentExtractorEnrich(t *testing.T) {
+	requested := []string{}
+	mockClient := &MockHTTPClient{
+		ExecuteRequestFunc: func(ctx context.Context, params ExecuteRequestParams) (*http.Response, error) {
+			requested = append(requested, params.URL)
+			switch params.URL {
+			case "https://example.com/new":
+				return htmlResponse(http.StatusOK, articlePage), nil
+			case "https://example.com/moved":
+				resp := htmlResponse(http.StatusMovedPermanently, "")
+				resp.Header.Set("Location", "/new")
+				return resp, nil
+			case "https://example.com/missing":
+				return htmlResponse(http.StatusNotFound, ""), nil
+			default:
+				return nil, errors.New("connection refused")
+			}
+		},
+	}
+	mockRepo := &MockFetcherRepository{
+		FindArticleURLsFunc: func(ctx context.Context, feedID string, urls []string) (map[string]bool, error) {
+			return map[string]bool{"https://example.com/done": true}, nil
+		},
+	}
+
+	extractor := NewContentExtractor(mockRepo, mockClient, NewRateLimiter(0), slog.Default(), 1024*1024, 10)
+	articles := []Article{
+		{URL: "https://example.com/new"},
+		{URL: "https://example.com/done"},
+		{URL: "https://example.com/moved"},
+		{URL: "https://example.com/missing"},
+		{URL: "https://example.com/down"},
+	}
+
+	extractor.Enrich(context.Background(), database.PublicFeedsSelect{Id: "feed-1"}, articles)
+
+	assert.NotContains(t, requested, "https://example.com/done")
+
+	require.NotNil(t, articles[0].FullContent)
+	assert.Contains(t, *articles[0].FullContent, "Why full text matters")
+	assert.NotNil(t, articles[0].FullContentFetchedAt)
+
+	assert.Nil(t, articles[1].FullContentFetchedAt, "already processed article is skipped")
+
+	require.NotNil(t, articles[2].FullContent, "redirect is followed")
+	assert.NotNil(t, articles[2].FullContentFetchedAt)
+
+	assert.Nil(t, articles[3].FullContent)
+	assert.NotNil(t, articles[3].FullContentFetchedAt, "permanent failure is not retried")
+
+	assert.Nil(t, articles[4].FullContent)
+	assert.Nil(t, articles[4].FullContentFetchedAt, "transient failure is retried")
+}
+
+// TestContentExtractorEnrichLimit tests the per-fetch download limit
+func TestContentExtractorEnrichLimit(t *testing.T) {
+	calls := 0
+	mockClient := &MockHTTPClient{
+		ExecuteRequestFunc: func(ctx context.Context, params ExecuteRequestParams) (*http.Response, error) {
+			calls++
+			return htmlResponse(http.StatusOK, articlePage), nil
+		},
+	}
+
+	extractor := NewContentExtractor(&MockFetcherRepository{}, mockClient, NewRateLimiter(0), slog.Default(), 1024*1024, 2)
+	articles := []Article{
+		{URL: "https://example.com/1"},
+		{URL: "https://example.com/2"},
+		{URL: "https://example.com/3"},
+	}
+
+	extractor.Enrich(context.Background(), database.PublicFeedsSelect{Id: "feed-1"}, articles)
+
+	assert.Equal(t, 2, calls)
+	assert.NotNil(t, articles[0].FullContent)
+	assert.NotNil(t, articles[1].FullContent)
+	assert.Nil(t, articles[2].FullContentFetchedAt)
+}
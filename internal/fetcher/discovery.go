@@ -0,0 +1,136 @@
+package fetcher
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// maxDiscoveryCandidates limits how many candidate feed URLs are probed for a single HTML page
+const maxDiscoveryCandidates = 5
+
+// feedLinkTypes lists MIME types of <link rel="alternate"> elements that point to feeds
+var feedLinkTypes = map[string]bool{
+	"application/rss+xml":   true,
+	"application/atom+xml":  true,
+	"application/feed+json": true,
+}
+
+// wellKnownFeedPaths lists common feed locations probed after the links declared by the page
+var wellKnownFeedPaths = []string{
+	"/feed",
+	"/rss.xml",
+	"/atom.xml",
+	"/feed.xml",
+	"/index.xml",
+}
+
+// isHTMLDocument reports whether a response body is an HTML page rather than a feed
+// Uses the Content-Type header first and falls back to content sniffing
+func isHTMLDocument(contentType string, body []byte) bool {
+	contentType = strings.ToLower(contentType)
+	if strings.Contains(contentType, "text/html") || strings.Contains(contentType, "application/xhtml+xml") {
+		return true
+	}
+
+	return strings.HasPrefix(http.DetectContentType(body), "text/html")
+}
+
+// discoverFeedCandidates returns feed URLs referenced by an HTML page followed by well-known feed paths
+// Links are resolved against <base href>; non-HTTP(S) and duplicate URLs, including the page itself, are skipped
+func discoverFeedCandidates(pageURL *url.URL, body []byte) []string {
+	candidates := make([]string, 0, maxDiscoveryCandidates)
+	seen := map[string]bool{pageURL.String(): true}
+
+	add := func(u *url.URL) {
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return
+		}
+		u.Fragment = ""
+		candidate := u.String()
+		if seen[candidate] || len(candidates) >= maxDiscoveryCandidates {
+			return
+		}
+		seen[candidate] = true
+		candidates = append(candidates, candidate)
+	}
+
+	baseURL, links := extractFeedLinks(body)
+	resolveBase := pageURL
+	if baseURL != "" {
+		if parsed, err := pageURL.Parse(baseURL); err == nil {
+			resolveBase = parsed
+		}
+	}
+
+	for _, link := range links {
+		if resolved, err := resolveBase.Parse(link); err == nil {
+			add(resolved)
+		}
+	}
+
+	for _, path := range wellKnownFeedPaths {
+		add(&url.URL{Scheme: pageURL.Scheme, Host: pageURL.Host, Path: path})
+	}
+
+	return candidates
+}
+
+// extractFeedLinks tokenizes an HTML document and returns the <base href> value (if any)
+// and the href values of <link rel="alternate"> elements with a feed MIME type, in document order
+func extractFeedLinks(body []byte) (string, []string) {
+	var baseHref string
+	var links []string
+
+	tokenizer := html.NewTokenizer(bytes.NewReader(body))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return baseHref, links
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			switch token.Data {
+			case "base":
+				if baseHref == "" {
+					baseHref = strings.TrimSpace(getAttr(token, "href"))
+				}
+			case "link":
+				href := strings.TrimSpace(getAttr(token, "href"))
+				if href == "" || !hasRel(getAttr(token, "rel"), "alternate") {
+					continue
+				}
+				linkType := strings.ToLower(strings.TrimSpace(getAttr(token, "type")))
+				if feedLinkTypes[linkType] {
+					links = append(links, href)
+				}
+			case "body":
+				// Feed links live in <head>; stop before scanning the whole page
+				return baseHref, links
+			}
+		}
+	}
+}
+
+// getAttr returns the value of the named attribute or an empty string
+func getAttr(token html.Token, name string) string {
+	for _, attr := range token.Attr {
+		if attr.Key == name {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+// hasRel reports whether a space-separated rel attribute contains the given value
+func hasRel(rel, value string) bool {
+	for _, r := range strings.Fields(strings.ToLower(rel)) {
+		if r == value {
+			return true
+		}
+	}
+	return false
+}
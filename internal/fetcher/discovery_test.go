@@ -0,0 +1,138 @@
+package fetcher
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIsHTMLDocument tests HTML detection by header and content sniffing
+func TestIsHTMLDocument(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		expected    bool
+	}{
+		{
+			name:        "text/html content type",
+			contentType: "text/html; charset=utf-8",
+			body:        "<rss></rss>",
+			expected:    true,
+		},
+		{
+			name:        "xhtml content type",
+			contentType: "application/xhtml+xml",
+			body:        "",
+			expected:    true,
+		},
+		{
+			name:        "sniffed html without content type",
+			contentType: "",
+			body:        "<!DOCTYPE html><html><head></head></html>",
+			expected:    true,
+		},
+		{
+			name:        "rss feed",
+			contentType: "application/rss+xml",
+			body:        `<?xml version="1.0"?><rss version="2.0"></rss>`,
+			expected:    false,
+		},
+		{
+			name:        "xml served without content type",
+			contentType: "",
+			body:        `<?xml version="1.0"?><feed></feed>`,
+			expected:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isHTMLDocument(tt.contentType, []byte(tt.body)))
+		})
+	}
+}
+
+// TestDiscoverFeedCandidates tests candidate extraction from HTML pages
+func TestDiscoverFeedCandidates(t *testing.T) {
+	tests := []struct {
+		name     string
+		pageURL  string
+		body     string
+		expected []string
+	}{
+		{
+			name:    "link elements come before well-known paths",
+			pageURL: "https://example.com/blog/",
+			body: `<html><head>
+				<link rel="stylesheet" href="/style.css">
+				<link rel="alternate" type="application/atom+xml" href="atom.xml">
+				<link rel="alternate" type="application/feed+json" href="https://feeds.example.com/feed.json">
+			</head><body></body></html>`,
+			expected: []string{
+				"https://example.com/blog/atom.xml",
+				"https://feeds.example.com/feed.json",
+				"https://example.com/feed",
+				"https://example.com/rss.xml",
+				"https://example.com/atom.xml",
+			},
+		},
+		{
+			name:    "respects base href and multi-value rel",
+			pageURL: "https://example.com/page",
+			body: `<html><head>
+				<base href="https://cdn.example.com/site/">
+				<link rel="Alternate Feed" type="Application/RSS+XML" href="rss">
+			</head></html>`,
+			expected: []string{
+				"https://cdn.example.com/site/rss",
+				"https://example.com/feed",
+				"https://example.com/rss.xml",
+				"https://example.com/atom.xml",
+				"https://example.com/feed.xml",
+			},
+		},
+		{
+			name:    "ignores non-feed alternates, non-http schemes and links in body",
+			pageURL: "https://example.com/",
+			body: `<html><head>
+				<link rel="alternate" hreflang="de" href="/de/">
+				<link rel="alternate" type="application/rss+xml" href="javascript:alert(1)">
+			</head><body>
+				<link rel="alternate" type="application/rss+xml" href="/hidden.xml">
+			</body></html>`,
+			expected: []string{
+				"https://example.com/feed",
+				"https://example.com/rss.xml",
+				"https://example.com/atom.xml",
+				"https://example.com/feed.xml",
+				"https://example.com/index.xml",
+			},
+		},
+		{
+			name:    "deduplicates candidates and skips the page itself",
+			pageURL: "https://example.com/feed",
+			body: `<html><head>
+				<link rel="alternate" type="application/rss+xml" href="/rss.xml">
+				<link rel="alternate" type="application/rss+xml" href="/rss.xml#latest">
+			</head></html>`,
+			expected: []string{
+				"https://example.com/rss.xml",
+				"https://example.com/atom.xml",
+				"https://example.com/feed.xml",
+				"https://example.com/index.xml",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pageURL, err := url.Parse(tt.pageURL)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, discoverFeedCandidates(pageURL, []byte(tt.body)))
+		})
+	}
+}
@@ -0,0 +1,293 @@
+package fetcher
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/tjanas94/vibefeeder/internal/shared/database"
+	"github.com/tjanas94/vibefeeder/internal/shared/tracing"
+	"golang.org/x/net/html"
+)
+
+const (
+	// maxFaviconSize caps the size of a downloaded icon
+	maxFaviconSize = 64 * 1024
+
+	// maxFaviconPageSize caps the part of the home page scanned for icon links
+	maxFaviconPageSize = 512 * 1024
+
+	// maxFaviconCandidates limits how many icon URLs are tried per site
+	maxFaviconCandidates = 4
+
+	// maxFaviconRedirects limits redirects followed per icon or home page request
+	maxFaviconRedirects = 3
+
+	// faviconRefreshInterval is how long a downloaded (or missing) icon is cached
+	faviconRefreshInterval = 7 * 24 * time.Hour
+)
+
+// faviconContentTypes lists sniffed image types accepted as favicons
+// SVG is not accepted since it can carry scripts and the icons are served from the application origin
+var faviconContentTypes = map[string]bool{
+	"image/x-icon": true,
+	"image/png":    true,
+	"image/gif":    true,
+	"image/jpeg":   true,
+	"image/webp":   true,
+	"image/bmp":    true,
+}
+
+// errNoFavicon is returned when no usable icon was found for a site
+var errNoFavicon = errors.New("no favicon found")
+
+// FaviconFetcher downloads site icons of feeds
+// Requests go through the SSRF-protected HTTP client and the per-domain rate limiter
+type FaviconFetcher struct {
+	httpClient  HTTPClientInterface
+	rateLimiter *RateLimiter
+	logger      *slog.Logger
+}
+
+// NewFaviconFetcher creates a new favicon fetcher
+func NewFaviconFetcher(httpClient HTTPClientInterface, rateLimiter *RateLimiter, logger *slog.Logger) *FaviconFetcher {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &FaviconFetcher{
+		httpClient:  httpClient,
+		rateLimiter: rateLimiter,
+		logger:      logger,
+	}
+}
+
+// Apply downloads the site icon after a successful fetch when the cached one is due for refresh
+// The attempt is recorded even if it fails, so sites without an icon are not asked on every fetch
+func (ff *FaviconFetcher) Apply(ctx context.Context, feed database.PublicFeedsSelect, decision FetchDecision, now time.Time) FetchDecision {
+	if decision.Status != "success" || !faviconDue(feed, now) {
+		return decision
+	}
+
+	siteURL := faviconSiteURL(feed, decision)
+	if siteURL == "" {
+		return decision
+	}
+
+	ctx, span := tracing.Start(ctx, "fetcher.FaviconFetcher.Fetch")
+	favicon, err := ff.Fetch(ctx, siteURL)
+	span.End()
+	if err != nil {
+		if ctx.Err() != nil {
+			// Out of time, try again on the next fetch
+			return decision
+		}
+		ff.logger.Debug("No favicon downloaded", "feed_id", feed.Id, "site_url", siteURL, "error", err)
+	}
+
+	decision.Favicon = favicon
+	decision.FaviconChecked = true
+	return decision
+}
+
+// Fetch downloads the icon of a site
+// Tries icons declared by the home page first, then the conventional /favicon.ico
+func (ff *FaviconFetcher) Fetch(ctx context.Context, siteURL string) (*Favicon, error) {
+	pageURL, err := url.Parse(siteURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid site URL: %w", err)
+	}
+
+	var links []string
+	base := pageURL
+	if body, finalURL, contentType, err := ff.get(ctx, pageURL, maxFaviconPageSize); err == nil && isHTMLDocument(contentType, body) {
+		base = finalURL
+		var baseHref string
+		baseHref, links = extractIconLinks(body)
+		if baseHref != "" {
+			if parsed, err := finalURL.Parse(baseHref); err == nil {
+				base = parsed
+			}
+		}
+	} else if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	for _, candidate := range faviconCandidates(base, links) {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		body, _, _, err := ff.get(ctx, candidate, maxFaviconSize)
+		if err != nil {
+			continue
+		}
+		if contentType := faviconContentType(body); contentType != "" {
+			return &Favicon{ContentType: contentType, Data: body}, nil
+		}
+	}
+
+	return nil, errNoFavicon
+}
+
+// get downloads a size-limited response body following redirects
+// Returns the body, the final URL and the response content type
+func (ff *FaviconFetcher) get(ctx context.Context, target *url.URL, limit int64) ([]byte, *url.URL, string, error) {
+	for redirects := 0; redirects <= maxFaviconRedirects; redirects++ {
+		if err := ff.rateLimiter.WaitIfNeeded(ctx, target.String()); err != nil {
+			return nil, nil, "", err
+		}
+
+		resp, err := ff.httpClient.ExecuteRequest(ctx, ExecuteRequestParams{URL: target.String()})
+		if err != nil {
+			return nil, nil, "", err
+		}
+
+		location := resp.Header.Get("Location")
+		var body []byte
+		var readErr error
+		if resp.StatusCode == http.StatusOK {
+			body, readErr = readResponseBody(resp, limit)
+		}
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			ff.logger.Error("failed to close response body", "error", closeErr)
+		}
+
+		switch {
+		case resp.StatusCode >= 300 && resp.StatusCode < 400 && location != "":
+			if target, err = ValidateURL(target, location); err != nil {
+				return nil, nil, "", err
+			}
+			continue
+		case resp.StatusCode != http.StatusOK:
+			return nil, nil, "", &httpStatusError{statusCode: resp.StatusCode}
+		case readErr != nil:
+			return nil, nil, "", readErr
+		}
+
+		return body, target, resp.Header.Get(HeaderContentType), nil
+	}
+
+	return nil, nil, "", errors.New("too many redirects")
+}
+
+// faviconDue reports whether the icon of a feed was never downloaded or the cached one is stale
+func faviconDue(feed database.PublicFeedsSelect, now time.Time) bool {
+	if feed.FaviconCheckedAt == nil {
+		return true
+	}
+	checkedAt, err := time.Parse(time.RFC3339, *feed.FaviconCheckedAt)
+	if err != nil {
+		return true
+	}
+	return now.Sub(checkedAt) >= faviconRefreshInterval
+}
+
+// faviconSiteURL returns the website whose icon represents the feed
+// Prefers the channel link; feeds without one use the origin of the feed URL
+func faviconSiteURL(feed database.PublicFeedsSelect, decision FetchDecision) string {
+	if decision.Site != nil && decision.Site.Link != "" {
+		return decision.Site.Link
+	}
+	if feed.SiteUrl != nil && *feed.SiteUrl != "" {
+		return *feed.SiteUrl
+	}
+
+	feedURL := feed.Url
+	if decision.NewURL != nil {
+		feedURL = *decision.NewURL
+	}
+	parsed, err := url.Parse(feedURL)
+	if err != nil || parsed.Host == "" {
+		return ""
+	}
+	return (&url.URL{Scheme: parsed.Scheme, Host: parsed.Host, Path: "/"}).String()
+}
+
+// faviconCandidates resolves icon links against the page URL and appends /favicon.ico of its origin
+// Unparsable and non-HTTP(S) links are skipped, duplicates keep their first position
+func faviconCandidates(base *url.URL, links []string) []*url.URL {
+	candidates := make([]*url.URL, 0, maxFaviconCandidates)
+	seen := make(map[string]bool)
+
+	add := func(u *url.URL) {
+		if (u.Scheme != "http" && u.Scheme != "https") || len(candidates) >= maxFaviconCandidates {
+			return
+		}
+		u.Fragment = ""
+		if seen[u.String()] {
+			return
+		}
+		seen[u.String()] = true
+		candidates = append(candidates, u)
+	}
+
+	for _, link := range links {
+		if resolved, err := base.Parse(link); err == nil {
+			add(resolved)
+		}
+	}
+	add(&url.URL{Scheme: base.Scheme, Host: base.Host, Path: "/favicon.ico"})
+
+	return candidates
+}
+
+// extractIconLinks tokenizes an HTML document and returns the <base href> value (if any)
+// and the href values of icon links: rel="icon" first, then apple-touch-icon
+// SVG icons are skipped
+func extractIconLinks(body []byte) (string, []string) {
+	var baseHref string
+	var icons, touchIcons []string
+
+	tokenizer := html.NewTokenizer(bytes.NewReader(body))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return baseHref, append(icons, touchIcons...)
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			switch token.Data {
+			case "base":
+				if baseHref == "" {
+					baseHref = strings.TrimSpace(getAttr(token, "href"))
+				}
+			case "link":
+				href := strings.TrimSpace(getAttr(token, "href"))
+				linkType := strings.ToLower(strings.TrimSpace(getAttr(token, "type")))
+				if href == "" || linkType == "image/svg+xml" || strings.HasSuffix(strings.ToLower(href), ".svg") {
+					continue
+				}
+				rel := getAttr(token, "rel")
+				switch {
+				case hasRel(rel, "icon"):
+					icons = append(icons, href)
+				case hasRel(rel, "apple-touch-icon"):
+					touchIcons = append(touchIcons, href)
+				}
+			case "body":
+				// Icon links live in <head>; stop before scanning the whole page
+				return baseHref, append(icons, touchIcons...)
+			}
+		}
+	}
+}
+
+// faviconContentType sniffs the image type of a downloaded icon
+// Returns an empty string for content that is not an accepted image (error pages, SVG, HTML)
+func faviconContentType(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+	contentType := http.DetectContentType(data)
+	if faviconContentTypes[contentType] {
+		return contentType
+	}
+	return ""
+}
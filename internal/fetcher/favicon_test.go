@@ -0,0 +1,177 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tjanas94/vibefeeder/internal/shared/database"
+)
+
+// pngIcon is the signature of a PNG image followed by filler bytes
+var pngIcon = "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"
+
+func TestExtractIconLinks(t *testing.T) {
+	page := `<html><head>
+<base href="https://cdn.example.com/">
+<link rel="apple-touch-icon" href="/touch.png">
+<link rel="icon" type="image/svg+xml" href="/icon.svg">
+<link rel="shortcut icon" href="favicon.png">
+<link rel="icon" href="/logo.SVG">
+</head><body><link rel="icon" href="/ignored.png"></body></html>`
+
+	base, links := extractIconLinks([]byte(page))
+
+	assert.Equal(t, "https://cdn.example.com/", base)
+	assert.Equal(t, []string{"favicon.png", "/touch.png"}, links, "rel=icon comes first, SVG icons and body links are skipped")
+}
+
+func TestFaviconCandidates(t *testing.T) {
+	base, _ := url.Parse("https://example.com/blog/")
+
+	candidates := faviconCandidates(base, []string{"icon.png", "javascript:alert(1)", "/favicon.ico"})
+
+	urls := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		urls = append(urls, candidate.String())
+	}
+	assert.Equal(t, []string{"https://example.com/blog/icon.png", "https://example.com/favicon.ico"}, urls)
+}
+
+func TestFaviconContentType(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{name: "ico", data: "\x00\x00\x01\x00\x01\x00", expected: "image/x-icon"},
+		{name: "png", data: pngIcon, expected: "image/png"},
+		{name: "html error page", data: "<!DOCTYPE html><html></html>", expected: ""},
+		{name: "svg", data: `<svg xmlns="http://www.w3.org/2000/svg"></svg>`, expected: ""},
+		{name: "empty", data: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, faviconContentType([]byte(tt.data)))
+		})
+	}
+}
+
+func TestFaviconFetcherFetch(t *testing.T) {
+	requested := []string{}
+	mockClient := &MockHTTPClient{
+		ExecuteRequestFunc: func(ctx context.Context, params ExecuteRequestParams) (*http.Response, error) {
+			requested = append(requested, params.URL)
+			switch params.URL {
+			case "https://example.com/":
+				return htmlResponse(http.StatusOK, `<html><head><link rel="icon" href="/missing.png"><link rel="icon" href="/moved.png"></head></html>`), nil
+			case "https://example.com/moved.png":
+				resp := htmlResponse(http.StatusMovedPermanently, "")
+				resp.Header.Set("Location", "/static/icon.png")
+				return resp, nil
+			case "https://example.com/static/icon.png":
+				return htmlResponse(http.StatusOK, pngIcon), nil
+			default:
+				return htmlResponse(http.StatusNotFound, ""), nil
+			}
+		},
+	}
+
+	fetcher := NewFaviconFetcher(mockClient, NewRateLimiter(0), slog.Default())
+	favicon, err := fetcher.Fetch(context.Background(), "https://example.com/")
+
+	require.NoError(t, err)
+	require.NotNil(t, favicon)
+	assert.Equal(t, "image/png", favicon.ContentType, "content type is sniffed, not taken from the header")
+	assert.Equal(t, []byte(pngIcon), favicon.Data)
+	assert.NotContains(t, requested, "https://example.com/favicon.ico", "fallback is not needed")
+}
+
+func TestFaviconFetcherFetchFallsBackToFaviconICO(t *testing.T) {
+	mockClient := &MockHTTPClient{
+		ExecuteRequestFunc: func(ctx context.Context, params ExecuteRequestParams) (*http.Response, error) {
+			switch params.URL {
+			case "https://example.com/favicon.ico":
+				return htmlResponse(http.StatusOK, "\x00\x00\x01\x00\x01\x00"), nil
+			default:
+				return nil, errors.New("connection refused")
+			}
+		},
+	}
+
+	fetcher := NewFaviconFetcher(mockClient, NewRateLimiter(0), slog.Default())
+	favicon, err := fetcher.Fetch(context.Background(), "https://example.com/")
+
+	require.NoError(t, err)
+	assert.Equal(t, "image/x-icon", favicon.ContentType)
+}
+
+func TestFaviconFetcherApply(t *testing.T) {
+	now := time.Date(2025, 11, 10, 12, 0, 0, 0, time.UTC)
+	recent := now.Add(-24 * time.Hour).Format(time.RFC3339)
+	stale := now.Add(-8 * 24 * time.Hour).Format(time.RFC3339)
+
+	tests := []struct {
+		name            string
+		feed            database.PublicFeedsSelect
+		decision        FetchDecision
+		expectChecked   bool
+		expectRequested string
+	}{
+		{
+			name:            "never checked feed downloads icon of channel link",
+			feed:            database.PublicFeedsSelect{Url: "https://feeds.example.com/rss"},
+			decision:        FetchDecision{Status: "success", Site: &SiteMetadata{Link: "https://example.com/"}},
+			expectChecked:   true,
+			expectRequested: "https://example.com/",
+		},
+		{
+			name:            "stale icon without channel link uses feed origin",
+			feed:            database.PublicFeedsSelect{Url: "https://example.com/feed.xml", FaviconCheckedAt: &stale},
+			decision:        FetchDecision{Status: "success"},
+			expectChecked:   true,
+			expectRequested: "https://example.com/",
+		},
+		{
+			name:     "recently checked icon is kept",
+			feed:     database.PublicFeedsSelect{Url: "https://example.com/feed.xml", FaviconCheckedAt: &recent},
+			decision: FetchDecision{Status: "success"},
+		},
+		{
+			name:     "failed fetch skips icon",
+			feed:     database.PublicFeedsSelect{Url: "https://example.com/feed.xml"},
+			decision: FetchDecision{Status: "temporary_error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requested := []string{}
+			mockClient := &MockHTTPClient{
+				ExecuteRequestFunc: func(ctx context.Context, params ExecuteRequestParams) (*http.Response, error) {
+					requested = append(requested, params.URL)
+					return htmlResponse(http.StatusNotFound, ""), nil
+				},
+			}
+
+			fetcher := NewFaviconFetcher(mockClient, NewRateLimiter(0), slog.Default())
+			decision := fetcher.Apply(context.Background(), tt.feed, tt.decision, now)
+
+			assert.Equal(t, tt.expectChecked, decision.FaviconChecked)
+			assert.Nil(t, decision.Favicon)
+			if tt.expectRequested != "" {
+				require.NotEmpty(t, requested)
+				assert.Equal(t, tt.expectRequested, requested[0])
+			} else {
+				assert.Empty(t, requested)
+			}
+		})
+	}
+}
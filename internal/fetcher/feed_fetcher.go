@@ -2,7 +2,10 @@ package fetcher
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
@@ -10,7 +13,11 @@ import (
 	"time"
 
 	"github.com/mmcdole/gofeed"
+	"github.com/tjanas94/vibefeeder/internal/shared/credentials"
 	"github.com/tjanas94/vibefeeder/internal/shared/database"
+	"github.com/tjanas94/vibefeeder/internal/shared/sanitize"
+	"github.com/tjanas94/vibefeeder/internal/shared/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // FeedFetcher handles HTTP requests and feed parsing
@@ -20,15 +27,18 @@ type FeedFetcher struct {
 	logger           *slog.Logger
 	maxBodySize      int64
 	maxArticlesCount int
+	credentials      *credentials.Cipher
 }
 
 // NewFeedFetcher creates a new feed fetcher
+// credentialCipher decrypts credentials of private feeds (nil if feed credentials are disabled)
 func NewFeedFetcher(
 	httpClient HTTPClientInterface,
 	responseHandler *HTTPResponseHandler,
 	logger *slog.Logger,
 	maxBodySize int64,
 	maxArticlesCount int,
+	credentialCipher *credentials.Cipher,
 ) *FeedFetcher {
 	if logger == nil {
 		logger = slog.Default()
@@ -40,6 +50,7 @@ func NewFeedFetcher(
 		logger:           logger,
 		maxBodySize:      maxBodySize,
 		maxArticlesCount: maxArticlesCount,
+		credentials:      credentialCipher,
 	}
 }
 
@@ -60,21 +71,111 @@ func (ff *FeedFetcher) Fetch(
 		}
 	}
 
+	creds, err := ff.openCredentials(feed)
+	if err != nil {
+		ff.logger.Error("Failed to decrypt feed credentials", "feed_id", feed.Id, "error", err)
+		errorMsg := "Saved credentials could not be decrypted, enter them again"
+		return FetchDecision{
+			Status:       "permanent_error",
+			ErrorMessage: &errorMsg,
+		}
+	}
+
 	// Start redirect chain
 	visitedURLs := make(map[string]bool)
-	return ff.fetchURL(ctx, feed, parsedURL, feed.Url, retryCount, 0, visitedURLs, nil)
+	return ff.fetchURL(ctx, feed, creds, parsedURL, feed.Url, retryCount, 0, visitedURLs, nil, true)
+}
+
+// FeedURLError is returned by ResolveFeedURL when a URL can't be subscribed to
+// Message is meant for the user, like the error messages of the fetch history
+type FeedURLError struct {
+	Message string
+}
+
+func (e *FeedURLError) Error() string {
+	return e.Message
+}
+
+// ResolveFeedURL fetches a URL entered by the user and returns the URL of the feed to subscribe to
+// Website pages resolve to the feed discovered on them and permanent redirects to their target;
+// transient failures keep the URL as entered, the first scheduled fetch retries it
+func (ff *FeedFetcher) ResolveFeedURL(ctx context.Context, rawURL string, creds *credentials.FeedCredentials) (string, error) {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return "", &FeedURLError{Message: "Invalid feed URL"}
+	}
+
+	feed := database.PublicFeedsSelect{Url: rawURL}
+	decision := ff.fetchURL(ctx, feed, creds, parsedURL, rawURL, 0, 0, make(map[string]bool), nil, true)
+	switch decision.Status {
+	case "success":
+		if decision.NewURL != nil {
+			return *decision.NewURL, nil
+		}
+	case "permanent_error":
+		message := "URL doesn't point to a feed"
+		if decision.ErrorMessage != nil {
+			message = *decision.ErrorMessage
+		}
+		return "", &FeedURLError{Message: message}
+	}
+
+	return rawURL, nil
+}
+
+// openCredentials decrypts the credentials of a private feed
+// Returns nil for public feeds
+func (ff *FeedFetcher) openCredentials(feed database.PublicFeedsSelect) (*credentials.FeedCredentials, error) {
+	if feed.CredentialsEncrypted == nil || *feed.CredentialsEncrypted == "" {
+		return nil, nil
+	}
+	if ff.credentials == nil {
+		return nil, fmt.Errorf("feed credentials are disabled (no encryption key configured)")
+	}
+
+	creds, err := ff.credentials.Open(*feed.CredentialsEncrypted)
+	if err != nil {
+		return nil, err
+	}
+
+	return &creds, nil
+}
+
+// credentialsForURL returns feed credentials only for requests to the feed's own origin
+// Prevents leaking secrets to other hosts through redirects or discovered links
+// Origins are compared by scheme and host, so a redirect to http:// drops the credentials too
+func credentialsForURL(creds *credentials.FeedCredentials, feedURL, requestURL string) *credentials.FeedCredentials {
+	if creds == nil {
+		return nil
+	}
+
+	feedOrigin, err := url.Parse(feedURL)
+	if err != nil {
+		return nil
+	}
+	requestOrigin, err := url.Parse(requestURL)
+	if err != nil {
+		return nil
+	}
+	if !strings.EqualFold(feedOrigin.Scheme, requestOrigin.Scheme) || !strings.EqualFold(feedOrigin.Host, requestOrigin.Host) {
+		return nil
+	}
+
+	return creds
 }
 
 // fetchURL recursively follows redirects
 func (ff *FeedFetcher) fetchURL(
 	ctx context.Context,
 	feed database.PublicFeedsSelect,
+	creds *credentials.FeedCredentials,
 	baseURL *url.URL,
 	currentURL string,
 	retryCount int,
 	redirectCount int,
 	visitedURLs map[string]bool,
 	permanentRedirectURL *string,
+	allowDiscovery bool,
 ) FetchDecision {
 	// Check redirect limit
 	if redirectCount >= 10 {
@@ -98,12 +199,18 @@ func (ff *FeedFetcher) fetchURL(
 
 	visitedURLs[currentURL] = true
 
-	// Execute HTTP request
-	resp, err := ff.httpClient.ExecuteRequest(ctx, ExecuteRequestParams{
+	// Execute HTTP request (the span ends once headers are received)
+	requestCtx, requestSpan := tracing.Start(ctx, "fetcher.FeedFetcher.request", attribute.String("url.full", currentURL))
+	resp, err := ff.httpClient.ExecuteRequest(requestCtx, ExecuteRequestParams{
 		URL:          currentURL,
 		ETag:         feed.Etag,
 		LastModified: feed.LastModified,
+		Credentials:  credentialsForURL(creds, feed.Url, currentURL),
 	})
+	if resp != nil {
+		requestSpan.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	}
+	tracing.End(requestSpan, err)
 	if err != nil {
 		ff.logger.Error("HTTP request failed", "feed_id", feed.Id, "url", currentURL, "error", err)
 
@@ -134,13 +241,16 @@ func (ff *FeedFetcher) fetchURL(
 			ErrorMessage:  &errMsg,
 		}
 	}
+	body := &countingReadCloser{ReadCloser: resp.Body}
+	resp.Body = body
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
 			ff.logger.Error("failed to close response body", "error", err)
 		}
 	}()
 
-	// Handle HTTP response
+	// Handle HTTP response: read, decode and parse the body
+	_, parseSpan := tracing.Start(ctx, "fetcher.HTTPResponseHandler.HandleResponse")
 	decision := ff.responseHandler.HandleResponse(
 		resp,
 		currentURL,
@@ -150,6 +260,14 @@ func (ff *FeedFetcher) fetchURL(
 		feed.Etag,
 		feed.LastModified,
 	)
+	decision.HTTPStatus = resp.StatusCode
+	decision.BytesRead = body.n
+	parseSpan.SetAttributes(
+		attribute.String("fetch.status", decision.Status),
+		attribute.Int64("fetch.bytes_read", body.n),
+		attribute.Int("fetch.articles", len(decision.Articles)),
+	)
+	parseSpan.End()
 
 	// Handle redirects
 	if decision.Status == "redirect" && decision.NewURL != nil {
@@ -172,7 +290,26 @@ func (ff *FeedFetcher) fetchURL(
 		}
 
 		// Recursively follow redirect
-		return ff.fetchURL(ctx, feed, redirectURL, redirectURL.String(), retryCount, redirectCount+1, visitedURLs, newPermanentRedirectURL)
+		result := ff.fetchURL(ctx, feed, creds, redirectURL, redirectURL.String(), retryCount, redirectCount+1, visitedURLs, newPermanentRedirectURL, allowDiscovery)
+
+		// Record the redirect chain ending with the final URL
+		if len(result.RedirectChain) == 0 {
+			result.RedirectChain = []string{redirectURL.String()}
+		}
+		result.RedirectChain = append([]string{currentURL}, result.RedirectChain...)
+		return result
+	}
+
+	// Handle HTML pages - probe discovered feed candidates
+	if decision.Status == "discovered" {
+		if !allowDiscovery {
+			errorMsg := "URL points to a web page, not a feed"
+			return FetchDecision{
+				Status:       "permanent_error",
+				ErrorMessage: &errorMsg,
+			}
+		}
+		return ff.discoverFeed(ctx, feed, creds, decision.FeedCandidates, retryCount, redirectCount+1, visitedURLs)
 	}
 
 	// Add permanent redirect URL to final decision if one was found
@@ -183,21 +320,98 @@ func (ff *FeedFetcher) fetchURL(
 	return decision
 }
 
+// discoverFeed tries feed candidates found on an HTML page and returns the first successful fetch
+// The decision carries the candidate URL in NewURL so the feed is switched to it, like a permanent redirect
+func (ff *FeedFetcher) discoverFeed(
+	ctx context.Context,
+	feed database.PublicFeedsSelect,
+	creds *credentials.FeedCredentials,
+	candidates []string,
+	retryCount int,
+	redirectCount int,
+	visitedURLs map[string]bool,
+) FetchDecision {
+	// Conditional headers belong to the page URL, not to the candidates
+	candidateFeed := feed
+	candidateFeed.Etag = nil
+	candidateFeed.LastModified = nil
+
+	var temporaryFailure *FetchDecision
+	for _, candidate := range candidates {
+		if ctx.Err() != nil {
+			break
+		}
+		if visitedURLs[candidate] {
+			continue
+		}
+
+		candidateURL, err := url.Parse(candidate)
+		if err != nil {
+			continue
+		}
+
+		ff.logger.Debug("Trying discovered feed candidate", "feed_id", feed.Id, "candidate", candidate)
+
+		decision := ff.fetchURL(ctx, candidateFeed, creds, candidateURL, candidate, retryCount, redirectCount, visitedURLs, nil, false)
+		switch decision.Status {
+		case "success":
+			// Keep the final URL if the candidate itself was permanently redirected
+			if decision.NewURL == nil {
+				decision.NewURL = &candidate
+			}
+			ff.logger.Info("Discovered feed for website URL", "feed_id", feed.Id, "page_url", feed.Url, "feed_url", *decision.NewURL)
+			return decision
+		case "temporary_error":
+			if temporaryFailure == nil {
+				temporaryFailure = &decision
+			}
+		}
+	}
+
+	// Retry later if a candidate could not be checked due to a transient failure
+	if temporaryFailure != nil {
+		return *temporaryFailure
+	}
+
+	ff.logger.Warn("No feed found for website URL", "feed_id", feed.Id, "url", feed.Url, "candidates", len(candidates))
+	errorMsg := "URL points to a web page and no feed was found on it"
+	return FetchDecision{
+		Status:       "permanent_error",
+		ErrorMessage: &errorMsg,
+	}
+}
+
 // isSSRFError checks if an error is from SSRF validation
 func isSSRFError(err error) bool {
 	return err != nil && strings.Contains(err.Error(), "security validation failed")
 }
 
 // transformFeedItems transforms gofeed items to our Article format
-// Pure function kept at package level for easy testing
+// Item content is sanitized into plain text and safe HTML before storing
+// Items without a publication or update date are dated now, duplicate GUIDs keep the first item
 func transformFeedItems(items []*gofeed.Item, now time.Time) []Article {
 	articles := make([]Article, 0, len(items))
+	seen := make(map[string]bool, len(items))
 
 	for _, item := range items {
+		// Titles may contain markup and entities as well
+		title := sanitize.Text(item.Title)
+
 		// Skip items without required fields
-		if item.Title == "" || item.Link == "" {
+		if title == "" || item.Link == "" {
+			continue
+		}
+
+		// Identify items by guid, falling back to the link for feeds without one
+		// Repeated items are skipped, the upsert cannot touch the same row twice
+		guid := strings.TrimSpace(item.GUID)
+		if guid == "" {
+			guid = item.Link
+		}
+		if seen[guid] {
 			continue
 		}
+		seen[guid] = true
 
 		// Parse published date
 		var publishedAt time.Time
@@ -210,18 +424,28 @@ func transformFeedItems(items []*gofeed.Item, now time.Time) []Article {
 		}
 
 		// Use description or content
-		var content *string
-		if item.Description != "" {
-			content = &item.Description
-		} else if item.Content != "" {
-			content = &item.Content
+		raw := item.Description
+		if raw == "" {
+			raw = item.Content
 		}
 
+		contentHTML := sanitize.HTML(raw, item.Link)
+
+		// Relative media URLs are resolved against the item link
+		itemURL, _ := url.Parse(item.Link)
+
 		article := Article{
-			Title:       item.Title,
+			GUID:        guid,
+			Title:       title,
 			URL:         item.Link,
-			Content:     content,
+			Content:     nonEmpty(sanitize.Text(raw)),
+			ContentHTML: nonEmpty(contentHTML),
+			ContentHash: contentHash(contentHTML),
 			PublishedAt: publishedAt,
+			Authors:     itemAuthors(item),
+			Categories:  itemCategories(item),
+			ImageURL:    itemImageURL(item, itemURL),
+			Enclosures:  itemEnclosures(item, itemURL),
 		}
 
 		articles = append(articles, article)
@@ -229,3 +453,32 @@ func transformFeedItems(items []*gofeed.Item, now time.Time) []Article {
 
 	return articles
 }
+
+// countingReadCloser counts bytes read from a response body
+type countingReadCloser struct {
+	io.ReadCloser
+	n int64
+}
+
+func (c *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// contentHash returns the hex SHA-256 of sanitized content, or nil for empty content
+func contentHash(content string) *string {
+	if content == "" {
+		return nil
+	}
+	sum := sha256.Sum256([]byte(content))
+	return nonEmpty(hex.EncodeToString(sum[:]))
+}
+
+// nonEmpty returns a pointer to s, or nil if s is empty
+func nonEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -2,6 +2,7 @@ package fetcher
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
@@ -14,6 +15,7 @@ import (
 	"github.com/mmcdole/gofeed"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tjanas94/vibefeeder/internal/shared/credentials"
 	"github.com/tjanas94/vibefeeder/internal/shared/database"
 )
 
@@ -65,7 +67,7 @@ func TestNewFeedFetcher(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ff := NewFeedFetcher(tt.httpClient, tt.responseHandler, tt.logger, tt.maxBodySize, tt.maxArticlesCount)
+			ff := NewFeedFetcher(tt.httpClient, tt.responseHandler, tt.logger, tt.maxBodySize, tt.maxArticlesCount, nil)
 
 			assert.NotNil(t, ff, tt.description)
 			assert.NotNil(t, ff.logger, "logger should never be nil")
@@ -83,6 +85,7 @@ func TestFetchInvalidURL(t *testing.T) {
 		slog.Default(),
 		10*1024*1024,
 		100,
+		nil,
 	)
 
 	feed := database.PublicFeedsSelect{
@@ -128,7 +131,7 @@ func TestFetchSuccess(t *testing.T) {
 	}
 
 	handler := NewHTTPResponseHandler(slog.Default(), 15*time.Minute)
-	ff := NewFeedFetcher(mockClient, handler, slog.Default(), 10*1024*1024, 100)
+	ff := NewFeedFetcher(mockClient, handler, slog.Default(), 10*1024*1024, 100, nil)
 
 	feed := database.PublicFeedsSelect{
 		Id:  "feed-1",
@@ -140,6 +143,9 @@ func TestFetchSuccess(t *testing.T) {
 	assert.Equal(t, "success", decision.Status)
 	assert.Nil(t, decision.ErrorMessage)
 	assert.Greater(t, len(decision.Articles), 0)
+	assert.Equal(t, http.StatusOK, decision.HTTPStatus)
+	assert.Equal(t, int64(len(validFeed)), decision.BytesRead)
+	assert.Empty(t, decision.RedirectChain)
 }
 
 // TestFetchSSRFError tests Fetch with SSRF validation error
@@ -156,6 +162,7 @@ func TestFetchSSRFError(t *testing.T) {
 		slog.Default(),
 		10*1024*1024,
 		100,
+		nil,
 	)
 
 	feed := database.PublicFeedsSelect{
@@ -184,6 +191,7 @@ func TestFetchNetworkError(t *testing.T) {
 		slog.Default(),
 		10*1024*1024,
 		100,
+		nil,
 	)
 
 	feed := database.PublicFeedsSelect{
@@ -214,6 +222,7 @@ func TestFetchMaxRetryCount(t *testing.T) {
 		slog.Default(),
 		10*1024*1024,
 		100,
+		nil,
 	)
 
 	feed := database.PublicFeedsSelect{
@@ -246,7 +255,7 @@ func TestFetchURLTooManyRedirects(t *testing.T) {
 	}
 
 	handler := NewHTTPResponseHandler(slog.Default(), 15*time.Minute)
-	ff := NewFeedFetcher(mockClient, handler, slog.Default(), 10*1024*1024, 100)
+	ff := NewFeedFetcher(mockClient, handler, slog.Default(), 10*1024*1024, 100, nil)
 
 	feed := database.PublicFeedsSelect{
 		Id:  "feed-1",
@@ -279,7 +288,7 @@ func TestFetchRedirectLoop(t *testing.T) {
 	}
 
 	handler := NewHTTPResponseHandler(slog.Default(), 15*time.Minute)
-	ff := NewFeedFetcher(mockClient, handler, slog.Default(), 10*1024*1024, 100)
+	ff := NewFeedFetcher(mockClient, handler, slog.Default(), 10*1024*1024, 100, nil)
 
 	feed := database.PublicFeedsSelect{
 		Id:  "feed-1",
@@ -334,7 +343,7 @@ func TestFetchPermanentRedirectTracking(t *testing.T) {
 	}
 
 	handler := NewHTTPResponseHandler(slog.Default(), 15*time.Minute)
-	ff := NewFeedFetcher(mockClient, handler, slog.Default(), 10*1024*1024, 100)
+	ff := NewFeedFetcher(mockClient, handler, slog.Default(), 10*1024*1024, 100, nil)
 
 	feed := database.PublicFeedsSelect{
 		Id:  "feed-1",
@@ -346,6 +355,101 @@ func TestFetchPermanentRedirectTracking(t *testing.T) {
 	assert.Equal(t, "success", decision.Status)
 	assert.NotNil(t, decision.NewURL)
 	assert.Equal(t, "https://example.com/feed-final", *decision.NewURL)
+	assert.Equal(t, []string{
+		"https://example.com/feed",
+		"https://example.com/feed-v2",
+		"https://example.com/feed-final",
+	}, decision.RedirectChain)
+	assert.Equal(t, http.StatusOK, decision.HTTPStatus)
+}
+
+// TestFetchSendsCredentialsToFeedOriginOnly tests that credentials are not leaked through redirects
+func TestFetchSendsCredentialsToFeedOriginOnly(t *testing.T) {
+	validFeed := `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+  <channel>
+    <title>Test Feed</title>
+    <link>https://example.com</link>
+    <description>Test Description</description>
+  </channel>
+</rss>`
+
+	cipher, err := credentials.NewCipher(base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32))))
+	require.NoError(t, err)
+	sealed, err := cipher.Seal(credentials.FeedCredentials{Token: "secret-token"})
+	require.NoError(t, err)
+
+	var sent []*credentials.FeedCredentials
+	mockClient := &MockHTTPClient{
+		ExecuteRequestFunc: func(ctx context.Context, params ExecuteRequestParams) (*http.Response, error) {
+			sent = append(sent, params.Credentials)
+			if len(sent) == 1 {
+				return &http.Response{
+					StatusCode: http.StatusFound,
+					Header:     http.Header{"Location": []string{"https://cdn.example.net/feed"}},
+					Body:       http.NoBody,
+				}, nil
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/rss+xml"}},
+				Body:       io.NopCloser(strings.NewReader(validFeed)),
+			}, nil
+		},
+	}
+
+	handler := NewHTTPResponseHandler(slog.Default(), 15*time.Minute)
+	ff := NewFeedFetcher(mockClient, handler, slog.Default(), 10*1024*1024, 100, cipher)
+
+	feed := database.PublicFeedsSelect{
+		Id:                   "feed-1",
+		Url:                  "https://example.com/feed",
+		CredentialsEncrypted: &sealed,
+	}
+
+	decision := ff.Fetch(context.Background(), feed, 0)
+
+	assert.Equal(t, "success", decision.Status)
+	require.Len(t, sent, 2)
+	require.NotNil(t, sent[0], "credentials should be sent to the feed host")
+	assert.Equal(t, "secret-token", sent[0].Token)
+	assert.Nil(t, sent[1], "credentials should not follow a redirect to another host")
+}
+
+// TestFetchUndecryptableCredentials tests feeds whose credentials cannot be decrypted
+func TestFetchUndecryptableCredentials(t *testing.T) {
+	mockClient := &MockHTTPClient{
+		ExecuteRequestFunc: func(ctx context.Context, params ExecuteRequestParams) (*http.Response, error) {
+			t.Fatal("request should not be sent without credentials")
+			return nil, nil
+		},
+	}
+
+	ff := NewFeedFetcher(mockClient, NewHTTPResponseHandler(slog.Default(), 15*time.Minute), slog.Default(), 10*1024*1024, 100, nil)
+
+	sealed := "v1:c2VhbGVk"
+	feed := database.PublicFeedsSelect{
+		Id:                   "feed-1",
+		Url:                  "https://example.com/feed",
+		CredentialsEncrypted: &sealed,
+	}
+
+	decision := ff.Fetch(context.Background(), feed, 0)
+
+	assert.Equal(t, "permanent_error", decision.Status)
+	require.NotNil(t, decision.ErrorMessage)
+	assert.Contains(t, *decision.ErrorMessage, "credentials")
+}
+
+// TestCredentialsForURL tests origin matching for feed credentials
+func TestCredentialsForURL(t *testing.T) {
+	creds := &credentials.FeedCredentials{Username: "alice"}
+
+	assert.Same(t, creds, credentialsForURL(creds, "https://example.com/feed", "https://EXAMPLE.com/other"))
+	assert.Nil(t, credentialsForURL(creds, "https://example.com/feed", "http://example.com/feed"), "scheme downgrade")
+	assert.Nil(t, credentialsForURL(creds, "https://example.com/feed", "https://example.com:8443/feed"), "different port")
+	assert.Nil(t, credentialsForURL(creds, "https://example.com/feed", "https://evil.example.org/feed"), "different host")
+	assert.Nil(t, credentialsForURL(nil, "https://example.com/feed", "https://example.com/feed"))
 }
 
 // TestFetchInvalidRedirectURL tests handling of invalid redirect URLs
@@ -362,7 +466,7 @@ func TestFetchInvalidRedirectURL(t *testing.T) {
 	}
 
 	handler := NewHTTPResponseHandler(slog.Default(), 15*time.Minute)
-	ff := NewFeedFetcher(mockClient, handler, slog.Default(), 10*1024*1024, 100)
+	ff := NewFeedFetcher(mockClient, handler, slog.Default(), 10*1024*1024, 100, nil)
 
 	feed := database.PublicFeedsSelect{
 		Id:  "feed-1",
@@ -376,6 +480,249 @@ func TestFetchInvalidRedirectURL(t *testing.T) {
 	assert.Contains(t, *decision.ErrorMessage, "Invalid redirect URL")
 }
 
+// TestFetchDiscoversFeedFromHTMLPage tests switching a website URL to a feed linked from the page
+func TestFetchDiscoversFeedFromHTMLPage(t *testing.T) {
+	page := `<!DOCTYPE html>
+<html>
+<head>
+  <title>Example Blog</title>
+  <link rel="alternate" type="application/rss+xml" href="/blog/rss.xml">
+</head>
+<body><h1>Example Blog</h1></body>
+</html>`
+
+	validFeed := `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+  <channel>
+    <title>Example Blog</title>
+    <item>
+      <title>Post</title>
+      <link>https://example.com/blog/post</link>
+    </item>
+  </channel>
+</rss>`
+
+	etag := "\"page-etag\""
+	var requested []ExecuteRequestParams
+	mockClient := &MockHTTPClient{
+		ExecuteRequestFunc: func(ctx context.Context, params ExecuteRequestParams) (*http.Response, error) {
+			requested = append(requested, params)
+			switch params.URL {
+			case "https://example.com/":
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": []string{"text/html; charset=utf-8"}},
+					Body:       io.NopCloser(strings.NewReader(page)),
+				}, nil
+			case "https://example.com/blog/rss.xml":
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": []string{"application/rss+xml"}},
+					Body:       io.NopCloser(strings.NewReader(validFeed)),
+				}, nil
+			}
+			return nil, fmt.Errorf("unexpected request: %s", params.URL)
+		},
+	}
+
+	handler := NewHTTPResponseHandler(slog.Default(), 15*time.Minute)
+	ff := NewFeedFetcher(mockClient, handler, slog.Default(), 10*1024*1024, 100, nil)
+
+	feed := database.PublicFeedsSelect{
+		Id:   "feed-1",
+		Url:  "https://example.com/",
+		Etag: &etag,
+	}
+
+	decision := ff.Fetch(context.Background(), feed, 0)
+
+	assert.Equal(t, "success", decision.Status)
+	require.NotNil(t, decision.NewURL)
+	assert.Equal(t, "https://example.com/blog/rss.xml", *decision.NewURL)
+	assert.Len(t, decision.Articles, 1)
+
+	require.Len(t, requested, 2)
+	assert.Nil(t, requested[1].ETag, "conditional headers of the page must not be sent to candidates")
+}
+
+// TestFetchDiscoveryFallsBackToWellKnownPaths tests probing well-known paths when the page has no feed links
+func TestFetchDiscoveryFallsBackToWellKnownPaths(t *testing.T) {
+	page := `<html><head><title>No feeds here</title></head><body><p>Hello</p></body></html>`
+	validFeed := `<?xml version="1.0"?><feed xmlns="http://www.w3.org/2005/Atom"><title>Atom</title></feed>`
+
+	var requested []string
+	mockClient := &MockHTTPClient{
+		ExecuteRequestFunc: func(ctx context.Context, params ExecuteRequestParams) (*http.Response, error) {
+			requested = append(requested, params.URL)
+			switch params.URL {
+			case "https://example.com/about":
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": []string{"text/html"}},
+					Body:       io.NopCloser(strings.NewReader(page)),
+				}, nil
+			case "https://example.com/atom.xml":
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": []string{"application/atom+xml"}},
+					Body:       io.NopCloser(strings.NewReader(validFeed)),
+				}, nil
+			}
+			return &http.Response{StatusCode: http.StatusNotFound, Header: http.Header{}, Body: http.NoBody}, nil
+		},
+	}
+
+	handler := NewHTTPResponseHandler(slog.Default(), 15*time.Minute)
+	ff := NewFeedFetcher(mockClient, handler, slog.Default(), 10*1024*1024, 100, nil)
+
+	feed := database.PublicFeedsSelect{
+		Id:  "feed-1",
+		Url: "https://example.com/about",
+	}
+
+	decision := ff.Fetch(context.Background(), feed, 0)
+
+	assert.Equal(t, "success", decision.Status)
+	require.NotNil(t, decision.NewURL)
+	assert.Equal(t, "https://example.com/atom.xml", *decision.NewURL)
+	assert.Equal(t, []string{
+		"https://example.com/about",
+		"https://example.com/feed",
+		"https://example.com/rss.xml",
+		"https://example.com/atom.xml",
+	}, requested)
+}
+
+// TestFetchDiscoveryNoFeedFound tests the error returned when no candidate is a feed
+func TestFetchDiscoveryNoFeedFound(t *testing.T) {
+	page := `<html><head><title>Page</title></head><body></body></html>`
+
+	mockClient := &MockHTTPClient{
+		ExecuteRequestFunc: func(ctx context.Context, params ExecuteRequestParams) (*http.Response, error) {
+			// Every URL returns an HTML page - discovery must not recurse into candidates
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"text/html"}},
+				Body:       io.NopCloser(strings.NewReader(page)),
+			}, nil
+		},
+	}
+
+	handler := NewHTTPResponseHandler(slog.Default(), 15*time.Minute)
+	ff := NewFeedFetcher(mockClient, handler, slog.Default(), 10*1024*1024, 100, nil)
+
+	feed := database.PublicFeedsSelect{
+		Id:  "feed-1",
+		Url: "https://example.com/",
+	}
+
+	decision := ff.Fetch(context.Background(), feed, 0)
+
+	assert.Equal(t, "permanent_error", decision.Status)
+	assert.Nil(t, decision.NewURL)
+	require.NotNil(t, decision.ErrorMessage)
+	assert.Contains(t, *decision.ErrorMessage, "no feed was found")
+}
+
+// TestFetchDiscoveryTemporaryFailure tests that transient candidate failures are retried later
+func TestFetchDiscoveryTemporaryFailure(t *testing.T) {
+	page := `<html><head><link rel="alternate" type="application/atom+xml" href="https://example.com/atom"></head></html>`
+
+	mockClient := &MockHTTPClient{
+		ExecuteRequestFunc: func(ctx context.Context, params ExecuteRequestParams) (*http.Response, error) {
+			if params.URL == "https://example.com/" {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": []string{"text/html"}},
+					Body:       io.NopCloser(strings.NewReader(page)),
+				}, nil
+			}
+			if params.URL == "https://example.com/atom" {
+				return nil, errors.New("connection reset")
+			}
+			return &http.Response{StatusCode: http.StatusNotFound, Header: http.Header{}, Body: http.NoBody}, nil
+		},
+	}
+
+	handler := NewHTTPResponseHandler(slog.Default(), 15*time.Minute)
+	ff := NewFeedFetcher(mockClient, handler, slog.Default(), 10*1024*1024, 100, nil)
+
+	feed := database.PublicFeedsSelect{
+		Id:  "feed-1",
+		Url: "https://example.com/",
+	}
+
+	decision := ff.Fetch(context.Background(), feed, 0)
+
+	assert.Equal(t, "temporary_error", decision.Status)
+	assert.Nil(t, decision.NewURL)
+}
+
+// TestResolveFeedURL tests checking a URL before a feed is added
+func TestResolveFeedURL(t *testing.T) {
+	page := `<html><head><link rel="alternate" type="application/rss+xml" href="/rss.xml"></head></html>`
+	emptyPage := `<html><head><title>Page</title></head><body></body></html>`
+	validFeed := `<?xml version="1.0"?><rss version="2.0"><channel><title>Blog</title></channel></rss>`
+
+	mockClient := &MockHTTPClient{
+		ExecuteRequestFunc: func(ctx context.Context, params ExecuteRequestParams) (*http.Response, error) {
+			switch params.URL {
+			case "https://example.com/", "https://empty.example.com/":
+				body := page
+				if strings.Contains(params.URL, "empty") {
+					body = emptyPage
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": []string{"text/html"}},
+					Body:       io.NopCloser(strings.NewReader(body)),
+				}, nil
+			case "https://example.com/rss.xml":
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": []string{"application/rss+xml"}},
+					Body:       io.NopCloser(strings.NewReader(validFeed)),
+				}, nil
+			case "https://down.example.com/feed":
+				return nil, errors.New("connection reset")
+			}
+			return &http.Response{StatusCode: http.StatusNotFound, Header: http.Header{}, Body: http.NoBody}, nil
+		},
+	}
+
+	handler := NewHTTPResponseHandler(slog.Default(), 15*time.Minute)
+	ff := NewFeedFetcher(mockClient, handler, slog.Default(), 10*1024*1024, 100, nil)
+
+	t.Run("keeps feed URL", func(t *testing.T) {
+		feedURL, err := ff.ResolveFeedURL(context.Background(), "https://example.com/rss.xml", nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/rss.xml", feedURL)
+	})
+
+	t.Run("resolves website page to discovered feed", func(t *testing.T) {
+		feedURL, err := ff.ResolveFeedURL(context.Background(), "https://example.com/", nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/rss.xml", feedURL)
+	})
+
+	t.Run("rejects page without feed", func(t *testing.T) {
+		_, err := ff.ResolveFeedURL(context.Background(), "https://empty.example.com/", nil)
+
+		var urlErr *FeedURLError
+		require.ErrorAs(t, err, &urlErr)
+		assert.Contains(t, urlErr.Message, "no feed was found")
+	})
+
+	t.Run("keeps URL on temporary failure", func(t *testing.T) {
+		feedURL, err := ff.ResolveFeedURL(context.Background(), "https://down.example.com/feed", nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://down.example.com/feed", feedURL)
+	})
+}
+
 // TestIsSSRFError tests SSRF error detection
 func TestIsSSRFError(t *testing.T) {
 	tests := []struct {
@@ -461,6 +808,66 @@ func TestTransformFeedItems(t *testing.T) {
 			},
 			description: "Should transform all valid items correctly",
 		},
+		{
+			name: "html content is sanitized",
+			items: []*gofeed.Item{
+				{
+					Title:           "Tom &amp; Jerry <em>return</em>",
+					Link:            "https://example.com/posts/1",
+					Description:     `<p>Hello <a href="/about">world</a></p><script>alert(1)</script><img src="https://t.example.com/p.gif" width="1" height="1">`,
+					PublishedParsed: &publishedTime,
+				},
+				{
+					Title:           "<b></b>",
+					Link:            "https://example.com/posts/2",
+					PublishedParsed: &publishedTime,
+				},
+			},
+			wantCount: 1,
+			validate: func(t *testing.T, articles []Article) {
+				assert.Equal(t, "Tom & Jerry return", articles[0].Title)
+				require.NotNil(t, articles[0].Content)
+				assert.Equal(t, "Hello world", *articles[0].Content)
+				require.NotNil(t, articles[0].ContentHTML)
+				assert.Equal(t, `<p>Hello <a href="https://example.com/about" rel="nofollow noreferrer noopener" target="_blank">world</a></p>`, *articles[0].ContentHTML)
+			},
+			description: "Should store plain text and sanitized HTML and skip items whose title is only markup",
+		},
+		{
+			name: "items are identified by guid with link fallback",
+			items: []*gofeed.Item{
+				{
+					Title:           "With guid",
+					Link:            "https://example.com/renamed-slug",
+					GUID:            " urn:uuid:1234 ",
+					Description:     "<p>Body</p>",
+					PublishedParsed: &publishedTime,
+				},
+				{
+					Title:           "Without guid",
+					Link:            "https://example.com/no-guid",
+					PublishedParsed: &publishedTime,
+				},
+				{
+					Title:           "Repeated guid",
+					Link:            "https://example.com/other",
+					GUID:            "urn:uuid:1234",
+					PublishedParsed: &publishedTime,
+				},
+			},
+			wantCount: 2,
+			validate: func(t *testing.T, articles []Article) {
+				assert.Equal(t, "urn:uuid:1234", articles[0].GUID)
+				assert.Equal(t, "With guid", articles[0].Title)
+				require.NotNil(t, articles[0].ContentHash)
+				assert.Equal(t, *contentHash("<p>Body</p>"), *articles[0].ContentHash)
+				assert.Len(t, *articles[0].ContentHash, 64)
+
+				assert.Equal(t, "https://example.com/no-guid", articles[1].GUID)
+				assert.Nil(t, articles[1].ContentHash, "items without content have no hash")
+			},
+			description: "Should use trimmed guid, fall back to link and skip repeated items",
+		},
 		{
 			name: "item without published date uses updated date",
 			items: []*gofeed.Item{
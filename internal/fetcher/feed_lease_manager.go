@@ -0,0 +1,94 @@
+package fetcher
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/tjanas94/vibefeeder/internal/shared/database"
+)
+
+// FeedLeaseManager claims feeds for this scheduler instance so that several
+// instances can fetch in parallel without processing the same feed twice
+// A lease is taken when feeds are claimed, extended when processing starts and
+// released once the fetch decision is saved; leases of crashed instances expire
+type FeedLeaseManager struct {
+	repo     FetcherRepository
+	owner    string
+	duration time.Duration
+	logger   *slog.Logger
+}
+
+// NewFeedLeaseManager creates a new lease manager with a unique owner ID
+func NewFeedLeaseManager(repo FetcherRepository, duration time.Duration, logger *slog.Logger) *FeedLeaseManager {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &FeedLeaseManager{
+		repo:     repo,
+		owner:    newLeaseOwnerID(),
+		duration: duration,
+		logger:   logger,
+	}
+}
+
+// Owner returns the ID written to the lease of every feed claimed by this instance
+func (lm *FeedLeaseManager) Owner() string {
+	return lm.owner
+}
+
+// ClaimDue leases up to limit feeds that are due for fetching
+func (lm *FeedLeaseManager) ClaimDue(ctx context.Context, limit int) ([]database.PublicFeedsSelect, error) {
+	return lm.repo.ClaimFeedsForFetch(ctx, lm.owner, limit, lm.duration)
+}
+
+// ClaimByID leases a single feed for an immediate fetch
+// Returns nil when the feed is already being processed, by this or another instance
+func (lm *FeedLeaseManager) ClaimByID(ctx context.Context, feedID string) (*database.PublicFeedsSelect, error) {
+	return lm.repo.ClaimFeedByID(ctx, feedID, lm.owner, lm.duration)
+}
+
+// Extend renews the lease right before a claimed feed is processed
+// Feeds may wait in the worker queue for a while; returns false if the lease expired
+// in the meantime and was reclaimed by another instance, so the feed must be skipped
+func (lm *FeedLeaseManager) Extend(ctx context.Context, feedID string) bool {
+	extended, err := lm.repo.ExtendFeedLease(ctx, feedID, lm.owner, lm.duration)
+	if err != nil {
+		lm.logger.Error("Failed to extend feed lease", "feed_id", feedID, "error", err)
+		return false
+	}
+
+	if !extended {
+		lm.logger.Info("Feed lease lost to another instance, skipping", "feed_id", feedID)
+	}
+
+	return extended
+}
+
+// Release gives the feed back once processing is finished
+// Failures are only logged: the lease expires on its own
+func (lm *FeedLeaseManager) Release(ctx context.Context, feedID string) {
+	if err := lm.repo.ReleaseFeedLease(ctx, feedID, lm.owner); err != nil {
+		lm.logger.Error("Failed to release feed lease", "feed_id", feedID, "error", err)
+	}
+}
+
+// newLeaseOwnerID builds an instance ID from the hostname and a random suffix,
+// so restarted instances on the same host don't reuse leases of their predecessors
+func newLeaseOwnerID() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "fetcher"
+	}
+
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return hostname
+	}
+
+	return hostname + "-" + hex.EncodeToString(suffix)
+}
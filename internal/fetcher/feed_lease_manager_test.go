@@ -0,0 +1,65 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeedLeaseManager_OwnerIsUniquePerInstance(t *testing.T) {
+	first := NewFeedLeaseManager(&MockFetcherRepository{}, 10*time.Minute, nil)
+	second := NewFeedLeaseManager(&MockFetcherRepository{}, 10*time.Minute, nil)
+
+	assert.NotEmpty(t, first.Owner())
+	assert.NotEqual(t, first.Owner(), second.Owner())
+}
+
+func TestFeedLeaseManager_Extend(t *testing.T) {
+	tests := []struct {
+		name     string
+		extended bool
+		err      error
+		expected bool
+	}{
+		{name: "lease held", extended: true, expected: true},
+		{name: "lease reclaimed by another instance", extended: false, expected: false},
+		{name: "repository error skips the feed", err: errors.New("connection refused"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotOwner string
+			var gotLease time.Duration
+			repo := &MockFetcherRepository{
+				ExtendFeedLeaseFunc: func(ctx context.Context, feedID, owner string, lease time.Duration) (bool, error) {
+					gotOwner = owner
+					gotLease = lease
+					return tt.extended, tt.err
+				},
+			}
+			lm := NewFeedLeaseManager(repo, 10*time.Minute, nil)
+
+			assert.Equal(t, tt.expected, lm.Extend(context.Background(), "feed-1"))
+			assert.Equal(t, lm.Owner(), gotOwner)
+			assert.Equal(t, 10*time.Minute, gotLease)
+		})
+	}
+}
+
+func TestFeedLeaseManager_ReleaseUsesOwner(t *testing.T) {
+	var released []string
+	repo := &MockFetcherRepository{
+		ReleaseFeedLeaseFunc: func(ctx context.Context, feedID, owner string) error {
+			released = append(released, feedID+"/"+owner)
+			return errors.New("timeout") // failures are only logged
+		},
+	}
+	lm := NewFeedLeaseManager(repo, 10*time.Minute, nil)
+
+	lm.Release(context.Background(), "feed-1")
+
+	assert.Equal(t, []string{"feed-1/" + lm.Owner()}, released)
+}
@@ -0,0 +1,48 @@
+package fetcher
+
+import (
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/tjanas94/vibefeeder/internal/shared/sanitize"
+)
+
+// Limits on channel metadata stored on the feed
+const (
+	maxSiteTitleLength       = 255 // Same as the feed name, the title becomes the name of auto-named feeds
+	maxSiteDescriptionLength = 1000
+	maxSiteLanguageLength    = 35
+)
+
+// feedSiteMetadata returns the channel metadata of a parsed feed
+// Relative links are resolved against the feed URL; returns nil when the feed declares nothing
+// Text fields are sanitized to plain text and cut to the maximum lengths of the feed columns
+func feedSiteMetadata(parsed *gofeed.Feed, feedURL string) *SiteMetadata {
+	base, _ := url.Parse(feedURL)
+
+	site := SiteMetadata{
+		Title:       truncateText(sanitize.Text(parsed.Title), maxSiteTitleLength),
+		Description: truncateText(sanitize.Text(parsed.Description), maxSiteDescriptionLength),
+		Link:        resolveMediaURL(base, parsed.Link),
+		Language:    truncateText(sanitize.Text(parsed.Language), maxSiteLanguageLength),
+	}
+	if parsed.Image != nil {
+		site.ImageURL = resolveMediaURL(base, parsed.Image.URL)
+	}
+
+	if site == (SiteMetadata{}) {
+		return nil
+	}
+	return &site
+}
+
+// truncateText trims whitespace and cuts text to at most maxLength characters
+func truncateText(text string, maxLength int) string {
+	text = strings.TrimSpace(text)
+	if utf8.RuneCountInString(text) <= maxLength {
+		return text
+	}
+	return strings.TrimSpace(string([]rune(text)[:maxLength]))
+}
@@ -0,0 +1,44 @@
+package fetcher
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeedSiteMetadata(t *testing.T) {
+	parsed, err := gofeed.NewParser().Parse(strings.NewReader(`<?xml version="1.0"?>
+<rss version="2.0"><channel>
+	<title>Example &amp; <b>Friends</b></title>
+	<link>/blog/</link>
+	<description>Notes about everything</description>
+	<language>en-us</language>
+	<image><url>/logo.png</url><title>Example</title><link>/blog/</link></image>
+</channel></rss>`))
+	require.NoError(t, err)
+
+	site := feedSiteMetadata(parsed, "https://example.com/feed.xml")
+
+	require.NotNil(t, site)
+	assert.Equal(t, SiteMetadata{
+		Title:       "Example & Friends",
+		Description: "Notes about everything",
+		Link:        "https://example.com/blog/",
+		ImageURL:    "https://example.com/logo.png",
+		Language:    "en-us",
+	}, *site)
+}
+
+func TestFeedSiteMetadata_Empty(t *testing.T) {
+	site := feedSiteMetadata(&gofeed.Feed{Link: "javascript:alert(1)"}, "https://example.com/feed.xml")
+
+	assert.Nil(t, site, "unsafe links are dropped and nothing is left")
+}
+
+func TestTruncateText(t *testing.T) {
+	assert.Equal(t, "Zażół", truncateText("  Zażółć  ", 5))
+	assert.Equal(t, "short", truncateText("short", 10))
+}
@@ -2,11 +2,14 @@ package fetcher
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"log/slog"
 	"time"
 
 	"github.com/tjanas94/vibefeeder/internal/shared/database"
+	"github.com/tjanas94/vibefeeder/internal/shared/metrics"
+	"github.com/tjanas94/vibefeeder/internal/shared/tracing"
 )
 
 // FeedStatusManager handles database updates after fetch decisions
@@ -34,15 +37,25 @@ func (fsm *FeedStatusManager) ApplyDecision(
 	ctx context.Context,
 	feed database.PublicFeedsSelect,
 	decision FetchDecision,
-) error {
+) (err error) {
+	ctx, span := tracing.Start(ctx, "fetcher.FeedStatusManager.ApplyDecision")
+	defer func() { tracing.End(span, err) }()
+
 	// Save articles if any (non-blocking failure)
+	newArticles := 0
 	if len(decision.Articles) > 0 {
-		if err := fsm.saveArticles(ctx, feed.Id, decision.Articles); err != nil {
+		var err error
+		if newArticles, err = fsm.saveArticles(ctx, feed.Id, decision.Articles); err != nil {
 			fsm.logger.Error("Failed to save articles", "feed_id", feed.Id, "error", err)
 			// Don't return error - we still want to update status
 		}
 	}
 
+	// Record the attempt in the fetch history (non-blocking failure)
+	if err := fsm.repo.InsertFetchLog(ctx, newFetchLogEntry(feed.Id, decision, newArticles)); err != nil {
+		fsm.logger.Error("Failed to record fetch log", "feed_id", feed.Id, "error", err)
+	}
+
 	// Build update params
 	update := database.PublicFeedsUpdate{
 		LastFetchStatus: &decision.Status,
@@ -68,12 +81,43 @@ func (fsm *FeedStatusManager) ApplyDecision(
 		update.Url = decision.NewURL
 	}
 
-	// Reset retry count on success, increment on failure
+	// Store channel metadata; auto-named feeds follow the channel title
+	if decision.Site != nil {
+		applySiteMetadata(&update, feed, *decision.Site)
+	}
+
+	// Cache the downloaded site icon (non-blocking failure)
+	if decision.FaviconChecked {
+		checkedAt := time.Now().UTC().Format(time.RFC3339)
+		update.FaviconCheckedAt = &checkedAt
+		if decision.Favicon != nil {
+			if err := fsm.saveFavicon(ctx, feed.Id, *decision.Favicon); err != nil {
+				fsm.logger.Error("Failed to save favicon", "feed_id", feed.Id, "error", err)
+			} else {
+				hasFavicon := true
+				update.HasFavicon = &hasFavicon
+			}
+		}
+	}
+
+	// Store learned adaptive polling statistics
+	if decision.Polling != nil {
+		intervalSeconds := int(decision.Polling.Interval.Seconds())
+		update.PollIntervalSeconds = &intervalSeconds
+		update.UnchangedFetchRatio = &decision.Polling.UnchangedRatio
+		if decision.Polling.PublishCadence != nil {
+			cadenceSeconds := int(decision.Polling.PublishCadence.Seconds())
+			update.PublishCadenceSeconds = &cadenceSeconds
+		}
+	}
+
+	// Reset retry count on success (also after the feed recovers from an authorization error),
+	// increment on failures that are retried with backoff
 	switch decision.Status {
 	case "success":
 		retryCount := 0
 		update.RetryCount = &retryCount
-	case "temporary_error":
+	case "temporary_error", "unauthorized", "too_large":
 		newRetryCount := feed.RetryCount + 1
 		update.RetryCount = &newRetryCount
 	}
@@ -90,36 +134,135 @@ func (fsm *FeedStatusManager) ApplyDecision(
 	return nil
 }
 
-// saveArticles saves parsed articles to database, skipping duplicates
+// saveArticles saves parsed articles to database, updating articles that are already stored
+// Returns the number of articles stored for the first time
 func (fsm *FeedStatusManager) saveArticles(
 	ctx context.Context,
 	feedID string,
 	articles []Article,
-) error {
+) (int, error) {
 	if len(articles) == 0 {
-		return nil
+		return 0, nil
 	}
 
 	fsm.logger.Info("Saving articles", "feed_id", feedID, "count", len(articles))
 
+	// Articles stored before item GUIDs were tracked carry their URL as GUID; they take over the GUID
+	// of the item with the same URL, so the upsert below updates them instead of inserting duplicates
+	var urls, itemGUIDs []string
+	for _, article := range articles {
+		if article.GUID != article.URL {
+			urls = append(urls, article.URL)
+			itemGUIDs = append(itemGUIDs, article.GUID)
+		}
+	}
+	if len(urls) > 0 {
+		adopted, err := fsm.repo.AdoptArticleGUIDs(ctx, feedID, urls, itemGUIDs)
+		if err != nil {
+			return 0, fmt.Errorf("failed to adopt article guids: %w", err)
+		}
+		if adopted > 0 {
+			fsm.logger.Info("Adopted item guids for stored articles", "feed_id", feedID, "count", adopted)
+		}
+	}
+
+	// Look up known articles to count new ones (a failed lookup only affects the count)
+	guids := make([]string, 0, len(articles))
+	for _, article := range articles {
+		guids = append(guids, article.GUID)
+	}
+	known, lookupErr := fsm.repo.FindArticleGUIDs(ctx, feedID, guids)
+	if lookupErr != nil {
+		fsm.logger.Warn("Failed to look up known articles", "feed_id", feedID, "error", lookupErr)
+	}
+
 	// Transform articles to database insert format
+	newArticles := 0
 	dbArticles := make([]database.PublicArticlesInsert, 0, len(articles))
 	for _, article := range articles {
+		if lookupErr == nil && !known[article.GUID] {
+			newArticles++
+		}
+
 		dbArticle := database.PublicArticlesInsert{
 			FeedId:      feedID,
+			Guid:        article.GUID,
 			Title:       article.Title,
 			Url:         article.URL,
 			Content:     article.Content,
+			ContentHtml: article.ContentHTML,
+			ContentHash: article.ContentHash,
 			PublishedAt: article.PublishedAt.UTC().Format(time.RFC3339),
+			FullContent: article.FullContent,
+			Authors:     article.Authors,
+			Categories:  article.Categories,
+			ImageUrl:    article.ImageURL,
+		}
+		// Keep the column null (not an empty JSON array) for items without enclosures
+		if len(article.Enclosures) > 0 {
+			dbArticle.Enclosures = article.Enclosures
+		}
+		// Existing full content is kept by a database trigger when these are null
+		if article.FullContentFetchedAt != nil {
+			fetchedAt := article.FullContentFetchedAt.UTC().Format(time.RFC3339)
+			dbArticle.FullContentFetchedAt = &fetchedAt
 		}
 		dbArticles = append(dbArticles, dbArticle)
 	}
 
-	// Insert articles (duplicates are ignored by UNIQUE constraint on feed_id, url)
+	// Upsert articles (known articles are matched by UNIQUE constraint on feed_id, guid)
 	if err := fsm.repo.InsertArticles(ctx, dbArticles); err != nil {
-		return fmt.Errorf("failed to insert articles: %w", err)
+		return 0, fmt.Errorf("failed to insert articles: %w", err)
 	}
+	metrics.ArticlesUpsertedTotal.Add(float64(len(dbArticles)))
+	metrics.ArticlesInsertedTotal.Add(float64(newArticles))
 
-	fsm.logger.Info("Articles saved successfully", "feed_id", feedID, "count", len(articles))
-	return nil
+	fsm.logger.Info("Articles saved successfully", "feed_id", feedID, "count", len(articles), "new", newArticles)
+	return newArticles, nil
+}
+
+// saveFavicon stores the downloaded icon of a feed, replacing the cached one
+func (fsm *FeedStatusManager) saveFavicon(ctx context.Context, feedID string, favicon Favicon) error {
+	updatedAt := time.Now().UTC().Format(time.RFC3339)
+	return fsm.repo.SaveFeedFavicon(ctx, database.PublicFeedFaviconsInsert{
+		FeedId:      feedID,
+		ContentType: favicon.ContentType,
+		Data:        base64.StdEncoding.EncodeToString(favicon.Data),
+		UpdatedAt:   &updatedAt,
+	})
+}
+
+// applySiteMetadata copies channel metadata to the feed update
+// Empty values keep the stored ones; the name of auto-named feeds is replaced by the channel title
+// Only the update is modified, the feed is read to leave user-chosen names alone
+func applySiteMetadata(update *database.PublicFeedsUpdate, feed database.PublicFeedsSelect, site SiteMetadata) {
+	update.SiteTitle = nonEmpty(site.Title)
+	update.SiteDescription = nonEmpty(site.Description)
+	update.SiteUrl = nonEmpty(site.Link)
+	update.SiteImageUrl = nonEmpty(site.ImageURL)
+	update.SiteLanguage = nonEmpty(site.Language)
+
+	if feed.NameIsAuto && site.Title != "" && site.Title != feed.Name {
+		update.Name = &site.Title
+	}
+}
+
+// newFetchLogEntry builds the fetch history entry for a fetch decision
+// The HTTP status stays null for fetches that got no response (network errors, timeouts)
+func newFetchLogEntry(feedID string, decision FetchDecision, newArticles int) database.PublicFeedFetchLogInsert {
+	entry := database.PublicFeedFetchLogInsert{
+		FeedId:        feedID,
+		Status:        decision.Status,
+		ErrorMessage:  decision.ErrorMessage,
+		DurationMs:    int(decision.Duration.Milliseconds()),
+		BytesRead:     &decision.BytesRead,
+		NewArticles:   &newArticles,
+		NotModified:   &decision.NotModified,
+		RedirectChain: decision.RedirectChain,
+	}
+	if decision.HTTPStatus != 0 {
+		entry.HttpStatus = &decision.HTTPStatus
+	}
+
+	return entry
 }
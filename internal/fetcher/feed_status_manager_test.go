@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"net/http"
 	"testing"
 	"time"
 
@@ -16,10 +17,18 @@ import (
 type MockFetcherRepository struct {
 	UpdateFeedAfterFetchFunc func(ctx context.Context, feedID string, update database.PublicFeedsUpdate) error
 	InsertArticlesFunc       func(ctx context.Context, articles []database.PublicArticlesInsert) error
+	FindArticleURLsFunc      func(ctx context.Context, feedID string, urls []string) (map[string]bool, error)
+	FindArticleGUIDsFunc     func(ctx context.Context, feedID string, guids []string) (map[string]bool, error)
+	AdoptArticleGUIDsFunc    func(ctx context.Context, feedID string, urls, guids []string) (int, error)
+	ExtendFeedLeaseFunc      func(ctx context.Context, feedID, owner string, lease time.Duration) (bool, error)
+	ReleaseFeedLeaseFunc     func(ctx context.Context, feedID, owner string) error
+	SaveFeedFaviconFunc      func(ctx context.Context, favicon database.PublicFeedFaviconsInsert) error
 
 	// Tracking for assertions
 	UpdateFeedCalls   []UpdateFeedCall
 	InsertArticleCall *InsertArticleCall
+	FetchLogEntries   []database.PublicFeedFetchLogInsert
+	SavedFavicons     []database.PublicFeedFaviconsInsert
 }
 
 type UpdateFeedCall struct {
@@ -31,14 +40,28 @@ type InsertArticleCall struct {
 	Articles []database.PublicArticlesInsert
 }
 
-func (m *MockFetcherRepository) FindFeedsDueForFetch(ctx context.Context, limit int) ([]database.PublicFeedsSelect, error) {
+func (m *MockFetcherRepository) ClaimFeedsForFetch(ctx context.Context, owner string, limit int, lease time.Duration) ([]database.PublicFeedsSelect, error) {
 	return nil, nil
 }
 
-func (m *MockFetcherRepository) FindFeedByID(ctx context.Context, feedID string) (*database.PublicFeedsSelect, error) {
+func (m *MockFetcherRepository) ClaimFeedByID(ctx context.Context, feedID, owner string, lease time.Duration) (*database.PublicFeedsSelect, error) {
 	return nil, nil
 }
 
+func (m *MockFetcherRepository) ExtendFeedLease(ctx context.Context, feedID, owner string, lease time.Duration) (bool, error) {
+	if m.ExtendFeedLeaseFunc != nil {
+		return m.ExtendFeedLeaseFunc(ctx, feedID, owner, lease)
+	}
+	return true, nil
+}
+
+func (m *MockFetcherRepository) ReleaseFeedLease(ctx context.Context, feedID, owner string) error {
+	if m.ReleaseFeedLeaseFunc != nil {
+		return m.ReleaseFeedLeaseFunc(ctx, feedID, owner)
+	}
+	return nil
+}
+
 func (m *MockFetcherRepository) UpdateFeedAfterFetch(ctx context.Context, feedID string, update database.PublicFeedsUpdate) error {
 	m.UpdateFeedCalls = append(m.UpdateFeedCalls, UpdateFeedCall{FeedID: feedID, Update: update})
 	if m.UpdateFeedAfterFetchFunc != nil {
@@ -55,6 +78,44 @@ func (m *MockFetcherRepository) InsertArticles(ctx context.Context, articles []d
 	return nil
 }
 
+func (m *MockFetcherRepository) FindArticleURLsWithFullContent(ctx context.Context, feedID string, urls []string) (map[string]bool, error) {
+	if m.FindArticleURLsFunc != nil {
+		return m.FindArticleURLsFunc(ctx, feedID, urls)
+	}
+	return map[string]bool{}, nil
+}
+
+func (m *MockFetcherRepository) FindArticleGUIDs(ctx context.Context, feedID string, guids []string) (map[string]bool, error) {
+	if m.FindArticleGUIDsFunc != nil {
+		return m.FindArticleGUIDsFunc(ctx, feedID, guids)
+	}
+	return map[string]bool{}, nil
+}
+
+func (m *MockFetcherRepository) AdoptArticleGUIDs(ctx context.Context, feedID string, urls, guids []string) (int, error) {
+	if m.AdoptArticleGUIDsFunc != nil {
+		return m.AdoptArticleGUIDsFunc(ctx, feedID, urls, guids)
+	}
+	return 0, nil
+}
+
+func (m *MockFetcherRepository) InsertFetchLog(ctx context.Context, entry database.PublicFeedFetchLogInsert) error {
+	m.FetchLogEntries = append(m.FetchLogEntries, entry)
+	return nil
+}
+
+func (m *MockFetcherRepository) SaveFeedFavicon(ctx context.Context, favicon database.PublicFeedFaviconsInsert) error {
+	m.SavedFavicons = append(m.SavedFavicons, favicon)
+	if m.SaveFeedFaviconFunc != nil {
+		return m.SaveFeedFaviconFunc(ctx, favicon)
+	}
+	return nil
+}
+
+func (m *MockFetcherRepository) DeleteFetchLogBefore(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
 // TestNewFeedStatusManager tests FeedStatusManager constructor
 func TestNewFeedStatusManager(t *testing.T) {
 	tests := []struct {
@@ -198,6 +259,46 @@ func TestApplyDecisionTemporaryError(t *testing.T) {
 	assert.Equal(t, 3, *updateCall.Update.RetryCount, "retry count should be incremented from 2 to 3")
 }
 
+// TestApplyDecisionUnauthorizedRecovery tests retry state across an authorization error and recovery
+func TestApplyDecisionUnauthorizedRecovery(t *testing.T) {
+	mockRepo := &MockFetcherRepository{}
+	fsm := NewFeedStatusManager(mockRepo, slog.Default())
+
+	unauthorizedStatus := "unauthorized"
+	feed := database.PublicFeedsSelect{
+		Id:              "feed-1",
+		Url:             "https://example.com/feed.xml",
+		LastFetchStatus: &unauthorizedStatus,
+		RetryCount:      3,
+	}
+
+	errorMsg := "Authorization required (HTTP 401), check the feed credentials"
+	err := fsm.ApplyDecision(context.Background(), feed, FetchDecision{
+		Status:        "unauthorized",
+		ErrorMessage:  &errorMsg,
+		NextFetchTime: time.Now().UTC().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	err = fsm.ApplyDecision(context.Background(), feed, FetchDecision{
+		Status:        "success",
+		NextFetchTime: time.Now().UTC().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	require.Len(t, mockRepo.UpdateFeedCalls, 2)
+
+	unauthorizedUpdate := mockRepo.UpdateFeedCalls[0].Update
+	require.NotNil(t, unauthorizedUpdate.RetryCount)
+	assert.Equal(t, 4, *unauthorizedUpdate.RetryCount, "unauthorized fetches should back off")
+	assert.NotNil(t, unauthorizedUpdate.FetchAfter)
+
+	successUpdate := mockRepo.UpdateFeedCalls[1].Update
+	assert.Equal(t, "success", *successUpdate.LastFetchStatus)
+	require.NotNil(t, successUpdate.RetryCount)
+	assert.Equal(t, 0, *successUpdate.RetryCount, "retry state should reset once authorized again")
+}
+
 // TestApplyDecisionPermanentError tests permanent error handling without retry increment
 func TestApplyDecisionPermanentError(t *testing.T) {
 	mockRepo := &MockFetcherRepository{}
@@ -305,6 +406,39 @@ func TestApplyDecisionNextFetchTime(t *testing.T) {
 	assert.Equal(t, nextFetch.Format(time.RFC3339), *updateCall.Update.FetchAfter)
 }
 
+// TestApplyDecisionPollingStats tests storing learned adaptive polling statistics
+func TestApplyDecisionPollingStats(t *testing.T) {
+	mockRepo := &MockFetcherRepository{}
+	fsm := NewFeedStatusManager(mockRepo, slog.Default())
+
+	feed := database.PublicFeedsSelect{
+		Id:  "feed-1",
+		Url: "https://example.com/feed.xml",
+	}
+
+	cadence := 6 * time.Hour
+	decision := FetchDecision{
+		Status:        "success",
+		NextFetchTime: time.Now().Add(3 * time.Hour),
+		Polling: &PollingStats{
+			Interval:       3 * time.Hour,
+			PublishCadence: &cadence,
+			UnchangedRatio: 0.25,
+		},
+	}
+
+	err := fsm.ApplyDecision(context.Background(), feed, decision)
+
+	assert.NoError(t, err)
+	updateCall := mockRepo.UpdateFeedCalls[0]
+	require.NotNil(t, updateCall.Update.PollIntervalSeconds)
+	assert.Equal(t, 10800, *updateCall.Update.PollIntervalSeconds)
+	require.NotNil(t, updateCall.Update.PublishCadenceSeconds)
+	assert.Equal(t, 21600, *updateCall.Update.PublishCadenceSeconds)
+	require.NotNil(t, updateCall.Update.UnchangedFetchRatio)
+	assert.Equal(t, 0.25, *updateCall.Update.UnchangedFetchRatio)
+}
+
 // TestApplyDecisionZeroNextFetchTime tests that zero timestamp doesn't set fetch_after
 func TestApplyDecisionZeroNextFetchTime(t *testing.T) {
 	mockRepo := &MockFetcherRepository{}
@@ -456,15 +590,20 @@ func TestSaveArticles(t *testing.T) {
 			name: "transforms articles correctly",
 			articles: []Article{
 				{
+					GUID:        "urn:uuid:1234",
 					Title:       "Test Article",
 					URL:         "https://example.com/article",
 					Content:     strPtr("Article content"),
+					ContentHash: strPtr("abc123"),
 					PublishedAt: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
 				},
 			},
 			validate: func(t *testing.T, dbArticles []database.PublicArticlesInsert) {
 				assert.Len(t, dbArticles, 1)
 				assert.Equal(t, "feed-1", dbArticles[0].FeedId)
+				assert.Equal(t, "urn:uuid:1234", dbArticles[0].Guid)
+				require.NotNil(t, dbArticles[0].ContentHash)
+				assert.Equal(t, "abc123", *dbArticles[0].ContentHash)
 				assert.Equal(t, "Test Article", dbArticles[0].Title)
 				assert.Equal(t, "https://example.com/article", dbArticles[0].Url)
 				// Content is *string in database.PublicArticlesInsert
@@ -506,7 +645,7 @@ func TestSaveArticles(t *testing.T) {
 			mockRepo := &MockFetcherRepository{}
 			fsm := NewFeedStatusManager(mockRepo, slog.Default())
 
-			err := fsm.saveArticles(context.Background(), "feed-1", tt.articles)
+			_, err := fsm.saveArticles(context.Background(), "feed-1", tt.articles)
 
 			if len(tt.articles) == 0 {
 				assert.NoError(t, err)
@@ -537,12 +676,301 @@ func TestSaveArticlesInsertionError(t *testing.T) {
 		},
 	}
 
-	err := fsm.saveArticles(context.Background(), "feed-1", articles)
+	_, err := fsm.saveArticles(context.Background(), "feed-1", articles)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to insert articles")
 }
 
+// TestSaveArticlesCountsNewArticles tests counting of articles stored for the first time
+func TestSaveArticlesCountsNewArticles(t *testing.T) {
+	tests := []struct {
+		name     string
+		lookup   func(ctx context.Context, feedID string, guids []string) (map[string]bool, error)
+		expected int
+	}{
+		{
+			name: "known articles are not counted",
+			lookup: func(ctx context.Context, feedID string, guids []string) (map[string]bool, error) {
+				return map[string]bool{"guid-1": true}, nil
+			},
+			expected: 2,
+		},
+		{
+			name: "failed lookup counts nothing but still saves",
+			lookup: func(ctx context.Context, feedID string, guids []string) (map[string]bool, error) {
+				return nil, errors.New("lookup failed")
+			},
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &MockFetcherRepository{FindArticleGUIDsFunc: tt.lookup}
+			fsm := NewFeedStatusManager(mockRepo, slog.Default())
+
+			articles := []Article{
+				{GUID: "guid-1", Title: "Known", URL: "https://example.com/1"},
+				{GUID: "guid-2", Title: "New", URL: "https://example.com/2"},
+				{GUID: "guid-3", Title: "New", URL: "https://example.com/3"},
+			}
+
+			newArticles, err := fsm.saveArticles(context.Background(), "feed-1", articles)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, newArticles)
+			require.NotNil(t, mockRepo.InsertArticleCall)
+			assert.Len(t, mockRepo.InsertArticleCall.Articles, 3)
+		})
+	}
+}
+
+// TestSaveArticlesAdoptsGUIDsOfMigratedArticles tests that articles stored with their URL as GUID
+// (before item GUIDs were tracked) take over the item GUID instead of being duplicated
+func TestSaveArticlesAdoptsGUIDsOfMigratedArticles(t *testing.T) {
+	// Stored articles of the feed by GUID; the migrated one carries its URL
+	stored := map[string]string{"https://example.com/1": "https://example.com/1"}
+	mockRepo := &MockFetcherRepository{
+		AdoptArticleGUIDsFunc: func(ctx context.Context, feedID string, urls, guids []string) (int, error) {
+			adopted := 0
+			for i, url := range urls {
+				if storedURL, ok := stored[url]; ok && storedURL == url {
+					delete(stored, url)
+					stored[guids[i]] = url
+					adopted++
+				}
+			}
+			return adopted, nil
+		},
+		FindArticleGUIDsFunc: func(ctx context.Context, feedID string, guids []string) (map[string]bool, error) {
+			known := map[string]bool{}
+			for _, guid := range guids {
+				_, known[guid] = stored[guid]
+			}
+			return known, nil
+		},
+	}
+	fsm := NewFeedStatusManager(mockRepo, slog.Default())
+
+	articles := []Article{
+		{GUID: "tag:example.com,2025:1", Title: "Migrated", URL: "https://example.com/1"},
+		{GUID: "tag:example.com,2025:2", Title: "New", URL: "https://example.com/2"},
+		{GUID: "https://example.com/3", Title: "Without guid", URL: "https://example.com/3"},
+	}
+
+	newArticles, err := fsm.saveArticles(context.Background(), "feed-1", articles)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, newArticles)
+	assert.Equal(t, map[string]string{"tag:example.com,2025:1": "https://example.com/1"}, stored)
+	require.NotNil(t, mockRepo.InsertArticleCall)
+	assert.Equal(t, "tag:example.com,2025:1", mockRepo.InsertArticleCall.Articles[0].Guid)
+}
+
+// TestSaveArticlesAdoptGUIDsError tests that articles aren't upserted when migrated articles can't be matched
+func TestSaveArticlesAdoptGUIDsError(t *testing.T) {
+	mockRepo := &MockFetcherRepository{
+		AdoptArticleGUIDsFunc: func(ctx context.Context, feedID string, urls, guids []string) (int, error) {
+			return 0, errors.New("connection refused")
+		},
+	}
+	fsm := NewFeedStatusManager(mockRepo, slog.Default())
+
+	articles := []Article{{GUID: "guid-1", Title: "Article", URL: "https://example.com/1"}}
+
+	_, err := fsm.saveArticles(context.Background(), "feed-1", articles)
+
+	assert.ErrorContains(t, err, "failed to adopt article guids")
+	assert.Nil(t, mockRepo.InsertArticleCall)
+}
+
+// TestSaveArticlesMediaMetadata tests that item metadata is stored with the article
+func TestSaveArticlesMediaMetadata(t *testing.T) {
+	mockRepo := &MockFetcherRepository{}
+	fsm := NewFeedStatusManager(mockRepo, slog.Default())
+
+	imageURL := "https://example.com/cover.jpg"
+	articles := []Article{
+		{
+			GUID:       "episode-1",
+			Title:      "Episode",
+			URL:        "https://example.com/1",
+			Authors:    []string{"Host"},
+			Categories: []string{"Technology"},
+			ImageURL:   &imageURL,
+			Enclosures: []Enclosure{{URL: "https://example.com/1.mp3", Type: "audio/mpeg", Length: 100}},
+		},
+		{GUID: "post-1", Title: "Post", URL: "https://example.com/2"},
+	}
+
+	_, err := fsm.saveArticles(context.Background(), "feed-1", articles)
+
+	require.NoError(t, err)
+	require.NotNil(t, mockRepo.InsertArticleCall)
+	saved := mockRepo.InsertArticleCall.Articles
+	require.Len(t, saved, 2)
+	assert.Equal(t, []string{"Host"}, saved[0].Authors)
+	assert.Equal(t, []string{"Technology"}, saved[0].Categories)
+	assert.Equal(t, &imageURL, saved[0].ImageUrl)
+	assert.Equal(t, articles[0].Enclosures, saved[0].Enclosures)
+	assert.Nil(t, saved[1].Enclosures, "articles without enclosures keep the column null")
+}
+
+// TestApplyDecisionRecordsFetchLog tests that every applied decision is recorded in the fetch history
+func TestApplyDecisionRecordsFetchLog(t *testing.T) {
+	mockRepo := &MockFetcherRepository{
+		FindArticleGUIDsFunc: func(ctx context.Context, feedID string, guids []string) (map[string]bool, error) {
+			return map[string]bool{}, nil
+		},
+	}
+	fsm := NewFeedStatusManager(mockRepo, slog.Default())
+
+	decision := FetchDecision{
+		Status:        "success",
+		NextFetchTime: time.Now().Add(time.Hour),
+		HTTPStatus:    http.StatusOK,
+		BytesRead:     2048,
+		Duration:      1500 * time.Millisecond,
+		RedirectChain: []string{"http://example.com/feed", "https://example.com/feed"},
+		Articles: []Article{
+			{GUID: "guid-1", Title: "New", URL: "https://example.com/1"},
+		},
+	}
+
+	err := fsm.ApplyDecision(context.Background(), database.PublicFeedsSelect{Id: "feed-1"}, decision)
+	require.NoError(t, err)
+
+	require.Len(t, mockRepo.FetchLogEntries, 1)
+	entry := mockRepo.FetchLogEntries[0]
+	assert.Equal(t, "feed-1", entry.FeedId)
+	assert.Equal(t, "success", entry.Status)
+	require.NotNil(t, entry.HttpStatus)
+	assert.Equal(t, http.StatusOK, *entry.HttpStatus)
+	assert.Equal(t, 1500, entry.DurationMs)
+	assert.Equal(t, int64(2048), *entry.BytesRead)
+	assert.Equal(t, 1, *entry.NewArticles)
+	assert.False(t, *entry.NotModified)
+	assert.Equal(t, decision.RedirectChain, entry.RedirectChain)
+	assert.Nil(t, entry.ErrorMessage)
+}
+
+// TestNewFetchLogEntry tests conversion of failed and not modified decisions
+func TestNewFetchLogEntry(t *testing.T) {
+	errorMsg := "connection refused"
+	entry := newFetchLogEntry("feed-1", FetchDecision{Status: "temporary_error", ErrorMessage: &errorMsg}, 0)
+	assert.Nil(t, entry.HttpStatus, "no response means no HTTP status")
+	assert.Equal(t, &errorMsg, entry.ErrorMessage)
+	assert.Nil(t, entry.RedirectChain)
+
+	entry = newFetchLogEntry("feed-1", FetchDecision{Status: "success", HTTPStatus: http.StatusNotModified, NotModified: true}, 0)
+	require.NotNil(t, entry.HttpStatus)
+	assert.Equal(t, http.StatusNotModified, *entry.HttpStatus)
+	assert.True(t, *entry.NotModified)
+}
+
+// TestApplyDecisionSiteMetadata tests storing channel metadata and automatic feed names
+func TestApplyDecisionSiteMetadata(t *testing.T) {
+	site := SiteMetadata{Title: "Example Blog", Link: "https://example.com/", Language: "en"}
+
+	tests := []struct {
+		name         string
+		feed         database.PublicFeedsSelect
+		expectedName *string
+	}{
+		{
+			name:         "auto-named feed takes the channel title",
+			feed:         database.PublicFeedsSelect{Id: "feed-1", Name: "example.com", NameIsAuto: true},
+			expectedName: strPtr("Example Blog"),
+		},
+		{
+			name: "user-defined name is kept",
+			feed: database.PublicFeedsSelect{Id: "feed-1", Name: "My blog"},
+		},
+		{
+			name: "auto-named feed with current title is not renamed",
+			feed: database.PublicFeedsSelect{Id: "feed-1", Name: "Example Blog", NameIsAuto: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &MockFetcherRepository{}
+			fsm := NewFeedStatusManager(mockRepo, slog.Default())
+
+			err := fsm.ApplyDecision(context.Background(), tt.feed, FetchDecision{Status: "success", Site: &site})
+
+			require.NoError(t, err)
+			require.Len(t, mockRepo.UpdateFeedCalls, 1)
+			update := mockRepo.UpdateFeedCalls[0].Update
+			assert.Equal(t, tt.expectedName, update.Name)
+			assert.Equal(t, strPtr("Example Blog"), update.SiteTitle)
+			assert.Equal(t, strPtr("https://example.com/"), update.SiteUrl)
+			assert.Equal(t, strPtr("en"), update.SiteLanguage)
+			assert.Nil(t, update.SiteDescription, "empty values keep the stored ones")
+		})
+	}
+}
+
+// TestApplyDecisionFavicon tests caching of downloaded site icons
+func TestApplyDecisionFavicon(t *testing.T) {
+	t.Run("downloaded icon is saved", func(t *testing.T) {
+		mockRepo := &MockFetcherRepository{}
+		fsm := NewFeedStatusManager(mockRepo, slog.Default())
+
+		err := fsm.ApplyDecision(context.Background(), database.PublicFeedsSelect{Id: "feed-1"}, FetchDecision{
+			Status:         "success",
+			Favicon:        &Favicon{ContentType: "image/png", Data: []byte("icon")},
+			FaviconChecked: true,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, mockRepo.SavedFavicons, 1)
+		assert.Equal(t, "feed-1", mockRepo.SavedFavicons[0].FeedId)
+		assert.Equal(t, "image/png", mockRepo.SavedFavicons[0].ContentType)
+		assert.Equal(t, "aWNvbg==", mockRepo.SavedFavicons[0].Data)
+		update := mockRepo.UpdateFeedCalls[0].Update
+		require.NotNil(t, update.HasFavicon)
+		assert.True(t, *update.HasFavicon)
+		assert.NotNil(t, update.FaviconCheckedAt)
+	})
+
+	t.Run("missing icon records the attempt only", func(t *testing.T) {
+		mockRepo := &MockFetcherRepository{}
+		fsm := NewFeedStatusManager(mockRepo, slog.Default())
+
+		err := fsm.ApplyDecision(context.Background(), database.PublicFeedsSelect{Id: "feed-1"}, FetchDecision{
+			Status:         "success",
+			FaviconChecked: true,
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, mockRepo.SavedFavicons)
+		update := mockRepo.UpdateFeedCalls[0].Update
+		assert.Nil(t, update.HasFavicon)
+		assert.NotNil(t, update.FaviconCheckedAt)
+	})
+
+	t.Run("failed save does not block the status update", func(t *testing.T) {
+		mockRepo := &MockFetcherRepository{
+			SaveFeedFaviconFunc: func(ctx context.Context, favicon database.PublicFeedFaviconsInsert) error {
+				return errors.New("database error")
+			},
+		}
+		fsm := NewFeedStatusManager(mockRepo, slog.Default())
+
+		err := fsm.ApplyDecision(context.Background(), database.PublicFeedsSelect{Id: "feed-1"}, FetchDecision{
+			Status:         "success",
+			Favicon:        &Favicon{ContentType: "image/png", Data: []byte("icon")},
+			FaviconChecked: true,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, mockRepo.UpdateFeedCalls, 1)
+		assert.Nil(t, mockRepo.UpdateFeedCalls[0].Update.HasFavicon)
+	})
+}
+
 // TestApplyDecisionContextCancellation tests handling of cancelled context
 func TestApplyDecisionContextCancellation(t *testing.T) {
 	mockRepo := &MockFetcherRepository{
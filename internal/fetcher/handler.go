@@ -0,0 +1,82 @@
+package fetcher
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderHubSignature is the WebSub content distribution signature header
+const HeaderHubSignature = "X-Hub-Signature"
+
+// Handler handles public WebSub callback requests from hubs
+type Handler struct {
+	service *FeedFetcherService
+}
+
+// NewHandler creates a new fetcher handler
+func NewHandler(service *FeedFetcherService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// HandleWebSubVerification handles GET /websub/:feedId endpoint
+// Echoes hub.challenge when the hub's intent matches a subscription we requested
+func (h *Handler) HandleWebSubVerification(c echo.Context) error {
+	feedID := c.Param("feedId")
+	if uuid.Validate(feedID) != nil {
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	challenge, err := h.service.VerifyWebSubIntent(c.Request().Context(), feedID, WebSubVerification{
+		Mode:         c.QueryParam("hub.mode"),
+		Topic:        c.QueryParam("hub.topic"),
+		Challenge:    c.QueryParam("hub.challenge"),
+		LeaseSeconds: c.QueryParam("hub.lease_seconds"),
+		Reason:       c.QueryParam("hub.reason"),
+	})
+	if err != nil {
+		if errors.Is(err, ErrWebSubUnknownSubscription) || errors.Is(err, ErrWebSubIntentRejected) {
+			return c.NoContent(http.StatusNotFound)
+		}
+		return err
+	}
+
+	return c.String(http.StatusOK, challenge)
+}
+
+// HandleWebSubNotification handles POST /websub/:feedId endpoint
+// Accepts content distribution requests; unknown subscriptions get 410 Gone so the hub stops pushing,
+// oversized notifications 413 and busy feeds 503 Service Unavailable so the hub delivers again
+func (h *Handler) HandleWebSubNotification(c echo.Context) error {
+	feedID := c.Param("feedId")
+	if uuid.Validate(feedID) != nil {
+		return c.NoContent(http.StatusGone)
+	}
+
+	err := h.service.HandleWebSubNotification(
+		c.Request().Context(),
+		feedID,
+		c.Request().Body,
+		c.Request().Header.Get(HeaderHubSignature),
+	)
+	if err != nil {
+		if errors.Is(err, ErrWebSubUnknownSubscription) {
+			return c.NoContent(http.StatusGone)
+		}
+		if errors.Is(err, ErrWebSubPayloadTooLarge) {
+			return c.NoContent(http.StatusRequestEntityTooLarge)
+		}
+		// Hubs retry failed deliveries; the feed is free again once the running fetch ends
+		if errors.Is(err, ErrWebSubFeedBusy) {
+			c.Response().Header().Set("Retry-After", "60")
+			return c.NoContent(http.StatusServiceUnavailable)
+		}
+		return err
+	}
+
+	return c.NoContent(http.StatusAccepted)
+}
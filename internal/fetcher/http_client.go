@@ -6,8 +6,11 @@ import (
 	"log/slog"
 	"net"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
+	"github.com/tjanas94/vibefeeder/internal/shared/credentials"
 	"github.com/tjanas94/vibefeeder/internal/shared/ssrf"
 )
 
@@ -20,6 +23,11 @@ const (
 	HeaderLastModified    = "Last-Modified"
 	HeaderCacheControl    = "Cache-Control"
 	HeaderRetryAfter      = "Retry-After"
+	HeaderContentType     = "Content-Type"
+	HeaderLink            = "Link"
+	HeaderAcceptEncoding  = "Accept-Encoding"
+	HeaderContentEncoding = "Content-Encoding"
+	AcceptEncodingValue   = "gzip, deflate, br" // Decompressed by readResponseBody, not by the transport
 	UserAgentValue        = "VibeFeeder/1.0 (+https://github.com/tjanas94/vibefeeder; mailto:vibefeeder@janas.dev)"
 )
 
@@ -36,8 +44,11 @@ type HTTPClient struct {
 	logger *slog.Logger
 }
 
-// Ensure HTTPClient implements HTTPClientInterface at compile time
-var _ HTTPClientInterface = (*HTTPClient)(nil)
+// Ensure HTTPClient implements HTTPClientInterface and HubClientInterface at compile time
+var (
+	_ HTTPClientInterface = (*HTTPClient)(nil)
+	_ HubClientInterface  = (*HTTPClient)(nil)
+)
 
 // NewHTTPClient creates a new HTTP client with SSRF protection via custom Dialer.
 // The SSRF validation happens at the connection level, preventing TOCTOU vulnerabilities.
@@ -111,9 +122,10 @@ type ExecuteRequestParams struct {
 	URL          string
 	ETag         *string
 	LastModified *string
+	Credentials  *credentials.FeedCredentials // Authorization for private feeds (nil for public feeds)
 }
 
-// ExecuteRequest creates and executes an HTTP GET request with optional conditional headers.
+// ExecuteRequest creates and executes an HTTP GET request with optional conditional and authorization headers.
 // The caller is responsible for closing the Response.Body when the response is no longer needed.
 //
 // The context timeout should be set appropriately for the expected request duration.
@@ -127,6 +139,10 @@ func (c *HTTPClient) ExecuteRequest(ctx context.Context, params ExecuteRequestPa
 	// Set User-Agent header
 	req.Header.Set(HeaderUserAgent, UserAgentValue)
 
+	// Request compressed content explicitly; bodies are decompressed by readResponseBody,
+	// which applies the size limit to the decompressed stream
+	req.Header.Set(HeaderAcceptEncoding, AcceptEncodingValue)
+
 	// Add conditional request headers if available
 	if params.ETag != nil && *params.ETag != "" {
 		req.Header.Set(HeaderIfNoneMatch, *params.ETag)
@@ -135,5 +151,24 @@ func (c *HTTPClient) ExecuteRequest(ctx context.Context, params ExecuteRequestPa
 		req.Header.Set(HeaderIfModifiedSince, *params.LastModified)
 	}
 
+	// Add feed credentials (Basic auth, bearer token and custom headers)
+	if params.Credentials != nil {
+		params.Credentials.Apply(req.Header)
+	}
+
+	return c.client.Do(req)
+}
+
+// PostForm sends a URL-encoded form POST request (used for WebSub hub subscriptions).
+// The caller is responsible for closing the Response.Body when the response is no longer needed.
+func (c *HTTPClient) PostForm(ctx context.Context, targetURL string, form url.Values) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", targetURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set(HeaderUserAgent, UserAgentValue)
+	req.Header.Set(HeaderContentType, "application/x-www-form-urlencoded")
+
 	return c.client.Do(req)
 }
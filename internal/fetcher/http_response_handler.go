@@ -1,8 +1,9 @@
 package fetcher
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
-	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
@@ -44,7 +45,7 @@ func (h *HTTPResponseHandler) HandleResponse(
 
 	switch {
 	case statusCode == http.StatusOK: // 200
-		return h.handleSuccess(resp, feedURL, maxResponseBodySize, maxArticlesPerFeed)
+		return h.handleSuccess(resp, feedURL, maxResponseBodySize, maxArticlesPerFeed, retryCount)
 
 	case statusCode == http.StatusNotModified: // 304
 		return h.handleNotModified(resp)
@@ -56,7 +57,7 @@ func (h *HTTPResponseHandler) HandleResponse(
 		return h.handleTemporaryRedirect(resp)
 
 	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden: // 401, 403
-		return h.handleUnauthorized(statusCode)
+		return h.handleUnauthorized(statusCode, retryCount)
 
 	case statusCode == http.StatusTooManyRequests: // 429
 		return h.handleTooManyRequests(resp, retryCount)
@@ -81,6 +82,7 @@ func (h *HTTPResponseHandler) handleSuccess(
 	feedURL string,
 	maxResponseBodySize int64,
 	maxArticlesPerFeed int,
+	retryCount int,
 ) FetchDecision {
 	// Extract conditional headers
 	etag := resp.Header.Get(HeaderETag)
@@ -94,13 +96,47 @@ func (h *HTTPResponseHandler) handleSuccess(
 		lastModifiedPtr = &lastModified
 	}
 
-	// Limit response body size to prevent zip bomb attacks
-	limitedReader := io.LimitReader(resp.Body, maxResponseBodySize)
+	// Decompress and limit response body size to prevent zip bomb attacks
+	body, err := readResponseBody(resp, maxResponseBodySize)
+	if errors.Is(err, errBodyTooLarge) {
+		return h.handleTooLarge(maxResponseBodySize, retryCount)
+	}
+	if err != nil {
+		h.logger.Error("Failed to read response body", "error", err)
+		errorMsg := fmt.Sprintf("Failed to read response body: %v", err)
+		return FetchDecision{
+			Status:       "permanent_error",
+			ErrorMessage: &errorMsg,
+		}
+	}
+
+	// Transcode declared or sniffed character sets (windows-1250, ISO-8859-2, Shift_JIS...) to UTF-8
+	body, err = toUTF8(body, resp.Header.Get(HeaderContentType))
+	if err != nil {
+		h.logger.Error("Failed to decode response body", "error", err)
+		errorMsg := fmt.Sprintf("Failed to decode response body: %v", err)
+		return FetchDecision{
+			Status:       "permanent_error",
+			ErrorMessage: &errorMsg,
+		}
+	}
 
 	// Parse feed
 	parser := gofeed.NewParser()
-	parsedFeed, err := parser.Parse(limitedReader)
+	parsedFeed, err := parser.Parse(bytes.NewReader(body))
 	if err != nil {
+		// Website URL instead of a feed URL - look for feeds referenced by the page
+		if isHTMLDocument(resp.Header.Get(HeaderContentType), body) {
+			if pageURL, parseErr := url.Parse(feedURL); parseErr == nil {
+				candidates := discoverFeedCandidates(pageURL, body)
+				h.logger.Info("Fetched HTML page instead of feed, discovering feeds", "url", feedURL, "candidates", len(candidates))
+				return FetchDecision{
+					Status:         "discovered",
+					FeedCandidates: candidates,
+				}
+			}
+		}
+
 		h.logger.Error("Failed to parse feed", "error", err)
 		errorMsg := fmt.Sprintf("Failed to parse feed: %v", err)
 		return FetchDecision{
@@ -121,16 +157,44 @@ func (h *HTTPResponseHandler) handleSuccess(
 	// Calculate next fetch time
 	cacheControl := resp.Header.Get(HeaderCacheControl)
 	nextFetch := calculateNextFetch(cacheControl, h.successInterval, time.Now())
+	maxAge, _ := parseCacheControlMaxAge(cacheControl)
 
 	h.logger.Info("Feed fetched successfully", "articles", len(articles), "next_fetch", nextFetch)
 
-	return FetchDecision{
+	decision := FetchDecision{
 		ShouldRetry:   false,
 		NextFetchTime: nextFetch,
 		Status:        "success",
 		Articles:      articles,
+		Site:          feedSiteMetadata(parsedFeed, feedURL),
 		ETag:          etagPtr,
 		LastModified:  lastModifiedPtr,
+		CacheMaxAge:   maxAge,
+	}
+
+	// Detect WebSub hub advertised via Link header or feed links
+	if hubURL, selfURL := discoverWebSubLinks(feedURL, resp.Header.Values(HeaderLink), body); hubURL != "" {
+		decision.HubURL = &hubURL
+		if selfURL != "" {
+			decision.TopicURL = &selfURL
+		}
+	}
+
+	return decision
+}
+
+// handleTooLarge processes a 200 response whose decompressed body exceeds the size limit
+// Retries with backoff since publishers often trim their feeds
+func (h *HTTPResponseHandler) handleTooLarge(maxResponseBodySize int64, retryCount int) FetchDecision {
+	h.logger.Warn("Feed exceeds the maximum size", "limit", maxResponseBodySize)
+
+	nextFetch := calculateBackoff(retryCount+1, time.Now())
+
+	errorMsg := fmt.Sprintf("Feed is larger than the %.1f MB size limit", float64(maxResponseBodySize)/(1024*1024))
+	return FetchDecision{
+		NextFetchTime: nextFetch,
+		Status:        "too_large",
+		ErrorMessage:  &errorMsg,
 	}
 }
 
@@ -141,12 +205,15 @@ func (h *HTTPResponseHandler) handleNotModified(resp *http.Response) FetchDecisi
 	// Calculate next fetch time based on Cache-Control header
 	cacheControl := resp.Header.Get(HeaderCacheControl)
 	nextFetch := calculateNextFetch(cacheControl, h.successInterval, time.Now())
+	maxAge, _ := parseCacheControlMaxAge(cacheControl)
 
 	return FetchDecision{
 		ShouldRetry:   false,
 		NextFetchTime: nextFetch,
 		Status:        "success",
 		Articles:      []Article{},
+		NotModified:   true,
+		CacheMaxAge:   maxAge,
 	}
 }
 
@@ -195,13 +262,17 @@ func (h *HTTPResponseHandler) handleTemporaryRedirect(resp *http.Response) Fetch
 }
 
 // handleUnauthorized processes 401/403 authorization errors
-func (h *HTTPResponseHandler) handleUnauthorized(statusCode int) FetchDecision {
+// Retries with backoff until the user fixes the feed credentials
+func (h *HTTPResponseHandler) handleUnauthorized(statusCode int, retryCount int) FetchDecision {
 	h.logger.Warn("Feed requires authorization", "status", statusCode)
 
-	errorMsg := fmt.Sprintf("Authorization required (HTTP %d)", statusCode)
+	nextFetch := calculateBackoff(retryCount+1, time.Now())
+
+	errorMsg := fmt.Sprintf("Authorization required (HTTP %d), check the feed credentials", statusCode)
 	return FetchDecision{
-		Status:       "unauthorized",
-		ErrorMessage: &errorMsg,
+		NextFetchTime: nextFetch,
+		Status:        "unauthorized",
+		ErrorMessage:  &errorMsg,
 	}
 }
 
@@ -248,6 +248,19 @@ func TestHandleSuccess(t *testing.T) {
 			},
 			description: "Should successfully parse valid RSS feed",
 		},
+		{
+			name:               "returns feed candidates for HTML page",
+			feedContent:        `<html><head><link rel="alternate" type="application/rss+xml" href="/rss"></head><body></body></html>`,
+			headers:            http.Header{"Content-Type": []string{"text/html"}},
+			maxArticlesPerFeed: 100,
+			validate: func(t *testing.T, decision FetchDecision) {
+				assert.Equal(t, "discovered", decision.Status)
+				assert.Nil(t, decision.ErrorMessage)
+				require.NotEmpty(t, decision.FeedCandidates)
+				assert.Equal(t, "https://example.com/rss", decision.FeedCandidates[0])
+			},
+			description: "Should switch to feed discovery when a web page is fetched",
+		},
 		{
 			name:               "extracts ETag header when present",
 			feedContent:        validFeed,
@@ -587,6 +600,7 @@ func TestHandleUnauthorized(t *testing.T) {
 				assert.Equal(t, "unauthorized", decision.Status)
 				assert.NotNil(t, decision.ErrorMessage)
 				assert.Contains(t, *decision.ErrorMessage, "401")
+				assert.True(t, decision.NextFetchTime.After(time.Now()), "should back off until credentials are fixed")
 			},
 			description: "Should return unauthorized status for 401",
 		},
@@ -999,16 +1013,27 @@ func TestResponseBodySize(t *testing.T) {
 			description: "Should accept feed within size limit",
 		},
 		{
-			name:                "large feed exceeding limit truncates",
+			name:                "large feed exceeding limit is reported as too large",
 			feedContent:         largeContent,
 			maxResponseBodySize: 100, // Very small limit
 			validate: func(t *testing.T, decision FetchDecision) {
-				// Feed parsing should fail due to truncated content
-				assert.Equal(t, "permanent_error", decision.Status)
-				assert.NotNil(t, decision.ErrorMessage)
+				// Oversized body is detected instead of parsing truncated content
+				assert.Equal(t, "too_large", decision.Status)
+				require.NotNil(t, decision.ErrorMessage)
+				assert.Contains(t, *decision.ErrorMessage, "size limit")
+				assert.True(t, decision.NextFetchTime.After(time.Now()), "too large feeds are retried with backoff")
 			},
 			description: "Should limit response body size",
 		},
+		{
+			name:                "feed exactly at limit is accepted",
+			feedContent:         `<?xml version="1.0"?><rss version="2.0"><channel><title>Test</title></channel></rss>`,
+			maxResponseBodySize: int64(len(`<?xml version="1.0"?><rss version="2.0"><channel><title>Test</title></channel></rss>`)),
+			validate: func(t *testing.T, decision FetchDecision) {
+				assert.Equal(t, "success", decision.Status)
+			},
+			description: "Should not treat a body of exactly the limit as too large",
+		},
 	}
 
 	for _, tt := range tests {
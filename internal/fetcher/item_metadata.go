@@ -0,0 +1,177 @@
+package fetcher
+
+import (
+	"mime"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/mmcdole/gofeed"
+	ext "github.com/mmcdole/gofeed/extensions"
+	"github.com/tjanas94/vibefeeder/internal/shared/sanitize"
+)
+
+// Limits on item metadata stored per article; feeds with hundreds of tags are trimmed
+const (
+	maxArticleAuthors    = 10
+	maxArticleCategories = 20
+	maxArticleEnclosures = 5
+	maxCategoryLength    = 100
+	maxMediaURLLength    = 2048
+)
+
+// itemAuthors returns the author names of a feed item, falling back to the iTunes author
+// Email-only authors are skipped to avoid showing personal addresses
+func itemAuthors(item *gofeed.Item) []string {
+	names := make([]string, 0, len(item.Authors))
+	for _, person := range item.Authors {
+		if person != nil {
+			names = append(names, person.Name)
+		}
+	}
+	if len(names) == 0 && item.ITunesExt != nil {
+		names = append(names, item.ITunesExt.Author)
+	}
+
+	return cleanTextList(names, maxArticleAuthors, maxCategoryLength)
+}
+
+// itemCategories returns the categories (tags) of a feed item
+func itemCategories(item *gofeed.Item) []string {
+	return cleanTextList(item.Categories, maxArticleCategories, maxCategoryLength)
+}
+
+// itemImageURL returns the lead image of a feed item
+// Falls back to Media RSS thumbnails, which Atom feeds of video channels use instead of item images
+func itemImageURL(item *gofeed.Item, base *url.URL) *string {
+	if item.Image != nil {
+		if imageURL := resolveMediaURL(base, item.Image.URL); imageURL != "" {
+			return &imageURL
+		}
+	}
+
+	for _, thumbnail := range mediaElements(item.Extensions, "thumbnail") {
+		if imageURL := resolveMediaURL(base, thumbnail.Attrs["url"]); imageURL != "" {
+			return &imageURL
+		}
+	}
+
+	return nil
+}
+
+// itemEnclosures returns the media files attached to a feed item (podcast audio, video)
+// Image enclosures are covered by itemImageURL; Media RSS audio and video content is included as well
+func itemEnclosures(item *gofeed.Item, base *url.URL) []Enclosure {
+	enclosures := make([]Enclosure, 0, len(item.Enclosures))
+	seen := make(map[string]bool)
+
+	add := func(rawURL, mimeType, length string) {
+		if len(enclosures) >= maxArticleEnclosures {
+			return
+		}
+		mediaURL := resolveMediaURL(base, rawURL)
+		mimeType = normalizeMIMEType(mimeType)
+		if mediaURL == "" || seen[mediaURL] || strings.HasPrefix(mimeType, "image/") {
+			return
+		}
+		seen[mediaURL] = true
+
+		enclosure := Enclosure{URL: mediaURL, Type: mimeType}
+		if n, err := strconv.ParseInt(strings.TrimSpace(length), 10, 64); err == nil && n > 0 {
+			enclosure.Length = n
+		}
+		enclosures = append(enclosures, enclosure)
+	}
+
+	for _, enclosure := range item.Enclosures {
+		if enclosure != nil {
+			add(enclosure.URL, enclosure.Type, enclosure.Length)
+		}
+	}
+
+	for _, content := range mediaElements(item.Extensions, "content") {
+		medium := content.Attrs["medium"]
+		mimeType := content.Attrs["type"]
+		if medium == "audio" || medium == "video" || strings.HasPrefix(mimeType, "audio/") || strings.HasPrefix(mimeType, "video/") {
+			add(content.Attrs["url"], mimeType, content.Attrs["fileSize"])
+		}
+	}
+
+	if len(enclosures) == 0 {
+		return nil
+	}
+	return enclosures
+}
+
+// mediaElements returns Media RSS elements with the given name, including those nested in media:group
+func mediaElements(extensions ext.Extensions, name string) []ext.Extension {
+	media, ok := extensions["media"]
+	if !ok {
+		return nil
+	}
+
+	elements := append([]ext.Extension{}, media[name]...)
+	for _, group := range media["group"] {
+		elements = append(elements, group.Children[name]...)
+	}
+
+	return elements
+}
+
+// resolveMediaURL resolves a media URL against the item link and accepts only http(s) URLs
+func resolveMediaURL(base *url.URL, rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" || len(rawURL) > maxMediaURLLength {
+		return ""
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	if base != nil {
+		parsed = base.ResolveReference(parsed)
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return ""
+	}
+
+	return parsed.String()
+}
+
+// normalizeMIMEType lowercases a MIME type and strips its parameters
+func normalizeMIMEType(mimeType string) string {
+	if mediaType, _, err := mime.ParseMediaType(mimeType); err == nil {
+		return mediaType
+	}
+	return ""
+}
+
+// cleanTextList sanitizes, trims and deduplicates text values, keeping at most limit entries
+// Returns nil for an empty result so the database column stays null
+func cleanTextList(values []string, limit, maxLength int) []string {
+	result := make([]string, 0, min(len(values), limit))
+	seen := make(map[string]bool, len(values))
+
+	for _, value := range values {
+		value = strings.TrimSpace(sanitize.Text(value))
+		if value == "" || len(value) > maxLength {
+			continue
+		}
+		key := strings.ToLower(value)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		result = append(result, value)
+		if len(result) == limit {
+			break
+		}
+	}
+
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
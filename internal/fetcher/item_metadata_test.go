@@ -0,0 +1,127 @@
+package fetcher
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseTestFeed(t *testing.T, feed string) []Article {
+	t.Helper()
+	parsed, err := gofeed.NewParser().Parse(strings.NewReader(feed))
+	require.NoError(t, err)
+	return transformFeedItems(parsed.Items, time.Now())
+}
+
+func TestTransformFeedItems_PodcastMetadata(t *testing.T) {
+	articles := parseTestFeed(t, `<?xml version="1.0"?>
+<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd">
+<channel><title>Podcast</title><link>https://pod.example.com/</link>
+<item>
+	<title>Episode 1</title>
+	<link>https://pod.example.com/episodes/1</link>
+	<guid>ep-1</guid>
+	<itunes:author>Jane Host</itunes:author>
+	<itunes:image href="https://pod.example.com/ep1.jpg"/>
+	<category>Technology</category>
+	<category>technology</category>
+	<category>Interviews</category>
+	<enclosure url="/media/ep1.mp3" length="12345678" type="audio/MPEG"/>
+</item>
+</channel></rss>`)
+
+	require.Len(t, articles, 1)
+	article := articles[0]
+	assert.Equal(t, []string{"Jane Host"}, article.Authors)
+	assert.Equal(t, []string{"Technology", "Interviews"}, article.Categories, "categories are deduplicated case-insensitively")
+	require.NotNil(t, article.ImageURL)
+	assert.Equal(t, "https://pod.example.com/ep1.jpg", *article.ImageURL)
+	require.Len(t, article.Enclosures, 1)
+	assert.Equal(t, Enclosure{
+		URL:    "https://pod.example.com/media/ep1.mp3",
+		Type:   "audio/mpeg",
+		Length: 12345678,
+	}, article.Enclosures[0])
+}
+
+func TestTransformFeedItems_MediaRSSVideoChannel(t *testing.T) {
+	articles := parseTestFeed(t, `<?xml version="1.0"?>
+<feed xmlns="http://www.w3.org/2005/Atom" xmlns:media="http://search.yahoo.com/mrss/">
+<title>Channel</title>
+<entry>
+	<id>yt:video:abc</id>
+	<title>Video</title>
+	<link rel="alternate" href="https://video.example.com/watch?v=abc"/>
+	<author><name>Channel Owner</name></author>
+	<updated>2024-01-14T10:00:00Z</updated>
+	<media:group>
+		<media:title>Video</media:title>
+		<media:content url="https://video.example.com/abc.mp4" type="video/mp4" fileSize="2048"/>
+		<media:thumbnail url="https://img.example.com/abc.jpg" width="480" height="360"/>
+	</media:group>
+</entry>
+</feed>`)
+
+	require.Len(t, articles, 1)
+	article := articles[0]
+	assert.Equal(t, []string{"Channel Owner"}, article.Authors)
+	require.NotNil(t, article.ImageURL)
+	assert.Equal(t, "https://img.example.com/abc.jpg", *article.ImageURL)
+	require.Len(t, article.Enclosures, 1)
+	assert.Equal(t, "https://video.example.com/abc.mp4", article.Enclosures[0].URL)
+	assert.Equal(t, "video/mp4", article.Enclosures[0].Type)
+	assert.Equal(t, int64(2048), article.Enclosures[0].Length)
+}
+
+func TestTransformFeedItems_NoMetadata(t *testing.T) {
+	articles := transformFeedItems([]*gofeed.Item{
+		{Title: "Plain", Link: "https://example.com/plain"},
+	}, time.Now())
+
+	require.Len(t, articles, 1)
+	assert.Nil(t, articles[0].Authors)
+	assert.Nil(t, articles[0].Categories)
+	assert.Nil(t, articles[0].ImageURL)
+	assert.Nil(t, articles[0].Enclosures)
+}
+
+func TestItemEnclosures_SkipsUnsafeAndImageEnclosures(t *testing.T) {
+	item := &gofeed.Item{
+		Enclosures: []*gofeed.Enclosure{
+			{URL: "javascript:alert(1)", Type: "audio/mpeg"},
+			{URL: "https://example.com/cover.jpg", Type: "image/jpeg"},
+			{URL: "https://example.com/a.mp3", Type: "audio/mpeg", Length: "not a number"},
+			{URL: "https://example.com/a.mp3", Type: "audio/mpeg"},
+		},
+	}
+
+	enclosures := itemEnclosures(item, nil)
+
+	require.Len(t, enclosures, 1)
+	assert.Equal(t, Enclosure{URL: "https://example.com/a.mp3", Type: "audio/mpeg"}, enclosures[0])
+}
+
+func TestCleanTextList(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []string
+		limit    int
+		expected []string
+	}{
+		{name: "empty input", values: nil, limit: 5, expected: nil},
+		{name: "blank values only", values: []string{"", "  "}, limit: 5, expected: nil},
+		{name: "markup is stripped", values: []string{"<b>Go</b>"}, limit: 5, expected: []string{"Go"}},
+		{name: "limit is applied", values: []string{"a", "b", "c"}, limit: 2, expected: []string{"a", "b"}},
+		{name: "too long values are skipped", values: []string{strings.Repeat("x", maxCategoryLength+1), "ok"}, limit: 5, expected: []string{"ok"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, cleanTextList(tt.values, tt.limit, maxCategoryLength))
+		})
+	}
+}
@@ -4,20 +4,75 @@ import "time"
 
 // Article represents a parsed feed article
 type Article struct {
+	GUID        string // Feed item guid/id, falls back to URL; identifies the article within its feed
 	Title       string
 	URL         string
-	Content     *string
+	Content     *string // Plain text for summaries
+	ContentHTML *string // Allow-list sanitized HTML for article views
+	ContentHash *string // SHA-256 of the sanitized content, used to detect publisher corrections
 	PublishedAt time.Time
+
+	// Item metadata for podcast and media feeds
+	Authors    []string
+	Categories []string
+	ImageURL   *string     // Lead image or thumbnail
+	Enclosures []Enclosure // Attached audio/video files
+
+	// Full text extracted from the article page (feeds with full content fetching enabled)
+	FullContent          *string
+	FullContentFetchedAt *time.Time // Set once extraction was attempted and should not be retried
+}
+
+// Enclosure is a media file attached to a feed item (stored as JSON on the article)
+type Enclosure struct {
+	URL    string `json:"url"`
+	Type   string `json:"type,omitempty"`   // MIME type, e.g. audio/mpeg
+	Length int64  `json:"length,omitempty"` // Size in bytes as declared by the feed (0 if unknown)
 }
 
 // FetchDecision represents the decision to make after handling an HTTP response
 type FetchDecision struct {
-	ShouldRetry   bool
-	NextFetchTime time.Time
-	Status        string
-	ErrorMessage  *string
-	ETag          *string
-	LastModified  *string
-	NewURL        *string
-	Articles      []Article
+	ShouldRetry    bool
+	NextFetchTime  time.Time
+	Status         string
+	ErrorMessage   *string
+	ETag           *string
+	LastModified   *string
+	NewURL         *string
+	Articles       []Article
+	FeedCandidates []string      // Feed URLs discovered on an HTML page (Status "discovered")
+	Site           *SiteMetadata // Channel metadata of the parsed feed
+	Favicon        *Favicon      // Site icon downloaded during this fetch
+	FaviconChecked bool          // Favicon download was attempted (successful or not)
+	HubURL         *string       // WebSub hub advertised by the feed
+	TopicURL       *string       // WebSub topic (rel="self") advertised by the feed
+	NotModified    bool          // Server answered 304 Not Modified
+	CacheMaxAge    time.Duration // Cache-Control max-age sent by the server (0 if absent)
+	Polling        *PollingStats // Learned polling statistics to store on the feed
+	HTTPStatus     int           // Status code of the final response (0 if no response was received)
+	BytesRead      int64         // Body bytes read from the final response
+	RedirectChain  []string      // URLs requested while following redirects, ending with the final URL
+	Duration       time.Duration // Time spent fetching the feed
+}
+
+// SiteMetadata holds the channel-level metadata of a feed (all fields sanitized, may be empty)
+type SiteMetadata struct {
+	Title       string
+	Description string
+	Link        string // Website of the feed, absolute http(s) URL
+	ImageURL    string // Channel image or logo
+	Language    string
+}
+
+// Favicon is a site icon downloaded for a feed
+type Favicon struct {
+	ContentType string
+	Data        []byte
+}
+
+// PollingStats holds the adaptive polling statistics learned for a feed
+type PollingStats struct {
+	Interval       time.Duration
+	PublishCadence *time.Duration // nil until enough dated items were observed
+	UnchangedRatio float64
 }
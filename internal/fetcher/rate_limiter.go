@@ -5,6 +5,10 @@ import (
 	"net/url"
 	"sync"
 	"time"
+
+	"github.com/tjanas94/vibefeeder/internal/shared/metrics"
+	"github.com/tjanas94/vibefeeder/internal/shared/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // RateLimiter ensures minimum delay between requests to same domain
@@ -33,7 +37,17 @@ func (rl *RateLimiter) WaitIfNeeded(ctx context.Context, feedURL string) error {
 	}
 
 	domain := parsedURL.Hostname()
-	return rl.waitForDomain(ctx, domain)
+
+	_, span := tracing.Start(ctx, "fetcher.RateLimiter.WaitIfNeeded", attribute.String("server.address", domain))
+	start := time.Now()
+	err = rl.waitForDomain(ctx, domain)
+	tracing.End(span, err)
+	if err != nil {
+		return err
+	}
+
+	metrics.FetcherRateLimitWait.Observe(time.Since(start).Seconds())
+	return nil
 }
 
 // waitForDomain blocks until enough time has passed for a specific domain
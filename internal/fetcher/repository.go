@@ -3,8 +3,10 @@ package fetcher
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/tjanas94/vibefeeder/internal/shared/database"
+	"github.com/tjanas94/vibefeeder/internal/shared/tracing"
 )
 
 // Repository handles data access for feed fetching operations
@@ -12,57 +14,108 @@ type Repository struct {
 	db *database.Client
 }
 
-// Ensure Repository implements FetcherRepository interface at compile time
-var _ FetcherRepository = (*Repository)(nil)
+// Ensure Repository implements FetcherRepository, WebSubRepository and RetentionRepository interfaces at compile time
+var (
+	_ FetcherRepository   = (*Repository)(nil)
+	_ WebSubRepository    = (*Repository)(nil)
+	_ RetentionRepository = (*Repository)(nil)
+)
 
 // NewRepository creates a new fetcher repository
 func NewRepository(db *database.Client) *Repository {
 	return &Repository{db: db}
 }
 
-// FindFeedsDueForFetch retrieves feeds that are ready to be fetched
-// Returns feeds where fetch_after is NULL or in the past, ordered by last_fetched_at
-func (r *Repository) FindFeedsDueForFetch(ctx context.Context, limit int) ([]database.PublicFeedsSelect, error) {
-	var feeds []database.PublicFeedsSelect
+// ClaimFeedsForFetch leases up to limit feeds that are ready to be fetched to the given owner
+// Feeds are due when fetch_after is NULL or in the past and no other instance holds an active lease
+// Never-fetched feeds come first; rows locked by a concurrent claim are skipped (FOR UPDATE SKIP LOCKED)
+func (r *Repository) ClaimFeedsForFetch(ctx context.Context, owner string, limit int, lease time.Duration) (_ []database.PublicFeedsSelect, err error) {
+	_, span := tracing.Start(ctx, "fetcher.Repository.ClaimFeedsForFetch")
+	defer func() { tracing.End(span, err) }()
 
-	// Query for feeds where:
-	// - fetch_after IS NULL (never fetched or no schedule set)
-	// - fetch_after <= NOW() (scheduled time has passed)
-	// Order by last_fetched_at ASC NULLS FIRST to prioritize never-fetched feeds
-	_, err := r.db.From("feeds").
-		Select("*", "", false).
-		Or("fetch_after.is.null,fetch_after.lte.now()", "").
-		Order("last_fetched_at", nil). // NULLS FIRST is default for ascending order
-		Limit(limit, "").
-		ExecuteTo(&feeds)
+	var feeds []database.PublicFeedsSelect
+	err = r.db.CallRPC("claim_feeds_for_fetch", map[string]any{
+		"p_owner":         owner,
+		"p_limit":         limit,
+		"p_lease_seconds": int(lease.Seconds()),
+	}, &feeds)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to find feeds due for fetch: %w", err)
+		return nil, fmt.Errorf("failed to claim feeds for fetch: %w", err)
 	}
 
 	return feeds, nil
 }
 
-// FindFeedByID retrieves a single feed by ID (for immediate fetch)
-func (r *Repository) FindFeedByID(ctx context.Context, feedID string) (*database.PublicFeedsSelect, error) {
-	var feed database.PublicFeedsSelect
-	_, err := r.db.From("feeds").
-		Select("*", "", false).
+// ClaimFeedByID leases a single feed for an immediate fetch
+// Returns nil without error when another instance holds an active lease on the feed
+func (r *Repository) ClaimFeedByID(ctx context.Context, feedID, owner string, lease time.Duration) (_ *database.PublicFeedsSelect, err error) {
+	_, span := tracing.Start(ctx, "fetcher.Repository.ClaimFeedByID")
+	defer func() { tracing.End(span, err) }()
+
+	var feeds []database.PublicFeedsSelect
+	err = r.db.CallRPC("claim_feed_for_fetch", map[string]any{
+		"p_feed_id":       feedID,
+		"p_owner":         owner,
+		"p_lease_seconds": int(lease.Seconds()),
+	}, &feeds)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim feed by id: %w", err)
+	}
+
+	if len(feeds) == 0 {
+		return nil, nil
+	}
+
+	return &feeds[0], nil
+}
+
+// ExtendFeedLease pushes back the expiry of a lease held by the owner
+// Returns false when the lease was lost to another instance
+func (r *Repository) ExtendFeedLease(ctx context.Context, feedID, owner string, lease time.Duration) (_ bool, err error) {
+	_, span := tracing.Start(ctx, "fetcher.Repository.ExtendFeedLease")
+	defer func() { tracing.End(span, err) }()
+
+	var extended bool
+	err = r.db.CallRPC("extend_feed_lease", map[string]any{
+		"p_feed_id":       feedID,
+		"p_owner":         owner,
+		"p_lease_seconds": int(lease.Seconds()),
+	}, &extended)
+
+	if err != nil {
+		return false, fmt.Errorf("failed to extend feed lease: %w", err)
+	}
+
+	return extended, nil
+}
+
+// ReleaseFeedLease clears the lease of a feed if it is still held by the owner
+func (r *Repository) ReleaseFeedLease(ctx context.Context, feedID, owner string) (err error) {
+	_, span := tracing.Start(ctx, "fetcher.Repository.ReleaseFeedLease")
+	defer func() { tracing.End(span, err) }()
+
+	_, _, err = r.db.From("feeds").
+		Update(map[string]any{"lease_owner": nil, "lease_expires_at": nil}, "minimal", "").
 		Eq("id", feedID).
-		Single().
-		ExecuteTo(&feed)
+		Eq("lease_owner", owner).
+		Execute()
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to find feed by id: %w", err)
+		return fmt.Errorf("failed to release feed lease: %w", err)
 	}
 
-	return &feed, nil
+	return nil
 }
 
 // UpdateFeedAfterFetch updates feed status after a fetch attempt
-func (r *Repository) UpdateFeedAfterFetch(ctx context.Context, feedID string, update database.PublicFeedsUpdate) error {
+func (r *Repository) UpdateFeedAfterFetch(ctx context.Context, feedID string, update database.PublicFeedsUpdate) (err error) {
+	_, span := tracing.Start(ctx, "fetcher.Repository.UpdateFeedAfterFetch")
+	defer func() { tracing.End(span, err) }()
+
 	var result database.PublicFeedsSelect
-	_, err := r.db.From("feeds").
+	_, err = r.db.From("feeds").
 		Update(update, "", "").
 		Eq("id", feedID).
 		Single().
@@ -75,17 +128,21 @@ func (r *Repository) UpdateFeedAfterFetch(ctx context.Context, feedID string, up
 	return nil
 }
 
-// InsertArticles inserts multiple articles into the database
-// Uses upsert with ON CONFLICT to skip duplicates based on (feed_id, url) unique constraint
-// Setting upsert=true with onConflict tells PostgREST to use ON CONFLICT DO NOTHING behavior
-func (r *Repository) InsertArticles(ctx context.Context, articles []database.PublicArticlesInsert) error {
+// InsertArticles inserts new articles and updates already stored ones
+// Uses upsert with ON CONFLICT on the (feed_id, guid) unique constraint, so articles whose link
+// changed are updated in place; database triggers track real changes in updated_at
+// and keep extracted full content
+func (r *Repository) InsertArticles(ctx context.Context, articles []database.PublicArticlesInsert) (err error) {
+	_, span := tracing.Start(ctx, "fetcher.Repository.InsertArticles")
+	defer func() { tracing.End(span, err) }()
+
 	if len(articles) == 0 {
 		return nil
 	}
 
 	var result []database.PublicArticlesSelect
-	_, err := r.db.From("articles").
-		Insert(articles, true, "feed_id,url", "", "").
+	_, err = r.db.From("articles").
+		Insert(articles, true, "feed_id,guid", "", "").
 		ExecuteTo(&result)
 
 	if err != nil {
@@ -94,3 +151,213 @@ func (r *Repository) InsertArticles(ctx context.Context, articles []database.Pub
 
 	return nil
 }
+
+// FindArticleURLsWithFullContent returns which of the given article URLs already had full content extraction attempted
+func (r *Repository) FindArticleURLsWithFullContent(ctx context.Context, feedID string, urls []string) (_ map[string]bool, err error) {
+	_, span := tracing.Start(ctx, "fetcher.Repository.FindArticleURLsWithFullContent")
+	defer func() { tracing.End(span, err) }()
+
+	processed := make(map[string]bool)
+	if len(urls) == 0 {
+		return processed, nil
+	}
+
+	var articles []struct {
+		URL string `json:"url"`
+	}
+	_, err = r.db.From("articles").
+		Select("url", "", false).
+		Eq("feed_id", feedID).
+		In("url", urls).
+		Not("full_content_fetched_at", "is", "null").
+		ExecuteTo(&articles)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find articles with full content: %w", err)
+	}
+
+	for _, article := range articles {
+		processed[article.URL] = true
+	}
+
+	return processed, nil
+}
+
+// FindArticleGUIDs returns which of the given article GUIDs are already stored for a feed
+func (r *Repository) FindArticleGUIDs(ctx context.Context, feedID string, guids []string) (_ map[string]bool, err error) {
+	_, span := tracing.Start(ctx, "fetcher.Repository.FindArticleGUIDs")
+	defer func() { tracing.End(span, err) }()
+
+	known := make(map[string]bool)
+	if len(guids) == 0 {
+		return known, nil
+	}
+
+	var articles []struct {
+		GUID string `json:"guid"`
+	}
+	_, err = r.db.From("articles").
+		Select("guid", "", false).
+		Eq("feed_id", feedID).
+		In("guid", guids).
+		ExecuteTo(&articles)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find article guids: %w", err)
+	}
+
+	for _, article := range articles {
+		known[article.GUID] = true
+	}
+
+	return known, nil
+}
+
+// AdoptArticleGUIDs moves item GUIDs onto stored articles of the feed that carry their URL as GUID
+// urls and guids are parallel slices of the items; returns how many articles took over a GUID (see adopt_article_guids)
+func (r *Repository) AdoptArticleGUIDs(ctx context.Context, feedID string, urls, guids []string) (_ int, err error) {
+	_, span := tracing.Start(ctx, "fetcher.Repository.AdoptArticleGUIDs")
+	defer func() { tracing.End(span, err) }()
+
+	var adopted int
+	err = r.db.CallRPC("adopt_article_guids", map[string]any{
+		"p_feed_id": feedID,
+		"p_urls":    urls,
+		"p_guids":   guids,
+	}, &adopted)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to adopt article guids: %w", err)
+	}
+
+	return adopted, nil
+}
+
+// InsertFetchLog records a fetch attempt in the feed fetch history
+func (r *Repository) InsertFetchLog(ctx context.Context, entry database.PublicFeedFetchLogInsert) (err error) {
+	_, span := tracing.Start(ctx, "fetcher.Repository.InsertFetchLog")
+	defer func() { tracing.End(span, err) }()
+
+	_, _, err = r.db.From("feed_fetch_log").
+		Insert(entry, false, "", "minimal", "").
+		Execute()
+
+	if err != nil {
+		return fmt.Errorf("failed to insert fetch log: %w", err)
+	}
+
+	return nil
+}
+
+// SaveFeedFavicon stores the site icon of a feed, replacing the cached one
+func (r *Repository) SaveFeedFavicon(ctx context.Context, favicon database.PublicFeedFaviconsInsert) (err error) {
+	_, span := tracing.Start(ctx, "fetcher.Repository.SaveFeedFavicon")
+	defer func() { tracing.End(span, err) }()
+
+	_, _, err = r.db.From("feed_favicons").
+		Insert(favicon, true, "feed_id", "minimal", "").
+		Execute()
+
+	if err != nil {
+		return fmt.Errorf("failed to save feed favicon: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteFetchLogBefore removes fetch history entries created before the given time
+// Returns the number of deleted entries
+func (r *Repository) DeleteFetchLogBefore(ctx context.Context, before time.Time) (_ int64, err error) {
+	_, span := tracing.Start(ctx, "fetcher.Repository.DeleteFetchLogBefore")
+	defer func() { tracing.End(span, err) }()
+
+	_, count, err := r.db.From("feed_fetch_log").
+		Delete("minimal", "exact").
+		Lt("created_at", before.UTC().Format(time.RFC3339)).
+		Execute()
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete fetch log: %w", err)
+	}
+
+	return count, nil
+}
+
+// PruneArticles deletes up to limit articles older than their retention and returns how many were deleted
+// Starred articles, summary sources and the keepPerFeed newest articles of every feed are kept (see prune_articles)
+func (r *Repository) PruneArticles(ctx context.Context, maxAgeDays, keepPerFeed, limit int) (_ int, err error) {
+	_, span := tracing.Start(ctx, "fetcher.Repository.PruneArticles")
+	defer func() { tracing.End(span, err) }()
+
+	var deleted int
+	err = r.db.CallRPC("prune_articles", map[string]any{
+		"p_max_age_days":  maxAgeDays,
+		"p_keep_per_feed": keepPerFeed,
+		"p_limit":         limit,
+	}, &deleted)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune articles: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// FindWebSubSubscription retrieves the WebSub subscription of a feed
+// Returns nil without error when the feed has no subscription
+func (r *Repository) FindWebSubSubscription(ctx context.Context, feedID string) (_ *database.PublicWebsubSubscriptionsSelect, err error) {
+	_, span := tracing.Start(ctx, "fetcher.Repository.FindWebSubSubscription")
+	defer func() { tracing.End(span, err) }()
+
+	var subscriptions []database.PublicWebsubSubscriptionsSelect
+	_, err = r.db.From("websub_subscriptions").
+		Select("*", "", false).
+		Eq("feed_id", feedID).
+		Limit(1, "").
+		ExecuteTo(&subscriptions)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find websub subscription: %w", err)
+	}
+
+	if len(subscriptions) == 0 {
+		return nil, nil
+	}
+
+	return &subscriptions[0], nil
+}
+
+// SaveWebSubSubscription creates or replaces the WebSub subscription of a feed
+func (r *Repository) SaveWebSubSubscription(ctx context.Context, subscription database.PublicWebsubSubscriptionsInsert) (err error) {
+	_, span := tracing.Start(ctx, "fetcher.Repository.SaveWebSubSubscription")
+	defer func() { tracing.End(span, err) }()
+
+	var result []database.PublicWebsubSubscriptionsSelect
+	_, err = r.db.From("websub_subscriptions").
+		Insert(subscription, true, "feed_id", "", "").
+		ExecuteTo(&result)
+
+	if err != nil {
+		return fmt.Errorf("failed to save websub subscription: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateWebSubSubscription updates the WebSub subscription of a feed
+func (r *Repository) UpdateWebSubSubscription(ctx context.Context, feedID string, update database.PublicWebsubSubscriptionsUpdate) (err error) {
+	_, span := tracing.Start(ctx, "fetcher.Repository.UpdateWebSubSubscription")
+	defer func() { tracing.End(span, err) }()
+
+	var result []database.PublicWebsubSubscriptionsSelect
+	_, err = r.db.From("websub_subscriptions").
+		Update(update, "", "").
+		Eq("feed_id", feedID).
+		ExecuteTo(&result)
+
+	if err != nil {
+		return fmt.Errorf("failed to update websub subscription: %w", err)
+	}
+
+	return nil
+}
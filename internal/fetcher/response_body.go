@@ -0,0 +1,129 @@
+package fetcher
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/andybalholm/brotli"
+	"golang.org/x/net/html/charset"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// errBodyTooLarge is returned when a decompressed response body exceeds the size limit
+var errBodyTooLarge = errors.New("response body too large")
+
+// xmlEncodingDecl matches the encoding attribute of an XML declaration at the start of a document
+var xmlEncodingDecl = regexp.MustCompile(`^(\s*<\?xml[^>]*?encoding\s*=\s*)["']([A-Za-z0-9._:-]+)["']`)
+
+// readResponseBody decompresses the response body according to Content-Encoding and reads
+// at most limit bytes of decoded content
+// Returns errBodyTooLarge instead of silently truncating, so oversized feeds (and zip bombs)
+// are reported as such rather than as parse errors
+func readResponseBody(resp *http.Response, limit int64) ([]byte, error) {
+	reader, err := decodeContentEncoding(resp.Body, resp.Header.Get(HeaderContentEncoding))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = reader.Close() }()
+
+	return readLimited(reader, limit)
+}
+
+// readLimited reads at most limit bytes, returning errBodyTooLarge when there is more
+func readLimited(reader io.Reader, limit int64) ([]byte, error) {
+	body, err := io.ReadAll(io.LimitReader(reader, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > limit {
+		return nil, errBodyTooLarge
+	}
+
+	return body, nil
+}
+
+// decodeContentEncoding wraps body with a decompressor for gzip, deflate or br content coding
+func decodeContentEncoding(body io.Reader, contentEncoding string) (io.ReadCloser, error) {
+	switch strings.ToLower(strings.TrimSpace(contentEncoding)) {
+	case "", "identity":
+		return io.NopCloser(body), nil
+	case "gzip", "x-gzip":
+		return gzip.NewReader(body)
+	case "deflate":
+		// "deflate" should be zlib-wrapped, but some servers send a raw deflate stream
+		buffered := bufio.NewReader(body)
+		if header, err := buffered.Peek(2); err == nil && isZlibHeader(header) {
+			return zlib.NewReader(buffered)
+		}
+		return flate.NewReader(buffered), nil
+	case "br":
+		return io.NopCloser(brotli.NewReader(body)), nil
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %q", contentEncoding)
+	}
+}
+
+// isZlibHeader reports whether the two bytes form a valid zlib header (RFC 1950)
+func isZlibHeader(header []byte) bool {
+	return header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0
+}
+
+// toUTF8 transcodes a feed or page body to UTF-8
+// The encoding is taken from a byte order mark, the Content-Type charset, the XML declaration
+// or an HTML meta tag; undeclared non-UTF-8 content falls back to windows-1252
+// The XML declaration is rewritten to UTF-8 so the feed parser doesn't decode the body again
+func toUTF8(body []byte, contentType string) ([]byte, error) {
+	enc, name := detectEncoding(body, contentType)
+	if name == "utf-8" {
+		return body, nil
+	}
+
+	decoded, err := enc.NewDecoder().Bytes(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s content: %w", name, err)
+	}
+	decoded = bytes.TrimPrefix(decoded, []byte("\ufeff"))
+
+	return xmlEncodingDecl.ReplaceAll(decoded, []byte(`${1}"UTF-8"`)), nil
+}
+
+// detectEncoding determines the character encoding of a body
+// A declared UTF-8 charset is ignored when the body is not valid UTF-8,
+// because servers often add it by default regardless of the document encoding
+func detectEncoding(body []byte, contentType string) (encoding.Encoding, string) {
+	enc, name, certain := charset.DetermineEncoding(body, contentType)
+	if certain && (name != "utf-8" || utf8.Valid(body)) {
+		return enc, name
+	}
+
+	head := body
+	if len(head) > 1024 {
+		head = head[:1024]
+	}
+	if match := xmlEncodingDecl.FindSubmatch(head); match != nil {
+		declared, declaredName := charset.Lookup(string(match[2]))
+		if declared != nil && (declaredName != "utf-8" || utf8.Valid(body)) {
+			return declared, declaredName
+		}
+	}
+
+	if utf8.Valid(body) {
+		return encoding.Nop, "utf-8"
+	}
+	if name == "utf-8" {
+		return charmap.Windows1252, "windows-1252"
+	}
+
+	return enc, name
+}
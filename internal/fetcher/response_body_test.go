@@ -0,0 +1,195 @@
+package fetcher
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+)
+
+const testFeedXML = `<?xml version="1.0"?><rss version="2.0"><channel><title>Test</title></channel></rss>`
+
+func compress(t *testing.T, encoding string, data []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch encoding {
+	case "gzip":
+		w = gzip.NewWriter(&buf)
+	case "deflate":
+		w = zlib.NewWriter(&buf)
+	case "raw-deflate":
+		fw, err := flate.NewWriter(&buf, flate.DefaultCompression)
+		require.NoError(t, err)
+		w = fw
+	case "br":
+		w = brotli.NewWriter(&buf)
+	default:
+		t.Fatalf("unknown encoding %q", encoding)
+	}
+
+	_, err := w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestReadResponseBody_Decompression(t *testing.T) {
+	tests := []struct {
+		name            string
+		contentEncoding string
+		compression     string
+	}{
+		{name: "gzip", contentEncoding: "gzip", compression: "gzip"},
+		{name: "x-gzip alias", contentEncoding: "x-gzip", compression: "gzip"},
+		{name: "zlib-wrapped deflate", contentEncoding: "deflate", compression: "deflate"},
+		{name: "raw deflate", contentEncoding: "deflate", compression: "raw-deflate"},
+		{name: "brotli", contentEncoding: "br", compression: "br"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				Header: http.Header{HeaderContentEncoding: []string{tt.contentEncoding}},
+				Body:   io.NopCloser(bytes.NewReader(compress(t, tt.compression, []byte(testFeedXML)))),
+			}
+
+			body, err := readResponseBody(resp, 1024)
+
+			require.NoError(t, err)
+			assert.Equal(t, testFeedXML, string(body))
+		})
+	}
+}
+
+func TestReadResponseBody_LimitAppliesToDecompressedStream(t *testing.T) {
+	// 1 MB of zeros compresses to about 1 KB
+	bomb := compress(t, "gzip", make([]byte, 1024*1024))
+	require.Less(t, len(bomb), 64*1024)
+
+	resp := &http.Response{
+		Header: http.Header{HeaderContentEncoding: []string{"gzip"}},
+		Body:   io.NopCloser(bytes.NewReader(bomb)),
+	}
+
+	_, err := readResponseBody(resp, 64*1024)
+
+	assert.ErrorIs(t, err, errBodyTooLarge)
+}
+
+func TestReadResponseBody_UnsupportedEncoding(t *testing.T) {
+	resp := &http.Response{
+		Header: http.Header{HeaderContentEncoding: []string{"zstd"}},
+		Body:   io.NopCloser(strings.NewReader("data")),
+	}
+
+	_, err := readResponseBody(resp, 1024)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported content encoding")
+}
+
+func TestToUTF8(t *testing.T) {
+	windows1250, err := charmap.Windows1250.NewEncoder().String(`<?xml version="1.0" encoding="windows-1250"?><rss><channel><title>Zażółć gęślą jaźń</title></channel></rss>`)
+	require.NoError(t, err)
+	latin2, err := charmap.ISO8859_2.NewEncoder().String(`<rss><channel><title>Łódź</title></channel></rss>`)
+	require.NoError(t, err)
+	shiftJIS, err := japanese.ShiftJIS.NewEncoder().String(`<?xml version="1.0" encoding="Shift_JIS"?><rss><channel><title>日本語</title></channel></rss>`)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+		contains    string
+		noDecl      string
+	}{
+		{
+			name:        "utf-8 passes through unchanged",
+			body:        `<?xml version="1.0" encoding="UTF-8"?><rss><channel><title>Zażółć</title></channel></rss>`,
+			contentType: "application/rss+xml",
+			contains:    "Zażółć",
+		},
+		{
+			name:        "encoding from xml declaration",
+			body:        windows1250,
+			contentType: "application/rss+xml",
+			contains:    "Zażółć gęślą jaźń",
+			noDecl:      "windows-1250",
+		},
+		{
+			name:        "encoding from content-type charset",
+			body:        latin2,
+			contentType: "text/xml; charset=ISO-8859-2",
+			contains:    "Łódź",
+		},
+		{
+			name:        "multi-byte encoding",
+			body:        shiftJIS,
+			contentType: "application/xml",
+			contains:    "日本語",
+			noDecl:      "Shift_JIS",
+		},
+		{
+			name:        "wrong utf-8 charset header falls back to xml declaration",
+			body:        windows1250,
+			contentType: "application/xml; charset=utf-8",
+			contains:    "Zażółć gęślą jaźń",
+		},
+		{
+			name:        "undeclared non utf-8 content is sniffed as windows-1252",
+			body:        "<rss><channel><title>Caf\xe9</title></channel></rss>",
+			contentType: "application/xml",
+			contains:    "Café",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := toUTF8([]byte(tt.body), tt.contentType)
+
+			require.NoError(t, err)
+			assert.Contains(t, string(result), tt.contains)
+			if tt.noDecl != "" {
+				assert.NotContains(t, string(result), tt.noDecl, "XML declaration should be rewritten to UTF-8")
+			}
+		})
+	}
+}
+
+func TestHandleResponse_TranscodesFeed(t *testing.T) {
+	body, err := charmap.Windows1250.NewEncoder().String(`<?xml version="1.0" encoding="windows-1250"?>
+<rss version="2.0"><channel><title>Blog</title>
+<item><title>Zażółć gęślą jaźń</title><link>https://example.com/1</link><guid>1</guid></item>
+</channel></rss>`)
+	require.NoError(t, err)
+
+	handler := NewHTTPResponseHandler(slog.Default(), 15*time.Minute)
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			HeaderContentType:     []string{"application/rss+xml"},
+			HeaderContentEncoding: []string{"gzip"},
+		},
+		Body: io.NopCloser(bytes.NewReader(compress(t, "gzip", []byte(body)))),
+	}
+
+	decision := handler.HandleResponse(resp, "https://example.com/feed", 1024*1024, 100, 0, nil, nil)
+
+	require.Equal(t, "success", decision.Status)
+	require.Len(t, decision.Articles, 1)
+	assert.Equal(t, "Zażółć gęślą jaźń", decision.Articles[0].Title)
+}
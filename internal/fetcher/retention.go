@@ -0,0 +1,104 @@
+package fetcher
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/tjanas94/vibefeeder/internal/shared/config"
+	"github.com/tjanas94/vibefeeder/internal/shared/metrics"
+)
+
+// RetentionRepository defines the interface for deleting expired articles
+type RetentionRepository interface {
+	PruneArticles(ctx context.Context, maxAgeDays, keepPerFeed, limit int) (int, error)
+}
+
+// RetentionJob periodically deletes articles older than the retention of their owner
+// Articles are deleted in batches, each batch is a short transaction, so fetching and reading
+// are not blocked; starred articles, sources of summaries and the newest articles of every feed are kept
+type RetentionJob struct {
+	repo        RetentionRepository
+	logger      *slog.Logger
+	config      config.RetentionConfig
+	keepPerFeed int // Newest articles kept per feed, they are still in the feed and would be fetched again
+	appCtx      context.Context
+}
+
+// NewRetentionJob creates a new retention job
+// keepPerFeed should match the number of articles saved per fetch (FETCHER_MAX_ARTICLES)
+func NewRetentionJob(repo RetentionRepository, logger *slog.Logger, cfg config.RetentionConfig, keepPerFeed int, appCtx context.Context) *RetentionJob {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &RetentionJob{
+		repo:        repo,
+		logger:      logger,
+		config:      cfg,
+		keepPerFeed: keepPerFeed,
+		appCtx:      appCtx,
+	}
+}
+
+// Start begins the retention loop, deleting expired articles on every interval
+func (j *RetentionJob) Start() {
+	j.logger.Info("Starting article retention job",
+		"interval", j.config.Interval,
+		"max_age_days", j.config.MaxAgeDays,
+		"batch_size", j.config.BatchSize,
+		"keep_per_feed", j.keepPerFeed,
+	)
+
+	ticker := time.NewTicker(j.config.Interval)
+	defer ticker.Stop()
+
+	// Run immediately on startup
+	j.Run()
+
+	for {
+		select {
+		case <-ticker.C:
+			j.Run()
+		case <-j.appCtx.Done():
+			j.logger.Info("Article retention job shutting down gracefully")
+			return
+		}
+	}
+}
+
+// Run deletes expired articles batch by batch until a batch comes back partial
+// Returns the number of deleted articles; stops early on errors and shutdown
+func (j *RetentionJob) Run() int {
+	start := time.Now()
+	total := 0
+	batches := 0
+
+	for j.appCtx.Err() == nil {
+		deleted, err := j.repo.PruneArticles(j.appCtx, j.config.MaxAgeDays, j.keepPerFeed, j.config.BatchSize)
+		if err != nil {
+			j.logger.Error("Failed to prune articles", "deleted", total, "error", err)
+			break
+		}
+
+		total += deleted
+		batches++
+		metrics.ArticlesPrunedTotal.Add(float64(deleted))
+
+		if deleted < j.config.BatchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		j.logger.Info("Pruned expired articles",
+			"deleted", total,
+			"batches", batches,
+			"duration", time.Since(start),
+		)
+	} else {
+		j.logger.Debug("No expired articles to prune")
+	}
+
+	return total
+}
@@ -0,0 +1,90 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tjanas94/vibefeeder/internal/shared/config"
+)
+
+// MockRetentionRepository is a mock implementation of RetentionRepository
+type MockRetentionRepository struct {
+	PruneArticlesFunc func(ctx context.Context, maxAgeDays, keepPerFeed, limit int) (int, error)
+}
+
+func (m *MockRetentionRepository) PruneArticles(ctx context.Context, maxAgeDays, keepPerFeed, limit int) (int, error) {
+	return m.PruneArticlesFunc(ctx, maxAgeDays, keepPerFeed, limit)
+}
+
+func newTestRetentionConfig() config.RetentionConfig {
+	return config.RetentionConfig{MaxAgeDays: 30, Interval: time.Hour, BatchSize: 100}
+}
+
+func TestRetentionJob_RunDeletesUntilPartialBatch(t *testing.T) {
+	results := []int{100, 100, 42}
+	calls := 0
+	repo := &MockRetentionRepository{
+		PruneArticlesFunc: func(ctx context.Context, maxAgeDays, keepPerFeed, limit int) (int, error) {
+			assert.Equal(t, 30, maxAgeDays)
+			assert.Equal(t, 50, keepPerFeed)
+			assert.Equal(t, 100, limit)
+			deleted := results[calls]
+			calls++
+			return deleted, nil
+		},
+	}
+	job := NewRetentionJob(repo, nil, newTestRetentionConfig(), 50, context.Background())
+
+	assert.Equal(t, 242, job.Run())
+	assert.Equal(t, 3, calls)
+}
+
+func TestRetentionJob_RunNothingExpired(t *testing.T) {
+	calls := 0
+	repo := &MockRetentionRepository{
+		PruneArticlesFunc: func(ctx context.Context, maxAgeDays, keepPerFeed, limit int) (int, error) {
+			calls++
+			return 0, nil
+		},
+	}
+	job := NewRetentionJob(repo, nil, newTestRetentionConfig(), 50, context.Background())
+
+	assert.Equal(t, 0, job.Run())
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetentionJob_RunStopsOnError(t *testing.T) {
+	calls := 0
+	repo := &MockRetentionRepository{
+		PruneArticlesFunc: func(ctx context.Context, maxAgeDays, keepPerFeed, limit int) (int, error) {
+			calls++
+			if calls == 2 {
+				return 0, errors.New("statement timeout")
+			}
+			return 100, nil
+		},
+	}
+	job := NewRetentionJob(repo, nil, newTestRetentionConfig(), 50, context.Background())
+
+	assert.Equal(t, 100, job.Run())
+	assert.Equal(t, 2, calls)
+}
+
+func TestRetentionJob_RunStopsOnShutdown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	repo := &MockRetentionRepository{
+		PruneArticlesFunc: func(ctx context.Context, maxAgeDays, keepPerFeed, limit int) (int, error) {
+			calls++
+			cancel() // Shutdown while the first batch runs
+			return 100, nil
+		},
+	}
+	job := NewRetentionJob(repo, nil, newTestRetentionConfig(), 50, ctx)
+
+	assert.Equal(t, 100, job.Run())
+	assert.Equal(t, 1, calls)
+}
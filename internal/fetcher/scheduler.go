@@ -3,10 +3,14 @@ package fetcher
 import (
 	"context"
 	"log/slog"
+	"sync/atomic"
 	"time"
 
 	"github.com/tjanas94/vibefeeder/internal/shared/config"
 	"github.com/tjanas94/vibefeeder/internal/shared/database"
+	"github.com/tjanas94/vibefeeder/internal/shared/metrics"
+	"github.com/tjanas94/vibefeeder/internal/shared/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // Scheduler orchestrates batch feed processing with timing and worker coordination
@@ -16,10 +20,16 @@ type Scheduler struct {
 	rateLimiter     *RateLimiter
 	feedFetcher     *FeedFetcher
 	statusManager   *FeedStatusManager
+	leases          *FeedLeaseManager
+	polling         *AdaptivePolling
+	websub          *WebSubManager // nil when WebSub is disabled
+	extractor       *ContentExtractor
+	favicons        *FaviconFetcher
 	logger          *slog.Logger
 	config          config.FetcherConfig
 	appCtx          context.Context
 	cleanupInterval time.Duration
+	lastBatchAt     atomic.Int64 // Unix nanoseconds of the last completed batch, 0 before the first one
 }
 
 // NewScheduler creates a new scheduler
@@ -29,6 +39,11 @@ func NewScheduler(
 	rateLimiter *RateLimiter,
 	feedFetcher *FeedFetcher,
 	statusManager *FeedStatusManager,
+	leases *FeedLeaseManager,
+	polling *AdaptivePolling,
+	websub *WebSubManager,
+	extractor *ContentExtractor,
+	favicons *FaviconFetcher,
 	logger *slog.Logger,
 	cfg config.FetcherConfig,
 	appCtx context.Context,
@@ -43,6 +58,11 @@ func NewScheduler(
 		rateLimiter:     rateLimiter,
 		feedFetcher:     feedFetcher,
 		statusManager:   statusManager,
+		leases:          leases,
+		polling:         polling,
+		websub:          websub,
+		extractor:       extractor,
+		favicons:        favicons,
 		logger:          logger,
 		config:          cfg,
 		appCtx:          appCtx,
@@ -56,6 +76,7 @@ func (s *Scheduler) Start() {
 		"fetch_interval", s.config.FetchInterval,
 		"worker_count", s.config.WorkerCount,
 		"domain_delay", s.config.DomainDelay,
+		"lease_owner", s.leases.Owner(),
 	)
 
 	ticker := time.NewTicker(s.config.FetchInterval)
@@ -75,22 +96,32 @@ func (s *Scheduler) Start() {
 	}
 }
 
-// ProcessBatch fetches and processes all feeds due for fetching
+// ProcessBatch claims and processes feeds due for fetching
+// Feeds are leased to this instance, so other instances running the scheduler skip them
 func (s *Scheduler) ProcessBatch() {
 	s.logger.Info("Starting feed processing batch")
 
+	batchStart := time.Now()
+	defer func() {
+		metrics.FetcherBatchDuration.Observe(time.Since(batchStart).Seconds())
+	}()
+
 	// Clean old rate limiting entries to prevent memory leak
 	s.rateLimiter.CleanOldEntries(time.Now().Add(-s.cleanupInterval))
 
-	// Query database for feeds ready to be fetched
-	feeds, err := s.repo.FindFeedsDueForFetch(s.appCtx, s.config.BatchSize)
+	// Prune fetch history older than the retention period
+	s.pruneFetchLog()
+
+	// Claim feeds ready to be fetched
+	feeds, err := s.leases.ClaimDue(s.appCtx, s.config.BatchSize)
 	if err != nil {
-		s.logger.Error("Failed to find feeds due for fetch", "error", err)
+		s.logger.Error("Failed to claim feeds due for fetch", "error", err)
 		return
 	}
 
 	if len(feeds) == 0 {
 		s.logger.Info("No feeds due for fetch")
+		s.lastBatchAt.Store(time.Now().UnixNano())
 		return
 	}
 
@@ -102,6 +133,29 @@ func (s *Scheduler) ProcessBatch() {
 	})
 
 	s.logger.Info("Feed processing batch completed", "processed", len(feeds))
+	s.lastBatchAt.Store(time.Now().UnixNano())
+}
+
+// LastBatchAt returns when the last batch completed, or the zero time if none has yet
+// Batches that failed to claim feeds don't count as completed
+func (s *Scheduler) LastBatchAt() time.Time {
+	nanos := s.lastBatchAt.Load()
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
+
+// pruneFetchLog deletes fetch history entries older than the configured retention
+func (s *Scheduler) pruneFetchLog() {
+	deleted, err := s.repo.DeleteFetchLogBefore(s.appCtx, time.Now().Add(-s.config.FetchLogRetention))
+	if err != nil {
+		s.logger.Error("Failed to prune fetch log", "error", err)
+		return
+	}
+	if deleted > 0 {
+		s.logger.Info("Pruned fetch log", "deleted", deleted)
+	}
 }
 
 // FetchSingleFeedByID fetches a specific feed by ID immediately
@@ -111,10 +165,14 @@ func (s *Scheduler) ProcessBatch() {
 func (s *Scheduler) FetchSingleFeedByID(feedID string) {
 	s.logger.Info("Immediate fetch requested", "feed_id", feedID)
 
-	// Query database for specific feed
-	feed, err := s.repo.FindFeedByID(s.appCtx, feedID)
+	// Claim the feed so a batch running on another instance doesn't fetch it at the same time
+	feed, err := s.leases.ClaimByID(s.appCtx, feedID)
 	if err != nil {
-		s.logger.Error("Failed to find feed for immediate fetch", "feed_id", feedID, "error", err)
+		s.logger.Error("Failed to claim feed for immediate fetch", "feed_id", feedID, "error", err)
+		return
+	}
+	if feed == nil {
+		s.logger.Info("Feed is already being fetched by another instance", "feed_id", feedID)
 		return
 	}
 
@@ -128,22 +186,108 @@ func (s *Scheduler) FetchSingleFeedByID(feedID string) {
 
 // processSingleFeed processes a single feed
 // Shared by both batch processing and immediate fetch
-// Implements the complete processing pipeline: rate limiting → fetching → decision application
+// Implements the complete processing pipeline: lease renewal → rate limiting → fetching → decision application
+// The feed must be claimed by this instance; its lease is released when processing ends
 func (s *Scheduler) processSingleFeed(feed database.PublicFeedsSelect) {
-	// Create job context with timeout
+	// Renew the lease for the duration of the job; the feed may have waited in the
+	// worker queue long enough for its lease to expire and be reclaimed elsewhere
+	if !s.leases.Extend(s.appCtx, feed.Id) {
+		return
+	}
+	defer s.leases.Release(s.appCtx, feed.Id)
+
+	// Create job context with timeout; every job is traced as its own trace
 	jobCtx, cancel := context.WithTimeout(s.appCtx, s.config.JobTimeout)
 	defer cancel()
+	jobCtx, span := tracing.Start(jobCtx, "fetcher.Scheduler.processSingleFeed",
+		attribute.String("feed.id", feed.Id),
+		attribute.String("feed.url", feed.Url),
+	)
+	defer span.End()
 
 	s.logger.Debug("Processing feed", "feed_id", feed.Id, "url", feed.Url)
 
 	// Apply rate limiting for this domain
 	if err := s.rateLimiter.WaitIfNeeded(jobCtx, feed.Url); err != nil {
 		s.logger.Debug("Rate limiting cancelled", "feed_id", feed.Id, "error", err)
+		span.SetAttributes(attribute.String("fetch.status", "cancelled"))
 		return
 	}
 
 	// Fetch the feed
+	fetchStart := time.Now()
 	decision := s.feedFetcher.Fetch(jobCtx, feed, feed.RetryCount)
+	decision.Duration = time.Since(fetchStart)
+	span.SetAttributes(attribute.String("fetch.status", decision.Status))
+
+	// Refresh the cached site icon (weekly), limited so the fetch result is always saved
+	faviconCtx, cancelFavicon := context.WithTimeout(jobCtx, s.config.JobTimeout/4)
+	decision = s.favicons.Apply(faviconCtx, feed, decision, time.Now())
+	cancelFavicon()
+
+	// Learn polling interval from publishing cadence and fetch outcome
+	decision = s.polling.Apply(feed, decision, time.Now())
+
+	// Subscribe to advertised WebSub hub; postpones polling while push is active
+	if s.websub != nil {
+		decision = s.websub.ApplySubscription(jobCtx, feed, decision)
+	}
+
+	s.applyDecision(jobCtx, feed, decision)
+}
+
+// ApplyPushedDecision processes articles pushed by a WebSub hub for a feed
+// The feed is claimed like for an immediate fetch and processed by the worker pool in the background
+// Returns ErrWebSubFeedBusy when another job holds the feed
+func (s *Scheduler) ApplyPushedDecision(ctx context.Context, feedID string, decision FetchDecision) error {
+	feed, err := s.leases.ClaimByID(ctx, feedID)
+	if err != nil {
+		return err
+	}
+	if feed == nil {
+		s.logger.Info("Feed is being fetched, websub notification is deferred", "feed_id", feedID)
+		return ErrWebSubFeedBusy
+	}
+
+	go s.workerPool.ProcessFeeds(s.appCtx, []database.PublicFeedsSelect{*feed},
+		func(f database.PublicFeedsSelect) {
+			s.processPushedFeed(f, decision)
+		})
+
+	return nil
+}
+
+// processPushedFeed applies the decision built from a WebSub notification
+// Nothing is fetched, so there is no rate limiting, favicon refresh or polling update
+func (s *Scheduler) processPushedFeed(feed database.PublicFeedsSelect, decision FetchDecision) {
+	if !s.leases.Extend(s.appCtx, feed.Id) {
+		return
+	}
+	defer s.leases.Release(s.appCtx, feed.Id)
+
+	jobCtx, cancel := context.WithTimeout(s.appCtx, s.config.JobTimeout)
+	defer cancel()
+	jobCtx, span := tracing.Start(jobCtx, "fetcher.Scheduler.processPushedFeed",
+		attribute.String("feed.id", feed.Id),
+		attribute.String("feed.url", feed.Url),
+	)
+	defer span.End()
+
+	s.applyDecision(jobCtx, feed, decision)
+}
+
+// applyDecision enriches the articles of a decision and stores it
+// Shared by fetched and pushed feeds, so both save articles, fetch history and metrics the same way
+func (s *Scheduler) applyDecision(jobCtx context.Context, feed database.PublicFeedsSelect, decision FetchDecision) {
+	// Download full article text for feeds that only publish excerpts
+	// Limited to half of the job timeout so the decision is always saved
+	if feed.FetchFullContent && decision.Status == "success" && len(decision.Articles) > 0 {
+		extractCtx, cancelExtract := context.WithTimeout(jobCtx, s.config.JobTimeout/2)
+		s.extractor.Enrich(extractCtx, feed, decision.Articles)
+		cancelExtract()
+	}
+
+	metrics.FetcherFeedsTotal.WithLabelValues(decision.Status).Inc()
 
 	// Apply decision to database
 	if err := s.statusManager.ApplyDecision(jobCtx, feed, decision); err != nil {
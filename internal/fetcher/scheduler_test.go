@@ -0,0 +1,74 @@
+package fetcher
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tjanas94/vibefeeder/internal/shared/config"
+	"github.com/tjanas94/vibefeeder/internal/shared/database"
+)
+
+// TestSchedulerProcessPushedFeed tests that pushed articles are stored like fetched ones
+func TestSchedulerProcessPushedFeed(t *testing.T) {
+	newScheduler := func(repo *MockFetcherRepository) *Scheduler {
+		return &Scheduler{
+			statusManager: NewFeedStatusManager(repo, slog.Default()),
+			leases:        NewFeedLeaseManager(repo, time.Minute, slog.Default()),
+			logger:        slog.Default(),
+			config:        config.FetcherConfig{JobTimeout: time.Minute},
+			appCtx:        context.Background(),
+		}
+	}
+	feed := database.PublicFeedsSelect{Id: "feed-1", Url: "https://example.com/feed"}
+	decision := FetchDecision{
+		Status:    "success",
+		Articles:  []Article{{GUID: "pushed", Title: "Pushed", URL: "https://example.com/pushed"}},
+		BytesRead: 512,
+	}
+
+	t.Run("saves articles and fetch history", func(t *testing.T) {
+		repo := &MockFetcherRepository{}
+
+		newScheduler(repo).processPushedFeed(feed, decision)
+
+		require.NotNil(t, repo.InsertArticleCall)
+		require.Len(t, repo.InsertArticleCall.Articles, 1)
+		assert.Equal(t, "feed-1", repo.InsertArticleCall.Articles[0].FeedId)
+		require.Len(t, repo.FetchLogEntries, 1)
+		assert.Equal(t, "success", repo.FetchLogEntries[0].Status)
+		assert.Len(t, repo.UpdateFeedCalls, 1)
+	})
+
+	t.Run("skips feed when lease is lost", func(t *testing.T) {
+		repo := &MockFetcherRepository{
+			ExtendFeedLeaseFunc: func(ctx context.Context, feedID, owner string, lease time.Duration) (bool, error) {
+				return false, nil
+			},
+		}
+
+		newScheduler(repo).processPushedFeed(feed, decision)
+
+		assert.Nil(t, repo.InsertArticleCall)
+		assert.Empty(t, repo.FetchLogEntries)
+	})
+}
+
+// TestSchedulerApplyPushedDecisionBusy tests that a push for a feed being fetched is reported as busy
+func TestSchedulerApplyPushedDecisionBusy(t *testing.T) {
+	// ClaimFeedByID returns no row while the feed is leased, also by this instance
+	repo := &MockFetcherRepository{}
+	s := &Scheduler{
+		leases: NewFeedLeaseManager(repo, time.Minute, slog.Default()),
+		logger: slog.Default(),
+		appCtx: context.Background(),
+	}
+
+	err := s.ApplyPushedDecision(context.Background(), "feed-1", FetchDecision{Status: "success"})
+
+	assert.ErrorIs(t, err, ErrWebSubFeedBusy)
+	assert.Nil(t, repo.InsertArticleCall)
+}
@@ -2,19 +2,38 @@ package fetcher
 
 import (
 	"context"
+	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/tjanas94/vibefeeder/internal/shared/config"
+	"github.com/tjanas94/vibefeeder/internal/shared/credentials"
 	"github.com/tjanas94/vibefeeder/internal/shared/database"
 )
 
 // FetcherRepository defines the interface for fetcher data access
 type FetcherRepository interface {
-	FindFeedsDueForFetch(ctx context.Context, limit int) ([]database.PublicFeedsSelect, error)
-	FindFeedByID(ctx context.Context, feedID string) (*database.PublicFeedsSelect, error)
+	ClaimFeedsForFetch(ctx context.Context, owner string, limit int, lease time.Duration) ([]database.PublicFeedsSelect, error)
+	ClaimFeedByID(ctx context.Context, feedID, owner string, lease time.Duration) (*database.PublicFeedsSelect, error)
+	ExtendFeedLease(ctx context.Context, feedID, owner string, lease time.Duration) (bool, error)
+	ReleaseFeedLease(ctx context.Context, feedID, owner string) error
 	UpdateFeedAfterFetch(ctx context.Context, feedID string, update database.PublicFeedsUpdate) error
 	InsertArticles(ctx context.Context, articles []database.PublicArticlesInsert) error
+	FindArticleURLsWithFullContent(ctx context.Context, feedID string, urls []string) (map[string]bool, error)
+	FindArticleGUIDs(ctx context.Context, feedID string, guids []string) (map[string]bool, error)
+	AdoptArticleGUIDs(ctx context.Context, feedID string, urls, guids []string) (int, error)
+	InsertFetchLog(ctx context.Context, entry database.PublicFeedFetchLogInsert) error
+	SaveFeedFavicon(ctx context.Context, favicon database.PublicFeedFaviconsInsert) error
+	DeleteFetchLogBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// WebSubRepository defines the interface for WebSub subscription data access
+type WebSubRepository interface {
+	FindWebSubSubscription(ctx context.Context, feedID string) (*database.PublicWebsubSubscriptionsSelect, error)
+	SaveWebSubSubscription(ctx context.Context, subscription database.PublicWebsubSubscriptionsInsert) error
+	UpdateWebSubSubscription(ctx context.Context, feedID string, update database.PublicWebsubSubscriptionsUpdate) error
 }
 
 // HTTPClientInterface defines the interface for HTTP client operations
@@ -22,19 +41,29 @@ type HTTPClientInterface interface {
 	ExecuteRequest(ctx context.Context, params ExecuteRequestParams) (*http.Response, error)
 }
 
+// HubClientInterface defines the interface for sending WebSub subscription requests
+type HubClientInterface interface {
+	PostForm(ctx context.Context, targetURL string, form url.Values) (*http.Response, error)
+}
+
 // FeedFetcherService is the main orchestrator for feed fetching operations
 // Delegates all processing to Scheduler to ensure consistency and reuse of processing pipeline
 type FeedFetcherService struct {
-	scheduler *Scheduler
-	logger    *slog.Logger
-	appCtx    context.Context
+	scheduler   *Scheduler
+	feedFetcher *FeedFetcher
+	websub      *WebSubManager // nil when WebSub is disabled
+	logger      *slog.Logger
+	appCtx      context.Context
 }
 
 // NewFeedFetcherService creates a new feed fetcher service instance
 // Initializes all sub-components and sets up dependencies
 func NewFeedFetcherService(
 	repo FetcherRepository,
+	websubRepo WebSubRepository,
 	httpClient HTTPClientInterface,
+	hubClient HubClientInterface,
+	credentialCipher *credentials.Cipher,
 	logger *slog.Logger,
 	cfg config.FetcherConfig,
 	appCtx context.Context,
@@ -53,14 +82,40 @@ func NewFeedFetcherService(
 		logger,
 		cfg.MaxResponseBodySize,
 		cfg.MaxArticlesPerFeed,
+		credentialCipher,
 	)
 
 	// Create status manager
 	statusManager := NewFeedStatusManager(repo, logger)
 
+	// Create lease manager (coordinates feed ownership between instances)
+	leaseManager := NewFeedLeaseManager(repo, cfg.LeaseDuration, logger)
+
+	// Create adaptive polling policy
+	polling := NewAdaptivePolling(cfg.SuccessInterval, cfg.MinInterval, cfg.MaxInterval, logger)
+
+	// Create WebSub manager (push subscriptions require a public callback URL)
+	var websub *WebSubManager
+	if cfg.WebSubCallbackURL != "" {
+		websub = NewWebSubManager(websubRepo, hubClient, logger, cfg)
+	}
+
 	// Create rate limiter
 	rateLimiter := NewRateLimiter(cfg.DomainDelay)
 
+	// Create full content extractor (shares the rate limiter with feed fetching)
+	contentExtractor := NewContentExtractor(
+		repo,
+		httpClient,
+		rateLimiter,
+		logger,
+		cfg.MaxResponseBodySize,
+		cfg.FullContentMaxArticles,
+	)
+
+	// Create favicon fetcher (site icons shown in the feed list)
+	faviconFetcher := NewFaviconFetcher(httpClient, rateLimiter, logger)
+
 	// Create worker pool
 	workerPool := NewWorkerPool(cfg.WorkerCount, logger)
 
@@ -71,15 +126,22 @@ func NewFeedFetcherService(
 		rateLimiter,
 		feedFetcher,
 		statusManager,
+		leaseManager,
+		polling,
+		websub,
+		contentExtractor,
+		faviconFetcher,
 		logger,
 		cfg,
 		appCtx,
 	)
 
 	return &FeedFetcherService{
-		scheduler: scheduler,
-		logger:    logger,
-		appCtx:    appCtx,
+		scheduler:   scheduler,
+		feedFetcher: feedFetcher,
+		websub:      websub,
+		logger:      logger,
+		appCtx:      appCtx,
 	}
 }
 
@@ -95,3 +157,45 @@ func (s *FeedFetcherService) Start() {
 func (s *FeedFetcherService) FetchFeedNow(feedID string) {
 	go s.scheduler.FetchSingleFeedByID(feedID)
 }
+
+// ResolveFeedURL checks a URL before a feed is added and returns the URL of the feed to subscribe to
+// Returns a *FeedURLError when the URL is not a feed and no feed was discovered on the page
+func (s *FeedFetcherService) ResolveFeedURL(ctx context.Context, rawURL string, creds *credentials.FeedCredentials) (string, error) {
+	return s.feedFetcher.ResolveFeedURL(ctx, rawURL, creds)
+}
+
+// LastBatchAt returns when the scheduler last completed a batch (zero time if none has yet)
+func (s *FeedFetcherService) LastBatchAt() time.Time {
+	return s.scheduler.LastBatchAt()
+}
+
+// VerifyWebSubIntent answers a hub's intent verification request for a feed
+func (s *FeedFetcherService) VerifyWebSubIntent(ctx context.Context, feedID string, verification WebSubVerification) (string, error) {
+	if s.websub == nil {
+		return "", ErrWebSubUnknownSubscription
+	}
+	return s.websub.VerifyIntent(ctx, feedID, verification)
+}
+
+// HandleWebSubNotification saves articles pushed by a hub
+// Pushed articles go through the same pipeline as fetched ones (full content, fetch history);
+// notifications without feed content trigger an immediate fetch instead
+// Returns ErrWebSubFeedBusy when the feed is being fetched, so the hub delivers the notification again
+func (s *FeedFetcherService) HandleWebSubNotification(ctx context.Context, feedID string, body io.Reader, signature string) error {
+	if s.websub == nil {
+		return ErrWebSubUnknownSubscription
+	}
+
+	decision, refetch, err := s.websub.HandleNotification(ctx, feedID, body, signature)
+	if err != nil {
+		return err
+	}
+	if refetch {
+		s.FetchFeedNow(feedID)
+	}
+	if decision == nil {
+		return nil
+	}
+
+	return s.scheduler.ApplyPushedDecision(ctx, feedID, *decision)
+}
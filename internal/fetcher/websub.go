@@ -0,0 +1,562 @@
+package fetcher
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/tjanas94/vibefeeder/internal/shared/config"
+	"github.com/tjanas94/vibefeeder/internal/shared/database"
+)
+
+// WebSub subscription statuses stored in websub_subscriptions.status
+const (
+	WebSubStatusPending = "pending"
+	WebSubStatusActive  = "active"
+	WebSubStatusDenied  = "denied"
+)
+
+const (
+	// websubRenewMargin is how long before lease expiry the subscription is renewed
+	websubRenewMargin = time.Hour
+
+	// websubResubscribeDelay throttles new subscription requests for pending or denied subscriptions
+	websubResubscribeDelay = 6 * time.Hour
+)
+
+// WebSub errors returned to the callback handler
+var (
+	ErrWebSubUnknownSubscription = errors.New("unknown websub subscription")
+	ErrWebSubIntentRejected      = errors.New("websub intent verification rejected")
+	ErrWebSubFeedBusy            = errors.New("websub feed is being processed")
+	ErrWebSubPayloadTooLarge     = errors.New("websub notification exceeds the size limit")
+)
+
+// WebSubVerification contains the hub.* query parameters of an intent verification request
+type WebSubVerification struct {
+	Mode         string
+	Topic        string
+	Challenge    string
+	LeaseSeconds string
+	Reason       string
+}
+
+// WebSubManager subscribes feeds to WebSub hubs and processes hub callbacks
+// While a subscription is active, polling falls back to a long interval capped by the lease
+type WebSubManager struct {
+	repo             WebSubRepository
+	hubClient        HubClientInterface
+	logger           *slog.Logger
+	callbackURL      string
+	leaseDuration    time.Duration
+	pollInterval     time.Duration
+	maxBodySize      int64
+	maxArticlesCount int
+}
+
+// NewWebSubManager creates a new WebSub manager
+func NewWebSubManager(
+	repo WebSubRepository,
+	hubClient HubClientInterface,
+	logger *slog.Logger,
+	cfg config.FetcherConfig,
+) *WebSubManager {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &WebSubManager{
+		repo:             repo,
+		hubClient:        hubClient,
+		logger:           logger,
+		callbackURL:      strings.TrimRight(cfg.WebSubCallbackURL, "/"),
+		leaseDuration:    cfg.WebSubLeaseDuration,
+		pollInterval:     cfg.WebSubPollInterval,
+		maxBodySize:      cfg.MaxResponseBodySize,
+		maxArticlesCount: cfg.MaxArticlesPerFeed,
+	}
+}
+
+// ApplySubscription subscribes or renews the subscription of a feed that advertises a hub
+// and postpones the next poll while an active lease covers the feed
+func (m *WebSubManager) ApplySubscription(
+	ctx context.Context,
+	feed database.PublicFeedsSelect,
+	decision FetchDecision,
+) FetchDecision {
+	if decision.Status != "success" || decision.HubURL == nil {
+		return decision
+	}
+
+	sub, err := m.repo.FindWebSubSubscription(ctx, feed.Id)
+	if err != nil {
+		m.logger.Error("Failed to load websub subscription", "feed_id", feed.Id, "error", err)
+		return decision
+	}
+
+	hubURL := *decision.HubURL
+	topicURL := feed.Url
+	if decision.NewURL != nil {
+		topicURL = *decision.NewURL
+	}
+	if decision.TopicURL != nil {
+		topicURL = *decision.TopicURL
+	}
+
+	now := time.Now()
+	if needsSubscription(sub, hubURL, topicURL, now) {
+		m.subscribe(ctx, feed.Id, hubURL, topicURL, sub, now)
+		return decision
+	}
+
+	if leaseExpiry, ok := activeLeaseExpiry(sub, now); ok {
+		decision.NextFetchTime = fallbackFetchTime(decision.NextFetchTime, leaseExpiry, m.pollInterval, now)
+		m.logger.Debug("Feed is pushed by websub hub, postponing poll", "feed_id", feed.Id, "next_fetch", decision.NextFetchTime)
+	}
+
+	return decision
+}
+
+// subscribe stores the subscription and sends a subscription request to the hub
+// The hub confirms asynchronously by calling the intent verification endpoint
+func (m *WebSubManager) subscribe(
+	ctx context.Context,
+	feedID string,
+	hubURL string,
+	topicURL string,
+	existing *database.PublicWebsubSubscriptionsSelect,
+	now time.Time,
+) {
+	sameTarget := existing != nil && existing.HubUrl == hubURL && existing.TopicUrl == topicURL
+
+	// Keep the secret for renewals so in-flight notifications still verify
+	secret := ""
+	if sameTarget {
+		secret = existing.Secret
+	} else {
+		var err error
+		if secret, err = generateWebSubSecret(); err != nil {
+			m.logger.Error("Failed to generate websub secret", "feed_id", feedID, "error", err)
+			return
+		}
+	}
+
+	// Renewals keep the current lease until the hub verifies the new one
+	status := WebSubStatusPending
+	var leaseExpiresAt *string
+	_, renewing := activeLeaseExpiry(existing, now)
+	renewing = renewing && sameTarget
+	if renewing {
+		status = WebSubStatusActive
+		leaseExpiresAt = existing.LeaseExpiresAt
+	}
+
+	if err := m.repo.SaveWebSubSubscription(ctx, database.PublicWebsubSubscriptionsInsert{
+		FeedId:         feedID,
+		HubUrl:         hubURL,
+		TopicUrl:       topicURL,
+		Secret:         secret,
+		Status:         &status,
+		LeaseExpiresAt: leaseExpiresAt,
+	}); err != nil {
+		m.logger.Error("Failed to save websub subscription", "feed_id", feedID, "error", err)
+		return
+	}
+
+	form := url.Values{}
+	form.Set("hub.callback", m.callbackURL+"/"+feedID)
+	form.Set("hub.mode", "subscribe")
+	form.Set("hub.topic", topicURL)
+	form.Set("hub.secret", secret)
+	form.Set("hub.lease_seconds", strconv.Itoa(int(m.leaseDuration.Seconds())))
+
+	m.logger.Info("Subscribing to websub hub", "feed_id", feedID, "hub", hubURL, "topic", topicURL, "renewal", renewing)
+
+	requestErr := m.postSubscription(ctx, hubURL, form)
+	if requestErr == nil {
+		return
+	}
+
+	m.logger.Warn("Websub subscription request failed", "feed_id", feedID, "hub", hubURL, "error", requestErr)
+
+	errorMsg := requestErr.Error()
+	update := database.PublicWebsubSubscriptionsUpdate{LastError: &errorMsg}
+	if !renewing {
+		// Fall back to polling; retried after websubResubscribeDelay
+		deniedStatus := WebSubStatusDenied
+		update.Status = &deniedStatus
+	}
+	if err := m.repo.UpdateWebSubSubscription(ctx, feedID, update); err != nil {
+		m.logger.Error("Failed to update websub subscription", "feed_id", feedID, "error", err)
+	}
+}
+
+// postSubscription sends a subscription request and checks that the hub accepted it
+func (m *WebSubManager) postSubscription(ctx context.Context, hubURL string, form url.Values) error {
+	resp, err := m.hubClient.PostForm(ctx, hubURL, form)
+	if err != nil {
+		return fmt.Errorf("hub request failed: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			m.logger.Error("failed to close response body", "error", err)
+		}
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("hub rejected subscription request with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// VerifyIntent handles a hub's intent verification request
+// Returns the challenge to echo back, or ErrWebSubIntentRejected / ErrWebSubUnknownSubscription
+func (m *WebSubManager) VerifyIntent(ctx context.Context, feedID string, v WebSubVerification) (string, error) {
+	sub, err := m.repo.FindWebSubSubscription(ctx, feedID)
+	if err != nil {
+		return "", err
+	}
+
+	if sub == nil {
+		// Confirm unsubscribing from topics we no longer track
+		if v.Mode == "unsubscribe" && v.Challenge != "" {
+			return v.Challenge, nil
+		}
+		return "", ErrWebSubUnknownSubscription
+	}
+
+	if v.Topic != sub.TopicUrl {
+		m.logger.Warn("Websub verification for unexpected topic", "feed_id", feedID, "topic", v.Topic, "expected", sub.TopicUrl)
+		return "", ErrWebSubIntentRejected
+	}
+
+	switch v.Mode {
+	case "subscribe":
+		if v.Challenge == "" {
+			return "", ErrWebSubIntentRejected
+		}
+
+		lease := m.leaseDuration
+		if seconds, err := strconv.Atoi(v.LeaseSeconds); err == nil && seconds > 0 {
+			lease = time.Duration(seconds) * time.Second
+		}
+
+		status := WebSubStatusActive
+		leaseExpiresAt := time.Now().Add(lease).UTC().Format(time.RFC3339)
+		if err := m.repo.UpdateWebSubSubscription(ctx, feedID, database.PublicWebsubSubscriptionsUpdate{
+			Status:         &status,
+			LeaseExpiresAt: &leaseExpiresAt,
+		}); err != nil {
+			return "", err
+		}
+
+		m.logger.Info("Websub subscription verified", "feed_id", feedID, "lease", lease)
+		return v.Challenge, nil
+
+	case "denied":
+		status := WebSubStatusDenied
+		reason := v.Reason
+		if reason == "" {
+			reason = "subscription denied by hub"
+		}
+		if err := m.repo.UpdateWebSubSubscription(ctx, feedID, database.PublicWebsubSubscriptionsUpdate{
+			Status:    &status,
+			LastError: &reason,
+		}); err != nil {
+			return "", err
+		}
+
+		m.logger.Warn("Websub subscription denied by hub", "feed_id", feedID, "reason", reason)
+		return "", nil
+
+	default:
+		// We never unsubscribe while tracking the feed
+		return "", ErrWebSubIntentRejected
+	}
+}
+
+// HandleNotification processes a content distribution request from the hub
+// Returns the decision carrying the pushed articles, to be applied like the decision of a fetch;
+// nil when the notification is ignored or carries no feed content (refetch=true: the feed should be polled)
+func (m *WebSubManager) HandleNotification(
+	ctx context.Context,
+	feedID string,
+	body io.Reader,
+	signature string,
+) (_ *FetchDecision, refetch bool, _ error) {
+	sub, err := m.repo.FindWebSubSubscription(ctx, feedID)
+	if err != nil {
+		return nil, false, err
+	}
+	if sub == nil {
+		return nil, false, ErrWebSubUnknownSubscription
+	}
+
+	// Oversized pushes are rejected before their signature can be checked, so they must not
+	// trigger a fetch either: anyone knowing the callback URL could force fetches of the feed
+	payload, err := readLimited(body, m.maxBodySize)
+	if errors.Is(err, errBodyTooLarge) {
+		m.logger.Warn("Rejecting websub notification exceeding the size limit", "feed_id", feedID, "limit", m.maxBodySize)
+		return nil, false, ErrWebSubPayloadTooLarge
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read notification body: %w", err)
+	}
+
+	// Per spec, unsigned or forged notifications are acknowledged but ignored
+	if !verifyWebSubSignature(sub.Secret, signature, payload) {
+		m.logger.Warn("Ignoring websub notification with invalid signature", "feed_id", feedID)
+		return nil, false, nil
+	}
+
+	parsedFeed, err := gofeed.NewParser().Parse(bytes.NewReader(payload))
+	if err != nil {
+		m.logger.Debug("Websub notification without feed content, scheduling fetch", "feed_id", feedID, "error", err)
+		return nil, true, nil
+	}
+
+	articles := transformFeedItems(parsedFeed.Items, time.Now())
+	if len(articles) > m.maxArticlesCount {
+		articles = articles[:m.maxArticlesCount]
+	}
+
+	m.logger.Info("Received websub notification", "feed_id", feedID, "articles", len(articles))
+
+	// The schedule and conditional headers of the feed are left as they are
+	return &FetchDecision{
+		Status:    "success",
+		Articles:  articles,
+		BytesRead: int64(len(payload)),
+	}, false, nil
+}
+
+// needsSubscription reports whether a (re)subscription request should be sent
+func needsSubscription(
+	sub *database.PublicWebsubSubscriptionsSelect,
+	hubURL string,
+	topicURL string,
+	now time.Time,
+) bool {
+	if sub == nil || sub.HubUrl != hubURL || sub.TopicUrl != topicURL {
+		return true
+	}
+
+	if sub.Status == WebSubStatusActive {
+		leaseExpiry, ok := activeLeaseExpiry(sub, now)
+		return !ok || !now.Before(leaseExpiry.Add(-websubRenewMargin))
+	}
+
+	// Pending or denied - retry only after a while
+	updatedAt, err := time.Parse(time.RFC3339, sub.UpdatedAt)
+	if err != nil {
+		return true
+	}
+	return !now.Before(updatedAt.Add(websubResubscribeDelay))
+}
+
+// activeLeaseExpiry returns the lease expiry of an active, unexpired subscription
+func activeLeaseExpiry(sub *database.PublicWebsubSubscriptionsSelect, now time.Time) (time.Time, bool) {
+	if sub == nil || sub.Status != WebSubStatusActive || sub.LeaseExpiresAt == nil {
+		return time.Time{}, false
+	}
+
+	leaseExpiry, err := time.Parse(time.RFC3339, *sub.LeaseExpiresAt)
+	if err != nil || !leaseExpiry.After(now) {
+		return time.Time{}, false
+	}
+
+	return leaseExpiry, true
+}
+
+// fallbackFetchTime calculates the next poll for a pushed feed
+// Polls at the fallback interval but no later than the renewal window of the lease,
+// and never earlier than the regular schedule
+func fallbackFetchTime(nextFetch, leaseExpiry time.Time, pollInterval time.Duration, now time.Time) time.Time {
+	fallback := now.Add(pollInterval)
+	if renewAt := leaseExpiry.Add(-websubRenewMargin); renewAt.Before(fallback) {
+		fallback = renewAt
+	}
+	if fallback.Before(nextFetch) {
+		return nextFetch
+	}
+	return fallback
+}
+
+// verifyWebSubSignature checks the X-Hub-Signature header ("method=hexdigest") against the payload
+func verifyWebSubSignature(secret, signature string, payload []byte) bool {
+	method, digest, ok := strings.Cut(signature, "=")
+	if !ok {
+		return false
+	}
+
+	var newHash func() hash.Hash
+	switch strings.ToLower(method) {
+	case "sha1":
+		newHash = sha1.New
+	case "sha256":
+		newHash = sha256.New
+	case "sha384":
+		newHash = sha512.New384
+	case "sha512":
+		newHash = sha512.New
+	default:
+		return false
+	}
+
+	expected, err := hex.DecodeString(digest)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(newHash, []byte(secret))
+	mac.Write(payload)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// generateWebSubSecret returns a random hex-encoded secret for signing notifications
+func generateWebSubSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+// discoverWebSubLinks returns the hub and self URLs advertised by a feed
+// Checks HTTP Link headers first, then feed-level <link rel="hub"> / <atom:link rel="hub"> elements
+func discoverWebSubLinks(feedURL string, linkHeaders []string, body []byte) (hubURL, selfURL string) {
+	base, err := url.Parse(feedURL)
+	if err != nil {
+		return "", ""
+	}
+
+	for _, header := range linkHeaders {
+		for _, link := range parseLinkHeader(header) {
+			if hubURL == "" && hasRel(link.rel, "hub") {
+				hubURL = link.href
+			}
+			if selfURL == "" && hasRel(link.rel, "self") {
+				selfURL = link.href
+			}
+		}
+	}
+
+	if hubURL == "" || selfURL == "" {
+		bodyHub, bodySelf := extractWebSubFeedLinks(body)
+		if hubURL == "" {
+			hubURL = bodyHub
+		}
+		if selfURL == "" {
+			selfURL = bodySelf
+		}
+	}
+
+	return resolveHTTPURL(base, hubURL), resolveHTTPURL(base, selfURL)
+}
+
+// extractWebSubFeedLinks scans feed-level <link> elements for rel="hub" and rel="self"
+// Stops at the first item or entry since hub links are declared on the channel
+func extractWebSubFeedLinks(body []byte) (hubURL, selfURL string) {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	decoder.Strict = false
+	decoder.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return hubURL, selfURL
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "item", "entry":
+			return hubURL, selfURL
+		case "link":
+			var rel, href string
+			for _, attr := range start.Attr {
+				switch attr.Name.Local {
+				case "rel":
+					rel = attr.Value
+				case "href":
+					href = strings.TrimSpace(attr.Value)
+				}
+			}
+			if href == "" {
+				continue
+			}
+			if hubURL == "" && hasRel(rel, "hub") {
+				hubURL = href
+			}
+			if selfURL == "" && hasRel(rel, "self") {
+				selfURL = href
+			}
+		}
+	}
+}
+
+// linkHeaderValue is a single entry of an HTTP Link header
+type linkHeaderValue struct {
+	href string
+	rel  string
+}
+
+// parseLinkHeader parses an RFC 8288 Link header value such as `<https://hub>; rel="hub"`
+func parseLinkHeader(header string) []linkHeaderValue {
+	var links []linkHeaderValue
+
+	for _, part := range strings.Split(header, ",") {
+		segments := strings.Split(part, ";")
+		target := strings.TrimSpace(segments[0])
+		if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+			continue
+		}
+
+		link := linkHeaderValue{href: strings.TrimSpace(target[1 : len(target)-1])}
+		for _, param := range segments[1:] {
+			key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if ok && strings.EqualFold(strings.TrimSpace(key), "rel") {
+				link.rel = strings.Trim(strings.TrimSpace(value), `"`)
+			}
+		}
+		links = append(links, link)
+	}
+
+	return links
+}
+
+// resolveHTTPURL resolves a possibly relative reference and returns it only if it is an http(s) URL
+func resolveHTTPURL(base *url.URL, ref string) string {
+	if ref == "" {
+		return ""
+	}
+
+	resolved, err := base.Parse(ref)
+	if err != nil || (resolved.Scheme != "http" && resolved.Scheme != "https") {
+		return ""
+	}
+
+	return resolved.String()
+}
@@ -0,0 +1,462 @@
+package fetcher
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tjanas94/vibefeeder/internal/shared/config"
+	"github.com/tjanas94/vibefeeder/internal/shared/database"
+)
+
+// MockWebSubRepository implements WebSubRepository for testing
+type MockWebSubRepository struct {
+	Subscription *database.PublicWebsubSubscriptionsSelect
+	FindErr      error
+
+	// Tracking for assertions
+	SaveCalls   []database.PublicWebsubSubscriptionsInsert
+	UpdateCalls []database.PublicWebsubSubscriptionsUpdate
+}
+
+func (m *MockWebSubRepository) FindWebSubSubscription(ctx context.Context, feedID string) (*database.PublicWebsubSubscriptionsSelect, error) {
+	return m.Subscription, m.FindErr
+}
+
+func (m *MockWebSubRepository) SaveWebSubSubscription(ctx context.Context, subscription database.PublicWebsubSubscriptionsInsert) error {
+	m.SaveCalls = append(m.SaveCalls, subscription)
+	return nil
+}
+
+func (m *MockWebSubRepository) UpdateWebSubSubscription(ctx context.Context, feedID string, update database.PublicWebsubSubscriptionsUpdate) error {
+	m.UpdateCalls = append(m.UpdateCalls, update)
+	return nil
+}
+
+// MockHubClient implements HubClientInterface for testing
+type MockHubClient struct {
+	PostFormFunc func(ctx context.Context, targetURL string, form url.Values) (*http.Response, error)
+
+	// Tracking for assertions
+	Forms []url.Values
+}
+
+func (m *MockHubClient) PostForm(ctx context.Context, targetURL string, form url.Values) (*http.Response, error) {
+	m.Forms = append(m.Forms, form)
+	if m.PostFormFunc != nil {
+		return m.PostFormFunc(ctx, targetURL, form)
+	}
+	return &http.Response{StatusCode: http.StatusAccepted, Body: http.NoBody}, nil
+}
+
+func newTestWebSubManager(repo *MockWebSubRepository, hubClient *MockHubClient) *WebSubManager {
+	return NewWebSubManager(repo, hubClient, slog.Default(), config.FetcherConfig{
+		WebSubCallbackURL:   "https://app.example.com/websub/",
+		WebSubLeaseDuration: 10 * 24 * time.Hour,
+		WebSubPollInterval:  24 * time.Hour,
+		MaxResponseBodySize: 1024 * 1024,
+		MaxArticlesPerFeed:  100,
+	})
+}
+
+func sign(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// TestDiscoverWebSubLinks tests hub and self link detection
+func TestDiscoverWebSubLinks(t *testing.T) {
+	tests := []struct {
+		name         string
+		linkHeaders  []string
+		body         string
+		expectedHub  string
+		expectedSelf string
+	}{
+		{
+			name: "atom feed links",
+			body: `<?xml version="1.0"?><feed xmlns="http://www.w3.org/2005/Atom">
+				<link rel="hub" href="https://hub.example.com/"/>
+				<link rel="self" href="https://example.com/atom.xml"/>
+				<entry><link rel="hub" href="https://ignored.example.com/"/></entry>
+			</feed>`,
+			expectedHub:  "https://hub.example.com/",
+			expectedSelf: "https://example.com/atom.xml",
+		},
+		{
+			name: "rss feed with atom namespace links",
+			body: `<?xml version="1.0"?><rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom"><channel>
+				<atom:link rel="self" href="/feed" type="application/rss+xml"/>
+				<atom:link rel="hub" href="https://pubsubhubbub.appspot.com/"/>
+			</channel></rss>`,
+			expectedHub:  "https://pubsubhubbub.appspot.com/",
+			expectedSelf: "https://example.com/feed",
+		},
+		{
+			name:         "link header takes precedence",
+			linkHeaders:  []string{`<https://hub.example.com/header>; rel="hub", <https://example.com/self>; rel="self"`},
+			body:         `<feed><link rel="hub" href="https://hub.example.com/body"/></feed>`,
+			expectedHub:  "https://hub.example.com/header",
+			expectedSelf: "https://example.com/self",
+		},
+		{
+			name:         "no hub",
+			body:         `<rss><channel><link>https://example.com</link></channel></rss>`,
+			expectedHub:  "",
+			expectedSelf: "",
+		},
+		{
+			name:         "non-http hub is ignored",
+			body:         `<feed><link rel="hub" href="ftp://hub.example.com/"/></feed>`,
+			expectedHub:  "",
+			expectedSelf: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub, self := discoverWebSubLinks("https://example.com/rss", tt.linkHeaders, []byte(tt.body))
+			assert.Equal(t, tt.expectedHub, hub)
+			assert.Equal(t, tt.expectedSelf, self)
+		})
+	}
+}
+
+// TestVerifyWebSubSignature tests HMAC signature verification
+func TestVerifyWebSubSignature(t *testing.T) {
+	payload := []byte("<feed></feed>")
+
+	tests := []struct {
+		name      string
+		signature string
+		expected  bool
+	}{
+		{name: "valid sha256", signature: sign("secret", string(payload)), expected: true},
+		{name: "wrong secret", signature: sign("other", string(payload)), expected: false},
+		{name: "missing signature", signature: "", expected: false},
+		{name: "unsupported method", signature: "md5=abc", expected: false},
+		{name: "invalid hex", signature: "sha256=zz", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, verifyWebSubSignature("secret", tt.signature, payload))
+		})
+	}
+}
+
+// TestNeedsSubscription tests (re)subscription decisions
+func TestNeedsSubscription(t *testing.T) {
+	now := time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC)
+	hub := "https://hub.example.com/"
+	topic := "https://example.com/feed"
+	format := func(t time.Time) *string {
+		s := t.Format(time.RFC3339)
+		return &s
+	}
+
+	tests := []struct {
+		name     string
+		sub      *database.PublicWebsubSubscriptionsSelect
+		expected bool
+	}{
+		{name: "no subscription", sub: nil, expected: true},
+		{
+			name:     "hub changed",
+			sub:      &database.PublicWebsubSubscriptionsSelect{HubUrl: "https://old.example.com/", TopicUrl: topic, Status: WebSubStatusActive, LeaseExpiresAt: format(now.Add(48 * time.Hour))},
+			expected: true,
+		},
+		{
+			name:     "active lease",
+			sub:      &database.PublicWebsubSubscriptionsSelect{HubUrl: hub, TopicUrl: topic, Status: WebSubStatusActive, LeaseExpiresAt: format(now.Add(48 * time.Hour))},
+			expected: false,
+		},
+		{
+			name:     "active lease within renew margin",
+			sub:      &database.PublicWebsubSubscriptionsSelect{HubUrl: hub, TopicUrl: topic, Status: WebSubStatusActive, LeaseExpiresAt: format(now.Add(30 * time.Minute))},
+			expected: true,
+		},
+		{
+			name:     "expired lease",
+			sub:      &database.PublicWebsubSubscriptionsSelect{HubUrl: hub, TopicUrl: topic, Status: WebSubStatusActive, LeaseExpiresAt: format(now.Add(-time.Minute))},
+			expected: true,
+		},
+		{
+			name:     "recently requested pending",
+			sub:      &database.PublicWebsubSubscriptionsSelect{HubUrl: hub, TopicUrl: topic, Status: WebSubStatusPending, UpdatedAt: *format(now.Add(-time.Hour))},
+			expected: false,
+		},
+		{
+			name:     "stale denied",
+			sub:      &database.PublicWebsubSubscriptionsSelect{HubUrl: hub, TopicUrl: topic, Status: WebSubStatusDenied, UpdatedAt: *format(now.Add(-7 * time.Hour))},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, needsSubscription(tt.sub, hub, topic, now))
+		})
+	}
+}
+
+// TestFallbackFetchTime tests poll postponement for pushed feeds
+func TestFallbackFetchTime(t *testing.T) {
+	now := time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC)
+	regular := now.Add(time.Hour)
+
+	tests := []struct {
+		name        string
+		leaseExpiry time.Time
+		expected    time.Time
+	}{
+		{name: "long lease uses poll interval", leaseExpiry: now.Add(10 * 24 * time.Hour), expected: now.Add(24 * time.Hour)},
+		{name: "short lease polls before renewal", leaseExpiry: now.Add(5 * time.Hour), expected: now.Add(4 * time.Hour)},
+		{name: "never earlier than regular schedule", leaseExpiry: now.Add(90 * time.Minute), expected: regular},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, fallbackFetchTime(regular, tt.leaseExpiry, 24*time.Hour, now))
+		})
+	}
+}
+
+// TestWebSubApplySubscription tests subscribing when a hub is advertised
+func TestWebSubApplySubscription(t *testing.T) {
+	hub := "https://hub.example.com/"
+	feed := database.PublicFeedsSelect{Id: "feed-1", Url: "https://example.com/feed"}
+
+	t.Run("subscribes to new hub", func(t *testing.T) {
+		repo := &MockWebSubRepository{}
+		hubClient := &MockHubClient{}
+		m := newTestWebSubManager(repo, hubClient)
+
+		next := time.Now().Add(time.Hour)
+		decision := m.ApplySubscription(context.Background(), feed, FetchDecision{Status: "success", HubURL: &hub, NextFetchTime: next})
+
+		assert.Equal(t, next, decision.NextFetchTime, "polling continues until the hub verifies")
+		require.Len(t, repo.SaveCalls, 1)
+		assert.Equal(t, WebSubStatusPending, *repo.SaveCalls[0].Status)
+		assert.NotEmpty(t, repo.SaveCalls[0].Secret)
+
+		require.Len(t, hubClient.Forms, 1)
+		form := hubClient.Forms[0]
+		assert.Equal(t, "subscribe", form.Get("hub.mode"))
+		assert.Equal(t, "https://app.example.com/websub/feed-1", form.Get("hub.callback"))
+		assert.Equal(t, "https://example.com/feed", form.Get("hub.topic"))
+		assert.Equal(t, repo.SaveCalls[0].Secret, form.Get("hub.secret"))
+		assert.Equal(t, "864000", form.Get("hub.lease_seconds"))
+		assert.Empty(t, repo.UpdateCalls)
+	})
+
+	t.Run("marks subscription denied when hub rejects request", func(t *testing.T) {
+		repo := &MockWebSubRepository{}
+		hubClient := &MockHubClient{
+			PostFormFunc: func(ctx context.Context, targetURL string, form url.Values) (*http.Response, error) {
+				return &http.Response{StatusCode: http.StatusBadRequest, Body: http.NoBody}, nil
+			},
+		}
+		m := newTestWebSubManager(repo, hubClient)
+
+		m.ApplySubscription(context.Background(), feed, FetchDecision{Status: "success", HubURL: &hub})
+
+		require.Len(t, repo.UpdateCalls, 1)
+		assert.Equal(t, WebSubStatusDenied, *repo.UpdateCalls[0].Status)
+		assert.Contains(t, *repo.UpdateCalls[0].LastError, "400")
+	})
+
+	t.Run("postpones polling while lease is active", func(t *testing.T) {
+		lease := time.Now().Add(5 * 24 * time.Hour).UTC().Format(time.RFC3339)
+		repo := &MockWebSubRepository{Subscription: &database.PublicWebsubSubscriptionsSelect{
+			FeedId: "feed-1", HubUrl: hub, TopicUrl: feed.Url, Status: WebSubStatusActive, LeaseExpiresAt: &lease,
+		}}
+		hubClient := &MockHubClient{}
+		m := newTestWebSubManager(repo, hubClient)
+
+		decision := m.ApplySubscription(context.Background(), feed, FetchDecision{Status: "success", HubURL: &hub, NextFetchTime: time.Now().Add(time.Hour)})
+
+		assert.WithinDuration(t, time.Now().Add(24*time.Hour), decision.NextFetchTime, time.Minute)
+		assert.Empty(t, hubClient.Forms)
+		assert.Empty(t, repo.SaveCalls)
+	})
+
+	t.Run("ignores failed fetches and feeds without hub", func(t *testing.T) {
+		repo := &MockWebSubRepository{}
+		hubClient := &MockHubClient{}
+		m := newTestWebSubManager(repo, hubClient)
+
+		m.ApplySubscription(context.Background(), feed, FetchDecision{Status: "temporary_error", HubURL: &hub})
+		m.ApplySubscription(context.Background(), feed, FetchDecision{Status: "success"})
+
+		assert.Empty(t, hubClient.Forms)
+		assert.Empty(t, repo.SaveCalls)
+	})
+}
+
+// TestWebSubVerifyIntent tests hub intent verification
+func TestWebSubVerifyIntent(t *testing.T) {
+	topic := "https://example.com/feed"
+	pending := func() *MockWebSubRepository {
+		return &MockWebSubRepository{Subscription: &database.PublicWebsubSubscriptionsSelect{
+			FeedId: "feed-1", HubUrl: "https://hub.example.com/", TopicUrl: topic, Status: WebSubStatusPending, Secret: "secret",
+		}}
+	}
+
+	t.Run("confirms subscription and stores lease", func(t *testing.T) {
+		repo := pending()
+		m := newTestWebSubManager(repo, &MockHubClient{})
+
+		challenge, err := m.VerifyIntent(context.Background(), "feed-1", WebSubVerification{
+			Mode: "subscribe", Topic: topic, Challenge: "abc", LeaseSeconds: "3600",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "abc", challenge)
+		require.Len(t, repo.UpdateCalls, 1)
+		assert.Equal(t, WebSubStatusActive, *repo.UpdateCalls[0].Status)
+		leaseExpiry, err := time.Parse(time.RFC3339, *repo.UpdateCalls[0].LeaseExpiresAt)
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), leaseExpiry, time.Minute)
+	})
+
+	t.Run("rejects mismatched topic", func(t *testing.T) {
+		repo := pending()
+		m := newTestWebSubManager(repo, &MockHubClient{})
+
+		_, err := m.VerifyIntent(context.Background(), "feed-1", WebSubVerification{
+			Mode: "subscribe", Topic: "https://evil.example.com/", Challenge: "abc",
+		})
+
+		assert.ErrorIs(t, err, ErrWebSubIntentRejected)
+		assert.Empty(t, repo.UpdateCalls)
+	})
+
+	t.Run("rejects unsubscribe for tracked feed", func(t *testing.T) {
+		m := newTestWebSubManager(pending(), &MockHubClient{})
+
+		_, err := m.VerifyIntent(context.Background(), "feed-1", WebSubVerification{
+			Mode: "unsubscribe", Topic: topic, Challenge: "abc",
+		})
+
+		assert.ErrorIs(t, err, ErrWebSubIntentRejected)
+	})
+
+	t.Run("confirms unsubscribe for unknown feed", func(t *testing.T) {
+		m := newTestWebSubManager(&MockWebSubRepository{}, &MockHubClient{})
+
+		challenge, err := m.VerifyIntent(context.Background(), "feed-1", WebSubVerification{
+			Mode: "unsubscribe", Topic: topic, Challenge: "abc",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "abc", challenge)
+	})
+
+	t.Run("records denial", func(t *testing.T) {
+		repo := pending()
+		m := newTestWebSubManager(repo, &MockHubClient{})
+
+		_, err := m.VerifyIntent(context.Background(), "feed-1", WebSubVerification{
+			Mode: "denied", Topic: topic, Reason: "topic not found",
+		})
+
+		require.NoError(t, err)
+		require.Len(t, repo.UpdateCalls, 1)
+		assert.Equal(t, WebSubStatusDenied, *repo.UpdateCalls[0].Status)
+		assert.Equal(t, "topic not found", *repo.UpdateCalls[0].LastError)
+	})
+
+	t.Run("unknown subscription", func(t *testing.T) {
+		m := newTestWebSubManager(&MockWebSubRepository{}, &MockHubClient{})
+
+		_, err := m.VerifyIntent(context.Background(), "feed-1", WebSubVerification{Mode: "subscribe", Topic: topic, Challenge: "abc"})
+
+		assert.ErrorIs(t, err, ErrWebSubUnknownSubscription)
+	})
+}
+
+// TestWebSubHandleNotification tests content distribution processing
+func TestWebSubHandleNotification(t *testing.T) {
+	payload := `<?xml version="1.0"?><rss version="2.0"><channel><title>Feed</title>
+		<item><title>Pushed</title><link>https://example.com/pushed</link></item>
+	</channel></rss>`
+	subscribed := func() *MockWebSubRepository {
+		return &MockWebSubRepository{Subscription: &database.PublicWebsubSubscriptionsSelect{
+			FeedId: "feed-1", Status: WebSubStatusActive, Secret: "secret",
+		}}
+	}
+
+	t.Run("returns decision with signed articles", func(t *testing.T) {
+		m := newTestWebSubManager(subscribed(), &MockHubClient{})
+
+		decision, refetch, err := m.HandleNotification(context.Background(), "feed-1", strings.NewReader(payload), sign("secret", payload))
+
+		require.NoError(t, err)
+		assert.False(t, refetch)
+		require.NotNil(t, decision)
+		assert.Equal(t, "success", decision.Status)
+		assert.Equal(t, int64(len(payload)), decision.BytesRead)
+		require.Len(t, decision.Articles, 1)
+		assert.Equal(t, "https://example.com/pushed", decision.Articles[0].URL)
+	})
+
+	t.Run("ignores invalid signature", func(t *testing.T) {
+		m := newTestWebSubManager(subscribed(), &MockHubClient{})
+
+		decision, refetch, err := m.HandleNotification(context.Background(), "feed-1", strings.NewReader(payload), sign("wrong", payload))
+
+		require.NoError(t, err)
+		assert.False(t, refetch)
+		assert.Nil(t, decision)
+	})
+
+	t.Run("requests fetch for thin ping", func(t *testing.T) {
+		m := newTestWebSubManager(subscribed(), &MockHubClient{})
+
+		decision, refetch, err := m.HandleNotification(context.Background(), "feed-1", strings.NewReader(""), sign("secret", ""))
+
+		require.NoError(t, err)
+		assert.True(t, refetch)
+		assert.Nil(t, decision)
+	})
+
+	t.Run("rejects oversized payload without fetch", func(t *testing.T) {
+		m := newTestWebSubManager(subscribed(), &MockHubClient{})
+		oversized := payload + strings.Repeat(" ", 1024*1024)
+
+		decision, refetch, err := m.HandleNotification(context.Background(), "feed-1", strings.NewReader(oversized), "")
+
+		assert.ErrorIs(t, err, ErrWebSubPayloadTooLarge)
+		assert.False(t, refetch)
+		assert.Nil(t, decision)
+	})
+
+	t.Run("unknown subscription", func(t *testing.T) {
+		m := newTestWebSubManager(&MockWebSubRepository{}, &MockHubClient{})
+
+		_, _, err := m.HandleNotification(context.Background(), "feed-1", strings.NewReader(payload), sign("secret", payload))
+
+		assert.ErrorIs(t, err, ErrWebSubUnknownSubscription)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := &MockWebSubRepository{FindErr: errors.New("db down")}
+		m := newTestWebSubManager(repo, &MockHubClient{})
+
+		_, _, err := m.HandleNotification(context.Background(), "feed-1", strings.NewReader(payload), "")
+
+		assert.Error(t, err)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"github.com/tjanas94/vibefeeder/internal/shared/database"
+	"github.com/tjanas94/vibefeeder/internal/shared/metrics"
 )
 
 // WorkerPool manages concurrent processing with a limited number of workers
@@ -54,6 +55,9 @@ func (wp *WorkerPool) ProcessFeeds(ctx context.Context, feeds []database.PublicF
 			defer wg.Done()
 			defer func() { <-wp.semaphore }() // Release semaphore slot
 
+			metrics.FetcherBusyWorkers.Inc()
+			defer metrics.FetcherBusyWorkers.Dec()
+
 			// Recover from panic to prevent one feed from crashing the pool
 			defer func() {
 				if r := recover(); r != nil {
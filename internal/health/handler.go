@@ -0,0 +1,41 @@
+package health
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/tjanas94/vibefeeder/internal/health/models"
+)
+
+// Handler handles liveness and readiness probes
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new health handler
+func NewHandler(service *Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// Livez handles GET /livez endpoint
+// Reports that the process is up and serving HTTP; dependencies are not checked
+func (h *Handler) Livez(c echo.Context) error {
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.JSON(http.StatusOK, models.LivenessReport{Status: models.StatusOK})
+}
+
+// Readyz handles GET /readyz endpoint
+// Returns 503 when a critical dependency fails; a degraded instance still accepts traffic
+func (h *Handler) Readyz(c echo.Context) error {
+	report := h.service.Readiness(c.Request().Context())
+
+	status := http.StatusOK
+	if report.Status == models.StatusUnavailable {
+		status = http.StatusServiceUnavailable
+	}
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.JSON(status, report)
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// Health status values, from best to worst
+const (
+	StatusOK          = "ok"
+	StatusDegraded    = "degraded"    // a non-critical component fails, the application still serves requests
+	StatusUnavailable = "unavailable" // a critical component fails, the instance should not receive traffic
+)
+
+// ReadinessReport is the result of the readiness checks.
+// Used by: GET /readyz, GET /healthz
+type ReadinessReport struct {
+	Status     string                     `json:"status"`
+	CheckedAt  time.Time                  `json:"checked_at"`
+	Components map[string]ComponentStatus `json:"components"`
+}
+
+// ComponentStatus is the result of checking a single dependency.
+type ComponentStatus struct {
+	Status    string  `json:"status"`
+	Critical  bool    `json:"critical"`          // failure makes the instance unavailable instead of degraded
+	LatencyMS float64 `json:"latency_ms"`        // time spent on the check
+	Message   string  `json:"message,omitempty"` // error or explanation when not ok
+}
+
+// LivenessReport is the result of the liveness probe.
+// Used by: GET /livez
+type LivenessReport struct {
+	Status string `json:"status"`
+}
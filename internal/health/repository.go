@@ -0,0 +1,37 @@
+package health
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tjanas94/vibefeeder/internal/shared/database"
+)
+
+// Repository runs database queries of the readiness checks
+type Repository struct {
+	db *database.Client
+}
+
+// Ensure Repository implements DatabasePinger interface at compile time
+var _ DatabasePinger = (*Repository)(nil)
+
+// NewRepository creates a new health repository
+func NewRepository(db *database.Client) *Repository {
+	return &Repository{db: db}
+}
+
+// Ping runs the cheapest real PostgREST query: a single feed ID through the service client
+// Not traced: probes run every few seconds and would flood the trace backend
+func (r *Repository) Ping(ctx context.Context) error {
+	var rows []database.PublicFeedsSelect
+	_, err := r.db.From("feeds").
+		Select("id", "", false).
+		Limit(1, "").
+		ExecuteTo(&rows)
+
+	if err != nil {
+		return fmt.Errorf("failed to query database: %w", err)
+	}
+
+	return nil
+}
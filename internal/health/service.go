@@ -0,0 +1,225 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/supabase-community/gotrue-go/types"
+	"github.com/tjanas94/vibefeeder/internal/health/models"
+)
+
+const (
+	// checkTimeout bounds each readiness check, so a hanging backend fails the probe instead of blocking it
+	checkTimeout = 2 * time.Second
+
+	// cacheTTL is how long a readiness report is reused, so frequent probes don't hammer the backends
+	cacheTTL = 5 * time.Second
+
+	// staleBatchFactor is how many fetch intervals may pass without a completed batch
+	staleBatchFactor = 3
+)
+
+// Component names used in the readiness report
+const (
+	ComponentDatabase = "database"
+	ComponentAuth     = "auth"
+	ComponentFetcher  = "fetcher"
+	ComponentAI       = "ai"
+)
+
+// DatabasePinger defines the interface for the database check
+type DatabasePinger interface {
+	Ping(ctx context.Context) error
+}
+
+// AuthHealthChecker defines the interface for the GoTrue check (implemented by gotrue.Client)
+type AuthHealthChecker interface {
+	HealthCheck() (*types.HealthCheckResponse, error)
+}
+
+// FetcherStatus defines the interface for the fetcher scheduler check
+type FetcherStatus interface {
+	LastBatchAt() time.Time
+}
+
+// Config holds the settings of the readiness checks
+type Config struct {
+	FetchInterval time.Duration // Scheduler tick; batches older than a few intervals are stale
+	AIConfigured  bool          // Whether an AI provider API key is set
+}
+
+// Service runs readiness checks and caches the report
+type Service struct {
+	db        DatabasePinger
+	auth      AuthHealthChecker
+	fetcher   FetcherStatus
+	config    Config
+	logger    *slog.Logger
+	startedAt time.Time
+	now       func() time.Time
+
+	mu     sync.Mutex
+	report *models.ReadinessReport
+}
+
+// NewService creates a new health service
+func NewService(db DatabasePinger, auth AuthHealthChecker, fetcher FetcherStatus, cfg Config, logger *slog.Logger) *Service {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &Service{
+		db:        db,
+		auth:      auth,
+		fetcher:   fetcher,
+		config:    cfg,
+		logger:    logger,
+		startedAt: time.Now(),
+		now:       time.Now,
+	}
+}
+
+// Readiness returns the readiness report, running the checks at most once per cacheTTL
+// Concurrent probes wait for the running checks instead of starting their own
+// Checks outlive a disconnected prober, the report is cached for the next one
+func (s *Service) Readiness(ctx context.Context) models.ReadinessReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if s.report != nil && now.Sub(s.report.CheckedAt) < cacheTTL {
+		return *s.report
+	}
+
+	report := s.check(context.WithoutCancel(ctx), now)
+	if report.Status != models.StatusOK {
+		s.logger.Warn("Readiness check failed", "status", report.Status, "components", report.Components)
+	}
+
+	s.report = &report
+	return report
+}
+
+// check runs all component checks concurrently and aggregates the overall status
+func (s *Service) check(ctx context.Context, now time.Time) models.ReadinessReport {
+	checks := map[string]struct {
+		critical bool
+		run      func(ctx context.Context) error
+	}{
+		ComponentDatabase: {critical: true, run: s.db.Ping},
+		ComponentAuth:     {critical: true, run: s.checkAuth},
+		ComponentFetcher:  {critical: false, run: func(context.Context) error { return s.checkFetcher(now) }},
+		ComponentAI:       {critical: false, run: s.checkAI},
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	components := make(map[string]models.ComponentStatus, len(checks))
+
+	for name, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			status := runCheck(ctx, c.critical, c.run)
+			mu.Lock()
+			components[name] = status
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	return models.ReadinessReport{
+		Status:     overallStatus(components),
+		CheckedAt:  now,
+		Components: components,
+	}
+}
+
+// checkAuth verifies that GoTrue responds to its health endpoint
+func (s *Service) checkAuth(ctx context.Context) error {
+	_, err := s.auth.HealthCheck()
+	if err != nil {
+		return fmt.Errorf("auth server unreachable: %w", err)
+	}
+	return nil
+}
+
+// checkFetcher verifies that the scheduler loop completed a batch recently
+// Right after startup the first batch may still be running, which is not a failure
+func (s *Service) checkFetcher(now time.Time) error {
+	maxAge := staleBatchFactor * s.config.FetchInterval
+
+	lastBatch := s.fetcher.LastBatchAt()
+	if lastBatch.IsZero() {
+		if now.Sub(s.startedAt) < maxAge {
+			return nil
+		}
+		return errors.New("no batch completed since startup")
+	}
+
+	if age := now.Sub(lastBatch); age > maxAge {
+		return fmt.Errorf("last batch completed %s ago", age.Round(time.Second))
+	}
+	return nil
+}
+
+// checkAI reports whether summaries can be generated
+func (s *Service) checkAI(ctx context.Context) error {
+	if !s.config.AIConfigured {
+		return errors.New("AI provider API key is not set")
+	}
+	return nil
+}
+
+// runCheck runs a single check with a timeout and measures its latency
+// The check keeps running in the background after a timeout (the Supabase clients don't accept a context)
+func runCheck(ctx context.Context, critical bool, run func(ctx context.Context) error) models.ComponentStatus {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- run(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("check timed out: %w", ctx.Err())
+	}
+
+	status := models.ComponentStatus{
+		Status:    models.StatusOK,
+		Critical:  critical,
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		status.Status = models.StatusDegraded
+		if critical {
+			status.Status = models.StatusUnavailable
+		}
+		status.Message = err.Error()
+	}
+	return status
+}
+
+// overallStatus returns the worst status among the components
+// Any failing component fails the whole check, a degraded one degrades it
+func overallStatus(components map[string]models.ComponentStatus) string {
+	status := models.StatusOK
+	for _, component := range components {
+		switch component.Status {
+		case models.StatusUnavailable:
+			return models.StatusUnavailable
+		case models.StatusDegraded:
+			status = models.StatusDegraded
+		}
+	}
+	return status
+}
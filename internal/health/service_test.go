@@ -0,0 +1,155 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase-community/gotrue-go/types"
+	"github.com/tjanas94/vibefeeder/internal/health/models"
+)
+
+// fakeDatabase is a DatabasePinger returning a fixed error and counting calls
+type fakeDatabase struct {
+	err   error
+	calls int
+}
+
+func (f *fakeDatabase) Ping(ctx context.Context) error {
+	f.calls++
+	return f.err
+}
+
+// fakeAuth is an AuthHealthChecker returning a fixed error
+type fakeAuth struct {
+	err error
+}
+
+func (f *fakeAuth) HealthCheck() (*types.HealthCheckResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &types.HealthCheckResponse{Name: "GoTrue"}, nil
+}
+
+// fakeFetcher is a FetcherStatus with a fixed last batch time
+type fakeFetcher struct {
+	lastBatchAt time.Time
+}
+
+func (f *fakeFetcher) LastBatchAt() time.Time {
+	return f.lastBatchAt
+}
+
+var testNow = time.Date(2025, 11, 12, 12, 0, 0, 0, time.UTC)
+
+func newTestService(db *fakeDatabase, auth *fakeAuth, fetcher *fakeFetcher, aiConfigured bool) *Service {
+	service := NewService(db, auth, fetcher, Config{FetchInterval: 5 * time.Minute, AIConfigured: aiConfigured}, slog.Default())
+	service.now = func() time.Time { return testNow }
+	service.startedAt = testNow.Add(-time.Hour)
+	return service
+}
+
+func TestReadiness(t *testing.T) {
+	tests := []struct {
+		name             string
+		db               *fakeDatabase
+		auth             *fakeAuth
+		fetcher          *fakeFetcher
+		aiConfigured     bool
+		expectedStatus   string
+		failedComponents map[string]string
+	}{
+		{
+			name:           "all components healthy",
+			db:             &fakeDatabase{},
+			auth:           &fakeAuth{},
+			fetcher:        &fakeFetcher{lastBatchAt: testNow.Add(-time.Minute)},
+			aiConfigured:   true,
+			expectedStatus: models.StatusOK,
+		},
+		{
+			name:             "database down makes the instance unavailable",
+			db:               &fakeDatabase{err: errors.New("connection refused")},
+			auth:             &fakeAuth{},
+			fetcher:          &fakeFetcher{lastBatchAt: testNow.Add(-time.Minute)},
+			aiConfigured:     true,
+			expectedStatus:   models.StatusUnavailable,
+			failedComponents: map[string]string{ComponentDatabase: models.StatusUnavailable},
+		},
+		{
+			name:             "auth server down makes the instance unavailable",
+			db:               &fakeDatabase{},
+			auth:             &fakeAuth{err: errors.New("503 service unavailable")},
+			fetcher:          &fakeFetcher{lastBatchAt: testNow.Add(-time.Minute)},
+			aiConfigured:     true,
+			expectedStatus:   models.StatusUnavailable,
+			failedComponents: map[string]string{ComponentAuth: models.StatusUnavailable},
+		},
+		{
+			name:             "stale fetcher and missing AI key only degrade",
+			db:               &fakeDatabase{},
+			auth:             &fakeAuth{},
+			fetcher:          &fakeFetcher{lastBatchAt: testNow.Add(-time.Hour)},
+			aiConfigured:     false,
+			expectedStatus:   models.StatusDegraded,
+			failedComponents: map[string]string{ComponentFetcher: models.StatusDegraded, ComponentAI: models.StatusDegraded},
+		},
+		{
+			name:             "no batch completed long after startup",
+			db:               &fakeDatabase{},
+			auth:             &fakeAuth{},
+			fetcher:          &fakeFetcher{},
+			aiConfigured:     true,
+			expectedStatus:   models.StatusDegraded,
+			failedComponents: map[string]string{ComponentFetcher: models.StatusDegraded},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newTestService(tt.db, tt.auth, tt.fetcher, tt.aiConfigured)
+
+			report := service.Readiness(context.Background())
+
+			assert.Equal(t, tt.expectedStatus, report.Status)
+			require.Len(t, report.Components, 4)
+			for name, component := range report.Components {
+				if expected, failed := tt.failedComponents[name]; failed {
+					assert.Equal(t, expected, component.Status, name)
+					assert.NotEmpty(t, component.Message, name)
+				} else {
+					assert.Equal(t, models.StatusOK, component.Status, name)
+					assert.Empty(t, component.Message, name)
+				}
+			}
+		})
+	}
+}
+
+func TestReadiness_FirstBatchStillRunning(t *testing.T) {
+	service := newTestService(&fakeDatabase{}, &fakeAuth{}, &fakeFetcher{}, true)
+	service.startedAt = testNow.Add(-time.Minute)
+
+	report := service.Readiness(context.Background())
+
+	assert.Equal(t, models.StatusOK, report.Components[ComponentFetcher].Status)
+}
+
+func TestReadiness_CachesReport(t *testing.T) {
+	db := &fakeDatabase{}
+	service := newTestService(db, &fakeAuth{}, &fakeFetcher{lastBatchAt: testNow}, true)
+
+	service.Readiness(context.Background())
+	service.now = func() time.Time { return testNow.Add(cacheTTL - time.Second) }
+	service.Readiness(context.Background())
+	assert.Equal(t, 1, db.calls, "report is reused within the cache TTL")
+
+	service.now = func() time.Time { return testNow.Add(cacheTTL) }
+	service.Readiness(context.Background())
+	assert.Equal(t, 2, db.calls, "checks run again once the report expires")
+}
@@ -0,0 +1,50 @@
+package mute
+
+import (
+	"net/http"
+
+	sharederrors "github.com/tjanas94/vibefeeder/internal/shared/errors"
+)
+
+// NewMuteRuleAlreadyExistsError creates a ServiceError when the user already has the same rule
+// Returns 409 Conflict with field error
+func NewMuteRuleAlreadyExistsError() *sharederrors.ServiceError {
+	return sharederrors.NewServiceErrorWithFields(
+		http.StatusConflict,
+		"",
+		map[string]string{
+			"Pattern": "You already have this rule",
+		},
+	)
+}
+
+// NewInvalidPatternError creates a ServiceError when the pattern can't be matched
+// Returns 422 Unprocessable Entity with field error
+func NewInvalidPatternError(message string) *sharederrors.ServiceError {
+	return sharederrors.NewServiceErrorWithFields(
+		http.StatusUnprocessableEntity,
+		"",
+		map[string]string{
+			"Pattern": message,
+		},
+	)
+}
+
+// NewMuteRuleNotFoundError creates a ServiceError when a rule is not found or doesn't belong to the user
+// Returns 404 Not Found
+func NewMuteRuleNotFoundError() *sharederrors.ServiceError {
+	return sharederrors.NewServiceError(
+		http.StatusNotFound,
+		"Mute rule not found",
+	)
+}
+
+// NewDatabaseError creates a ServiceError for database operation failures
+// Returns 500 Internal Server Error
+func NewDatabaseError(err error) *sharederrors.ServiceError {
+	return sharederrors.NewServiceErrorWithCause(
+		http.StatusInternalServerError,
+		"Database operation failed",
+		err,
+	)
+}
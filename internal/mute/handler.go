@@ -0,0 +1,126 @@
+package mute
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/tjanas94/vibefeeder/internal/mute/models"
+	"github.com/tjanas94/vibefeeder/internal/mute/view"
+	"github.com/tjanas94/vibefeeder/internal/shared/auth"
+	sharederrors "github.com/tjanas94/vibefeeder/internal/shared/errors"
+	"github.com/tjanas94/vibefeeder/internal/shared/validator"
+	sharedview "github.com/tjanas94/vibefeeder/internal/shared/view/components"
+)
+
+// Handler handles HTTP requests for mute rules
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new mute rule handler
+func NewHandler(service *Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// ListRules handles GET /mute-rules endpoint
+// Returns the mute rules of the authenticated user with their match counts as an HTML fragment
+func (h *Handler) ListRules(c echo.Context) error {
+	vm, err := h.service.ListRules(c.Request().Context(), auth.GetUserID(c))
+	if err != nil {
+		// Path 3: Handle business errors (ServiceError)
+		var serviceErr *sharederrors.ServiceError
+		if errors.As(err, &serviceErr) {
+			errVM := models.MuteRuleListViewModel{
+				Rules:        []models.MuteRuleViewModel{},
+				ErrorMessage: serviceErr.Message,
+			}
+			return c.Render(serviceErr.Code, "", view.MuteRuleList(errVM))
+		}
+
+		// Path 4: Unexpected error - delegate to global error handler
+		return err
+	}
+
+	return c.Render(http.StatusOK, "", view.MuteRuleList(*vm))
+}
+
+// CreateRule handles POST /mute-rules endpoint
+// Adds a mute rule for the authenticated user and re-renders the form
+func (h *Handler) CreateRule(c echo.Context) error {
+	cmd := new(models.CreateMuteRuleCommand)
+	// Path 1: Handle bind errors (invalid request format)
+	if err := c.Bind(cmd); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid form data")
+	}
+
+	// Sanitize pattern input
+	cmd.Normalize()
+
+	// Get user ID from authenticated session
+	cmd.UserID = auth.GetUserID(c)
+
+	// Path 2: Handle validation errors (invalid data)
+	if err := c.Validate(cmd); err != nil {
+		fieldErrors := validator.ParseFieldErrors(err)
+		errorVM := models.NewMuteRuleFormErrorFromFieldErrors(fieldErrors)
+		return c.Render(http.StatusUnprocessableEntity, "", view.MuteRuleForm(cmd.ToFormViewModel(errorVM)))
+	}
+
+	if err := h.service.CreateRule(c.Request().Context(), *cmd); err != nil {
+		// Path 3: Handle business errors (ServiceError)
+		var serviceErr *sharederrors.ServiceError
+		if errors.As(err, &serviceErr) {
+			errorVM := models.MuteRuleFormErrorViewModel{
+				GeneralError: serviceErr.Message,
+				PatternError: serviceErr.FieldErrors["Pattern"],
+			}
+			return c.Render(serviceErr.Code, "", view.MuteRuleForm(cmd.ToFormViewModel(errorVM)))
+		}
+
+		// Path 4: Unexpected error - delegate to global error handler
+		return err
+	}
+
+	// Success - refresh the rule list and render an empty form for the next rule, keeping the kind
+	c.Response().Header().Set("HX-Trigger", `{"refreshMuteRules": null}`)
+	return c.Render(http.StatusOK, "", view.MuteRuleCreated(models.MuteRuleFormViewModel{Kind: cmd.Kind}))
+}
+
+// DeleteRule handles DELETE /mute-rules/:id endpoint
+// Deletes a mute rule of the authenticated user, the articles it hid show up again
+func (h *Handler) DeleteRule(c echo.Context) error {
+	// Path 2: Handle validation errors (malformed ID) - nothing to delete
+	ruleID := c.Param("id")
+	if uuid.Validate(ruleID) != nil {
+		return h.renderToast(c, http.StatusNotFound, "error", "Mute rule not found")
+	}
+
+	if err := h.service.DeleteRule(c.Request().Context(), ruleID, auth.GetUserID(c)); err != nil {
+		// Path 3: Handle business errors (ServiceError)
+		var serviceErr *sharederrors.ServiceError
+		if errors.As(err, &serviceErr) {
+			return h.renderToast(c, serviceErr.Code, "error", serviceErr.Message)
+		}
+
+		// Path 4: Unexpected error - delegate to global error handler
+		return err
+	}
+
+	// Success - refresh the rule list and show toast
+	c.Response().Header().Set("HX-Trigger", `{"refreshMuteRules": null}`)
+	return h.renderToast(c, http.StatusOK, "success", "Mute rule was deleted")
+}
+
+// renderToast renders a toast out of band, leaving the target of the request untouched
+func (h *Handler) renderToast(c echo.Context, statusCode int, toastType, message string) error {
+	c.Response().Header().Set("HX-Reswap", "none")
+	return c.Render(statusCode, "", sharedview.Toast(sharedview.ToastProps{
+		Type:    toastType,
+		Message: message,
+		UseOOB:  true,
+	}))
+}
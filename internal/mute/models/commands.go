@@ -0,0 +1,81 @@
+package models
+
+import (
+	"net/url"
+	"strings"
+	"unicode"
+
+	"github.com/tjanas94/vibefeeder/internal/shared/database"
+)
+
+// Mute rule kinds, what a rule is matched against (see mute_rule_matches)
+const (
+	KindKeyword  = "keyword"  // Whole words or phrase in the title or content
+	KindRegex    = "regex"    // Regular expression in the title or content
+	KindAuthor   = "author"   // One of the authors
+	KindCategory = "category" // One of the categories
+	KindDomain   = "domain"   // Host of the article URL, subdomains included
+)
+
+// CreateMuteRuleCommand represents the input for adding a mute rule.
+// Maps to database.PublicMuteRulesInsert.
+// Used by: POST /mute-rules
+type CreateMuteRuleCommand struct {
+	UserID  string `form:"-"`
+	Kind    string `form:"kind" validate:"required,oneof=keyword regex author category domain"`
+	Pattern string `form:"pattern" validate:"required,max=200"`
+}
+
+// Normalize trims the pattern and brings domains to a bare host name,
+// so "https://www.example.com/jobs" mutes www.example.com and its subdomains
+func (c *CreateMuteRuleCommand) Normalize() {
+	c.Pattern = strings.TrimSpace(c.Pattern)
+
+	switch c.Kind {
+	case KindKeyword, KindAuthor, KindCategory:
+		c.Pattern = strings.Join(strings.Fields(c.Pattern), " ")
+	case KindDomain:
+		c.Pattern = normalizeDomain(c.Pattern)
+	}
+}
+
+// PatternError returns why the pattern can't be matched, empty for usable patterns.
+// Regular expressions are checked by the database, its syntax differs from Go's.
+func (c *CreateMuteRuleCommand) PatternError() string {
+	switch c.Kind {
+	case KindKeyword:
+		// Keywords are matched on word boundaries, punctuation alone matches nothing
+		if !strings.ContainsFunc(c.Pattern, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) {
+			return "Must contain a letter or digit"
+		}
+	case KindDomain:
+		if c.Pattern == "" || strings.ContainsAny(c.Pattern, " /?#@") {
+			return "Must be a domain, e.g. example.com"
+		}
+	}
+	return ""
+}
+
+// ToInsert converts the command to a database insert
+func (c *CreateMuteRuleCommand) ToInsert() database.PublicMuteRulesInsert {
+	return database.PublicMuteRulesInsert{
+		UserId:  c.UserID,
+		Kind:    c.Kind,
+		Pattern: c.Pattern,
+	}
+}
+
+// normalizeDomain extracts the lower-case host of a domain or URL, dropping a wildcard prefix
+func normalizeDomain(domain string) string {
+	domain = strings.ToLower(domain)
+	if !strings.Contains(domain, "://") {
+		domain = "http://" + domain
+	}
+	if u, err := url.Parse(domain); err == nil && u.Hostname() != "" {
+		domain = u.Hostname()
+	} else {
+		domain = strings.TrimPrefix(domain, "http://")
+	}
+	domain = strings.TrimPrefix(domain, "*.")
+	return strings.Trim(domain, ".")
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCreateMuteRuleCommand_Normalize tests the Normalize method
+func TestCreateMuteRuleCommand_Normalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		kind     string
+		pattern  string
+		expected string
+	}{
+		{name: "keyword collapses whitespace", kind: KindKeyword, pattern: "  job \t offer ", expected: "job offer"},
+		{name: "author collapses whitespace", kind: KindAuthor, pattern: " Jane   Doe", expected: "Jane Doe"},
+		{name: "regex is only trimmed", kind: KindRegex, pattern: "  a  b+ ", expected: "a  b+"},
+		{name: "domain is lower-cased", kind: KindDomain, pattern: "Example.COM", expected: "example.com"},
+		{name: "domain from url", kind: KindDomain, pattern: "https://www.example.com:8080/jobs?page=1", expected: "www.example.com"},
+		{name: "domain wildcard", kind: KindDomain, pattern: "*.example.com", expected: "example.com"},
+		{name: "domain trailing dot", kind: KindDomain, pattern: "example.com.", expected: "example.com"},
+		{name: "domain with path", kind: KindDomain, pattern: "example.com/blog", expected: "example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := CreateMuteRuleCommand{Kind: tt.kind, Pattern: tt.pattern}
+			cmd.Normalize()
+			assert.Equal(t, tt.expected, cmd.Pattern)
+		})
+	}
+}
+
+// TestCreateMuteRuleCommand_PatternError tests the PatternError method
+func TestCreateMuteRuleCommand_PatternError(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		pattern string
+		valid   bool
+	}{
+		{name: "keyword", kind: KindKeyword, pattern: "sponsored", valid: true},
+		{name: "keyword with digits", kind: KindKeyword, pattern: "c++ 20", valid: true},
+		{name: "keyword without letters", kind: KindKeyword, pattern: "!!!", valid: false},
+		{name: "domain", kind: KindDomain, pattern: "example.com", valid: true},
+		{name: "domain with spaces", kind: KindDomain, pattern: "example com", valid: false},
+		{name: "empty domain", kind: KindDomain, pattern: "", valid: false},
+		{name: "regex is checked by the database", kind: KindRegex, pattern: "(unclosed", valid: true},
+		{name: "category", kind: KindCategory, pattern: "Sports", valid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := CreateMuteRuleCommand{Kind: tt.kind, Pattern: tt.pattern}
+			if tt.valid {
+				assert.Empty(t, cmd.PatternError())
+			} else {
+				assert.NotEmpty(t, cmd.PatternError())
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"time"
+
+	"github.com/tjanas94/vibefeeder/internal/shared/database"
+)
+
+// KindOption describes a mute rule kind in the form and the list of rules
+type KindOption struct {
+	Kind        string
+	Label       string
+	Description string // How the pattern is matched, shown below the form
+}
+
+// KindOptions are the mute rule kinds in the order of the form
+var KindOptions = []KindOption{
+	{Kind: KindKeyword, Label: "Keyword", Description: "Hides articles with the words or phrase in the title or content, e.g. sponsored."},
+	{Kind: KindRegex, Label: "Regular expression", Description: `Hides articles whose title or content matches the PostgreSQL regular expression, e.g. \m(hiring|job offer)\M. Case is ignored.`},
+	{Kind: KindAuthor, Label: "Author", Description: "Hides articles by the author, the whole name must match."},
+	{Kind: KindCategory, Label: "Category", Description: "Hides articles in the category, the whole name must match."},
+	{Kind: KindDomain, Label: "Domain", Description: "Hides articles linking to the domain and its subdomains, e.g. example.com also hides jobs.example.com."},
+}
+
+// KindLabel returns the label of a mute rule kind, the kind itself for unknown kinds
+func KindLabel(kind string) string {
+	for _, option := range KindOptions {
+		if option.Kind == kind {
+			return option.Label
+		}
+	}
+	return kind
+}
+
+// MuteRuleListViewModel represents the mute rules of the user.
+// Used by: GET /mute-rules
+type MuteRuleListViewModel struct {
+	Rules        []MuteRuleViewModel `json:"rules"`
+	ErrorMessage string              `json:"error_message,omitempty"`
+}
+
+// MuteRuleViewModel represents a single mute rule with the number of articles it hides.
+// Derived from database.PublicMuteRulesSelect.
+type MuteRuleViewModel struct {
+	ID         string    `json:"id"`
+	Kind       string    `json:"kind"`
+	KindLabel  string    `json:"kind_label"`
+	Pattern    string    `json:"pattern"`
+	MatchCount int       `json:"match_count"` // Stored articles the rule matches, other rules may match them too
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// NewMuteRuleFromDB creates a MuteRuleViewModel from database.PublicMuteRulesSelect.
+func NewMuteRuleFromDB(dbRule database.PublicMuteRulesSelect, matchCount int) MuteRuleViewModel {
+	vm := MuteRuleViewModel{
+		ID:         dbRule.Id,
+		Kind:       dbRule.Kind,
+		KindLabel:  KindLabel(dbRule.Kind),
+		Pattern:    dbRule.Pattern,
+		MatchCount: matchCount,
+	}
+
+	if createdAt, err := time.Parse(time.RFC3339, dbRule.CreatedAt); err == nil {
+		vm.CreatedAt = createdAt
+	}
+
+	return vm
+}
+
+// MuteRuleFormViewModel represents the form for adding a mute rule.
+// Used by: GET /settings, POST /mute-rules
+type MuteRuleFormViewModel struct {
+	Kind    string                     `json:"kind"`
+	Pattern string                     `json:"pattern"`
+	Errors  MuteRuleFormErrorViewModel `json:"errors"`
+}
+
+// MuteRuleFormErrorViewModel represents validation errors of the mute rule form.
+type MuteRuleFormErrorViewModel struct {
+	KindError    string `json:"kind_error,omitempty"`
+	PatternError string `json:"pattern_error,omitempty"`
+	GeneralError string `json:"general_error,omitempty"`
+}
+
+// NewMuteRuleFormErrorFromFieldErrors creates MuteRuleFormErrorViewModel from field error map.
+// Accepts a map of field names to error messages (from validator.ParseFieldErrors or a ServiceError).
+func NewMuteRuleFormErrorFromFieldErrors(fieldErrors map[string]string) MuteRuleFormErrorViewModel {
+	if fieldErrors == nil {
+		return MuteRuleFormErrorViewModel{GeneralError: "Invalid request"}
+	}
+
+	return MuteRuleFormErrorViewModel{
+		KindError:    fieldErrors["Kind"],
+		PatternError: fieldErrors["Pattern"],
+	}
+}
+
+// ToFormViewModel converts the command to a form view model with errors, keeping the input
+func (c *CreateMuteRuleCommand) ToFormViewModel(errors MuteRuleFormErrorViewModel) MuteRuleFormViewModel {
+	return MuteRuleFormViewModel{
+		Kind:    c.Kind,
+		Pattern: c.Pattern,
+		Errors:  errors,
+	}
+}
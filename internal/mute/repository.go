@@ -0,0 +1,117 @@
+package mute
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/supabase-community/postgrest-go"
+	"github.com/tjanas94/vibefeeder/internal/shared/database"
+	"github.com/tjanas94/vibefeeder/internal/shared/tracing"
+)
+
+// Repository handles data access for mute rules
+type Repository struct {
+	db *database.Client
+}
+
+// Ensure Repository implements MuteRuleRepository interface at compile time
+var _ MuteRuleRepository = (*Repository)(nil)
+
+// NewRepository creates a new mute rule repository
+func NewRepository(db *database.Client) *Repository {
+	return &Repository{db: db}
+}
+
+// ListRules retrieves the mute rules of the user, newest first
+func (r *Repository) ListRules(ctx context.Context, userID string) (_ []database.PublicMuteRulesSelect, err error) {
+	_, span := tracing.Start(ctx, "mute.Repository.ListRules")
+	defer func() { tracing.End(span, err) }()
+
+	// Get authenticated client for RLS
+	client, err := r.db.NewAuthenticatedClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []database.PublicMuteRulesSelect
+	_, err = client.From("mute_rules").
+		Select("*", "", false).
+		Eq("user_id", userID).
+		Order("created_at", &postgrest.OrderOpts{Ascending: false}).
+		ExecuteTo(&rules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch mute rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+// matchCount is a row returned by the count_mute_rule_matches function
+type matchCount struct {
+	RuleID     string `json:"rule_id"`
+	MatchCount int    `json:"match_count"`
+}
+
+// CountMatches counts the articles of the calling user every mute rule matches
+func (r *Repository) CountMatches(ctx context.Context) (_ map[string]int, err error) {
+	_, span := tracing.Start(ctx, "mute.Repository.CountMatches")
+	defer func() { tracing.End(span, err) }()
+
+	var rows []matchCount
+	if err = r.db.CallAuthenticatedRPC(ctx, "count_mute_rule_matches", map[string]any{}, &rows); err != nil {
+		return nil, fmt.Errorf("failed to count mute rule matches: %w", err)
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.RuleID] = row.MatchCount
+	}
+	return counts, nil
+}
+
+// InsertRule creates a new mute rule
+// Returns error that can be checked with database.IsUniqueViolationError for duplicate rules
+// and database.IsCheckViolationError for invalid regular expressions
+func (r *Repository) InsertRule(ctx context.Context, rule database.PublicMuteRulesInsert) (err error) {
+	_, span := tracing.Start(ctx, "mute.Repository.InsertRule")
+	defer func() { tracing.End(span, err) }()
+
+	// Get authenticated client for RLS
+	client, err := r.db.NewAuthenticatedClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = client.From("mute_rules").Insert(rule, false, "", "minimal", "").Execute()
+	if err != nil {
+		return fmt.Errorf("failed to insert mute rule: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteRule deletes a mute rule by ID and user ID
+// Returns error that can be checked with database.IsNotFoundError if the rule doesn't exist or doesn't belong to user
+func (r *Repository) DeleteRule(ctx context.Context, ruleID, userID string) (err error) {
+	_, span := tracing.Start(ctx, "mute.Repository.DeleteRule")
+	defer func() { tracing.End(span, err) }()
+
+	// Get authenticated client for RLS
+	client, err := r.db.NewAuthenticatedClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	var result database.PublicMuteRulesSelect
+	_, err = client.From("mute_rules").
+		Delete("", "").
+		Eq("id", ruleID).
+		Eq("user_id", userID).
+		Single().
+		ExecuteTo(&result)
+	if err != nil {
+		return fmt.Errorf("failed to delete mute rule: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package mute
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/tjanas94/vibefeeder/internal/mute/models"
+	"github.com/tjanas94/vibefeeder/internal/shared/database"
+)
+
+// MuteRuleRepository defines the interface for mute rule data access
+type MuteRuleRepository interface {
+	ListRules(ctx context.Context, userID string) ([]database.PublicMuteRulesSelect, error)
+	CountMatches(ctx context.Context) (map[string]int, error)
+	InsertRule(ctx context.Context, rule database.PublicMuteRulesInsert) error
+	DeleteRule(ctx context.Context, ruleID, userID string) error
+}
+
+// Service handles business logic for mute rules
+// The rules are applied by the database when articles are read (see the muted computed field)
+type Service struct {
+	repo   MuteRuleRepository
+	logger *slog.Logger
+}
+
+// NewService creates a new mute rule service
+func NewService(repo MuteRuleRepository, logger *slog.Logger) *Service {
+	return &Service{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// ListRules retrieves the mute rules of the user with the number of articles each one hides
+func (s *Service) ListRules(ctx context.Context, userID string) (*models.MuteRuleListViewModel, error) {
+	dbRules, err := s.repo.ListRules(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to list mute rules", "user_id", userID, "error", err)
+		return nil, NewDatabaseError(err)
+	}
+
+	// Counting matches every stored article, skip it when there is nothing to count
+	matchCounts := map[string]int{}
+	if len(dbRules) > 0 {
+		matchCounts, err = s.repo.CountMatches(ctx)
+		if err != nil {
+			s.logger.Error("failed to count mute rule matches", "user_id", userID, "error", err)
+			return nil, NewDatabaseError(err)
+		}
+	}
+
+	rules := make([]models.MuteRuleViewModel, len(dbRules))
+	for i, dbRule := range dbRules {
+		rules[i] = models.NewMuteRuleFromDB(dbRule, matchCounts[dbRule.Id])
+	}
+
+	return &models.MuteRuleListViewModel{Rules: rules}, nil
+}
+
+// CreateRule adds a mute rule for the authenticated user
+// The command must be normalized, see models.CreateMuteRuleCommand.Normalize
+func (s *Service) CreateRule(ctx context.Context, cmd models.CreateMuteRuleCommand) error {
+	if msg := cmd.PatternError(); msg != "" {
+		return NewInvalidPatternError(msg)
+	}
+
+	if err := s.repo.InsertRule(ctx, cmd.ToInsert()); err != nil {
+		if database.IsUniqueViolationError(err) {
+			return NewMuteRuleAlreadyExistsError()
+		}
+		// Regular expressions are validated by the database, it matches them
+		if database.IsCheckViolationError(err) {
+			return NewInvalidPatternError("Invalid regular expression")
+		}
+		return fmt.Errorf("failed to create mute rule %w", err)
+	}
+
+	return nil
+}
+
+// DeleteRule deletes a mute rule of the authenticated user
+func (s *Service) DeleteRule(ctx context.Context, ruleID, userID string) error {
+	if err := s.repo.DeleteRule(ctx, ruleID, userID); err != nil {
+		if database.IsNotFoundError(err) {
+			return NewMuteRuleNotFoundError()
+		}
+		return fmt.Errorf("failed to delete mute rule %w", err)
+	}
+
+	return nil
+}
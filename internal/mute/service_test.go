@@ -0,0 +1,230 @@
+package mute
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tjanas94/vibefeeder/internal/mute/models"
+	"github.com/tjanas94/vibefeeder/internal/shared/database"
+	sharederrors "github.com/tjanas94/vibefeeder/internal/shared/errors"
+)
+
+// MockMuteRuleRepository is a mock implementation of MuteRuleRepository
+type MockMuteRuleRepository struct {
+	mock.Mock
+}
+
+func (m *MockMuteRuleRepository) ListRules(ctx context.Context, userID string) ([]database.PublicMuteRulesSelect, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]database.PublicMuteRulesSelect), args.Error(1)
+}
+
+func (m *MockMuteRuleRepository) CountMatches(ctx context.Context) (map[string]int, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+func (m *MockMuteRuleRepository) InsertRule(ctx context.Context, rule database.PublicMuteRulesInsert) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+
+func (m *MockMuteRuleRepository) DeleteRule(ctx context.Context, ruleID, userID string) error {
+	args := m.Called(ctx, ruleID, userID)
+	return args.Error(0)
+}
+
+func newTestLogger() *slog.Logger {
+	// Use io.Discard to suppress log output during tests
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// Tests for ListRules
+func TestListRules_Success(t *testing.T) {
+	mockRepo := new(MockMuteRuleRepository)
+	service := NewService(mockRepo, newTestLogger())
+	ctx := context.Background()
+
+	mockRepo.On("ListRules", ctx, "user-123").Return([]database.PublicMuteRulesSelect{
+		{Id: "rule-1", Kind: models.KindDomain, Pattern: "example.com", CreatedAt: "2025-11-15T10:00:00Z"},
+		{Id: "rule-2", Kind: models.KindKeyword, Pattern: "sponsored", CreatedAt: "2025-11-14T10:00:00Z"},
+	}, nil)
+	// Rules without matches are missing from the counts
+	mockRepo.On("CountMatches", ctx).Return(map[string]int{"rule-1": 7}, nil)
+
+	vm, err := service.ListRules(ctx, "user-123")
+
+	require.NoError(t, err)
+	require.Len(t, vm.Rules, 2)
+	assert.Equal(t, "rule-1", vm.Rules[0].ID)
+	assert.Equal(t, "Domain", vm.Rules[0].KindLabel)
+	assert.Equal(t, 7, vm.Rules[0].MatchCount)
+	assert.False(t, vm.Rules[0].CreatedAt.IsZero())
+	assert.Equal(t, "Keyword", vm.Rules[1].KindLabel)
+	assert.Equal(t, 0, vm.Rules[1].MatchCount)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListRules_NoRulesSkipsCounting(t *testing.T) {
+	mockRepo := new(MockMuteRuleRepository)
+	service := NewService(mockRepo, newTestLogger())
+	ctx := context.Background()
+
+	mockRepo.On("ListRules", ctx, "user-123").Return([]database.PublicMuteRulesSelect{}, nil)
+
+	vm, err := service.ListRules(ctx, "user-123")
+
+	require.NoError(t, err)
+	assert.Empty(t, vm.Rules)
+	mockRepo.AssertNotCalled(t, "CountMatches", mock.Anything)
+}
+
+func TestListRules_CountError(t *testing.T) {
+	mockRepo := new(MockMuteRuleRepository)
+	service := NewService(mockRepo, newTestLogger())
+	ctx := context.Background()
+
+	mockRepo.On("ListRules", ctx, "user-123").Return([]database.PublicMuteRulesSelect{{Id: "rule-1"}}, nil)
+	mockRepo.On("CountMatches", ctx).Return(nil, errors.New("connection refused"))
+
+	vm, err := service.ListRules(ctx, "user-123")
+
+	assert.Nil(t, vm)
+	var serviceErr *sharederrors.ServiceError
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, http.StatusInternalServerError, serviceErr.Code)
+}
+
+// Tests for CreateRule
+func TestCreateRule_Success(t *testing.T) {
+	mockRepo := new(MockMuteRuleRepository)
+	service := NewService(mockRepo, newTestLogger())
+	ctx := context.Background()
+
+	cmd := models.CreateMuteRuleCommand{UserID: "user-123", Kind: models.KindKeyword, Pattern: "sponsored"}
+	mockRepo.On("InsertRule", ctx, database.PublicMuteRulesInsert{
+		UserId:  "user-123",
+		Kind:    models.KindKeyword,
+		Pattern: "sponsored",
+	}).Return(nil)
+
+	err := service.CreateRule(ctx, cmd)
+
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateRule_InvalidPatternNotInserted(t *testing.T) {
+	mockRepo := new(MockMuteRuleRepository)
+	service := NewService(mockRepo, newTestLogger())
+
+	cmd := models.CreateMuteRuleCommand{UserID: "user-123", Kind: models.KindKeyword, Pattern: "!!!"}
+
+	err := service.CreateRule(context.Background(), cmd)
+
+	var serviceErr *sharederrors.ServiceError
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, http.StatusUnprocessableEntity, serviceErr.Code)
+	assert.NotEmpty(t, serviceErr.FieldErrors["Pattern"])
+	mockRepo.AssertNotCalled(t, "InsertRule", mock.Anything, mock.Anything)
+}
+
+func TestCreateRule_DatabaseErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		repoErr      error
+		expectedCode int
+		patternError string
+	}{
+		{
+			name:         "duplicate rule",
+			repoErr:      errors.New("duplicate key value violates unique constraint"),
+			expectedCode: http.StatusConflict,
+			patternError: "You already have this rule",
+		},
+		{
+			name:         "invalid regular expression",
+			repoErr:      errors.New(`(23514) new row for relation "mute_rules" violates check constraint "valid_regex_pattern"`),
+			expectedCode: http.StatusUnprocessableEntity,
+			patternError: "Invalid regular expression",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockMuteRuleRepository)
+			service := NewService(mockRepo, newTestLogger())
+
+			mockRepo.On("InsertRule", mock.Anything, mock.Anything).Return(tt.repoErr)
+
+			err := service.CreateRule(context.Background(), models.CreateMuteRuleCommand{
+				UserID:  "user-123",
+				Kind:    models.KindRegex,
+				Pattern: "(unclosed",
+			})
+
+			var serviceErr *sharederrors.ServiceError
+			require.ErrorAs(t, err, &serviceErr)
+			assert.Equal(t, tt.expectedCode, serviceErr.Code)
+			assert.Equal(t, tt.patternError, serviceErr.FieldErrors["Pattern"])
+		})
+	}
+}
+
+func TestCreateRule_UnexpectedError(t *testing.T) {
+	mockRepo := new(MockMuteRuleRepository)
+	service := NewService(mockRepo, newTestLogger())
+
+	mockRepo.On("InsertRule", mock.Anything, mock.Anything).Return(errors.New("connection refused"))
+
+	err := service.CreateRule(context.Background(), models.CreateMuteRuleCommand{
+		UserID:  "user-123",
+		Kind:    models.KindAuthor,
+		Pattern: "Jane Doe",
+	})
+
+	require.Error(t, err)
+	var serviceErr *sharederrors.ServiceError
+	assert.False(t, errors.As(err, &serviceErr))
+}
+
+// Tests for DeleteRule
+func TestDeleteRule_Success(t *testing.T) {
+	mockRepo := new(MockMuteRuleRepository)
+	service := NewService(mockRepo, newTestLogger())
+	ctx := context.Background()
+
+	mockRepo.On("DeleteRule", ctx, "rule-1", "user-123").Return(nil)
+
+	err := service.DeleteRule(ctx, "rule-1", "user-123")
+
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDeleteRule_NotFound(t *testing.T) {
+	mockRepo := new(MockMuteRuleRepository)
+	service := NewService(mockRepo, newTestLogger())
+	ctx := context.Background()
+
+	mockRepo.On("DeleteRule", ctx, "rule-1", "user-123").Return(errors.New("no rows"))
+
+	err := service.DeleteRule(ctx, "rule-1", "user-123")
+
+	var serviceErr *sharederrors.ServiceError
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, http.StatusNotFound, serviceErr.Code)
+}
@@ -0,0 +1,11 @@
+package view
+
+import "fmt"
+
+// matchCountLabel describes how many articles a mute rule hides
+func matchCountLabel(count int) string {
+	if count == 1 {
+		return "1 article"
+	}
+	return fmt.Sprintf("%d articles", count)
+}
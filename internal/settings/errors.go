@@ -0,0 +1,30 @@
+package settings
+
+import (
+	"fmt"
+	"net/http"
+
+	sharederrors "github.com/tjanas94/vibefeeder/internal/shared/errors"
+)
+
+// NewRetentionTooLongError creates a ServiceError when the user's retention exceeds the server-wide one
+// Returns 422 Unprocessable Entity with field error
+func NewRetentionTooLongError(serverMaxAgeDays int) *sharederrors.ServiceError {
+	return sharederrors.NewServiceErrorWithFields(
+		http.StatusUnprocessableEntity,
+		"",
+		map[string]string{
+			"RetentionDays": fmt.Sprintf("This server deletes articles after %d days", serverMaxAgeDays),
+		},
+	)
+}
+
+// NewDatabaseError creates a ServiceError for database operation failures
+// Returns 500 Internal Server Error
+func NewDatabaseError(err error) *sharederrors.ServiceError {
+	return sharederrors.NewServiceErrorWithCause(
+		http.StatusInternalServerError,
+		"Database operation failed",
+		err,
+	)
+}
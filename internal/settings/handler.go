@@ -0,0 +1,97 @@
+package settings
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/tjanas94/vibefeeder/internal/settings/models"
+	"github.com/tjanas94/vibefeeder/internal/settings/view"
+	"github.com/tjanas94/vibefeeder/internal/shared/auth"
+	sharederrors "github.com/tjanas94/vibefeeder/internal/shared/errors"
+	"github.com/tjanas94/vibefeeder/internal/shared/validator"
+)
+
+// Handler handles HTTP requests for the settings page
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new settings handler
+func NewHandler(service *Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// ShowSettings handles GET /settings endpoint
+// Renders the settings page; the sections are loaded by htmx (GET /mute-rules, GET /settings/retention)
+func (h *Handler) ShowSettings(c echo.Context) error {
+	vm := models.SettingsViewModel{
+		Title:     "Settings - VibeFeeder",
+		UserEmail: auth.GetUserEmail(c),
+	}
+
+	return c.Render(http.StatusOK, "", view.Settings(vm))
+}
+
+// GetRetentionForm handles GET /settings/retention endpoint
+// Returns the article retention form of the authenticated user as an HTML fragment
+func (h *Handler) GetRetentionForm(c echo.Context) error {
+	vm, err := h.service.GetRetentionForm(c.Request().Context(), auth.GetUserID(c))
+	if err != nil {
+		// Path 3: Handle business errors (ServiceError)
+		var serviceErr *sharederrors.ServiceError
+		if errors.As(err, &serviceErr) {
+			errVM := models.RetentionFormViewModel{
+				ServerMaxAgeDays: h.service.ServerMaxAgeDays(),
+				Errors:           models.RetentionFormErrorViewModel{GeneralError: serviceErr.Message},
+			}
+			return c.Render(serviceErr.Code, "", view.RetentionForm(errVM))
+		}
+
+		// Path 4: Unexpected error - delegate to global error handler
+		return err
+	}
+
+	return c.Render(http.StatusOK, "", view.RetentionForm(*vm))
+}
+
+// UpdateRetention handles PUT /settings/retention endpoint
+// Changes the article retention of the authenticated user and re-renders the form
+func (h *Handler) UpdateRetention(c echo.Context) error {
+	cmd := new(models.UpdateRetentionCommand)
+	// Path 1: Handle bind errors (invalid request format)
+	if err := c.Bind(cmd); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid form data")
+	}
+
+	// Get user ID from authenticated session
+	cmd.UserID = auth.GetUserID(c)
+
+	// Path 2: Handle validation errors (invalid data)
+	if err := c.Validate(cmd); err != nil {
+		fieldErrors := validator.ParseFieldErrors(err)
+		errorVM := models.NewRetentionFormErrorFromFieldErrors(fieldErrors)
+		return c.Render(http.StatusUnprocessableEntity, "", view.RetentionForm(cmd.ToFormViewModel(h.service.ServerMaxAgeDays(), errorVM)))
+	}
+
+	vm, err := h.service.UpdateRetention(c.Request().Context(), *cmd)
+	if err != nil {
+		// Path 3: Handle business errors (ServiceError)
+		var serviceErr *sharederrors.ServiceError
+		if errors.As(err, &serviceErr) {
+			errorVM := models.RetentionFormErrorViewModel{
+				GeneralError:       serviceErr.Message,
+				RetentionDaysError: serviceErr.FieldErrors["RetentionDays"],
+			}
+			return c.Render(serviceErr.Code, "", view.RetentionForm(cmd.ToFormViewModel(h.service.ServerMaxAgeDays(), errorVM)))
+		}
+
+		// Path 4: Unexpected error - delegate to global error handler
+		return err
+	}
+
+	// Success - render the saved form with a toast
+	return c.Render(http.StatusOK, "", view.RetentionSaved(*vm))
+}
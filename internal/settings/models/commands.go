@@ -0,0 +1,21 @@
+package models
+
+import "github.com/tjanas94/vibefeeder/internal/shared/database"
+
+// UpdateRetentionCommand represents the input for changing the article retention of the user.
+// Maps to database.PublicUserSettingsInsert.
+// Used by: PUT /settings/retention
+type UpdateRetentionCommand struct {
+	UserID        string `form:"-"`
+	RetentionDays int    `form:"retention_days" validate:"omitempty,min=1,max=3650"` // 0 (empty field) for the server default
+}
+
+// ToInsert converts the command to a database upsert of the user's settings
+func (c *UpdateRetentionCommand) ToInsert() database.PublicUserSettingsInsert {
+	settings := database.PublicUserSettingsInsert{UserId: c.UserID}
+	if c.RetentionDays > 0 {
+		days := c.RetentionDays
+		settings.ArticleRetentionDays = &days
+	}
+	return settings
+}
@@ -0,0 +1,68 @@
+package models
+
+import "github.com/tjanas94/vibefeeder/internal/shared/database"
+
+// SettingsViewModel contains the data needed to render the settings page.
+// The sections are loaded by htmx, the page only renders their containers.
+// Used by: GET /settings
+type SettingsViewModel struct {
+	Title     string
+	UserEmail string
+}
+
+// RetentionFormViewModel represents the article retention form.
+// Used by: GET /settings/retention, PUT /settings/retention
+type RetentionFormViewModel struct {
+	RetentionDays    int                         `json:"retention_days"`      // Retention chosen by the user, 0 for the server default
+	ServerMaxAgeDays int                         `json:"server_max_age_days"` // Server-wide retention, 0 when articles are kept forever
+	Errors           RetentionFormErrorViewModel `json:"errors"`
+}
+
+// RetentionFormErrorViewModel represents validation errors of the retention form.
+type RetentionFormErrorViewModel struct {
+	RetentionDaysError string `json:"retention_days_error,omitempty"`
+	GeneralError       string `json:"general_error,omitempty"`
+}
+
+// NewRetentionFormFromDB creates a RetentionFormViewModel from the user's settings (nil when the user has none)
+func NewRetentionFormFromDB(dbSettings *database.PublicUserSettingsSelect, serverMaxAgeDays int) RetentionFormViewModel {
+	vm := RetentionFormViewModel{ServerMaxAgeDays: serverMaxAgeDays}
+	if dbSettings != nil && dbSettings.ArticleRetentionDays != nil {
+		vm.RetentionDays = *dbSettings.ArticleRetentionDays
+	}
+	return vm
+}
+
+// EffectiveDays returns after how many days the user's articles are deleted, 0 when they are kept forever
+// The shorter of the user's and the server-wide retention applies
+func (vm RetentionFormViewModel) EffectiveDays() int {
+	switch {
+	case vm.RetentionDays == 0:
+		return vm.ServerMaxAgeDays
+	case vm.ServerMaxAgeDays == 0:
+		return vm.RetentionDays
+	default:
+		return min(vm.RetentionDays, vm.ServerMaxAgeDays)
+	}
+}
+
+// NewRetentionFormErrorFromFieldErrors creates RetentionFormErrorViewModel from field error map.
+// Accepts a map of field names to error messages (from validator.ParseFieldErrors or a ServiceError).
+func NewRetentionFormErrorFromFieldErrors(fieldErrors map[string]string) RetentionFormErrorViewModel {
+	if fieldErrors == nil {
+		return RetentionFormErrorViewModel{GeneralError: "Invalid request"}
+	}
+
+	return RetentionFormErrorViewModel{
+		RetentionDaysError: fieldErrors["RetentionDays"],
+	}
+}
+
+// ToFormViewModel converts the command to a form view model with errors, keeping the input
+func (c *UpdateRetentionCommand) ToFormViewModel(serverMaxAgeDays int, errors RetentionFormErrorViewModel) RetentionFormViewModel {
+	return RetentionFormViewModel{
+		RetentionDays:    c.RetentionDays,
+		ServerMaxAgeDays: serverMaxAgeDays,
+		Errors:           errors,
+	}
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRetentionFormViewModel_EffectiveDays tests that the shorter retention applies
+func TestRetentionFormViewModel_EffectiveDays(t *testing.T) {
+	tests := []struct {
+		name     string
+		user     int
+		server   int
+		expected int
+	}{
+		{name: "no retention", user: 0, server: 0, expected: 0},
+		{name: "server default", user: 0, server: 90, expected: 90},
+		{name: "user retention only", user: 30, server: 0, expected: 30},
+		{name: "user retention shorter", user: 30, server: 90, expected: 30},
+		{name: "server retention shorter", user: 120, server: 90, expected: 90},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := RetentionFormViewModel{RetentionDays: tt.user, ServerMaxAgeDays: tt.server}
+			assert.Equal(t, tt.expected, vm.EffectiveDays())
+		})
+	}
+}
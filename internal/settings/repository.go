@@ -0,0 +1,72 @@
+package settings
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tjanas94/vibefeeder/internal/shared/database"
+	"github.com/tjanas94/vibefeeder/internal/shared/tracing"
+)
+
+// Repository handles data access for user settings
+type Repository struct {
+	db *database.Client
+}
+
+// Ensure Repository implements SettingsRepository interface at compile time
+var _ SettingsRepository = (*Repository)(nil)
+
+// NewRepository creates a new settings repository
+func NewRepository(db *database.Client) *Repository {
+	return &Repository{db: db}
+}
+
+// FindSettings retrieves the settings of the user
+// Returns nil without error when the user has not changed any setting yet
+func (r *Repository) FindSettings(ctx context.Context, userID string) (_ *database.PublicUserSettingsSelect, err error) {
+	_, span := tracing.Start(ctx, "settings.Repository.FindSettings")
+	defer func() { tracing.End(span, err) }()
+
+	// Get authenticated client for RLS
+	client, err := r.db.NewAuthenticatedClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var settings []database.PublicUserSettingsSelect
+	_, err = client.From("user_settings").
+		Select("*", "", false).
+		Eq("user_id", userID).
+		Limit(1, "").
+		ExecuteTo(&settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user settings: %w", err)
+	}
+
+	if len(settings) == 0 {
+		return nil, nil
+	}
+
+	return &settings[0], nil
+}
+
+// SaveSettings creates or replaces the settings of the user
+func (r *Repository) SaveSettings(ctx context.Context, settings database.PublicUserSettingsInsert) (err error) {
+	_, span := tracing.Start(ctx, "settings.Repository.SaveSettings")
+	defer func() { tracing.End(span, err) }()
+
+	// Get authenticated client for RLS
+	client, err := r.db.NewAuthenticatedClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = client.From("user_settings").
+		Upsert(settings, "user_id", "minimal", "").
+		Execute()
+	if err != nil {
+		return fmt.Errorf("failed to save user settings: %w", err)
+	}
+
+	return nil
+}
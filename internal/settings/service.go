@@ -0,0 +1,65 @@
+package settings
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/tjanas94/vibefeeder/internal/settings/models"
+	"github.com/tjanas94/vibefeeder/internal/shared/database"
+)
+
+// SettingsRepository defines the interface for user settings data access
+type SettingsRepository interface {
+	FindSettings(ctx context.Context, userID string) (*database.PublicUserSettingsSelect, error)
+	SaveSettings(ctx context.Context, settings database.PublicUserSettingsInsert) error
+}
+
+// Service handles business logic for user settings
+type Service struct {
+	repo             SettingsRepository
+	serverMaxAgeDays int // Server-wide article retention, 0 when articles are kept forever
+	logger           *slog.Logger
+}
+
+// NewService creates a new settings service
+func NewService(repo SettingsRepository, serverMaxAgeDays int, logger *slog.Logger) *Service {
+	return &Service{
+		repo:             repo,
+		serverMaxAgeDays: serverMaxAgeDays,
+		logger:           logger,
+	}
+}
+
+// ServerMaxAgeDays returns the server-wide article retention, 0 when articles are kept forever
+func (s *Service) ServerMaxAgeDays() int {
+	return s.serverMaxAgeDays
+}
+
+// GetRetentionForm retrieves the article retention of the user for editing
+func (s *Service) GetRetentionForm(ctx context.Context, userID string) (*models.RetentionFormViewModel, error) {
+	dbSettings, err := s.repo.FindSettings(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to find user settings", "user_id", userID, "error", err)
+		return nil, NewDatabaseError(err)
+	}
+
+	vm := models.NewRetentionFormFromDB(dbSettings, s.serverMaxAgeDays)
+	return &vm, nil
+}
+
+// UpdateRetention changes the article retention of the user
+// The retention job applies it on its next run; a retention longer than the server-wide one is rejected,
+// the shorter one always applies
+func (s *Service) UpdateRetention(ctx context.Context, cmd models.UpdateRetentionCommand) (*models.RetentionFormViewModel, error) {
+	if s.serverMaxAgeDays > 0 && cmd.RetentionDays > s.serverMaxAgeDays {
+		return nil, NewRetentionTooLongError(s.serverMaxAgeDays)
+	}
+
+	if err := s.repo.SaveSettings(ctx, cmd.ToInsert()); err != nil {
+		s.logger.Error("failed to save user settings", "user_id", cmd.UserID, "error", err)
+		return nil, NewDatabaseError(err)
+	}
+
+	vm := cmd.ToFormViewModel(s.serverMaxAgeDays, models.RetentionFormErrorViewModel{})
+	return &vm, nil
+}
@@ -0,0 +1,152 @@
+package settings
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tjanas94/vibefeeder/internal/settings/models"
+	"github.com/tjanas94/vibefeeder/internal/shared/database"
+	sharederrors "github.com/tjanas94/vibefeeder/internal/shared/errors"
+)
+
+// MockSettingsRepository is a mock implementation of SettingsRepository
+type MockSettingsRepository struct {
+	mock.Mock
+}
+
+func (m *MockSettingsRepository) FindSettings(ctx context.Context, userID string) (*database.PublicUserSettingsSelect, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*database.PublicUserSettingsSelect), args.Error(1)
+}
+
+func (m *MockSettingsRepository) SaveSettings(ctx context.Context, settings database.PublicUserSettingsInsert) error {
+	args := m.Called(ctx, settings)
+	return args.Error(0)
+}
+
+func newTestLogger() *slog.Logger {
+	// Use io.Discard to suppress log output during tests
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// Tests for GetRetentionForm
+func TestGetRetentionForm_UserRetention(t *testing.T) {
+	mockRepo := new(MockSettingsRepository)
+	service := NewService(mockRepo, 90, newTestLogger())
+	ctx := context.Background()
+
+	days := 30
+	mockRepo.On("FindSettings", ctx, "user-123").Return(&database.PublicUserSettingsSelect{
+		UserId:               "user-123",
+		ArticleRetentionDays: &days,
+	}, nil)
+
+	vm, err := service.GetRetentionForm(ctx, "user-123")
+
+	require.NoError(t, err)
+	assert.Equal(t, 30, vm.RetentionDays)
+	assert.Equal(t, 90, vm.ServerMaxAgeDays)
+}
+
+func TestGetRetentionForm_NoSettings(t *testing.T) {
+	mockRepo := new(MockSettingsRepository)
+	service := NewService(mockRepo, 90, newTestLogger())
+	ctx := context.Background()
+
+	mockRepo.On("FindSettings", ctx, "user-123").Return(nil, nil)
+
+	vm, err := service.GetRetentionForm(ctx, "user-123")
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, vm.RetentionDays)
+	assert.Equal(t, 90, vm.EffectiveDays())
+}
+
+func TestGetRetentionForm_DatabaseError(t *testing.T) {
+	mockRepo := new(MockSettingsRepository)
+	service := NewService(mockRepo, 90, newTestLogger())
+	ctx := context.Background()
+
+	mockRepo.On("FindSettings", ctx, "user-123").Return(nil, errors.New("connection refused"))
+
+	vm, err := service.GetRetentionForm(ctx, "user-123")
+
+	assert.Nil(t, vm)
+	var serviceErr *sharederrors.ServiceError
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, http.StatusInternalServerError, serviceErr.Code)
+}
+
+// Tests for UpdateRetention
+func TestUpdateRetention_Success(t *testing.T) {
+	days := 14
+	tests := []struct {
+		name     string
+		days     int
+		expected database.PublicUserSettingsInsert
+	}{
+		{
+			name:     "user retention",
+			days:     14,
+			expected: database.PublicUserSettingsInsert{UserId: "user-123", ArticleRetentionDays: &days},
+		},
+		{
+			name:     "empty field resets to the server default",
+			days:     0,
+			expected: database.PublicUserSettingsInsert{UserId: "user-123"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockSettingsRepository)
+			service := NewService(mockRepo, 90, newTestLogger())
+			ctx := context.Background()
+
+			mockRepo.On("SaveSettings", ctx, tt.expected).Return(nil)
+
+			vm, err := service.UpdateRetention(ctx, models.UpdateRetentionCommand{UserID: "user-123", RetentionDays: tt.days})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.days, vm.RetentionDays)
+			assert.Equal(t, 90, vm.ServerMaxAgeDays)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateRetention_LongerThanServer(t *testing.T) {
+	mockRepo := new(MockSettingsRepository)
+	service := NewService(mockRepo, 90, newTestLogger())
+
+	vm, err := service.UpdateRetention(context.Background(), models.UpdateRetentionCommand{UserID: "user-123", RetentionDays: 365})
+
+	assert.Nil(t, vm)
+	var serviceErr *sharederrors.ServiceError
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, http.StatusUnprocessableEntity, serviceErr.Code)
+	assert.NotEmpty(t, serviceErr.FieldErrors["RetentionDays"])
+	mockRepo.AssertNotCalled(t, "SaveSettings", mock.Anything, mock.Anything)
+}
+
+func TestUpdateRetention_NoServerRetention(t *testing.T) {
+	mockRepo := new(MockSettingsRepository)
+	service := NewService(mockRepo, 0, newTestLogger())
+
+	mockRepo.On("SaveSettings", mock.Anything, mock.Anything).Return(nil)
+
+	vm, err := service.UpdateRetention(context.Background(), models.UpdateRetentionCommand{UserID: "user-123", RetentionDays: 3650})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3650, vm.EffectiveDays())
+}
@@ -0,0 +1,37 @@
+package view
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/tjanas94/vibefeeder/internal/settings/models"
+)
+
+// retentionPlaceholder describes the server default shown in the empty retention field
+func retentionPlaceholder(serverMaxAgeDays int) string {
+	if serverMaxAgeDays == 0 {
+		return "Keep forever"
+	}
+	return fmt.Sprintf("Server default: %d", serverMaxAgeDays)
+}
+
+// retentionSummary describes when the articles of the user are deleted
+func retentionSummary(vm models.RetentionFormViewModel) string {
+	days := vm.EffectiveDays()
+	switch days {
+	case 0:
+		return "Articles are kept forever."
+	case 1:
+		return "Articles are deleted 1 day after publication."
+	default:
+		return fmt.Sprintf("Articles are deleted %d days after publication.", days)
+	}
+}
+
+// retentionValue renders the retention of the user, empty for the server default
+func retentionValue(days int) string {
+	if days == 0 {
+		return ""
+	}
+	return strconv.Itoa(days)
+}
@@ -7,8 +7,12 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/tjanas94/vibefeeder/internal/shared/config"
+	"github.com/tjanas94/vibefeeder/internal/shared/metrics"
+	"github.com/tjanas94/vibefeeder/internal/shared/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const (
@@ -112,7 +116,12 @@ func NewOpenRouterService(cfg config.OpenRouterConfig, httpClient HTTPClient) (*
 
 // GenerateChatCompletion sends a chat completion request to OpenRouter API.
 // Returns the parsed response or an error.
-func (s *OpenRouterService) GenerateChatCompletion(ctx context.Context, options GenerateChatCompletionOptions) (*ChatCompletionResponse, error) {
+func (s *OpenRouterService) GenerateChatCompletion(ctx context.Context, options GenerateChatCompletionOptions) (_ *ChatCompletionResponse, err error) {
+	ctx, span := tracing.Start(ctx, "ai.OpenRouterService.GenerateChatCompletion",
+		attribute.String("gen_ai.request.model", options.Model),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	// Validate input
 	if options.UserPrompt == "" {
 		return nil, fmt.Errorf("user prompt is required")
@@ -128,7 +137,22 @@ func (s *OpenRouterService) GenerateChatCompletion(ctx context.Context, options
 		return nil, fmt.Errorf("failed to build request: %w", err)
 	}
 
-	// Send request
+	// Send request and parse response
+	start := time.Now()
+	chatResp, err := s.send(req)
+	recordMetrics(options.Model, time.Since(start), chatResp, err)
+	if chatResp != nil && chatResp.Usage != nil {
+		span.SetAttributes(
+			attribute.Int("gen_ai.usage.input_tokens", chatResp.Usage.PromptTokens),
+			attribute.Int("gen_ai.usage.output_tokens", chatResp.Usage.CompletionTokens),
+		)
+	}
+
+	return chatResp, err
+}
+
+// send executes the request and parses the response
+func (s *OpenRouterService) send(req *http.Request) (*ChatCompletionResponse, error) {
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %w", err)
@@ -137,10 +161,22 @@ func (s *OpenRouterService) GenerateChatCompletion(ctx context.Context, options
 		_ = resp.Body.Close()
 	}()
 
-	// Parse response
 	return s.parseResponse(resp)
 }
 
+// recordMetrics records latency, errors and token usage of a chat completion call
+func recordMetrics(model string, duration time.Duration, resp *ChatCompletionResponse, err error) {
+	metrics.AIRequestDuration.WithLabelValues(model).Observe(duration.Seconds())
+	if err != nil {
+		metrics.AIErrorsTotal.WithLabelValues(model).Inc()
+		return
+	}
+	if resp.Usage != nil {
+		metrics.AITokensTotal.WithLabelValues(model, "prompt").Add(float64(resp.Usage.PromptTokens))
+		metrics.AITokensTotal.WithLabelValues(model, "completion").Add(float64(resp.Usage.CompletionTokens))
+	}
+}
+
 // buildRequest creates an HTTP request from the given options
 func (s *OpenRouterService) buildRequest(ctx context.Context, options GenerateChatCompletionOptions) (*http.Request, error) {
 	// Build messages array
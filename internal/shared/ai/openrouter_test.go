@@ -10,10 +10,12 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/tjanas94/vibefeeder/internal/shared/config"
+	"github.com/tjanas94/vibefeeder/internal/shared/metrics"
 )
 
 // MockHTTPClient is a mock implementation of HTTPClient interface
@@ -839,3 +841,32 @@ func TestBuildRequest_BodyStructure(t *testing.T) {
 	assert.Equal(t, "user", reqBody.Messages[1].Role)
 	assert.Equal(t, "User", reqBody.Messages[1].Content)
 }
+
+// Tests for metrics
+func TestGenerateChatCompletion_RecordsMetrics(t *testing.T) {
+	cfg := newTestConfig("test-api-key")
+	mockHTTP := new(MockHTTPClient)
+	service, _ := NewOpenRouterService(cfg, mockHTTP)
+
+	model := "test/metrics-model"
+	options := newTestChatCompletionOptions(model, "System", "User")
+	responseBody := `{
+		"id": "response-123",
+		"model": "test/metrics-model",
+		"choices": [{"index": 0, "message": {"role": "assistant", "content": "Hi"}, "finish_reason": "stop"}],
+		"usage": {"prompt_tokens": 10, "completion_tokens": 15, "total_tokens": 25}
+	}`
+
+	mockHTTP.On("Do", mock.AnythingOfType("*http.Request")).Return(newTestHTTPResponse(http.StatusOK, responseBody), nil).Once()
+	mockHTTP.On("Do", mock.AnythingOfType("*http.Request")).Return(nil, errors.New("network error")).Once()
+
+	_, err := service.GenerateChatCompletion(context.Background(), options)
+	require.NoError(t, err)
+	_, err = service.GenerateChatCompletion(context.Background(), options)
+	require.Error(t, err)
+
+	assert.Equal(t, 10.0, testutil.ToFloat64(metrics.AITokensTotal.WithLabelValues(model, "prompt")))
+	assert.Equal(t, 15.0, testutil.ToFloat64(metrics.AITokensTotal.WithLabelValues(model, "completion")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.AIErrorsTotal.WithLabelValues(model)))
+	mockHTTP.AssertExpectations(t)
+}
@@ -11,13 +11,17 @@ import (
 
 // Config holds all application configuration
 type Config struct {
-	Server     ServerConfig
-	Supabase   SupabaseConfig
-	Auth       AuthConfig
-	Log        LogConfig
-	OpenRouter OpenRouterConfig
-	Fetcher    FetcherConfig
-	RateLimit  RateLimitConfig
+	Server      ServerConfig
+	Supabase    SupabaseConfig
+	Auth        AuthConfig
+	Log         LogConfig
+	OpenRouter  OpenRouterConfig
+	Fetcher     FetcherConfig
+	Retention   RetentionConfig
+	RateLimit   RateLimitConfig
+	Credentials CredentialsConfig
+	Metrics     MetricsConfig
+	Tracing     TracingConfig
 }
 
 // ServerConfig contains server configuration
@@ -58,17 +62,58 @@ type RateLimitConfig struct {
 	SummaryGenerationInterval time.Duration // Minimum interval between summary generation requests per user
 }
 
+// CredentialsConfig contains configuration for storing feed credentials
+type CredentialsConfig struct {
+	EncryptionKey string // Base64-encoded 32-byte AES key for feed credentials (empty disables feed credentials)
+}
+
+// MetricsConfig contains Prometheus metrics endpoint configuration
+// The endpoint is disabled unless a token or a separate listen address is set
+type MetricsConfig struct {
+	Token   string // Bearer token required to scrape /metrics (optional when Address is set)
+	Address string // Separate listen address for /metrics, e.g. 127.0.0.1:9090 (empty serves it on the main server)
+}
+
+// Enabled reports whether the metrics endpoint is exposed
+func (c MetricsConfig) Enabled() bool {
+	return c.Token != "" || c.Address != ""
+}
+
+// TracingConfig contains OpenTelemetry tracing configuration
+// OTLP endpoint, headers and protocol options use the standard OTEL_EXPORTER_OTLP_* variables
+type TracingConfig struct {
+	Exporter    string  // otlp, stdout or empty to disable tracing
+	ServiceName string  // Service name reported with spans
+	SampleRatio float64 // Fraction of traces recorded (0-1), traces started upstream follow the caller's decision
+}
+
 // FetcherConfig holds configuration for the feed fetcher service
 type FetcherConfig struct {
-	FetchInterval       time.Duration // How often to check for feeds to fetch (in seconds)
-	SuccessInterval     time.Duration // Minimum interval after successful fetch (in seconds)
-	WorkerCount         int           // Number of concurrent workers
-	BatchSize           int           // Maximum number of feeds to process per batch
-	DomainDelay         time.Duration // Delay between requests to same domain (in seconds)
-	JobTimeout          time.Duration // Timeout for entire job (in seconds)
-	RequestTimeout      time.Duration // Timeout for HTTP request (in seconds)
-	MaxArticlesPerFeed  int           // Maximum number of articles to save per feed
-	MaxResponseBodySize int64         // Maximum response body size in bytes
+	FetchInterval          time.Duration // How often to check for feeds to fetch (in seconds)
+	SuccessInterval        time.Duration // Polling interval for feeds whose publishing cadence is not known yet (in seconds)
+	MinInterval            time.Duration // Lower bound of the adaptive polling interval (in seconds)
+	MaxInterval            time.Duration // Upper bound of the adaptive polling interval (in seconds)
+	WorkerCount            int           // Number of concurrent workers
+	BatchSize              int           // Maximum number of feeds to process per batch
+	DomainDelay            time.Duration // Delay between requests to same domain (in seconds)
+	JobTimeout             time.Duration // Timeout for entire job (in seconds)
+	RequestTimeout         time.Duration // Timeout for HTTP request (in seconds)
+	MaxArticlesPerFeed     int           // Maximum number of articles to save per feed
+	MaxResponseBodySize    int64         // Maximum response body size in bytes
+	FullContentMaxArticles int           // Maximum number of article pages downloaded per feed fetch for full content
+	FetchLogRetention      time.Duration // How long fetch history entries are kept (in seconds)
+	LeaseDuration          time.Duration // How long a claimed feed stays reserved for this instance (in seconds)
+	WebSubCallbackURL      string        // Public base URL of the WebSub callback endpoint (empty disables WebSub)
+	WebSubLeaseDuration    time.Duration // Requested WebSub subscription lease (in seconds)
+	WebSubPollInterval     time.Duration // Fallback polling interval for feeds with an active WebSub subscription (in seconds)
+}
+
+// RetentionConfig holds configuration for the article retention job
+// Users can choose a shorter retention for their own articles in the settings
+type RetentionConfig struct {
+	MaxAgeDays int           // Days after publication articles are deleted (0 keeps them unless the user sets a retention)
+	Interval   time.Duration // How often expired articles are deleted (in seconds)
+	BatchSize  int           // Maximum number of articles deleted per statement
 }
 
 // Load reads configuration from environment variables
@@ -108,19 +153,44 @@ func Load() (*Config, error) {
 			APIKey: os.Getenv("OPENROUTER_API_KEY"),
 		},
 		Fetcher: FetcherConfig{
-			FetchInterval:       getDurationSeconds("FETCHER_INTERVAL", 300),          // 5 minutes
-			SuccessInterval:     getDurationSeconds("FETCHER_SUCCESS_INTERVAL", 3600), // 1 hour
-			WorkerCount:         getEnvInt("FETCHER_WORKERS", 10),
-			BatchSize:           getEnvInt("FETCHER_BATCH_SIZE", 1000),
-			DomainDelay:         getDurationSeconds("FETCHER_DOMAIN_DELAY", 3),
-			RequestTimeout:      getDurationSeconds("FETCHER_REQUEST_TIMEOUT", 30),
-			JobTimeout:          getDurationSeconds("FETCHER_JOB_TIMEOUT", 45),
-			MaxArticlesPerFeed:  getEnvInt("FETCHER_MAX_ARTICLES", 100),
-			MaxResponseBodySize: int64(getEnvInt("FETCHER_MAX_BODY_SIZE_MB", 2) * 1024 * 1024),
+			FetchInterval:          getDurationSeconds("FETCHER_INTERVAL", 300),          // 5 minutes
+			SuccessInterval:        getDurationSeconds("FETCHER_SUCCESS_INTERVAL", 3600), // 1 hour
+			MinInterval:            getDurationSeconds("FETCHER_MIN_INTERVAL", 900),      // 15 minutes
+			MaxInterval:            getDurationSeconds("FETCHER_MAX_INTERVAL", 86400),    // 24 hours
+			WorkerCount:            getEnvInt("FETCHER_WORKERS", 10),
+			BatchSize:              getEnvInt("FETCHER_BATCH_SIZE", 1000),
+			DomainDelay:            getDurationSeconds("FETCHER_DOMAIN_DELAY", 3),
+			RequestTimeout:         getDurationSeconds("FETCHER_REQUEST_TIMEOUT", 30),
+			JobTimeout:             getDurationSeconds("FETCHER_JOB_TIMEOUT", 45),
+			MaxArticlesPerFeed:     getEnvInt("FETCHER_MAX_ARTICLES", 100),
+			MaxResponseBodySize:    int64(getEnvInt("FETCHER_MAX_BODY_SIZE_MB", 2) * 1024 * 1024),
+			FullContentMaxArticles: getEnvInt("FETCHER_FULL_CONTENT_MAX_ARTICLES", 5),
+			FetchLogRetention:      getDurationSeconds("FETCHER_FETCH_LOG_RETENTION", 1209600), // 14 days
+			LeaseDuration:          getDurationSeconds("FETCHER_LEASE_DURATION", 600),          // 10 minutes
+			WebSubCallbackURL:      os.Getenv("FETCHER_WEBSUB_CALLBACK_URL"),                   // Optional - empty disables WebSub
+			WebSubLeaseDuration:    getDurationSeconds("FETCHER_WEBSUB_LEASE", 864000),         // 10 days
+			WebSubPollInterval:     getDurationSeconds("FETCHER_WEBSUB_POLL_INTERVAL", 86400),  // 24 hours
+		},
+		Retention: RetentionConfig{
+			MaxAgeDays: getEnvInt("RETENTION_MAX_AGE_DAYS", 0),         // Keep forever unless set
+			Interval:   getDurationSeconds("RETENTION_INTERVAL", 3600), // 1 hour
+			BatchSize:  getEnvInt("RETENTION_BATCH_SIZE", 1000),
 		},
 		RateLimit: RateLimitConfig{
 			SummaryGenerationInterval: getDurationSeconds("RATE_LIMIT_SUMMARY_INTERVAL", 30), // 30 seconds (for testing, use 300 for production)
 		},
+		Credentials: CredentialsConfig{
+			EncryptionKey: os.Getenv("CREDENTIALS_ENCRYPTION_KEY"), // Optional - empty disables feed credentials
+		},
+		Tracing: TracingConfig{
+			Exporter:    os.Getenv("TRACING_EXPORTER"), // Optional - empty disables tracing
+			ServiceName: getEnvOrDefault("TRACING_SERVICE_NAME", "vibefeeder"),
+			SampleRatio: getEnvFloat("TRACING_SAMPLE_RATIO", 1.0),
+		},
+		Metrics: MetricsConfig{
+			Token:   os.Getenv("METRICS_TOKEN"),   // Optional - required on the main server
+			Address: os.Getenv("METRICS_ADDRESS"), // Optional - empty serves /metrics on the main server
+		},
 	}
 
 	if err := cfg.validate(); err != nil {
@@ -148,6 +218,16 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getEnvFloat returns environment variable as float or default
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
 // getDurationSeconds returns environment variable as duration in seconds or default
 func getDurationSeconds(key string, defaultSeconds int) time.Duration {
 	seconds := getEnvInt(key, defaultSeconds)
@@ -168,5 +248,35 @@ func (c *Config) validate() error {
 		return fmt.Errorf("OPENROUTER_API_KEY is required")
 	}
 
+	if c.Fetcher.MinInterval > c.Fetcher.MaxInterval {
+		return fmt.Errorf("FETCHER_MIN_INTERVAL must not be greater than FETCHER_MAX_INTERVAL")
+	}
+
+	if c.Fetcher.LeaseDuration <= c.Fetcher.JobTimeout {
+		return fmt.Errorf("FETCHER_LEASE_DURATION must be greater than FETCHER_JOB_TIMEOUT")
+	}
+
+	if c.Retention.MaxAgeDays < 0 {
+		return fmt.Errorf("RETENTION_MAX_AGE_DAYS must not be negative")
+	}
+
+	if c.Retention.Interval <= 0 || c.Retention.BatchSize <= 0 {
+		return fmt.Errorf("RETENTION_INTERVAL and RETENTION_BATCH_SIZE must be positive")
+	}
+
+	switch c.Tracing.Exporter {
+	case "", "otlp", "stdout":
+	default:
+		return fmt.Errorf("TRACING_EXPORTER must be otlp, stdout or empty")
+	}
+
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		return fmt.Errorf("TRACING_SAMPLE_RATIO must be between 0 and 1")
+	}
+
+	if c.Metrics.Address != "" && c.Metrics.Address == c.Server.Address {
+		return fmt.Errorf("METRICS_ADDRESS must differ from SERVER_ADDRESS")
+	}
+
 	return nil
 }
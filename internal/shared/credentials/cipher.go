@@ -0,0 +1,87 @@
+package credentials
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// sealedPrefix versions the stored format to allow key or algorithm rotation later
+const sealedPrefix = "v1:"
+
+// Cipher encrypts feed credentials with AES-256-GCM
+type Cipher struct {
+	aead cipher.AEAD
+}
+
+// NewCipher creates a cipher from a base64 encoded 32-byte key
+func NewCipher(encodedKey string) (*Cipher, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encodedKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key encoding: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("encryption key must be 32 bytes, got %d", len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	return &Cipher{aead: aead}, nil
+}
+
+// Seal encrypts credentials into a printable string for storage
+func (c *Cipher) Seal(creds FeedCredentials) (string, error) {
+	plaintext, err := json.Marshal(creds)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode credentials: %w", err)
+	}
+
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := c.aead.Seal(nonce, nonce, plaintext, nil)
+	return sealedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Open decrypts credentials produced by Seal
+func (c *Cipher) Open(sealed string) (FeedCredentials, error) {
+	var creds FeedCredentials
+
+	encoded, ok := strings.CutPrefix(sealed, sealedPrefix)
+	if !ok {
+		return creds, errors.New("unsupported credentials format")
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return creds, fmt.Errorf("invalid credentials encoding: %w", err)
+	}
+	if len(data) < c.aead.NonceSize() {
+		return creds, errors.New("credentials are truncated")
+	}
+
+	nonce, ciphertext := data[:c.aead.NonceSize()], data[c.aead.NonceSize():]
+	plaintext, err := c.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return creds, fmt.Errorf("failed to decrypt credentials: %w", err)
+	}
+
+	if err := json.Unmarshal(plaintext, &creds); err != nil {
+		return creds, fmt.Errorf("failed to decode credentials: %w", err)
+	}
+
+	return creds, nil
+}
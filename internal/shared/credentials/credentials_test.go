@@ -0,0 +1,176 @@
+package credentials
+
+import (
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCipher(t *testing.T, seed byte) *Cipher {
+	t.Helper()
+	key := base64.StdEncoding.EncodeToString([]byte(strings.Repeat(string(rune('a'+seed)), 32)))
+	c, err := NewCipher(key)
+	require.NoError(t, err)
+	return c
+}
+
+func TestNewCipher_InvalidKey(t *testing.T) {
+	_, err := NewCipher("not base64!")
+	assert.Error(t, err)
+
+	_, err = NewCipher(base64.StdEncoding.EncodeToString([]byte("too short")))
+	assert.ErrorContains(t, err, "32 bytes")
+}
+
+func TestCipher_SealOpen(t *testing.T) {
+	c := newTestCipher(t, 0)
+	creds := FeedCredentials{
+		Username: "alice",
+		Password: "s3cret",
+		Headers:  map[string]string{"X-Api-Key": "key"},
+	}
+
+	sealed, err := c.Seal(creds)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(sealed, sealedPrefix))
+	assert.NotContains(t, sealed, "s3cret")
+
+	// Each seal uses a fresh nonce
+	again, err := c.Seal(creds)
+	require.NoError(t, err)
+	assert.NotEqual(t, sealed, again)
+
+	opened, err := c.Open(sealed)
+	require.NoError(t, err)
+	assert.True(t, creds.Equal(opened))
+}
+
+func TestCipher_OpenRejectsForeignData(t *testing.T) {
+	c := newTestCipher(t, 0)
+	sealed, err := c.Seal(FeedCredentials{Token: "token"})
+	require.NoError(t, err)
+
+	_, err = newTestCipher(t, 1).Open(sealed)
+	assert.Error(t, err, "different key must not decrypt")
+
+	tampered := sealed[:len(sealed)-4] + "AAAA"
+	_, err = c.Open(tampered)
+	assert.Error(t, err, "modified ciphertext must not decrypt")
+
+	_, err = c.Open("plain text")
+	assert.Error(t, err)
+}
+
+func TestFeedCredentials_AuthType(t *testing.T) {
+	assert.Equal(t, AuthTypeNone, FeedCredentials{}.AuthType())
+	assert.Equal(t, AuthTypeBasic, FeedCredentials{Username: "alice"}.AuthType())
+	assert.Equal(t, AuthTypeBearer, FeedCredentials{Token: "token"}.AuthType())
+	assert.True(t, FeedCredentials{}.IsEmpty())
+	assert.False(t, FeedCredentials{Headers: map[string]string{"Cookie": "a=b"}}.IsEmpty())
+}
+
+func TestFeedCredentials_Apply(t *testing.T) {
+	tests := []struct {
+		name     string
+		creds    FeedCredentials
+		expected http.Header
+	}{
+		{
+			name:  "basic auth",
+			creds: FeedCredentials{Username: "alice", Password: "s3cret"},
+			expected: http.Header{
+				"Authorization": {"Basic " + base64.StdEncoding.EncodeToString([]byte("alice:s3cret"))},
+			},
+		},
+		{
+			name:  "bearer token takes precedence over custom authorization header",
+			creds: FeedCredentials{Token: "token", Headers: map[string]string{"Authorization": "Custom", "X-Api-Key": "key"}},
+			expected: http.Header{
+				"Authorization": {"Bearer token"},
+				"X-Api-Key":     {"key"},
+			},
+		},
+		{
+			name:     "nothing configured",
+			creds:    FeedCredentials{},
+			expected: http.Header{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			tt.creds.Apply(header)
+			assert.Equal(t, tt.expected, header)
+		})
+	}
+}
+
+func TestParseHeaders(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		expected  map[string]string
+		expectErr bool
+	}{
+		{
+			name:     "empty text",
+			text:     "  \n ",
+			expected: nil,
+		},
+		{
+			name:     "canonicalizes names and trims values",
+			text:     "x-api-key:  abc \r\n\nPRIVATE-TOKEN: glpat-123\n",
+			expected: map[string]string{"X-Api-Key": "abc", "Private-Token": "glpat-123"},
+		},
+		{
+			name:     "value may contain colons",
+			text:     "Cookie: session=a:b",
+			expected: map[string]string{"Cookie": "session=a:b"},
+		},
+		{
+			name:      "missing colon",
+			text:      "X-Api-Key abc",
+			expectErr: true,
+		},
+		{
+			name:      "invalid name",
+			text:      "X Api Key: abc",
+			expectErr: true,
+		},
+		{
+			name:      "reserved header",
+			text:      "Host: intranet.local",
+			expectErr: true,
+		},
+		{
+			name:      "too many headers",
+			text:      headerLines(21),
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers, err := ParseHeaders(tt.text)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, headers)
+		})
+	}
+}
+
+func headerLines(n int) string {
+	var sb strings.Builder
+	for i := range n {
+		sb.WriteString("X-Header-" + string(rune('a'+i)) + ": value\n")
+	}
+	return sb.String()
+}
@@ -29,3 +29,14 @@ func IsNotFoundError(err error) bool {
 		strings.Contains(errMsg, "no rows") ||
 		strings.Contains(errMsg, "404")
 }
+
+// IsCheckViolationError checks if the error is due to a check constraint violation.
+// PostgREST returns the PostgreSQL error code 23514 with the name of the violated constraint.
+func IsCheckViolationError(err error) bool {
+	if err == nil {
+		return false
+	}
+	errMsg := strings.ToLower(err.Error())
+	return strings.Contains(errMsg, "23514") ||
+		strings.Contains(errMsg, "check constraint")
+}
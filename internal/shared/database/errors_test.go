@@ -357,3 +357,26 @@ func TestIsNotFoundError_WhitespaceHandling(t *testing.T) {
 		assert.Equal(t, tc.expected, result, "failed for message: %s", tc.msg)
 	}
 }
+
+// Tests for IsCheckViolationError
+
+// TestIsCheckViolationError tests detection of check constraint violations
+func TestIsCheckViolationError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "nil error", err: nil, expected: false},
+		{name: "error code", err: errors.New("(23514) new row violates check constraint"), expected: true},
+		{name: "constraint message", err: errors.New(`new row for relation "mute_rules" violates CHECK CONSTRAINT "valid_regex_pattern"`), expected: true},
+		{name: "unique violation", err: errors.New("duplicate key value violates unique constraint"), expected: false},
+		{name: "other error", err: errors.New("connection refused"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsCheckViolationError(tt.err))
+		})
+	}
+}
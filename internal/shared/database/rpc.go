@@ -0,0 +1,57 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// rpcError is the error body returned by PostgREST when a function call fails
+type rpcError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Details string `json:"details"`
+	Hint    string `json:"hint"`
+}
+
+// CallRPC calls a Postgres function through PostgREST and decodes the result into result
+// The underlying client only returns the response body, so errors are detected from the body
+func (c *Client) CallRPC(name string, params any, result any) error {
+	body := c.Rpc(name, "", params)
+	if err := decodeRPCResponse(body, result); err != nil {
+		return fmt.Errorf("rpc %s: %w", name, err)
+	}
+	return nil
+}
+
+// CallAuthenticatedRPC calls a Postgres function with the access token from context, so RLS applies to it
+func (c *Client) CallAuthenticatedRPC(ctx context.Context, name string, params any, result any) error {
+	client, err := c.NewAuthenticatedClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	body := client.Rpc(name, "", params)
+	if err := decodeRPCResponse(body, result); err != nil {
+		return fmt.Errorf("rpc %s: %w", name, err)
+	}
+	return nil
+}
+
+// decodeRPCResponse decodes a PostgREST function response, returning an error for error bodies
+func decodeRPCResponse(body string, result any) error {
+	if body == "" {
+		return fmt.Errorf("empty response")
+	}
+
+	var rpcErr rpcError
+	if err := json.Unmarshal([]byte(body), &rpcErr); err == nil && rpcErr.Message != "" {
+		return fmt.Errorf("(%s) %s", rpcErr.Code, rpcErr.Message)
+	}
+
+	if err := json.Unmarshal([]byte(body), result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for decodeRPCResponse
+
+// TestDecodeRPCResponse_Rows tests decoding a set of rows
+func TestDecodeRPCResponse_Rows(t *testing.T) {
+	var feeds []PublicFeedsSelect
+	err := decodeRPCResponse(`[{"id":"feed-1","name":"Blog"},{"id":"feed-2","name":"News"}]`, &feeds)
+	require.NoError(t, err)
+	require.Len(t, feeds, 2)
+	assert.Equal(t, "feed-1", feeds[0].Id)
+	assert.Equal(t, "News", feeds[1].Name)
+}
+
+// TestDecodeRPCResponse_Scalar tests decoding a scalar function result
+func TestDecodeRPCResponse_Scalar(t *testing.T) {
+	var extended bool
+	err := decodeRPCResponse("true", &extended)
+	require.NoError(t, err)
+	assert.True(t, extended)
+}
+
+// TestDecodeRPCResponse_ErrorBody tests that PostgREST error bodies are returned as errors
+func TestDecodeRPCResponse_ErrorBody(t *testing.T) {
+	var feeds []PublicFeedsSelect
+	err := decodeRPCResponse(`{"code":"42883","details":null,"hint":null,"message":"function does not exist"}`, &feeds)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "42883")
+	assert.Contains(t, err.Error(), "function does not exist")
+}
+
+// TestDecodeRPCResponse_EmptyBody tests that an empty body (transport failure) is an error
+func TestDecodeRPCResponse_EmptyBody(t *testing.T) {
+	var extended bool
+	err := decodeRPCResponse("", &extended)
+	assert.Error(t, err)
+}
@@ -1,94 +1,175 @@
 package database
 
 type PublicFeedsSelect struct {
-	CreatedAt       string  `json:"created_at"`
-	Etag            *string `json:"etag"`
-	FetchAfter      *string `json:"fetch_after"`
-	Id              string  `json:"id"`
-	LastFetchError  *string `json:"last_fetch_error"`
-	LastFetchStatus *string `json:"last_fetch_status"`
-	LastFetchedAt   *string `json:"last_fetched_at"`
-	LastModified    *string `json:"last_modified"`
-	Name            string  `json:"name"`
-	RetryCount      int     `json:"retry_count"`
-	UpdatedAt       string  `json:"updated_at"`
-	Url             string  `json:"url"`
-	UserId          string  `json:"user_id"`
+	CreatedAt             string  `json:"created_at"`
+	CredentialsEncrypted  *string `json:"credentials_encrypted"`
+	Etag                  *string `json:"etag"`
+	FaviconCheckedAt      *string `json:"favicon_checked_at"`
+	FetchAfter            *string `json:"fetch_after"`
+	FetchFullContent      bool    `json:"fetch_full_content"`
+	HasFavicon            bool    `json:"has_favicon"`
+	Id                    string  `json:"id"`
+	LastFetchError        *string `json:"last_fetch_error"`
+	LastFetchStatus       *string `json:"last_fetch_status"`
+	LastFetchedAt         *string `json:"last_fetched_at"`
+	LastModified          *string `json:"last_modified"`
+	LeaseExpiresAt        *string `json:"lease_expires_at"`
+	LeaseOwner            *string `json:"lease_owner"`
+	Name                  string  `json:"name"`
+	NameIsAuto            bool    `json:"name_is_auto"`
+	PollIntervalSeconds   *int    `json:"poll_interval_seconds"`
+	PublishCadenceSeconds *int    `json:"publish_cadence_seconds"`
+	RetryCount            int     `json:"retry_count"`
+	SiteDescription       *string `json:"site_description"`
+	SiteImageUrl          *string `json:"site_image_url"`
+	SiteLanguage          *string `json:"site_language"`
+	SiteTitle             *string `json:"site_title"`
+	SiteUrl               *string `json:"site_url"`
+	UnchangedFetchRatio   float64 `json:"unchanged_fetch_ratio"`
+	UpdatedAt             string  `json:"updated_at"`
+	Url                   string  `json:"url"`
+	UserId                string  `json:"user_id"`
 }
 
 type PublicFeedsInsert struct {
-	CreatedAt       *string `json:"created_at,omitempty"`
-	Etag            *string `json:"etag"`
-	FetchAfter      *string `json:"fetch_after"`
-	Id              *string `json:"id,omitempty"`
-	LastFetchError  *string `json:"last_fetch_error"`
-	LastFetchStatus *string `json:"last_fetch_status"`
-	LastFetchedAt   *string `json:"last_fetched_at"`
-	LastModified    *string `json:"last_modified"`
-	Name            string  `json:"name"`
-	RetryCount      *int    `json:"retry_count,omitempty"`
-	UpdatedAt       *string `json:"updated_at,omitempty"`
-	Url             string  `json:"url"`
-	UserId          string  `json:"user_id"`
+	CreatedAt             *string  `json:"created_at,omitempty"`
+	CredentialsEncrypted  *string  `json:"credentials_encrypted"`
+	Etag                  *string  `json:"etag"`
+	FaviconCheckedAt      *string  `json:"favicon_checked_at"`
+	FetchAfter            *string  `json:"fetch_after"`
+	FetchFullContent      *bool    `json:"fetch_full_content,omitempty"`
+	HasFavicon            *bool    `json:"has_favicon,omitempty"`
+	Id                    *string  `json:"id,omitempty"`
+	LastFetchError        *string  `json:"last_fetch_error"`
+	LastFetchStatus       *string  `json:"last_fetch_status"`
+	LastFetchedAt         *string  `json:"last_fetched_at"`
+	LastModified          *string  `json:"last_modified"`
+	LeaseExpiresAt        *string  `json:"lease_expires_at"`
+	LeaseOwner            *string  `json:"lease_owner"`
+	Name                  string   `json:"name"`
+	NameIsAuto            *bool    `json:"name_is_auto,omitempty"`
+	PollIntervalSeconds   *int     `json:"poll_interval_seconds"`
+	PublishCadenceSeconds *int     `json:"publish_cadence_seconds"`
+	RetryCount            *int     `json:"retry_count,omitempty"`
+	SiteDescription       *string  `json:"site_description"`
+	SiteImageUrl          *string  `json:"site_image_url"`
+	SiteLanguage          *string  `json:"site_language"`
+	SiteTitle             *string  `json:"site_title"`
+	SiteUrl               *string  `json:"site_url"`
+	UnchangedFetchRatio   *float64 `json:"unchanged_fetch_ratio,omitempty"`
+	UpdatedAt             *string  `json:"updated_at,omitempty"`
+	Url                   string   `json:"url"`
+	UserId                string   `json:"user_id"`
 }
 
 type PublicFeedsUpdate struct {
-	CreatedAt       *string `json:"created_at,omitempty"`
-	Etag            *string `json:"etag,omitempty"`
-	FetchAfter      *string `json:"fetch_after,omitempty"`
-	Id              *string `json:"id,omitempty"`
-	LastFetchError  *string `json:"last_fetch_error,omitempty"`
-	LastFetchStatus *string `json:"last_fetch_status,omitempty"`
-	LastFetchedAt   *string `json:"last_fetched_at,omitempty"`
-	LastModified    *string `json:"last_modified,omitempty"`
-	Name            *string `json:"name,omitempty"`
-	RetryCount      *int    `json:"retry_count,omitempty"`
-	UpdatedAt       *string `json:"updated_at,omitempty"`
-	Url             *string `json:"url,omitempty"`
-	UserId          *string `json:"user_id,omitempty"`
+	CreatedAt             *string  `json:"created_at,omitempty"`
+	CredentialsEncrypted  *string  `json:"credentials_encrypted,omitempty"`
+	Etag                  *string  `json:"etag,omitempty"`
+	FaviconCheckedAt      *string  `json:"favicon_checked_at,omitempty"`
+	FetchAfter            *string  `json:"fetch_after,omitempty"`
+	FetchFullContent      *bool    `json:"fetch_full_content,omitempty"`
+	HasFavicon            *bool    `json:"has_favicon,omitempty"`
+	Id                    *string  `json:"id,omitempty"`
+	LastFetchError        *string  `json:"last_fetch_error,omitempty"`
+	LastFetchStatus       *string  `json:"last_fetch_status,omitempty"`
+	LastFetchedAt         *string  `json:"last_fetched_at,omitempty"`
+	LastModified          *string  `json:"last_modified,omitempty"`
+	LeaseExpiresAt        *string  `json:"lease_expires_at,omitempty"`
+	LeaseOwner            *string  `json:"lease_owner,omitempty"`
+	Name                  *string  `json:"name,omitempty"`
+	NameIsAuto            *bool    `json:"name_is_auto,omitempty"`
+	PollIntervalSeconds   *int     `json:"poll_interval_seconds,omitempty"`
+	PublishCadenceSeconds *int     `json:"publish_cadence_seconds,omitempty"`
+	RetryCount            *int     `json:"retry_count,omitempty"`
+	SiteDescription       *string  `json:"site_description,omitempty"`
+	SiteImageUrl          *string  `json:"site_image_url,omitempty"`
+	SiteLanguage          *string  `json:"site_language,omitempty"`
+	SiteTitle             *string  `json:"site_title,omitempty"`
+	SiteUrl               *string  `json:"site_url,omitempty"`
+	UnchangedFetchRatio   *float64 `json:"unchanged_fetch_ratio,omitempty"`
+	UpdatedAt             *string  `json:"updated_at,omitempty"`
+	Url                   *string  `json:"url,omitempty"`
+	UserId                *string  `json:"user_id,omitempty"`
 }
 
 type PublicArticlesSelect struct {
-	Content     *string `json:"content"`
-	CreatedAt   string  `json:"created_at"`
-	FeedId      string  `json:"feed_id"`
-	Id          string  `json:"id"`
-	PublishedAt string  `json:"published_at"`
-	Title       string  `json:"title"`
-	Url         string  `json:"url"`
+	Authors              []string    `json:"authors"`
+	Categories           []string    `json:"categories"`
+	Content              *string     `json:"content"`
+	ContentHash          *string     `json:"content_hash"`
+	ContentHtml          *string     `json:"content_html"`
+	CreatedAt            string      `json:"created_at"`
+	Enclosures           interface{} `json:"enclosures"`
+	FeedId               string      `json:"feed_id"`
+	FullContent          *string     `json:"full_content"`
+	FullContentFetchedAt *string     `json:"full_content_fetched_at"`
+	Guid                 string      `json:"guid"`
+	Id                   string      `json:"id"`
+	ImageUrl             *string     `json:"image_url"`
+	PublishedAt          string      `json:"published_at"`
+	SearchConfig         interface{} `json:"search_config"`
+	SearchVector         interface{} `json:"search_vector"`
+	Title                string      `json:"title"`
+	UpdatedAt            string      `json:"updated_at"`
+	Url                  string      `json:"url"`
 }
 
 type PublicArticlesInsert struct {
-	Content     *string `json:"content"`
-	CreatedAt   *string `json:"created_at,omitempty"`
-	FeedId      string  `json:"feed_id"`
-	Id          *string `json:"id,omitempty"`
-	PublishedAt string  `json:"published_at"`
-	Title       string  `json:"title"`
-	Url         string  `json:"url"`
+	Authors              []string    `json:"authors"`
+	Categories           []string    `json:"categories"`
+	Content              *string     `json:"content"`
+	ContentHash          *string     `json:"content_hash"`
+	ContentHtml          *string     `json:"content_html"`
+	CreatedAt            *string     `json:"created_at,omitempty"`
+	Enclosures           interface{} `json:"enclosures"`
+	FeedId               string      `json:"feed_id"`
+	FullContent          *string     `json:"full_content"`
+	FullContentFetchedAt *string     `json:"full_content_fetched_at"`
+	Guid                 string      `json:"guid"`
+	Id                   *string     `json:"id,omitempty"`
+	ImageUrl             *string     `json:"image_url"`
+	PublishedAt          string      `json:"published_at"`
+	SearchConfig         interface{} `json:"search_config,omitempty"`
+	Title                string      `json:"title"`
+	UpdatedAt            *string     `json:"updated_at,omitempty"`
+	Url                  string      `json:"url"`
 }
 
 type PublicArticlesUpdate struct {
-	Content     *string `json:"content,omitempty"`
-	CreatedAt   *string `json:"created_at,omitempty"`
-	FeedId      *string `json:"feed_id,omitempty"`
-	Id          *string `json:"id,omitempty"`
-	PublishedAt *string `json:"published_at,omitempty"`
-	Title       *string `json:"title,omitempty"`
-	Url         *string `json:"url,omitempty"`
+	Authors              []string    `json:"authors,omitempty"`
+	Categories           []string    `json:"categories,omitempty"`
+	Content              *string     `json:"content,omitempty"`
+	ContentHash          *string     `json:"content_hash,omitempty"`
+	ContentHtml          *string     `json:"content_html,omitempty"`
+	CreatedAt            *string     `json:"created_at,omitempty"`
+	Enclosures           interface{} `json:"enclosures,omitempty"`
+	FeedId               *string     `json:"feed_id,omitempty"`
+	FullContent          *string     `json:"full_content,omitempty"`
+	FullContentFetchedAt *string     `json:"full_content_fetched_at,omitempty"`
+	Guid                 *string     `json:"guid,omitempty"`
+	Id                   *string     `json:"id,omitempty"`
+	ImageUrl             *string     `json:"image_url,omitempty"`
+	PublishedAt          *string     `json:"published_at,omitempty"`
+	SearchConfig         interface{} `json:"search_config,omitempty"`
+	Title                *string     `json:"title,omitempty"`
+	UpdatedAt            *string     `json:"updated_at,omitempty"`
+	Url                  *string     `json:"url,omitempty"`
 }
 
 type PublicSummariesSelect struct {
-	Content   string `json:"content"`
-	CreatedAt string `json:"created_at"`
-	Id        string `json:"id"`
-	UserId    string `json:"user_id"`
+	Content   string  `json:"content"`
+	CreatedAt string  `json:"created_at"`
+	Id        string  `json:"id"`
+	TagName   *string `json:"tag_name"`
+	UserId    string  `json:"user_id"`
 }
 
 type PublicSummariesInsert struct {
 	Content   string  `json:"content"`
 	CreatedAt *string `json:"created_at,omitempty"`
 	Id        *string `json:"id,omitempty"`
+	TagName   *string `json:"tag_name,omitempty"`
 	UserId    string  `json:"user_id"`
 }
 
@@ -96,6 +177,7 @@ type PublicSummariesUpdate struct {
 	Content   *string `json:"content,omitempty"`
 	CreatedAt *string `json:"created_at,omitempty"`
 	Id        *string `json:"id,omitempty"`
+	TagName   *string `json:"tag_name,omitempty"`
 	UserId    *string `json:"user_id,omitempty"`
 }
 
@@ -122,3 +204,255 @@ type PublicEventsUpdate struct {
 	Metadata  interface{} `json:"metadata,omitempty"`
 	UserId    *string     `json:"user_id,omitempty"`
 }
+
+type PublicWebsubSubscriptionsSelect struct {
+	CreatedAt      string  `json:"created_at"`
+	FeedId         string  `json:"feed_id"`
+	HubUrl         string  `json:"hub_url"`
+	LastError      *string `json:"last_error"`
+	LeaseExpiresAt *string `json:"lease_expires_at"`
+	Secret         string  `json:"secret"`
+	Status         string  `json:"status"`
+	TopicUrl       string  `json:"topic_url"`
+	UpdatedAt      string  `json:"updated_at"`
+}
+
+type PublicWebsubSubscriptionsInsert struct {
+	CreatedAt      *string `json:"created_at,omitempty"`
+	FeedId         string  `json:"feed_id"`
+	HubUrl         string  `json:"hub_url"`
+	LastError      *string `json:"last_error"`
+	LeaseExpiresAt *string `json:"lease_expires_at"`
+	Secret         string  `json:"secret"`
+	Status         *string `json:"status,omitempty"`
+	TopicUrl       string  `json:"topic_url"`
+	UpdatedAt      *string `json:"updated_at,omitempty"`
+}
+
+type PublicWebsubSubscriptionsUpdate struct {
+	CreatedAt      *string `json:"created_at,omitempty"`
+	FeedId         *string `json:"feed_id,omitempty"`
+	HubUrl         *string `json:"hub_url,omitempty"`
+	LastError      *string `json:"last_error,omitempty"`
+	LeaseExpiresAt *string `json:"lease_expires_at,omitempty"`
+	Secret         *string `json:"secret,omitempty"`
+	Status         *string `json:"status,omitempty"`
+	TopicUrl       *string `json:"topic_url,omitempty"`
+	UpdatedAt      *string `json:"updated_at,omitempty"`
+}
+
+type PublicFeedFetchLogSelect struct {
+	BytesRead     int64    `json:"bytes_read"`
+	CreatedAt     string   `json:"created_at"`
+	DurationMs    int      `json:"duration_ms"`
+	ErrorMessage  *string  `json:"error_message"`
+	FeedId        string   `json:"feed_id"`
+	HttpStatus    *int     `json:"http_status"`
+	Id            string   `json:"id"`
+	NewArticles   int      `json:"new_articles"`
+	NotModified   bool     `json:"not_modified"`
+	RedirectChain []string `json:"redirect_chain"`
+	Status        string   `json:"status"`
+}
+
+type PublicFeedFetchLogInsert struct {
+	BytesRead     *int64   `json:"bytes_read,omitempty"`
+	CreatedAt     *string  `json:"created_at,omitempty"`
+	DurationMs    int      `json:"duration_ms"`
+	ErrorMessage  *string  `json:"error_message"`
+	FeedId        string   `json:"feed_id"`
+	HttpStatus    *int     `json:"http_status"`
+	Id            *string  `json:"id,omitempty"`
+	NewArticles   *int     `json:"new_articles,omitempty"`
+	NotModified   *bool    `json:"not_modified,omitempty"`
+	RedirectChain []string `json:"redirect_chain"`
+	Status        string   `json:"status"`
+}
+
+type PublicFeedFetchLogUpdate struct {
+	BytesRead     *int64   `json:"bytes_read,omitempty"`
+	CreatedAt     *string  `json:"created_at,omitempty"`
+	DurationMs    *int     `json:"duration_ms,omitempty"`
+	ErrorMessage  *string  `json:"error_message,omitempty"`
+	FeedId        *string  `json:"feed_id,omitempty"`
+	HttpStatus    *int     `json:"http_status,omitempty"`
+	Id            *string  `json:"id,omitempty"`
+	NewArticles   *int     `json:"new_articles,omitempty"`
+	NotModified   *bool    `json:"not_modified,omitempty"`
+	RedirectChain []string `json:"redirect_chain,omitempty"`
+	Status        *string  `json:"status,omitempty"`
+}
+
+type PublicFeedFaviconsSelect struct {
+	ContentType string `json:"content_type"`
+	Data        string `json:"data"`
+	FeedId      string `json:"feed_id"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+type PublicFeedFaviconsInsert struct {
+	ContentType string  `json:"content_type"`
+	Data        string  `json:"data"`
+	FeedId      string  `json:"feed_id"`
+	UpdatedAt   *string `json:"updated_at,omitempty"`
+}
+
+type PublicFeedFaviconsUpdate struct {
+	ContentType *string `json:"content_type,omitempty"`
+	Data        *string `json:"data,omitempty"`
+	FeedId      *string `json:"feed_id,omitempty"`
+	UpdatedAt   *string `json:"updated_at,omitempty"`
+}
+
+type PublicTagsSelect struct {
+	CreatedAt string `json:"created_at"`
+	Id        string `json:"id"`
+	Name      string `json:"name"`
+	UserId    string `json:"user_id"`
+}
+
+type PublicTagsInsert struct {
+	CreatedAt *string `json:"created_at,omitempty"`
+	Id        *string `json:"id,omitempty"`
+	Name      string  `json:"name"`
+	UserId    string  `json:"user_id"`
+}
+
+type PublicTagsUpdate struct {
+	CreatedAt *string `json:"created_at,omitempty"`
+	Id        *string `json:"id,omitempty"`
+	Name      *string `json:"name,omitempty"`
+	UserId    *string `json:"user_id,omitempty"`
+}
+
+type PublicFeedTagsSelect struct {
+	FeedId string `json:"feed_id"`
+	TagId  string `json:"tag_id"`
+}
+
+type PublicFeedTagsInsert struct {
+	FeedId string `json:"feed_id"`
+	TagId  string `json:"tag_id"`
+}
+
+type PublicFeedTagsUpdate struct {
+	FeedId *string `json:"feed_id,omitempty"`
+	TagId  *string `json:"tag_id,omitempty"`
+}
+
+type PublicArticleReadsSelect struct {
+	ArticleId string `json:"article_id"`
+	ReadAt    string `json:"read_at"`
+	UserId    string `json:"user_id"`
+}
+
+type PublicArticleReadsInsert struct {
+	ArticleId string  `json:"article_id"`
+	ReadAt    *string `json:"read_at,omitempty"`
+	UserId    string  `json:"user_id"`
+}
+
+type PublicArticleReadsUpdate struct {
+	ArticleId *string `json:"article_id,omitempty"`
+	ReadAt    *string `json:"read_at,omitempty"`
+	UserId    *string `json:"user_id,omitempty"`
+}
+
+type PublicSavedArticlesSelect struct {
+	ArticleId   *string  `json:"article_id"`
+	Authors     []string `json:"authors"`
+	Content     *string  `json:"content"`
+	FeedName    string   `json:"feed_name"`
+	Id          string   `json:"id"`
+	ImageUrl    *string  `json:"image_url"`
+	PublishedAt string   `json:"published_at"`
+	SavedAt     string   `json:"saved_at"`
+	Title       string   `json:"title"`
+	Url         string   `json:"url"`
+	UserId      string   `json:"user_id"`
+}
+
+type PublicSavedArticlesInsert struct {
+	ArticleId   *string  `json:"article_id,omitempty"`
+	Authors     []string `json:"authors,omitempty"`
+	Content     *string  `json:"content,omitempty"`
+	FeedName    string   `json:"feed_name"`
+	Id          *string  `json:"id,omitempty"`
+	ImageUrl    *string  `json:"image_url,omitempty"`
+	PublishedAt string   `json:"published_at"`
+	SavedAt     *string  `json:"saved_at,omitempty"`
+	Title       string   `json:"title"`
+	Url         string   `json:"url"`
+	UserId      string   `json:"user_id"`
+}
+
+type PublicSavedArticlesUpdate struct {
+	ArticleId   *string  `json:"article_id,omitempty"`
+	Authors     []string `json:"authors,omitempty"`
+	Content     *string  `json:"content,omitempty"`
+	FeedName    *string  `json:"feed_name,omitempty"`
+	Id          *string  `json:"id,omitempty"`
+	ImageUrl    *string  `json:"image_url,omitempty"`
+	PublishedAt *string  `json:"published_at,omitempty"`
+	SavedAt     *string  `json:"saved_at,omitempty"`
+	Title       *string  `json:"title,omitempty"`
+	Url         *string  `json:"url,omitempty"`
+	UserId      *string  `json:"user_id,omitempty"`
+}
+
+type PublicSummaryArticlesSelect struct {
+	ArticleId string `json:"article_id"`
+	SummaryId string `json:"summary_id"`
+}
+
+type PublicSummaryArticlesInsert struct {
+	ArticleId string `json:"article_id"`
+	SummaryId string `json:"summary_id"`
+}
+
+type PublicSummaryArticlesUpdate struct {
+	ArticleId *string `json:"article_id,omitempty"`
+	SummaryId *string `json:"summary_id,omitempty"`
+}
+
+type PublicMuteRulesSelect struct {
+	CreatedAt string `json:"created_at"`
+	Id        string `json:"id"`
+	Kind      string `json:"kind"`
+	Pattern   string `json:"pattern"`
+	UserId    string `json:"user_id"`
+}
+
+type PublicMuteRulesInsert struct {
+	CreatedAt *string `json:"created_at,omitempty"`
+	Id        *string `json:"id,omitempty"`
+	Kind      string  `json:"kind"`
+	Pattern   string  `json:"pattern"`
+	UserId    string  `json:"user_id"`
+}
+
+type PublicMuteRulesUpdate struct {
+	CreatedAt *string `json:"created_at,omitempty"`
+	Id        *string `json:"id,omitempty"`
+	Kind      *string `json:"kind,omitempty"`
+	Pattern   *string `json:"pattern,omitempty"`
+	UserId    *string `json:"user_id,omitempty"`
+}
+
+type PublicUserSettingsSelect struct {
+	ArticleRetentionDays *int   `json:"article_retention_days"`
+	UpdatedAt            string `json:"updated_at"`
+	UserId               string `json:"user_id"`
+}
+
+type PublicUserSettingsInsert struct {
+	ArticleRetentionDays *int    `json:"article_retention_days"`
+	UpdatedAt            *string `json:"updated_at,omitempty"`
+	UserId               string  `json:"user_id"`
+}
+
+type PublicUserSettingsUpdate struct {
+	ArticleRetentionDays *int    `json:"article_retention_days,omitempty"`
+	UpdatedAt            *string `json:"updated_at,omitempty"`
+	UserId               *string `json:"user_id,omitempty"`
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/tjanas94/vibefeeder/internal/shared/database"
+	"github.com/tjanas94/vibefeeder/internal/shared/tracing"
 )
 
 // EventRepository defines the interface for event logging
@@ -26,9 +27,12 @@ func NewRepository(db *database.Client) *Repository {
 }
 
 // RecordEvent creates a new event in the database
-func (r *Repository) RecordEvent(ctx context.Context, event database.PublicEventsInsert) error {
+func (r *Repository) RecordEvent(ctx context.Context, event database.PublicEventsInsert) (err error) {
+	_, span := tracing.Start(ctx, "events.Repository.RecordEvent")
+	defer func() { tracing.End(span, err) }()
+
 	var result []database.PublicEventsSelect
-	_, err := r.db.From("events").Insert(event, false, "", "", "").ExecuteTo(&result)
+	_, err = r.db.From("events").Insert(event, false, "", "", "").ExecuteTo(&result)
 	if err != nil {
 		return fmt.Errorf("failed to record event: %w", err)
 	}
@@ -1,11 +1,11 @@
 package logger
 
 import (
-	"context"
 	"log/slog"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/tjanas94/vibefeeder/internal/shared/tracing"
 )
 
 // RequestLoggerConfig returns Echo's RequestLogger middleware configured for slog
@@ -38,12 +38,17 @@ func RequestLoggerConfig(logger *slog.Logger) echo.MiddlewareFunc {
 				slog.String("host", v.Host),
 			}
 
+			// Add trace ID so log lines can be matched with traces
+			if traceID := tracing.TraceID(c.Request().Context()); traceID != "" {
+				attrs = append(attrs, slog.String("trace_id", traceID))
+			}
+
 			// Add error if present
 			if v.Error != nil {
 				attrs = append(attrs, slog.String("error", v.Error.Error()))
 			}
 
-			logger.LogAttrs(context.Background(), level, "HTTP request", attrs...)
+			logger.LogAttrs(c.Request().Context(), level, "HTTP request", attrs...)
 			return nil
 		},
 	})
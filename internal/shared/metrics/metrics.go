@@ -0,0 +1,139 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "vibefeeder"
+
+// Registry holds all application collectors together with Go runtime and process metrics
+// A dedicated registry keeps metrics of imported libraries out of the endpoint
+var Registry = prometheus.NewRegistry()
+
+var factory = promauto.With(Registry)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// HTTP server metrics
+var (
+	// HTTPRequestsTotal counts handled requests per route pattern (not raw path, to keep cardinality bounded)
+	HTTPRequestsTotal = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Number of handled HTTP requests by method, route and status code.",
+	}, []string{"method", "route", "status"})
+
+	// HTTPRequestDuration tracks request latency per route pattern
+	HTTPRequestDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by method and route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+)
+
+// Feed fetcher metrics
+var (
+	// FetcherBatchDuration tracks how long a scheduler batch takes, from claiming feeds to the last worker
+	FetcherBatchDuration = factory.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "fetcher",
+		Name:      "batch_duration_seconds",
+		Help:      "Duration of feed fetcher batches.",
+		Buckets:   []float64{0.1, 0.5, 1, 5, 15, 30, 60, 120, 300, 600},
+	})
+
+	// FetcherFeedsTotal counts processed feeds by the status of their fetch decision
+	FetcherFeedsTotal = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "fetcher",
+		Name:      "feeds_total",
+		Help:      "Number of processed feeds by fetch status.",
+	}, []string{"status"})
+
+	// FetcherBusyWorkers is the number of worker pool slots currently processing a feed
+	FetcherBusyWorkers = factory.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "fetcher",
+		Name:      "busy_workers",
+		Help:      "Number of fetcher workers currently processing a feed.",
+	})
+
+	// FetcherRateLimitWait tracks how long requests wait for the per-domain delay
+	FetcherRateLimitWait = factory.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "fetcher",
+		Name:      "rate_limit_wait_seconds",
+		Help:      "Time spent waiting for the per-domain rate limiter.",
+		Buckets:   []float64{0.001, 0.01, 0.1, 0.5, 1, 2, 5, 10, 30},
+	})
+
+	// ArticlesInsertedTotal counts articles stored for the first time
+	ArticlesInsertedTotal = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "fetcher",
+		Name:      "articles_inserted_total",
+		Help:      "Number of new articles stored.",
+	})
+
+	// ArticlesUpsertedTotal counts all saved articles, including updates of already stored ones
+	ArticlesUpsertedTotal = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "fetcher",
+		Name:      "articles_upserted_total",
+		Help:      "Number of articles written to the database, new and updated.",
+	})
+
+	// ArticlesPrunedTotal counts articles deleted by the retention job
+	ArticlesPrunedTotal = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "fetcher",
+		Name:      "articles_pruned_total",
+		Help:      "Number of expired articles deleted.",
+	})
+)
+
+// OpenRouter metrics
+var (
+	// AIRequestDuration tracks OpenRouter call latency per model
+	AIRequestDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "openrouter",
+		Name:      "request_duration_seconds",
+		Help:      "OpenRouter chat completion latency by model.",
+		Buckets:   []float64{0.5, 1, 2.5, 5, 10, 20, 30, 60, 90},
+	}, []string{"model"})
+
+	// AIErrorsTotal counts failed OpenRouter calls per model
+	AIErrorsTotal = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "openrouter",
+		Name:      "errors_total",
+		Help:      "Number of failed OpenRouter chat completion calls by model.",
+	}, []string{"model"})
+
+	// AITokensTotal counts tokens reported in the usage of OpenRouter responses
+	AITokensTotal = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "openrouter",
+		Name:      "tokens_total",
+		Help:      "Number of tokens used by model and type (prompt, completion).",
+	}, []string{"model", "type"})
+)
+
+// Handler returns the HTTP handler serving the registry in Prometheus text format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
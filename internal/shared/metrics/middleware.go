@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// unmatchedRoute labels requests that did not match any route (404s for arbitrary paths)
+const unmatchedRoute = "unmatched"
+
+// Middleware records request count and latency per Echo route
+// Must be registered first so the latency covers the whole middleware chain
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+
+			route := c.Path()
+			if route == "" {
+				route = unmatchedRoute
+			}
+			method := c.Request().Method
+
+			HTTPRequestsTotal.WithLabelValues(method, route, strconv.Itoa(responseStatus(c, err))).Inc()
+			HTTPRequestDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+
+			return err
+		}
+	}
+}
+
+// responseStatus returns the status code sent (or about to be sent) for a request
+// Errors not yet handled by the global error handler carry their own status code
+func responseStatus(c echo.Context, err error) int {
+	if err == nil || c.Response().Committed {
+		return c.Response().Status
+	}
+
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code
+	}
+	return http.StatusInternalServerError
+}
+
+// TokenAuth protects the metrics endpoint with a static bearer token
+func TokenAuth(token string) echo.MiddlewareFunc {
+	return middleware.KeyAuthWithConfig(middleware.KeyAuthConfig{
+		KeyLookup:  "header:" + echo.HeaderAuthorization,
+		AuthScheme: "Bearer",
+		Validator: func(key string, c echo.Context) (bool, error) {
+			return subtle.ConstantTimeCompare([]byte(key), []byte(token)) == 1, nil
+		},
+		// A missing header is reported as 401 like a wrong token (KeyAuth defaults to 400)
+		ErrorHandler: func(err error, c echo.Context) error {
+			return echo.ErrUnauthorized
+		},
+	})
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Use(Middleware())
+	e.GET("/feeds/:id", func(c echo.Context) error {
+		if c.Param("id") == "missing" {
+			return echo.NewHTTPError(http.StatusNotFound)
+		}
+		return c.NoContent(http.StatusOK)
+	})
+
+	tests := []struct {
+		name   string
+		path   string
+		route  string
+		status string
+	}{
+		{name: "route pattern is used instead of the path", path: "/feeds/123", route: "/feeds/:id", status: "200"},
+		{name: "status of unhandled error", path: "/feeds/missing", route: "/feeds/:id", status: "404"},
+		{name: "unknown path", path: "/wp-login.php", route: unmatchedRoute, status: "404"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counter := HTTPRequestsTotal.WithLabelValues(http.MethodGet, tt.route, tt.status)
+			before := testutil.ToFloat64(counter)
+
+			e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, before+1, testutil.ToFloat64(counter))
+		})
+	}
+}
+
+func TestTokenAuth(t *testing.T) {
+	e := echo.New()
+	e.GET("/metrics", echo.WrapHandler(Handler()), TokenAuth("secret"))
+
+	tests := []struct {
+		name           string
+		authorization  string
+		expectedStatus int
+	}{
+		{name: "valid token", authorization: "Bearer secret", expectedStatus: http.StatusOK},
+		{name: "wrong token", authorization: "Bearer guess", expectedStatus: http.StatusUnauthorized},
+		{name: "missing token", authorization: "", expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			if tt.authorization != "" {
+				req.Header.Set(echo.HeaderAuthorization, tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Contains(t, rec.Body.String(), "vibefeeder_fetcher_busy_workers")
+			}
+		})
+	}
+}
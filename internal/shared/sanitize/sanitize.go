@@ -0,0 +1,223 @@
+// Package sanitize normalizes untrusted HTML from feeds into safe HTML and plain text.
+package sanitize
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// policy is the allow-list applied to article HTML
+// Based on the user generated content policy: formatting, links, images, lists, tables and code
+// Links get rel="nofollow noreferrer noopener" and open in a new tab
+var policy = func() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.RequireNoFollowOnLinks(true)
+	p.RequireNoReferrerOnLinks(true)
+	p.AddTargetBlankToFullyQualifiedLinks(true)
+	return p
+}()
+
+// droppedElements are removed together with their content before sanitizing
+var droppedElements = map[atom.Atom]bool{
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Noscript: true,
+	atom.Template: true,
+	atom.Iframe:   true,
+	atom.Object:   true,
+	atom.Embed:    true,
+	atom.Form:     true,
+	atom.Svg:      true,
+	atom.Math:     true,
+}
+
+// blockElements start a new paragraph in plain text output
+var blockElements = map[atom.Atom]bool{
+	atom.Address:    true,
+	atom.Article:    true,
+	atom.Aside:      true,
+	atom.Blockquote: true,
+	atom.Dd:         true,
+	atom.Div:        true,
+	atom.Dl:         true,
+	atom.Dt:         true,
+	atom.Figcaption: true,
+	atom.Figure:     true,
+	atom.Footer:     true,
+	atom.H1:         true,
+	atom.H2:         true,
+	atom.H3:         true,
+	atom.H4:         true,
+	atom.H5:         true,
+	atom.H6:         true,
+	atom.Header:     true,
+	atom.Hr:         true,
+	atom.Li:         true,
+	atom.Ol:         true,
+	atom.P:          true,
+	atom.Pre:        true,
+	atom.Section:    true,
+	atom.Table:      true,
+	atom.Tr:         true,
+	atom.Ul:         true,
+}
+
+// HTML returns an allow-list sanitized version of untrusted HTML
+// Scripts, styles, embeds and tracking pixels are removed, and relative links
+// and image sources are resolved against baseURL (ignored if empty or invalid)
+func HTML(raw string, baseURL string) string {
+	nodes := parseFragment(raw)
+	if len(nodes) == 0 {
+		return ""
+	}
+
+	base, err := url.Parse(baseURL)
+	if err != nil || !base.IsAbs() {
+		base = nil
+	}
+
+	var sb strings.Builder
+	for _, n := range nodes {
+		if removeUnwanted(n) {
+			continue
+		}
+		if base != nil {
+			resolveURLs(n, base)
+		}
+		if err := html.Render(&sb, n); err != nil {
+			return ""
+		}
+	}
+
+	return strings.TrimSpace(policy.Sanitize(sb.String()))
+}
+
+// Text returns the plain text of untrusted HTML
+// Entities are decoded, whitespace is collapsed and block elements are separated by blank lines
+func Text(raw string) string {
+	var blocks []string
+	var inline strings.Builder
+
+	flush := func() {
+		if text := strings.Join(strings.Fields(inline.String()), " "); text != "" {
+			blocks = append(blocks, text)
+		}
+		inline.Reset()
+	}
+
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			inline.WriteString(n.Data)
+			return
+		case html.ElementNode:
+			if droppedElements[n.DataAtom] {
+				return
+			}
+			if n.DataAtom == atom.Br || blockElements[n.DataAtom] {
+				flush()
+			}
+			if n.DataAtom == atom.Td || n.DataAtom == atom.Th {
+				inline.WriteByte(' ')
+			}
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+
+		if n.Type == html.ElementNode && blockElements[n.DataAtom] {
+			flush()
+		}
+	}
+
+	for _, n := range parseFragment(raw) {
+		walk(n)
+	}
+	flush()
+
+	return strings.Join(blocks, "\n\n")
+}
+
+// parseFragment parses HTML as the content of a <body> element
+func parseFragment(raw string) []*html.Node {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+
+	body := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(raw), body)
+	if err != nil {
+		return nil
+	}
+
+	return nodes
+}
+
+// removeUnwanted detaches dropped elements and tracking pixels from the tree
+// Returns true if n itself should be dropped
+func removeUnwanted(n *html.Node) bool {
+	if n.Type == html.CommentNode {
+		return true
+	}
+	if n.Type == html.ElementNode && (droppedElements[n.DataAtom] || isTrackingPixel(n)) {
+		return true
+	}
+
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+		if removeUnwanted(c) {
+			n.RemoveChild(c)
+		}
+		c = next
+	}
+
+	return false
+}
+
+// isTrackingPixel reports whether an image is a hidden or 1x1 tracking beacon
+func isTrackingPixel(n *html.Node) bool {
+	if n.DataAtom != atom.Img {
+		return false
+	}
+
+	for _, attr := range n.Attr {
+		switch attr.Key {
+		case "width", "height":
+			if size, err := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(attr.Val), "px")); err == nil && size <= 1 {
+				return true
+			}
+		case "style":
+			style := strings.ReplaceAll(strings.ToLower(attr.Val), " ", "")
+			if strings.Contains(style, "display:none") || strings.Contains(style, "visibility:hidden") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// resolveURLs rewrites relative href and src attributes to absolute URLs
+func resolveURLs(n *html.Node, base *url.URL) {
+	if n.Type == html.ElementNode {
+		for i, attr := range n.Attr {
+			if attr.Key != "href" && attr.Key != "src" {
+				continue
+			}
+			if ref, err := url.Parse(strings.TrimSpace(attr.Val)); err == nil && !ref.IsAbs() {
+				n.Attr[i].Val = base.ResolveReference(ref).String()
+			}
+		}
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		resolveURLs(c, base)
+	}
+}
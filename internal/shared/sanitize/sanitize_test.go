@@ -0,0 +1,129 @@
+package sanitize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		baseURL  string
+		expected string
+	}{
+		{
+			name:     "empty input",
+			raw:      "   ",
+			expected: "",
+		},
+		{
+			name:     "keeps formatting",
+			raw:      "<p>Hello <strong>world</strong> &amp; <em>friends</em></p>",
+			expected: "<p>Hello <strong>world</strong> &amp; <em>friends</em></p>",
+		},
+		{
+			name:     "removes scripts and styles with content",
+			raw:      `<p>Text</p><script>alert("x")</script><style>p{color:red}</style>`,
+			expected: "<p>Text</p>",
+		},
+		{
+			name:     "removes event handlers and inline styles",
+			raw:      `<p onclick="steal()" style="color:red">Text</p>`,
+			expected: "<p>Text</p>",
+		},
+		{
+			name:     "removes javascript links",
+			raw:      `<a href="javascript:alert(1)">Click</a>`,
+			expected: "Click",
+		},
+		{
+			name:     "removes iframes",
+			raw:      `<p>Video</p><iframe src="https://example.com/embed"></iframe>`,
+			expected: "<p>Video</p>",
+		},
+		{
+			name:     "removes tracking pixels",
+			raw:      `<p>Text</p><img src="https://tracker.example.com/p.gif" width="1" height="1"><img src="https://example.com/b.gif" style="display: none">`,
+			expected: "<p>Text</p>",
+		},
+		{
+			name:     "keeps regular images",
+			raw:      `<img src="https://example.com/photo.jpg" alt="Photo" width="600">`,
+			expected: `<img src="https://example.com/photo.jpg" alt="Photo" width="600"/>`,
+		},
+		{
+			name:     "resolves relative URLs and hardens links",
+			raw:      `<a href="/post/2">Next</a><img src="img/a.png" alt="A">`,
+			baseURL:  "https://example.com/blog/post/1",
+			expected: `<a href="https://example.com/post/2" rel="nofollow noreferrer noopener" target="_blank">Next</a><img src="https://example.com/blog/post/img/a.png" alt="A"/>`,
+		},
+		{
+			name:     "removes comments",
+			raw:      "<p>Text<!-- hidden --></p>",
+			expected: "<p>Text</p>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, HTML(tt.raw, tt.baseURL))
+		})
+	}
+}
+
+func TestText(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected string
+	}{
+		{
+			name:     "empty input",
+			raw:      "",
+			expected: "",
+		},
+		{
+			name:     "plain text is kept",
+			raw:      "Just some text",
+			expected: "Just some text",
+		},
+		{
+			name:     "decodes entities",
+			raw:      "Tom &amp; Jerry &#8211; &quot;classic&quot;",
+			expected: "Tom & Jerry – \"classic\"",
+		},
+		{
+			name:     "collapses whitespace",
+			raw:      "  Many \n\n   spaces\tand\nlines  ",
+			expected: "Many spaces and lines",
+		},
+		{
+			name:     "separates paragraphs",
+			raw:      "<h2>Title</h2><p>First <b>para</b>.</p><p>Second<br>line.</p>",
+			expected: "Title\n\nFirst para.\n\nSecond\n\nline.",
+		},
+		{
+			name:     "separates list items",
+			raw:      "<ul><li>One</li><li>Two</li></ul>",
+			expected: "One\n\nTwo",
+		},
+		{
+			name:     "drops scripts and styles",
+			raw:      "<p>Text</p><script>var x = 1;</script><style>p{}</style>",
+			expected: "Text",
+		},
+		{
+			name:     "keeps literal less-than sign",
+			raw:      "a < b",
+			expected: "a < b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Text(tt.raw))
+		})
+	}
+}
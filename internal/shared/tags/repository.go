@@ -0,0 +1,52 @@
+package tags
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/supabase-community/postgrest-go"
+	"github.com/tjanas94/vibefeeder/internal/shared/database"
+	"github.com/tjanas94/vibefeeder/internal/shared/tracing"
+)
+
+// TagRepository defines the interface for looking up the tags of a user
+type TagRepository interface {
+	ListTags(ctx context.Context, userID string) ([]database.PublicTagsSelect, error)
+}
+
+// Repository handles data access for tags
+type Repository struct {
+	db *database.Client
+}
+
+// Ensure Repository implements TagRepository interface at compile time
+var _ TagRepository = (*Repository)(nil)
+
+// NewRepository creates a new tags repository
+func NewRepository(db *database.Client) *Repository {
+	return &Repository{db: db}
+}
+
+// ListTags retrieves all tags of the user ordered by name
+func (r *Repository) ListTags(ctx context.Context, userID string) (_ []database.PublicTagsSelect, err error) {
+	_, span := tracing.Start(ctx, "tags.Repository.ListTags")
+	defer func() { tracing.End(span, err) }()
+
+	// Get authenticated client for RLS
+	client, err := r.db.NewAuthenticatedClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []database.PublicTagsSelect
+	_, err = client.From("tags").
+		Select("id,name", "", false).
+		Eq("user_id", userID).
+		Order("name", &postgrest.OrderOpts{Ascending: true}).
+		ExecuteTo(&tags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tags: %w", err)
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,66 @@
+package tags
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// MaxTags is the maximum number of tags of a single feed
+	MaxTags = 10
+
+	// MaxNameLength is the maximum length of a tag name in characters (enforced by the database as well)
+	MaxNameLength = 50
+
+	// separator separates tag names in form input
+	separator = ","
+)
+
+// Parse parses tag names written as a comma-separated list
+// Names are trimmed, empty names are ignored and duplicates (regardless of case) are dropped
+func Parse(text string) ([]string, error) {
+	var names []string
+	seen := make(map[string]bool)
+
+	for name := range strings.SplitSeq(text, separator) {
+		name = strings.Join(strings.Fields(name), " ")
+		if name == "" {
+			continue
+		}
+		if utf8.RuneCountInString(name) > MaxNameLength {
+			return nil, fmt.Errorf("tag %q is longer than %d characters", name, MaxNameLength)
+		}
+
+		key := strings.ToLower(name)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		names = append(names, name)
+	}
+
+	if len(names) > MaxTags {
+		return nil, fmt.Errorf("at most %d tags are allowed", MaxTags)
+	}
+
+	return names, nil
+}
+
+// Format joins tag names into the comma-separated form input
+func Format(names []string) string {
+	return strings.Join(names, separator+" ")
+}
+
+// Sanitize turns an arbitrary label (e.g. an OPML folder name) into a valid tag name
+// Separators are replaced, whitespace is collapsed and long names are shortened
+func Sanitize(label string) string {
+	name := strings.ReplaceAll(label, separator, " ")
+	name = strings.Join(strings.Fields(name), " ")
+
+	runes := []rune(name)
+	if len(runes) > MaxNameLength {
+		name = strings.TrimSpace(string(runes[:MaxNameLength]))
+	}
+	return name
+}
@@ -0,0 +1,61 @@
+package tags
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{name: "empty", input: "", expected: nil},
+		{name: "only separators", input: " , ,", expected: nil},
+		{name: "single tag", input: "Tech", expected: []string{"Tech"}},
+		{name: "trims and collapses whitespace", input: "  Tech ,  Open   Source ", expected: []string{"Tech", "Open Source"}},
+		{name: "drops duplicates regardless of case", input: "Go, go, GO, Rust", expected: []string{"Go", "Rust"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names, err := Parse(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestParse_Limits(t *testing.T) {
+	_, err := Parse(strings.Repeat("a", MaxNameLength+1))
+	assert.Error(t, err, "name too long")
+
+	_, err = Parse(strings.Repeat("ż", MaxNameLength))
+	assert.NoError(t, err, "length is counted in characters")
+
+	many := make([]string, MaxTags+1)
+	for i := range many {
+		many[i] = strings.Repeat("x", i+1)
+	}
+	_, err = Parse(strings.Join(many, ","))
+	assert.Error(t, err, "too many tags")
+}
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, "Tech, Open Source", Format([]string{"Tech", "Open Source"}))
+	assert.Equal(t, "", Format(nil))
+
+	names, err := Parse(Format([]string{"Tech", "Open Source"}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Tech", "Open Source"}, names)
+}
+
+func TestSanitize(t *testing.T) {
+	assert.Equal(t, "News World", Sanitize(" News,World "))
+	assert.Equal(t, MaxNameLength, len([]rune(Sanitize(strings.Repeat("ż", 80)))))
+	assert.Equal(t, "", Sanitize(" , "))
+}
@@ -0,0 +1,60 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tjanas94/vibefeeder/internal/shared/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// useRecorder installs a tracer provider recording spans in memory for the duration of a test
+func useRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func TestStartAndEnd(t *testing.T) {
+	recorder := useRecorder(t)
+
+	ctx, parent := Start(context.Background(), "parent")
+	_, child := Start(ctx, "child")
+	End(child, errors.New("query failed"))
+	End(parent, nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "child", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "query failed", spans[0].Status().Description)
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID(), "child span is nested in the parent from ctx")
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+}
+
+func TestTraceID(t *testing.T) {
+	useRecorder(t)
+
+	assert.Empty(t, TraceID(context.Background()))
+
+	ctx, span := Start(context.Background(), "request")
+	defer span.End()
+	assert.Equal(t, span.SpanContext().TraceID().String(), TraceID(ctx))
+}
+
+func TestSetup(t *testing.T) {
+	shutdown, err := Setup(context.Background(), config.TracingConfig{Exporter: ExporterNone})
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+
+	_, err = Setup(context.Background(), config.TracingConfig{Exporter: "zipkin"})
+	assert.Error(t, err)
+}
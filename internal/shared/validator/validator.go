@@ -6,6 +6,8 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
+	"github.com/tjanas94/vibefeeder/internal/shared/credentials"
+	"github.com/tjanas94/vibefeeder/internal/shared/tags"
 	passwordvalidator "github.com/wagslane/go-password-validator"
 )
 
@@ -21,6 +23,12 @@ func New() *CustomValidator {
 	// Register custom validation for strong passwords
 	_ = v.RegisterValidation("strongpassword", validateStrongPassword)
 
+	// Register custom validation for custom HTTP request headers
+	_ = v.RegisterValidation("httpheaders", validateHTTPHeaders)
+
+	// Register custom validation for comma-separated tag names
+	_ = v.RegisterValidation("tagnames", validateTagNames)
+
 	return &CustomValidator{
 		validator: v,
 	}
@@ -49,6 +57,20 @@ func validateStrongPassword(fl validator.FieldLevel) bool {
 	return err == nil
 }
 
+// validateHTTPHeaders validates custom request headers written one "Name: value" pair per line
+// Empty values are valid, use "required" to enforce presence
+func validateHTTPHeaders(fl validator.FieldLevel) bool {
+	_, err := credentials.ParseHeaders(fl.Field().String())
+	return err == nil
+}
+
+// validateTagNames validates tag names written as a comma-separated list
+// Empty values are valid, use "required" to enforce presence
+func validateTagNames(fl validator.FieldLevel) bool {
+	_, err := tags.Parse(fl.Field().String())
+	return err == nil
+}
+
 // Validate validates a struct based on validation tags
 func (cv *CustomValidator) Validate(i any) error {
 	if err := cv.validator.Struct(i); err != nil {
@@ -81,7 +103,7 @@ func formatFieldError(err validator.FieldError) string {
 	param := err.Param()
 
 	switch tag {
-	case "required":
+	case "required", "required_if":
 		return "This field is required"
 	case "email":
 		return "Must be a valid email address"
@@ -91,6 +113,10 @@ func formatFieldError(err validator.FieldError) string {
 		return "Must be a valid HTTP or HTTPS URL"
 	case "strongpassword":
 		return "Make password longer or add numbers and symbols"
+	case "httpheaders":
+		return "Enter one \"Name: value\" header per line (Host, User-Agent and conditional headers cannot be set)"
+	case "tagnames":
+		return fmt.Sprintf("Enter at most %d comma-separated tags of up to %d characters", tags.MaxTags, tags.MaxNameLength)
 	case "min":
 		return fmt.Sprintf("Must be at least %s characters long", param)
 	case "max":
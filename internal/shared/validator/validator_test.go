@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -66,6 +67,104 @@ func TestCustomValidator_ValidateStrongPassword(t *testing.T) {
 	}
 }
 
+// TestCustomValidator_ValidateHTTPHeaders tests custom request header validation
+func TestCustomValidator_ValidateHTTPHeaders(t *testing.T) {
+	validator := New()
+
+	type TestStruct struct {
+		Headers string `validate:"httpheaders"`
+	}
+
+	tests := []struct {
+		name      string
+		headers   string
+		wantError bool
+	}{
+		{
+			name:      "empty headers",
+			headers:   "",
+			wantError: false,
+		},
+		{
+			name:      "valid headers",
+			headers:   "X-Api-Key: abc\nCookie: session=1",
+			wantError: false,
+		},
+		{
+			name:      "line without colon",
+			headers:   "X-Api-Key abc",
+			wantError: true,
+		},
+		{
+			name:      "reserved header",
+			headers:   "User-Agent: curl",
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testData := TestStruct{Headers: tt.headers}
+			err := validator.Validate(&testData)
+
+			if tt.wantError {
+				assert.Error(t, err, "expected validation error")
+			} else {
+				assert.NoError(t, err, "expected no validation error")
+			}
+		})
+	}
+}
+
+// TestCustomValidator_ValidateTagNames tests comma-separated tag name validation
+func TestCustomValidator_ValidateTagNames(t *testing.T) {
+	validator := New()
+
+	type TestStruct struct {
+		Tags string `validate:"tagnames"`
+	}
+
+	tests := []struct {
+		name      string
+		tags      string
+		wantError bool
+	}{
+		{
+			name:      "empty tags",
+			tags:      "",
+			wantError: false,
+		},
+		{
+			name:      "valid tags",
+			tags:      "Tech, Open Source",
+			wantError: false,
+		},
+		{
+			name:      "tag name too long",
+			tags:      "Tech, " + strings.Repeat("a", 51),
+			wantError: true,
+		},
+		{
+			name:      "too many tags",
+			tags:      "a,b,c,d,e,f,g,h,i,j,k",
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testData := TestStruct{Tags: tt.tags}
+			err := validator.Validate(&testData)
+
+			if tt.wantError {
+				assert.Error(t, err, "expected validation error")
+			} else {
+				assert.NoError(t, err, "expected no validation error")
+			}
+		})
+	}
+}
+
 // TestCustomValidator_ValidateRequired tests required field validation
 func TestCustomValidator_ValidateRequired(t *testing.T) {
 	validator := New()
@@ -16,6 +16,18 @@ type PaginationProps struct {
 	// FormID is the HTML id of the form to include in htmx requests
 	// Example: "#feed-filter-form"
 	FormID string
+
+	// Target is the selector of the element replaced with the loaded page
+	// Default: "#feed-list"
+	Target string
+}
+
+// target returns the htmx target of the pagination buttons
+func (p PaginationProps) target() string {
+	if p.Target == "" {
+		return "#feed-list"
+	}
+	return p.Target
 }
 
 // EmptyStateProps defines configuration for the EmptyState component.
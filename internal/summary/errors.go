@@ -1,6 +1,7 @@
 package summary
 
 import (
+	"errors"
 	"net/http"
 
 	sharederrors "github.com/tjanas94/vibefeeder/internal/shared/errors"
@@ -15,6 +16,37 @@ func NewNoArticlesFoundError() *sharederrors.ServiceError {
 	)
 }
 
+// NewNoUnreadArticlesFoundError creates a ServiceError when all recent articles are already read
+// Returns 404 Not Found
+func NewNoUnreadArticlesFoundError() *sharederrors.ServiceError {
+	return sharederrors.NewServiceError(
+		http.StatusNotFound,
+		"No unread articles found in the last 24 hours",
+	)
+}
+
+// ErrTagNotFound is the cause of the error returned by NewTagNotFoundError
+var ErrTagNotFound = errors.New("tag not found")
+
+// NewTagNotFoundError creates a ServiceError when the tag to limit the summary to doesn't exist
+// Returns 404 Not Found
+func NewTagNotFoundError() *sharederrors.ServiceError {
+	return sharederrors.NewServiceErrorWithCause(
+		http.StatusNotFound,
+		"Tag not found, it may have been removed",
+		ErrTagNotFound,
+	)
+}
+
+// NewSummaryNotFoundError creates a ServiceError when a summary is not found or doesn't belong to the user
+// Returns 404 Not Found
+func NewSummaryNotFoundError() *sharederrors.ServiceError {
+	return sharederrors.NewServiceError(
+		http.StatusNotFound,
+		"Summary not found",
+	)
+}
+
 // NewAIServiceUnavailableError creates a ServiceError when the AI service fails
 // Returns 503 Service Unavailable
 func NewAIServiceUnavailableError() *sharederrors.ServiceError {
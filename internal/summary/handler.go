@@ -3,10 +3,13 @@ package summary
 import (
 	"errors"
 	"net/http"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/tjanas94/vibefeeder/internal/shared/auth"
 	sharederrors "github.com/tjanas94/vibefeeder/internal/shared/errors"
+	sharedmodels "github.com/tjanas94/vibefeeder/internal/shared/models"
 	"github.com/tjanas94/vibefeeder/internal/summary/models"
 	"github.com/tjanas94/vibefeeder/internal/summary/view"
 )
@@ -26,13 +29,29 @@ func NewHandler(service *Service) *Handler {
 // GenerateSummary handles POST /summaries endpoint
 // Generates a new AI summary from user's recent articles
 func (h *Handler) GenerateSummary(c echo.Context) error {
+	cmd := new(models.GenerateSummaryCommand)
+	// Path 1: Handle bind errors (malformed form data)
+	if err := c.Bind(cmd); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid form data")
+	}
+
+	// Path 2: Handle validation errors (malformed tag ID) - reported like a removed tag
+	if err := c.Validate(cmd); err != nil {
+		return h.handleServiceError(c, NewTagNotFoundError(), models.GenerateSummaryCommand{UnreadOnly: cmd.UnreadOnly}, "invalid summary tag")
+	}
+
 	// Get user ID from authenticated session
-	userID := auth.GetUserID(c)
+	cmd.UserID = auth.GetUserID(c)
 
 	// Call service to generate summary and get view model
-	vm, err := h.service.GenerateSummary(c.Request().Context(), userID)
+	vm, err := h.service.GenerateSummary(c.Request().Context(), *cmd)
 	if err != nil {
-		return h.handleServiceError(c, err, "service error during summary generation", "code", "message")
+		// Path 3 & 4: the retry keeps the selected options, unless the tag is gone
+		retry := *cmd
+		if errors.Is(err, ErrTagNotFound) {
+			retry.TagID = ""
+		}
+		return h.handleServiceError(c, err, retry, "service error during summary generation", "code", "message")
 	}
 
 	// Success - render display view with view model
@@ -48,7 +67,7 @@ func (h *Handler) GetLatestSummary(c echo.Context) error {
 	// Call service to get latest summary and view model
 	vm, err := h.service.GetLatestSummaryForUser(c.Request().Context(), userID)
 	if err != nil {
-		return h.handleServiceError(c, err, "service error getting latest summary", "user_id", userID, "code")
+		return h.handleServiceError(c, err, models.GenerateSummaryCommand{}, "service error getting latest summary", "user_id", userID, "code")
 	}
 
 	// Success - add HX-Trigger header to open modal and render display view with view model
@@ -56,15 +75,117 @@ func (h *Handler) GetLatestSummary(c echo.Context) error {
 	return c.Render(http.StatusOK, "", view.Display(*vm))
 }
 
+// ShowArchive handles GET /summaries endpoint
+// Renders the summary archive page; the summaries are loaded by htmx from GET /summaries/list
+func (h *Handler) ShowArchive(c echo.Context) error {
+	// Bind and sanitize query parameters
+	query := new(models.ListSummariesQuery)
+	_ = c.Bind(query) // Ignore bind errors for query parameters
+	query.SetDefaults()
+
+	vm := models.SummaryArchivePageViewModel{
+		Title:     "Summary archive - VibeFeeder",
+		UserEmail: auth.GetUserEmail(c),
+		Query:     query,
+	}
+
+	return c.Render(http.StatusOK, "", view.Archive(vm))
+}
+
+// ListSummaries handles GET /summaries/list endpoint
+// Returns a page of the summary archive as an HTML fragment
+func (h *Handler) ListSummaries(c echo.Context) error {
+	// Bind and sanitize query parameters
+	query := new(models.ListSummariesQuery)
+	_ = c.Bind(query) // Ignore bind errors for query parameters
+	query.SetDefaults()
+
+	// Set user ID from authenticated session
+	query.UserID = auth.GetUserID(c)
+
+	vm, err := h.service.ListSummaries(c.Request().Context(), *query)
+	if err != nil {
+		// Path 3: Handle business errors (ServiceError)
+		var serviceErr *sharederrors.ServiceError
+		if errors.As(err, &serviceErr) {
+			errVM := models.SummaryArchiveListViewModel{
+				Summaries:    []models.SummaryViewModel{},
+				Date:         query.Date,
+				ErrorMessage: serviceErr.Message,
+				Pagination:   sharedmodels.PaginationViewModel{},
+			}
+			return c.Render(serviceErr.Code, "", view.ArchiveList(errVM))
+		}
+
+		// Path 4: Unexpected error - delegate to global error handler
+		return err
+	}
+
+	// Build URL for HX-Push-Url header to update browser history
+	c.Response().Header().Set("HX-Push-Url", buildArchiveURL(*query))
+
+	return c.Render(http.StatusOK, "", view.ArchiveList(*vm))
+}
+
+// ShowSummary handles GET /summaries/:id endpoint
+// Renders the permalink page of a single summary with its sources
+func (h *Handler) ShowSummary(c echo.Context) error {
+	// Path 2: Handle validation errors (malformed summary ID)
+	summaryID := c.Param("id")
+	if uuid.Validate(summaryID) != nil {
+		return NewSummaryNotFoundError()
+	}
+
+	// Path 3 & 4: errors of a full page are rendered by the global error handler
+	summary, err := h.service.GetSummary(c.Request().Context(), auth.GetUserID(c), summaryID)
+	if err != nil {
+		return err
+	}
+
+	vm := models.SummaryPageViewModel{
+		Title:     "Summary - VibeFeeder",
+		UserEmail: auth.GetUserEmail(c),
+		Summary:   *summary,
+	}
+
+	return c.Render(http.StatusOK, "", view.Permalink(vm))
+}
+
+// CompareSummaries handles GET /summaries/compare endpoint
+// Renders the summaries of two days side by side, today and yesterday by default
+func (h *Handler) CompareSummaries(c echo.Context) error {
+	// Bind and sanitize query parameters
+	query := new(models.CompareSummariesQuery)
+	_ = c.Bind(query) // Ignore bind errors for query parameters
+	query.SetDefaults(time.Now())
+
+	// Set user ID from authenticated session
+	query.UserID = auth.GetUserID(c)
+
+	// Path 3 & 4: errors of a full page are rendered by the global error handler
+	vm, err := h.service.CompareDays(c.Request().Context(), *query)
+	if err != nil {
+		return err
+	}
+
+	vm.Title = "Compare summaries - VibeFeeder"
+	vm.UserEmail = auth.GetUserEmail(c)
+
+	return c.Render(http.StatusOK, "", view.Compare(*vm))
+}
+
 // handleServiceError handles ServiceError responses with logging and error view rendering.
 // If err is a ServiceError, logs a warning and renders an error view.
 // If err is not a ServiceError, returns the error for global error handler processing.
-func (h *Handler) handleServiceError(c echo.Context, err error, logMsg string, logAttrs ...any) error {
+// The tag and unread filter of retry are kept in the retry action, so trying again generates the same summary.
+func (h *Handler) handleServiceError(c echo.Context, err error, retry models.GenerateSummaryCommand, logMsg string, logAttrs ...any) error {
 	var serviceErr *sharederrors.ServiceError
 	if errors.As(err, &serviceErr) {
 		errVM := &models.SummaryDisplayViewModel{
 			ErrorMessage: serviceErr.Message,
 			CanGenerate:  true,
+			SelectedTag:  retry.TagID,
+			UnreadOnly:   retry.UnreadOnly,
 		}
 		return c.Render(serviceErr.Code, "", view.Display(*errVM))
 	}
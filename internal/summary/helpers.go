@@ -2,8 +2,11 @@ package summary
 
 import (
 	"errors"
+	"fmt"
+	"net/url"
 
 	"github.com/tjanas94/vibefeeder/internal/shared/ai"
+	"github.com/tjanas94/vibefeeder/internal/summary/models"
 )
 
 // extractSummaryContent safely extracts content from AI response.
@@ -24,3 +27,23 @@ func extractSummaryContent(response *ai.ChatCompletionResponse) (string, error)
 
 	return content, nil
 }
+
+// buildArchiveURL builds the summary archive URL with query parameters based on the archive query.
+// This is a pure function that constructs a URL string from the query parameters.
+func buildArchiveURL(query models.ListSummariesQuery) string {
+	pushURL := "/summaries"
+	params := make(url.Values)
+
+	if query.Date != "" {
+		params.Set("date", query.Date)
+	}
+	if query.Page > 1 {
+		params.Set("page", fmt.Sprintf("%d", query.Page))
+	}
+
+	if len(params) > 0 {
+		pushURL += "?" + params.Encode()
+	}
+
+	return pushURL
+}
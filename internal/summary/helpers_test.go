@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tjanas94/vibefeeder/internal/shared/ai"
+	"github.com/tjanas94/vibefeeder/internal/summary/models"
 )
 
 // TestExtractSummaryContent tests the extractSummaryContent helper function
@@ -327,6 +328,37 @@ func TestExtractSummaryContent_EdgeCases(t *testing.T) {
 	}
 }
 
+// TestBuildArchiveURL tests the buildArchiveURL helper function
+func TestBuildArchiveURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    models.ListSummariesQuery
+		expected string
+	}{
+		{
+			name:     "empty query returns base URL",
+			query:    models.ListSummariesQuery{},
+			expected: "/summaries",
+		},
+		{
+			name:     "page 1 is omitted from URL",
+			query:    models.ListSummariesQuery{Page: 1},
+			expected: "/summaries",
+		},
+		{
+			name:     "date and page",
+			query:    models.ListSummariesQuery{Date: "2025-11-11", Page: 2},
+			expected: "/summaries?date=2025-11-11&page=2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, buildArchiveURL(tt.query))
+		})
+	}
+}
+
 // BenchmarkExtractSummaryContent benchmarks the extractSummaryContent function
 func BenchmarkExtractSummaryContent(b *testing.B) {
 	response := &ai.ChatCompletionResponse{
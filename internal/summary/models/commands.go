@@ -3,19 +3,23 @@ package models
 import "github.com/tjanas94/vibefeeder/internal/shared/database"
 
 // GenerateSummaryCommand represents the input for generating a new summary.
-// No input fields required - summary is generated from user's articles from last 24h.
+// The summary is generated from user's articles from last 24h, optionally limited to the feeds of one tag
+// and to unread articles, which are marked as read once summarized.
 // Used by: POST /summaries
 type GenerateSummaryCommand struct {
-	// Empty command - all data comes from database queries
-	// UserID will be provided by authenticated session
+	UserID     string `form:"-"`                             // Set from authenticated session
+	TagID      string `form:"tag" validate:"omitempty,uuid"` // Optional: only articles of feeds with this tag
+	UnreadOnly bool   `form:"unread_only"`                   // Optional: only unread articles, marked as read afterwards
 }
 
 // ToInsert converts the generated summary content to database.PublicSummariesInsert.
 // UserID must be set from authenticated session, Content from AI generation.
-func ToInsert(userID string, content string) database.PublicSummariesInsert {
+// TagName is the name of the tag the summary was limited to, nil for all feeds.
+func ToInsert(userID string, content string, tagName *string) database.PublicSummariesInsert {
 	return database.PublicSummariesInsert{
 		UserId:  userID,
 		Content: content,
+		TagName: tagName,
 		// CreatedAt, Id will be set by database
 	}
 }
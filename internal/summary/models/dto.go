@@ -1,39 +1,164 @@
 package models
 
 import (
+	"sort"
 	"time"
 
 	"github.com/tjanas94/vibefeeder/internal/shared/database"
+	sharedmodels "github.com/tjanas94/vibefeeder/internal/shared/models"
 )
 
 // ArticleForPrompt contains only the fields needed for AI prompt generation.
 // Used by: fetchRecentArticles, buildPromptFromArticles
 type ArticleForPrompt struct {
-	Title   string  `json:"title"`
-	Content *string `json:"content"`
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Content     *string  `json:"content"`      // Plain text excerpt from the feed
+	FullContent *string  `json:"full_content"` // Extracted article text, preferred over the feed excerpt
+	Categories  []string `json:"categories"`   // Feed item categories, passed as topic context
+}
+
+// SummarySource is an article a summary was generated from, as selected from summary_articles.
+// Used by: ListSummarySources, NewSourcesFromDB
+type SummarySource struct {
+	ArticleID string `json:"article_id"`
+	Article   struct {
+		ID          string `json:"id"`
+		Title       string `json:"title"`
+		URL         string `json:"url"`
+		PublishedAt string `json:"published_at"`
+		Feed        struct {
+			Name string `json:"name"`
+		} `json:"feeds"`
+		Saved []struct {
+			ID string `json:"id"`
+		} `json:"saved_articles"`
+	} `json:"articles"`
 }
 
 // SummaryViewModel represents a single summary for display.
 // Derived from database.PublicSummariesSelect.
-// Used by: GET /summaries/latest, POST /summaries, GET /dashboard
+// Used by: GET /summaries/latest, POST /summaries, GET /dashboard, GET /summaries/list, GET /summaries/:id,
+// GET /summaries/compare
 type SummaryViewModel struct {
-	ID        string    `json:"id"`
-	Content   string    `json:"content"`
-	CreatedAt time.Time `json:"created_at"`
+	ID        string            `json:"id"`
+	Content   string            `json:"content"`
+	TagName   string            `json:"tag_name,omitempty"` // Tag the summary was limited to (empty for all feeds)
+	CreatedAt time.Time         `json:"created_at"`
+	Sources   []SourceViewModel `json:"sources"` // Articles the summary was generated from, newest first (not loaded in lists)
+}
+
+// SourceViewModel represents an article a summary was generated from.
+// Used by: GET /summaries/latest, POST /summaries
+type SourceViewModel struct {
+	ArticleID   string    `json:"article_id"`
+	Title       string    `json:"title"`
+	URL         string    `json:"url"`
+	FeedName    string    `json:"feed_name"`
+	PublishedAt time.Time `json:"published_at"`
+	Starred     bool      `json:"starred"`
+}
+
+// NewSourcesFromDB creates SourceViewModels from SummarySource rows, newest article first.
+func NewSourcesFromDB(dbSources []SummarySource) []SourceViewModel {
+	vms := make([]SourceViewModel, len(dbSources))
+	for i, dbSource := range dbSources {
+		vms[i] = SourceViewModel{
+			ArticleID: dbSource.ArticleID,
+			Title:     dbSource.Article.Title,
+			URL:       dbSource.Article.URL,
+			FeedName:  dbSource.Article.Feed.Name,
+			Starred:   len(dbSource.Article.Saved) > 0,
+		}
+		if publishedAt, err := time.Parse(time.RFC3339, dbSource.Article.PublishedAt); err == nil {
+			vms[i].PublishedAt = publishedAt
+		}
+	}
+
+	// PostgREST can't order by a column of the embedded article
+	sort.SliceStable(vms, func(i, j int) bool {
+		return vms[i].PublishedAt.After(vms[j].PublishedAt)
+	})
+
+	return vms
 }
 
 // SummaryDisplayViewModel represents the summary section display with empty state support.
 // Used by: GET /summaries/latest, POST /summaries
 type SummaryDisplayViewModel struct {
-	Summary      *SummaryViewModel `json:"summary,omitempty"`
-	CanGenerate  bool              `json:"can_generate"`            // true if user has at least one working feed
-	ErrorMessage string            `json:"error_message,omitempty"` // non-empty -> render error state instead of other states
+	Summary      *SummaryViewModel    `json:"summary,omitempty"`
+	CanGenerate  bool                 `json:"can_generate"`            // true if user has at least one working feed
+	Tags         []TagOptionViewModel `json:"tags"`                    // Tags the next summary can be limited to
+	SelectedTag  string               `json:"selected_tag"`            // ID of the tag of the last generation request
+	UnreadOnly   bool                 `json:"unread_only"`             // The last generation request was limited to unread articles
+	ErrorMessage string               `json:"error_message,omitempty"` // non-empty -> render error state instead of other states
+}
+
+// SummaryArchivePageViewModel contains the data needed to render the summary archive page.
+// Used by: GET /summaries
+type SummaryArchivePageViewModel struct {
+	Title     string
+	UserEmail string
+	Query     *ListSummariesQuery
+}
+
+// SummaryArchiveListViewModel represents a page of the summary archive with empty state support.
+// Used by: GET /summaries/list
+type SummaryArchiveListViewModel struct {
+	Summaries      []SummaryViewModel               `json:"summaries"`
+	Date           string                           `json:"date,omitempty"` // Day the archive is limited to (YYYY-MM-DD)
+	ShowEmptyState bool                             `json:"show_empty_state"`
+	ErrorMessage   string                           `json:"error_message,omitempty"`
+	Pagination     sharedmodels.PaginationViewModel `json:"pagination"`
+}
+
+// SummaryPageViewModel contains the data needed to render the permalink page of a summary.
+// Used by: GET /summaries/:id
+type SummaryPageViewModel struct {
+	Title     string
+	UserEmail string
+	Summary   SummaryViewModel
+}
+
+// SummaryCompareViewModel contains the data needed to render the summaries of two days side by side.
+// Used by: GET /summaries/compare
+type SummaryCompareViewModel struct {
+	Title     string
+	UserEmail string
+	Left      SummaryDayViewModel `json:"left"`
+	Right     SummaryDayViewModel `json:"right"`
+}
+
+// SummaryDayViewModel represents the summaries generated on one day, newest first.
+// Used by: GET /summaries/compare
+type SummaryDayViewModel struct {
+	Date      string             `json:"date"` // YYYY-MM-DD
+	Summaries []SummaryViewModel `json:"summaries"`
+	MoreCount int                `json:"more_count"` // Summaries of the day not shown, browsable in the archive
+}
+
+// TagOptionViewModel represents a tag the summary can be limited to.
+// Used by: GET /summaries/latest, POST /summaries
+type TagOptionViewModel struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// NewTagOptionsFromDB creates TagOptionViewModels from database.PublicTagsSelect rows.
+func NewTagOptionsFromDB(dbTags []database.PublicTagsSelect) []TagOptionViewModel {
+	vms := make([]TagOptionViewModel, len(dbTags))
+	for i, dbTag := range dbTags {
+		vms[i] = TagOptionViewModel{ID: dbTag.Id, Name: dbTag.Name}
+	}
+	return vms
 }
 
 // SummaryErrorViewModel represents errors during summary generation.
 // Used by: POST /summaries
 type SummaryErrorViewModel struct {
 	ErrorMessage string `json:"error_message"`
+	SelectedTag  string `json:"selected_tag,omitempty"` // Tag kept for the retry
+	UnreadOnly   bool   `json:"unread_only,omitempty"`  // Unread filter kept for the retry
 }
 
 // NewSummaryFromDB creates a SummaryViewModel from database.PublicSummariesSelect.
@@ -44,6 +169,10 @@ func NewSummaryFromDB(dbSummary database.PublicSummariesSelect) SummaryViewModel
 		Content: dbSummary.Content,
 	}
 
+	if dbSummary.TagName != nil {
+		vm.TagName = *dbSummary.TagName
+	}
+
 	// Parse created_at timestamp
 	if createdAt, err := time.Parse(time.RFC3339, dbSummary.CreatedAt); err == nil {
 		vm.CreatedAt = createdAt
@@ -51,3 +180,12 @@ func NewSummaryFromDB(dbSummary database.PublicSummariesSelect) SummaryViewModel
 
 	return vm
 }
+
+// NewSummariesFromDB creates SummaryViewModels (without sources) from database.PublicSummariesSelect rows.
+func NewSummariesFromDB(dbSummaries []database.PublicSummariesSelect) []SummaryViewModel {
+	vms := make([]SummaryViewModel, len(dbSummaries))
+	for i, dbSummary := range dbSummaries {
+		vms[i] = NewSummaryFromDB(dbSummary)
+	}
+	return vms
+}
@@ -0,0 +1,63 @@
+package models
+
+import "time"
+
+// DateFormat is the format of the date parameters (as sent by date inputs)
+const DateFormat = "2006-01-02"
+
+// ListSummariesQuery represents the input parameters for browsing the summary archive.
+// Used by: GET /summaries, GET /summaries/list
+type ListSummariesQuery struct {
+	UserID string `query:"-"`    // Required: User ID from authenticated session (set by handler)
+	Date   string `query:"date"` // Optional: Only summaries generated on this day (YYYY-MM-DD, UTC)
+	Page   int    `query:"page"` // Optional: Page number (1-indexed), default: 1
+}
+
+// SetDefaults sets default values for optional query parameters
+// and sanitizes invalid values
+func (q *ListSummariesQuery) SetDefaults() {
+	// Sanitize date - drop dates that can't be parsed
+	if _, err := time.Parse(DateFormat, q.Date); err != nil {
+		q.Date = ""
+	}
+
+	// Sanitize page - must be >= 1
+	if q.Page < 1 {
+		q.Page = 1
+	}
+}
+
+// CreatedRange returns the bounds of the created_at filter in UTC.
+// The end is exclusive (the day after Date). Zero times mean the archive is not limited to a day.
+func (q *ListSummariesQuery) CreatedRange() (from, to time.Time) {
+	if day, err := time.Parse(DateFormat, q.Date); err == nil {
+		return day, day.AddDate(0, 0, 1)
+	}
+	return time.Time{}, time.Time{}
+}
+
+// CompareSummariesQuery represents the input parameters for comparing the summaries of two days.
+// Used by: GET /summaries/compare
+type CompareSummariesQuery struct {
+	UserID string `query:"-"`     // Required: User ID from authenticated session (set by handler)
+	Left   string `query:"left"`  // Optional: First day (YYYY-MM-DD, UTC), default: the day before Right
+	Right  string `query:"right"` // Optional: Second day (YYYY-MM-DD, UTC), default: today
+}
+
+// SetDefaults sanitizes the days and fills in the missing ones relative to now,
+// so without parameters today is compared with yesterday
+func (q *CompareSummariesQuery) SetDefaults(now time.Time) {
+	// Sanitize days - drop dates that can't be parsed
+	if _, err := time.Parse(DateFormat, q.Left); err != nil {
+		q.Left = ""
+	}
+	right, err := time.Parse(DateFormat, q.Right)
+	if err != nil {
+		right = now.UTC().Truncate(24 * time.Hour)
+		q.Right = right.Format(DateFormat)
+	}
+
+	if q.Left == "" {
+		q.Left = right.AddDate(0, 0, -1).Format(DateFormat)
+	}
+}
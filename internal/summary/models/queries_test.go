@@ -0,0 +1,98 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestListSummariesQuery_SetDefaults tests the SetDefaults method
+func TestListSummariesQuery_SetDefaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		initial  ListSummariesQuery
+		expected ListSummariesQuery
+	}{
+		{
+			name:     "all fields empty",
+			initial:  ListSummariesQuery{},
+			expected: ListSummariesQuery{Page: 1},
+		},
+		{
+			name:     "valid date is kept",
+			initial:  ListSummariesQuery{Date: "2025-11-11", Page: 2},
+			expected: ListSummariesQuery{Date: "2025-11-11", Page: 2},
+		},
+		{
+			name:     "invalid date is dropped",
+			initial:  ListSummariesQuery{Date: "tuesday"},
+			expected: ListSummariesQuery{Page: 1},
+		},
+		{
+			name:     "negative page is reset",
+			initial:  ListSummariesQuery{Page: -1},
+			expected: ListSummariesQuery{Page: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.initial
+			q.SetDefaults()
+			assert.Equal(t, tt.expected, q)
+		})
+	}
+}
+
+func TestListSummariesQuery_CreatedRange(t *testing.T) {
+	// The whole day is included, the end is exclusive
+	from, to := (&ListSummariesQuery{Date: "2025-11-11"}).CreatedRange()
+	assert.Equal(t, time.Date(2025, 11, 11, 0, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, time.Date(2025, 11, 12, 0, 0, 0, 0, time.UTC), to)
+
+	from, to = (&ListSummariesQuery{}).CreatedRange()
+	assert.True(t, from.IsZero())
+	assert.True(t, to.IsZero())
+}
+
+// TestCompareSummariesQuery_SetDefaults tests the SetDefaults method
+func TestCompareSummariesQuery_SetDefaults(t *testing.T) {
+	// Late evening in a zone ahead of UTC, the UTC day is still the 11th
+	now := time.Date(2025, 11, 12, 0, 30, 0, 0, time.FixedZone("CET", 3600))
+
+	tests := []struct {
+		name     string
+		initial  CompareSummariesQuery
+		expected CompareSummariesQuery
+	}{
+		{
+			name:     "today and yesterday by default",
+			initial:  CompareSummariesQuery{},
+			expected: CompareSummariesQuery{Left: "2025-11-10", Right: "2025-11-11"},
+		},
+		{
+			name:     "day before the second day",
+			initial:  CompareSummariesQuery{Right: "2025-03-01"},
+			expected: CompareSummariesQuery{Left: "2025-02-28", Right: "2025-03-01"},
+		},
+		{
+			name:     "both days are kept",
+			initial:  CompareSummariesQuery{Left: "2025-01-01", Right: "2025-06-01"},
+			expected: CompareSummariesQuery{Left: "2025-01-01", Right: "2025-06-01"},
+		},
+		{
+			name:     "invalid days are replaced",
+			initial:  CompareSummariesQuery{Left: "2025-02-30", Right: "today"},
+			expected: CompareSummariesQuery{Left: "2025-11-10", Right: "2025-11-11"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.initial
+			q.SetDefaults(now)
+			assert.Equal(t, tt.expected, q)
+		})
+	}
+}
@@ -15,6 +15,8 @@ const (
 
 // buildPromptFromArticles creates a prompt for the AI from article data.
 // This is a pure function with no side effects.
+// Extracted full content is used instead of the feed excerpt when available.
+// Feed item categories are included as topic context.
 // Article content is truncated to maxContentLength to prevent excessive token usage.
 func buildPromptFromArticles(articles []models.ArticleForPrompt) string {
 	var sb strings.Builder
@@ -25,8 +27,11 @@ func buildPromptFromArticles(articles []models.ArticleForPrompt) string {
 		sb.WriteString(fmt.Sprintf("Article %d:\n", i+1))
 		sb.WriteString(fmt.Sprintf("Title: %s\n", article.Title))
 
-		if article.Content != nil && *article.Content != "" {
-			content := *article.Content
+		if len(article.Categories) > 0 {
+			sb.WriteString(fmt.Sprintf("Categories: %s\n", strings.Join(article.Categories, ", ")))
+		}
+
+		if content := articleContent(article); content != "" {
 			// Truncate content if it exceeds maxContentLength
 			if len(content) > maxContentLength {
 				content = content[:maxContentLength] + "..."
@@ -41,3 +46,14 @@ func buildPromptFromArticles(articles []models.ArticleForPrompt) string {
 
 	return sb.String()
 }
+
+// articleContent returns the best available text of an article
+func articleContent(article models.ArticleForPrompt) string {
+	if article.FullContent != nil && *article.FullContent != "" {
+		return *article.FullContent
+	}
+	if article.Content != nil {
+		return *article.Content
+	}
+	return ""
+}
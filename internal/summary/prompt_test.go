@@ -43,6 +43,20 @@ func TestBuildPromptFromArticles(t *testing.T) {
 			},
 			description: "Should format single article with title and content",
 		},
+		{
+			name: "article with categories",
+			articles: []models.ArticleForPrompt{
+				{
+					Title:      "Episode 42",
+					Content:    ptr("Interview about distributed systems."),
+					Categories: []string{"Technology", "Podcasts"},
+				},
+			},
+			validate: func(t *testing.T, prompt string) {
+				assert.Contains(t, prompt, "Title: Episode 42\nCategories: Technology, Podcasts\n")
+			},
+			description: "Should pass categories as context after the title",
+		},
 		{
 			name: "single article with nil content",
 			articles: []models.ArticleForPrompt{
@@ -368,6 +382,49 @@ func TestBuildPromptFromArticles_ContentTruncation(t *testing.T) {
 	}
 }
 
+// TestBuildPromptFromArticles_FullContent tests that extracted full content is preferred over the excerpt
+func TestBuildPromptFromArticles_FullContent(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     *string
+		fullContent *string
+		expected    string
+		notExpected string
+	}{
+		{
+			name:        "full content replaces excerpt",
+			content:     ptr("Short excerpt"),
+			fullContent: ptr("The whole article text"),
+			expected:    "Content: The whole article text\n",
+			notExpected: "Short excerpt",
+		},
+		{
+			name:        "empty full content falls back to excerpt",
+			content:     ptr("Short excerpt"),
+			fullContent: ptr(""),
+			expected:    "Content: Short excerpt\n",
+		},
+		{
+			name:        "full content without excerpt",
+			fullContent: ptr("The whole article text"),
+			expected:    "Content: The whole article text\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompt := buildPromptFromArticles([]models.ArticleForPrompt{
+				{Title: "Test", Content: tt.content, FullContent: tt.fullContent},
+			})
+
+			assert.Contains(t, prompt, tt.expected)
+			if tt.notExpected != "" {
+				assert.NotContains(t, prompt, tt.notExpected)
+			}
+		})
+	}
+}
+
 // TestBuildPromptFromArticles_NilInput tests behavior with nil input
 func TestBuildPromptFromArticles_NilInput(t *testing.T) {
 	prompt := buildPromptFromArticles(nil)
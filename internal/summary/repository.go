@@ -7,6 +7,7 @@ import (
 
 	"github.com/supabase-community/postgrest-go"
 	"github.com/tjanas94/vibefeeder/internal/shared/database"
+	"github.com/tjanas94/vibefeeder/internal/shared/tracing"
 	"github.com/tjanas94/vibefeeder/internal/summary/models"
 )
 
@@ -24,8 +25,12 @@ func NewRepository(db *database.Client) *Repository {
 }
 
 // FetchRecentArticles retrieves articles published in the last 24 hours for the user's feeds
-// Limited to maxArticlesForSummary most recent articles
-func (r *Repository) FetchRecentArticles(ctx context.Context, userID string, limit int) ([]models.ArticleForPrompt, error) {
+// With tagID only feeds with that tag are included, with unreadOnly only articles the user has not read;
+// muted articles are left out; limited to maxArticlesForSummary most recent articles
+func (r *Repository) FetchRecentArticles(ctx context.Context, userID, tagID string, unreadOnly bool, limit int) (_ []models.ArticleForPrompt, err error) {
+	_, span := tracing.Start(ctx, "summary.Repository.FetchRecentArticles")
+	defer func() { tracing.End(span, err) }()
+
 	// Get authenticated client for RLS
 	client, err := r.db.NewAuthenticatedClient(ctx)
 	if err != nil {
@@ -37,10 +42,30 @@ func (r *Repository) FetchRecentArticles(ctx context.Context, userID string, lim
 
 	var articles []models.ArticleForPrompt
 
-	// Query articles joined with feeds to filter by user_id
-	_, err = client.From("articles").
-		Select("title, content, feeds!inner(user_id)", "", false).
+	// Query articles joined with feeds to filter by user_id (and feed_tags to filter by tag)
+	feedsJoin := "feeds!inner(user_id)"
+	if tagID != "" {
+		feedsJoin = "feeds!inner(user_id, feed_tags!inner(tag_id))"
+	}
+	columns := "id, title, content, full_content, categories, " + feedsJoin
+	if unreadOnly {
+		// Anti-join on the read state, RLS limits it to the rows of the user
+		columns += ", article_reads(article_id)"
+	}
+	// Articles matching a mute rule of the user never reach the prompt (computed field)
+	// Evaluated per row, the 24-hour window keeps the number of candidates small
+	articleQuery := client.From("articles").
+		Select(columns, "", false).
 		Eq("feeds.user_id", userID).
+		Is("muted", "false")
+	if tagID != "" {
+		articleQuery = articleQuery.Eq("feeds.feed_tags.tag_id", tagID)
+	}
+	if unreadOnly {
+		articleQuery = articleQuery.Is("article_reads", "null")
+	}
+
+	_, err = articleQuery.
 		Gte("published_at", twentyFourHoursAgo).
 		Order("published_at", &postgrest.OrderOpts{Ascending: false}).
 		Limit(limit, "").
@@ -54,14 +79,17 @@ func (r *Repository) FetchRecentArticles(ctx context.Context, userID string, lim
 }
 
 // SaveSummary stores the generated summary in the database
-func (r *Repository) SaveSummary(ctx context.Context, userID, content string) (*database.PublicSummariesSelect, error) {
+func (r *Repository) SaveSummary(ctx context.Context, userID, content string, tagName *string) (_ *database.PublicSummariesSelect, err error) {
+	_, span := tracing.Start(ctx, "summary.Repository.SaveSummary")
+	defer func() { tracing.End(span, err) }()
+
 	// Get authenticated client for RLS
 	client, err := r.db.NewAuthenticatedClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	insert := models.ToInsert(userID, content)
+	insert := models.ToInsert(userID, content, tagName)
 
 	var result database.PublicSummariesSelect
 	_, err = client.From("summaries").
@@ -76,8 +104,88 @@ func (r *Repository) SaveSummary(ctx context.Context, userID, content string) (*
 	return &result, nil
 }
 
+// MarkArticlesRead marks the given articles as read by the user, keeping the read time of articles read before
+func (r *Repository) MarkArticlesRead(ctx context.Context, userID string, articleIDs []string) (err error) {
+	_, span := tracing.Start(ctx, "summary.Repository.MarkArticlesRead")
+	defer func() { tracing.End(span, err) }()
+
+	// Get authenticated client for RLS
+	client, err := r.db.NewAuthenticatedClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	reads := make([]database.PublicArticleReadsInsert, len(articleIDs))
+	for i, articleID := range articleIDs {
+		reads[i] = database.PublicArticleReadsInsert{UserId: userID, ArticleId: articleID}
+	}
+
+	_, _, err = client.From("article_reads").
+		Upsert(reads, "user_id,article_id", "minimal", "").
+		Execute()
+	if err != nil {
+		return fmt.Errorf("failed to mark articles as read: %w", err)
+	}
+
+	return nil
+}
+
+// AddSummarySources links the summary with the articles it was generated from
+func (r *Repository) AddSummarySources(ctx context.Context, summaryID string, articleIDs []string) (err error) {
+	_, span := tracing.Start(ctx, "summary.Repository.AddSummarySources")
+	defer func() { tracing.End(span, err) }()
+
+	// Get authenticated client for RLS
+	client, err := r.db.NewAuthenticatedClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	sources := make([]database.PublicSummaryArticlesInsert, len(articleIDs))
+	for i, articleID := range articleIDs {
+		sources[i] = database.PublicSummaryArticlesInsert{SummaryId: summaryID, ArticleId: articleID}
+	}
+
+	_, _, err = client.From("summary_articles").
+		Insert(sources, false, "", "minimal", "").
+		Execute()
+	if err != nil {
+		return fmt.Errorf("failed to add summary sources: %w", err)
+	}
+
+	return nil
+}
+
+// ListSummarySources retrieves the articles the summary was generated from, with their starred state
+// Articles deleted since the summary was generated are gone from the list
+func (r *Repository) ListSummarySources(ctx context.Context, summaryID string) (_ []models.SummarySource, err error) {
+	_, span := tracing.Start(ctx, "summary.Repository.ListSummarySources")
+	defer func() { tracing.End(span, err) }()
+
+	// Get authenticated client for RLS
+	client, err := r.db.NewAuthenticatedClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// saved_articles is limited to the rows of the user by RLS
+	var sources []models.SummarySource
+	_, err = client.From("summary_articles").
+		Select("article_id, articles!inner(id, title, url, published_at, feeds(name), saved_articles(id))", "", false).
+		Eq("summary_id", summaryID).
+		ExecuteTo(&sources)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch summary sources: %w", err)
+	}
+
+	return sources, nil
+}
+
 // GetLatestSummary retrieves the most recent summary for a user
-func (r *Repository) GetLatestSummary(ctx context.Context, userID string) (*database.PublicSummariesSelect, error) {
+func (r *Repository) GetLatestSummary(ctx context.Context, userID string) (_ *database.PublicSummariesSelect, err error) {
+	_, span := tracing.Start(ctx, "summary.Repository.GetLatestSummary")
+	defer func() { tracing.End(span, err) }()
+
 	// Get authenticated client for RLS
 	client, err := r.db.NewAuthenticatedClient(ctx)
 	if err != nil {
@@ -103,8 +211,85 @@ func (r *Repository) GetLatestSummary(ctx context.Context, userID string) (*data
 	return &summaries[0], nil
 }
 
+// ListSummariesResult contains the result of listing summaries from the database
+type ListSummariesResult struct {
+	Summaries  []database.PublicSummariesSelect
+	TotalCount int
+}
+
+// ListSummaries retrieves a page of the user's summaries, newest first, optionally generated on one day
+// Filters and order match idx_summaries_user_created, so the archive is read from the index
+func (r *Repository) ListSummaries(ctx context.Context, query models.ListSummariesQuery) (_ *ListSummariesResult, err error) {
+	_, span := tracing.Start(ctx, "summary.Repository.ListSummaries")
+	defer func() { tracing.End(span, err) }()
+
+	// Get authenticated client for RLS
+	client, err := r.db.NewAuthenticatedClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate offset for pagination
+	offset := (query.Page - 1) * pageSize
+
+	summaryQuery := client.From("summaries").
+		Select("*", "exact", false).
+		Eq("user_id", query.UserID)
+	if from, to := query.CreatedRange(); !from.IsZero() {
+		summaryQuery = summaryQuery.
+			Gte("created_at", from.Format(time.RFC3339)).
+			Lt("created_at", to.Format(time.RFC3339))
+	}
+
+	var summaries []database.PublicSummariesSelect
+	count, err := summaryQuery.
+		Order("created_at", &postgrest.OrderOpts{Ascending: false}).
+		Range(offset, offset+pageSize-1, "").
+		ExecuteTo(&summaries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch summaries: %w", err)
+	}
+
+	return &ListSummariesResult{
+		Summaries:  summaries,
+		TotalCount: int(count),
+	}, nil
+}
+
+// FindSummary retrieves a summary of the user by ID, nil if the user has no such summary
+func (r *Repository) FindSummary(ctx context.Context, userID, summaryID string) (_ *database.PublicSummariesSelect, err error) {
+	_, span := tracing.Start(ctx, "summary.Repository.FindSummary")
+	defer func() { tracing.End(span, err) }()
+
+	// Get authenticated client for RLS
+	client, err := r.db.NewAuthenticatedClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var summaries []database.PublicSummariesSelect
+	_, err = client.From("summaries").
+		Select("*", "", false).
+		Eq("id", summaryID).
+		Eq("user_id", userID).
+		Limit(1, "").
+		ExecuteTo(&summaries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch summary: %w", err)
+	}
+
+	if len(summaries) == 0 {
+		return nil, nil
+	}
+
+	return &summaries[0], nil
+}
+
 // HasFeeds checks if a user has at least one feed
-func (r *Repository) HasFeeds(ctx context.Context, userID string) (bool, error) {
+func (r *Repository) HasFeeds(ctx context.Context, userID string) (_ bool, err error) {
+	_, span := tracing.Start(ctx, "summary.Repository.HasFeeds")
+	defer func() { tracing.End(span, err) }()
+
 	// Get authenticated client for RLS
 	client, err := r.db.NewAuthenticatedClient(ctx)
 	if err != nil {
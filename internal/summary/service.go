@@ -8,19 +8,29 @@ import (
 	"github.com/tjanas94/vibefeeder/internal/shared/ai"
 	"github.com/tjanas94/vibefeeder/internal/shared/database"
 	"github.com/tjanas94/vibefeeder/internal/shared/events"
+	sharedmodels "github.com/tjanas94/vibefeeder/internal/shared/models"
+	"github.com/tjanas94/vibefeeder/internal/shared/tags"
 	"github.com/tjanas94/vibefeeder/internal/summary/models"
 )
 
 const (
 	// maxArticlesForSummary limits the number of articles fetched for summary generation
 	maxArticlesForSummary = 100
+
+	// pageSize defines how many summaries are shown per page of the archive and per day of a comparison
+	pageSize = 10
 )
 
 // SummaryRepository defines the interface for summary data access
 type SummaryRepository interface {
-	FetchRecentArticles(ctx context.Context, userID string, limit int) ([]models.ArticleForPrompt, error)
-	SaveSummary(ctx context.Context, userID, content string) (*database.PublicSummariesSelect, error)
+	FetchRecentArticles(ctx context.Context, userID, tagID string, unreadOnly bool, limit int) ([]models.ArticleForPrompt, error)
+	MarkArticlesRead(ctx context.Context, userID string, articleIDs []string) error
+	SaveSummary(ctx context.Context, userID, content string, tagName *string) (*database.PublicSummariesSelect, error)
+	AddSummarySources(ctx context.Context, summaryID string, articleIDs []string) error
+	ListSummarySources(ctx context.Context, summaryID string) ([]models.SummarySource, error)
 	GetLatestSummary(ctx context.Context, userID string) (*database.PublicSummariesSelect, error)
+	ListSummaries(ctx context.Context, query models.ListSummariesQuery) (*ListSummariesResult, error)
+	FindSummary(ctx context.Context, userID, summaryID string) (*database.PublicSummariesSelect, error)
 	HasFeeds(ctx context.Context, userID string) (bool, error)
 }
 
@@ -32,15 +42,17 @@ type AIClient interface {
 // Service handles business logic for summary generation
 type Service struct {
 	repo       SummaryRepository
+	tagRepo    tags.TagRepository
 	aiClient   AIClient
 	logger     *slog.Logger
 	eventsRepo events.EventRepository
 }
 
 // NewService creates a new summary service
-func NewService(repo SummaryRepository, aiClient AIClient, logger *slog.Logger, eventsRepo events.EventRepository) *Service {
+func NewService(repo SummaryRepository, tagRepo tags.TagRepository, aiClient AIClient, logger *slog.Logger, eventsRepo events.EventRepository) *Service {
 	return &Service{
 		repo:       repo,
+		tagRepo:    tagRepo,
 		aiClient:   aiClient,
 		logger:     logger,
 		eventsRepo: eventsRepo,
@@ -48,21 +60,41 @@ func NewService(repo SummaryRepository, aiClient AIClient, logger *slog.Logger,
 }
 
 // GenerateSummary generates a new AI summary from user's articles from the last 24 hours
-func (s *Service) GenerateSummary(ctx context.Context, userID string) (*models.SummaryDisplayViewModel, error) {
-	// Step 1: Fetch articles from last 24 hours
-	articles, err := s.repo.FetchRecentArticles(ctx, userID, maxArticlesForSummary)
+// With a tag in the command only articles of the feeds with that tag are summarized
+func (s *Service) GenerateSummary(ctx context.Context, cmd models.GenerateSummaryCommand) (*models.SummaryDisplayViewModel, error) {
+	userID := cmd.UserID
+
+	// Step 1: Resolve the tag; the tags are also the options of the next generation
+	dbTags, err := s.tagRepo.ListTags(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to list tags", "user_id", userID, "error", err)
+		return nil, NewDatabaseError(err)
+	}
+	var tagName *string
+	if cmd.TagID != "" {
+		tagName = findTagName(dbTags, cmd.TagID)
+		if tagName == nil {
+			return nil, NewTagNotFoundError()
+		}
+	}
+
+	// Step 2: Fetch articles from last 24 hours
+	articles, err := s.repo.FetchRecentArticles(ctx, userID, cmd.TagID, cmd.UnreadOnly, maxArticlesForSummary)
 	if err != nil {
 		s.logger.Error("failed to fetch articles", "user_id", userID, "error", err)
 		return nil, NewDatabaseError(err)
 	}
 	if len(articles) == 0 {
+		if cmd.UnreadOnly {
+			return nil, NewNoUnreadArticlesFoundError()
+		}
 		return nil, NewNoArticlesFoundError()
 	}
 
-	// Step 2: Prepare prompt from articles
+	// Step 3: Prepare prompt from articles
 	prompt := buildPromptFromArticles(articles)
 
-	// Step 3: Call AI service with timeout
+	// Step 4: Call AI service with timeout
 	aiCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
 	defer cancel()
 
@@ -88,24 +120,51 @@ func (s *Service) GenerateSummary(ctx context.Context, userID string) (*models.S
 		return nil, NewAIServiceUnavailableError()
 	}
 
-	// Step 4: Save summary to database
-	dbSummary, err := s.repo.SaveSummary(ctx, userID, summaryContent)
+	// Step 5: Save summary to database
+	dbSummary, err := s.repo.SaveSummary(ctx, userID, summaryContent, tagName)
 	if err != nil {
 		s.logger.Error("failed to save summary to database", "user_id", userID, "error", err)
 		return nil, NewDatabaseError(err)
 	}
 
+	// Step 6: Link the summary with its sources; the summary is saved, so a failure is only logged
+	sourceIDs := articleIDs(articles)
+	if err := s.repo.AddSummarySources(ctx, dbSummary.Id, sourceIDs); err != nil {
+		s.logger.Warn("failed to add summary sources", "user_id", userID, "summary_id", dbSummary.Id, "error", err)
+	}
+
+	// Step 7: Mark the summarized articles as read; the summary is saved, so a failure is only logged
+	if cmd.UnreadOnly {
+		if err := s.repo.MarkArticlesRead(ctx, userID, sourceIDs); err != nil {
+			s.logger.Warn("failed to mark summarized articles as read", "user_id", userID, "error", err)
+		}
+	}
+
 	// Log summary_generated event
+	var metadata map[string]any
+	if tagName != nil || cmd.UnreadOnly {
+		metadata = map[string]any{}
+		if tagName != nil {
+			metadata["tag"] = *tagName
+		}
+		if cmd.UnreadOnly {
+			metadata["unread_only"] = true
+		}
+	}
 	if err := s.eventsRepo.RecordEvent(ctx, database.PublicEventsInsert{
 		EventType: events.EventSummaryGenerated,
 		UserId:    &userID,
-		Metadata:  nil,
+		Metadata:  metadata,
 	}); err != nil {
 		s.logger.Warn("Failed to log event", "event_type", events.EventSummaryGenerated, "error", err, "user_id", userID)
 	}
 
 	// Convert database type to view model
 	vm := buildSummaryDisplayViewModel(dbSummary, true)
+	vm.Summary.Sources = s.listSources(ctx, userID, dbSummary.Id)
+	vm.Tags = models.NewTagOptionsFromDB(dbTags)
+	vm.SelectedTag = cmd.TagID
+	vm.UnreadOnly = cmd.UnreadOnly
 	return &vm, nil
 }
 
@@ -125,11 +184,98 @@ func (s *Service) GetLatestSummaryForUser(ctx context.Context, userID string) (*
 		return nil, NewDatabaseError(err)
 	}
 
-	// Step 3: Build the view model
+	// Step 3: Fetch tags the next summary can be limited to
+	dbTags, err := s.tagRepo.ListTags(ctx, userID)
+	if err != nil {
+		s.logger.Error("failed to list tags", "user_id", userID, "error", err)
+		return nil, NewDatabaseError(err)
+	}
+
+	// Step 4: Build the view model
 	vm := buildSummaryDisplayViewModel(summary, canGenerate)
+	if vm.Summary != nil {
+		vm.Summary.Sources = s.listSources(ctx, userID, vm.Summary.ID)
+	}
+	vm.Tags = models.NewTagOptionsFromDB(dbTags)
+	return &vm, nil
+}
+
+// ListSummaries retrieves a page of the summary archive, optionally limited to the summaries of one day
+func (s *Service) ListSummaries(ctx context.Context, query models.ListSummariesQuery) (*models.SummaryArchiveListViewModel, error) {
+	result, err := s.repo.ListSummaries(ctx, query)
+	if err != nil {
+		s.logger.Error("failed to list summaries", "user_id", query.UserID, "error", err)
+		return nil, NewDatabaseError(err)
+	}
+
+	return &models.SummaryArchiveListViewModel{
+		Summaries:      models.NewSummariesFromDB(result.Summaries),
+		Date:           query.Date,
+		ShowEmptyState: result.TotalCount == 0,
+		Pagination:     sharedmodels.BuildPagination(result.TotalCount, query.Page, pageSize),
+	}, nil
+}
+
+// GetSummary retrieves a single summary of the user with its sources
+func (s *Service) GetSummary(ctx context.Context, userID, summaryID string) (*models.SummaryViewModel, error) {
+	dbSummary, err := s.repo.FindSummary(ctx, userID, summaryID)
+	if err != nil {
+		s.logger.Error("failed to find summary", "user_id", userID, "summary_id", summaryID, "error", err)
+		return nil, NewDatabaseError(err)
+	}
+	if dbSummary == nil {
+		return nil, NewSummaryNotFoundError()
+	}
+
+	vm := models.NewSummaryFromDB(*dbSummary)
+	vm.Sources = s.listSources(ctx, userID, vm.ID)
 	return &vm, nil
 }
 
+// CompareDays retrieves the summaries of two days side by side
+// Each day shows its first page of summaries, the rest is left to the archive of that day
+func (s *Service) CompareDays(ctx context.Context, query models.CompareSummariesQuery) (*models.SummaryCompareViewModel, error) {
+	left, err := s.summariesOfDay(ctx, query.UserID, query.Left)
+	if err != nil {
+		return nil, err
+	}
+	right, err := s.summariesOfDay(ctx, query.UserID, query.Right)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SummaryCompareViewModel{
+		Left:  *left,
+		Right: *right,
+	}, nil
+}
+
+// summariesOfDay retrieves the first page of the summaries generated on a day
+func (s *Service) summariesOfDay(ctx context.Context, userID, date string) (*models.SummaryDayViewModel, error) {
+	result, err := s.repo.ListSummaries(ctx, models.ListSummariesQuery{UserID: userID, Date: date, Page: 1})
+	if err != nil {
+		s.logger.Error("failed to list summaries of day", "user_id", userID, "date", date, "error", err)
+		return nil, NewDatabaseError(err)
+	}
+
+	return &models.SummaryDayViewModel{
+		Date:      date,
+		Summaries: models.NewSummariesFromDB(result.Summaries),
+		MoreCount: max(result.TotalCount-len(result.Summaries), 0),
+	}, nil
+}
+
+// listSources retrieves the sources of a summary
+// The summary is still useful without them, so a failure is only logged
+func (s *Service) listSources(ctx context.Context, userID, summaryID string) []models.SourceViewModel {
+	dbSources, err := s.repo.ListSummarySources(ctx, summaryID)
+	if err != nil {
+		s.logger.Warn("failed to list summary sources", "user_id", userID, "summary_id", summaryID, "error", err)
+		return nil
+	}
+	return models.NewSourcesFromDB(dbSources)
+}
+
 // buildSummaryDisplayViewModel is a pure function that transforms database result to view model
 func buildSummaryDisplayViewModel(summary *database.PublicSummariesSelect, canGenerate bool) models.SummaryDisplayViewModel {
 	vm := models.SummaryDisplayViewModel{
@@ -143,3 +289,24 @@ func buildSummaryDisplayViewModel(summary *database.PublicSummariesSelect, canGe
 
 	return vm
 }
+
+// articleIDs returns the IDs of the summarized articles
+// The prompt order is kept, the IDs are stored as the sources of the summary
+func articleIDs(articles []models.ArticleForPrompt) []string {
+	ids := make([]string, len(articles))
+	for i, article := range articles {
+		ids[i] = article.ID
+	}
+	return ids
+}
+
+// findTagName returns the name of the tag with the given ID, nil if the user has no such tag
+// Searches the tags already loaded for the tag options, so no extra query is needed
+func findTagName(dbTags []database.PublicTagsSelect, tagID string) *string {
+	for _, tag := range dbTags {
+		if tag.Id == tagID {
+			return &tag.Name
+		}
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package summary
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"io"
 	"log/slog"
@@ -23,22 +24,40 @@ type MockSummaryRepository struct {
 	mock.Mock
 }
 
-func (m *MockSummaryRepository) FetchRecentArticles(ctx context.Context, userID string, limit int) ([]models.ArticleForPrompt, error) {
-	args := m.Called(ctx, userID, limit)
+func (m *MockSummaryRepository) FetchRecentArticles(ctx context.Context, userID, tagID string, unreadOnly bool, limit int) ([]models.ArticleForPrompt, error) {
+	args := m.Called(ctx, userID, tagID, unreadOnly, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]models.ArticleForPrompt), args.Error(1)
 }
 
-func (m *MockSummaryRepository) SaveSummary(ctx context.Context, userID, content string) (*database.PublicSummariesSelect, error) {
-	args := m.Called(ctx, userID, content)
+func (m *MockSummaryRepository) SaveSummary(ctx context.Context, userID, content string, tagName *string) (*database.PublicSummariesSelect, error) {
+	args := m.Called(ctx, userID, content, tagName)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*database.PublicSummariesSelect), args.Error(1)
 }
 
+func (m *MockSummaryRepository) MarkArticlesRead(ctx context.Context, userID string, articleIDs []string) error {
+	args := m.Called(ctx, userID, articleIDs)
+	return args.Error(0)
+}
+
+func (m *MockSummaryRepository) AddSummarySources(ctx context.Context, summaryID string, articleIDs []string) error {
+	args := m.Called(ctx, summaryID, articleIDs)
+	return args.Error(0)
+}
+
+func (m *MockSummaryRepository) ListSummarySources(ctx context.Context, summaryID string) ([]models.SummarySource, error) {
+	args := m.Called(ctx, summaryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.SummarySource), args.Error(1)
+}
+
 func (m *MockSummaryRepository) GetLatestSummary(ctx context.Context, userID string) (*database.PublicSummariesSelect, error) {
 	args := m.Called(ctx, userID)
 	if args.Get(0) == nil {
@@ -47,11 +66,35 @@ func (m *MockSummaryRepository) GetLatestSummary(ctx context.Context, userID str
 	return args.Get(0).(*database.PublicSummariesSelect), args.Error(1)
 }
 
+func (m *MockSummaryRepository) ListSummaries(ctx context.Context, query models.ListSummariesQuery) (*ListSummariesResult, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ListSummariesResult), args.Error(1)
+}
+
+func (m *MockSummaryRepository) FindSummary(ctx context.Context, userID, summaryID string) (*database.PublicSummariesSelect, error) {
+	args := m.Called(ctx, userID, summaryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*database.PublicSummariesSelect), args.Error(1)
+}
+
 func (m *MockSummaryRepository) HasFeeds(ctx context.Context, userID string) (bool, error) {
 	args := m.Called(ctx, userID)
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockSummaryRepository) ListTags(ctx context.Context, userID string) ([]database.PublicTagsSelect, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]database.PublicTagsSelect), args.Error(1)
+}
+
 // MockAIClient is a mock implementation of AIClient
 type MockAIClient struct {
 	mock.Mock
@@ -121,7 +164,7 @@ func TestGenerateSummary_Success(t *testing.T) {
 	mockAI := new(MockAIClient)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockAI, logger, mockEventRepo)
+	service := NewService(mockRepo, mockRepo, mockAI, logger, mockEventRepo)
 
 	ctx := context.Background()
 	userID := "user-123"
@@ -134,7 +177,8 @@ func TestGenerateSummary_Success(t *testing.T) {
 	summaryContent := "This is a summary of recent articles."
 	dbSummary := newTestSummary("summary-123", userID, summaryContent)
 
-	mockRepo.On("FetchRecentArticles", ctx, userID, maxArticlesForSummary).
+	mockRepo.On("ListTags", ctx, userID).Return([]database.PublicTagsSelect{}, nil)
+	mockRepo.On("FetchRecentArticles", ctx, userID, "", false, maxArticlesForSummary).
 		Return(articles, nil)
 
 	// Note: context is a timeout context created inside GenerateSummary, not the original context
@@ -142,14 +186,16 @@ func TestGenerateSummary_Success(t *testing.T) {
 		return opts.Model == "openai/gpt-4o-mini" && opts.Temperature == 0.7 && opts.MaxTokens == 2000
 	})).Return(newTestAIResponse(summaryContent), nil)
 
-	mockRepo.On("SaveSummary", ctx, userID, summaryContent).
+	mockRepo.On("SaveSummary", ctx, userID, summaryContent, (*string)(nil)).
 		Return(dbSummary, nil)
+	mockRepo.On("AddSummarySources", ctx, "summary-123", mock.Anything).Return(nil)
+	mockRepo.On("ListSummarySources", ctx, "summary-123").Return([]models.SummarySource{}, nil)
 
 	mockEventRepo.On("RecordEvent", ctx, mock.MatchedBy(func(event database.PublicEventsInsert) bool {
 		return event.EventType == events.EventSummaryGenerated && event.UserId != nil && *event.UserId == userID
 	})).Return(nil)
 
-	result, err := service.GenerateSummary(ctx, userID)
+	result, err := service.GenerateSummary(ctx, models.GenerateSummaryCommand{UserID: userID})
 
 	require.NoError(t, err)
 	assert.NotNil(t, result)
@@ -160,20 +206,139 @@ func TestGenerateSummary_Success(t *testing.T) {
 	mockEventRepo.AssertExpectations(t)
 }
 
+func TestGenerateSummary_ScopedToTag(t *testing.T) {
+	mockRepo := new(MockSummaryRepository)
+	mockAI := new(MockAIClient)
+	mockEventRepo := new(MockEventRepository)
+	service := NewService(mockRepo, mockRepo, mockAI, newTestLogger(), mockEventRepo)
+
+	ctx := context.Background()
+	userID := "user-123"
+	tagName := "Go"
+	summaryContent := "Go news of the day."
+	dbSummary := newTestSummary("summary-123", userID, summaryContent)
+	dbSummary.TagName = &tagName
+
+	mockRepo.On("ListTags", ctx, userID).Return([]database.PublicTagsSelect{{Id: "tag-1", Name: "Go"}, {Id: "tag-2", Name: "News"}}, nil)
+	mockRepo.On("FetchRecentArticles", ctx, userID, "tag-1", false, maxArticlesForSummary).
+		Return([]models.ArticleForPrompt{newTestArticle("Go 1.26", "Released")}, nil)
+	mockAI.On("GenerateChatCompletion", mock.Anything, mock.AnythingOfType("ai.GenerateChatCompletionOptions")).
+		Return(newTestAIResponse(summaryContent), nil)
+	mockRepo.On("SaveSummary", ctx, userID, summaryContent, &tagName).Return(dbSummary, nil)
+	mockRepo.On("AddSummarySources", ctx, "summary-123", mock.Anything).Return(nil)
+	mockRepo.On("ListSummarySources", ctx, "summary-123").Return([]models.SummarySource{}, nil)
+	mockEventRepo.On("RecordEvent", ctx, mock.MatchedBy(func(event database.PublicEventsInsert) bool {
+		metadata, ok := event.Metadata.(map[string]any)
+		return ok && metadata["tag"] == "Go"
+	})).Return(nil)
+
+	result, err := service.GenerateSummary(ctx, models.GenerateSummaryCommand{UserID: userID, TagID: "tag-1"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Go", result.Summary.TagName)
+	assert.Equal(t, "tag-1", result.SelectedTag)
+	assert.Len(t, result.Tags, 2)
+	mockRepo.AssertExpectations(t)
+	mockEventRepo.AssertExpectations(t)
+}
+
+func TestGenerateSummary_UnreadOnly(t *testing.T) {
+	mockRepo := new(MockSummaryRepository)
+	mockAI := new(MockAIClient)
+	mockEventRepo := new(MockEventRepository)
+	service := NewService(mockRepo, mockRepo, mockAI, newTestLogger(), mockEventRepo)
+
+	ctx := context.Background()
+	userID := "user-123"
+	summaryContent := "Unread news of the day."
+
+	first := newTestArticle("First", "Content")
+	first.ID = "article-1"
+	second := newTestArticle("Second", "Content")
+	second.ID = "article-2"
+
+	mockRepo.On("ListTags", ctx, userID).Return([]database.PublicTagsSelect{}, nil)
+	mockRepo.On("FetchRecentArticles", ctx, userID, "", true, maxArticlesForSummary).
+		Return([]models.ArticleForPrompt{first, second}, nil)
+	mockAI.On("GenerateChatCompletion", mock.Anything, mock.AnythingOfType("ai.GenerateChatCompletionOptions")).
+		Return(newTestAIResponse(summaryContent), nil)
+	mockRepo.On("SaveSummary", ctx, userID, summaryContent, (*string)(nil)).
+		Return(newTestSummary("summary-123", userID, summaryContent), nil)
+	mockRepo.On("AddSummarySources", ctx, "summary-123", []string{"article-1", "article-2"}).Return(errors.New("insert error"))
+	mockRepo.On("ListSummarySources", ctx, "summary-123").Return([]models.SummarySource{}, nil)
+	mockRepo.On("MarkArticlesRead", ctx, userID, []string{"article-1", "article-2"}).Return(errors.New("rpc error"))
+	mockEventRepo.On("RecordEvent", ctx, mock.MatchedBy(func(event database.PublicEventsInsert) bool {
+		metadata, ok := event.Metadata.(map[string]any)
+		return ok && metadata["unread_only"] == true
+	})).Return(nil)
+
+	result, err := service.GenerateSummary(ctx, models.GenerateSummaryCommand{UserID: userID, UnreadOnly: true})
+
+	// Failing to link the sources or mark the articles as read doesn't fail the saved summary
+	require.NoError(t, err)
+	assert.Equal(t, summaryContent, result.Summary.Content)
+	assert.True(t, result.UnreadOnly)
+	mockRepo.AssertExpectations(t)
+	mockEventRepo.AssertExpectations(t)
+}
+
+func TestGenerateSummary_NoUnreadArticles(t *testing.T) {
+	mockRepo := new(MockSummaryRepository)
+	mockAI := new(MockAIClient)
+	service := NewService(mockRepo, mockRepo, mockAI, newTestLogger(), new(MockEventRepository))
+
+	ctx := context.Background()
+	userID := "user-123"
+
+	mockRepo.On("ListTags", ctx, userID).Return([]database.PublicTagsSelect{}, nil)
+	mockRepo.On("FetchRecentArticles", ctx, userID, "", true, maxArticlesForSummary).
+		Return([]models.ArticleForPrompt{}, nil)
+
+	result, err := service.GenerateSummary(ctx, models.GenerateSummaryCommand{UserID: userID, UnreadOnly: true})
+
+	assert.Nil(t, result)
+	serviceErr, ok := sharederrors.AsServiceError(err)
+	require.True(t, ok, "error should be a ServiceError")
+	assert.Equal(t, 404, serviceErr.Code)
+	assert.Equal(t, "No unread articles found in the last 24 hours", serviceErr.Message)
+	mockAI.AssertNotCalled(t, "GenerateChatCompletion")
+	mockRepo.AssertNotCalled(t, "MarkArticlesRead")
+}
+
+func TestGenerateSummary_UnknownTag(t *testing.T) {
+	mockRepo := new(MockSummaryRepository)
+	mockAI := new(MockAIClient)
+	service := NewService(mockRepo, mockRepo, mockAI, newTestLogger(), new(MockEventRepository))
+
+	ctx := context.Background()
+	mockRepo.On("ListTags", ctx, "user-123").Return([]database.PublicTagsSelect{{Id: "tag-1", Name: "Go"}}, nil)
+
+	result, err := service.GenerateSummary(ctx, models.GenerateSummaryCommand{UserID: "user-123", TagID: "tag-removed"})
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, ErrTagNotFound)
+	serviceErr, ok := sharederrors.AsServiceError(err)
+	assert.True(t, ok, "error should be a ServiceError")
+	assert.Equal(t, 404, serviceErr.Code)
+	mockRepo.AssertNotCalled(t, "FetchRecentArticles")
+	mockAI.AssertNotCalled(t, "GenerateChatCompletion")
+}
+
 func TestGenerateSummary_NoArticles(t *testing.T) {
 	mockRepo := new(MockSummaryRepository)
 	mockAI := new(MockAIClient)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockAI, logger, mockEventRepo)
+	service := NewService(mockRepo, mockRepo, mockAI, logger, mockEventRepo)
 
 	ctx := context.Background()
 	userID := "user-123"
 
-	mockRepo.On("FetchRecentArticles", ctx, userID, maxArticlesForSummary).
+	mockRepo.On("ListTags", ctx, userID).Return([]database.PublicTagsSelect{}, nil)
+	mockRepo.On("FetchRecentArticles", ctx, userID, "", false, maxArticlesForSummary).
 		Return([]models.ArticleForPrompt{}, nil)
 
-	result, err := service.GenerateSummary(ctx, userID)
+	result, err := service.GenerateSummary(ctx, models.GenerateSummaryCommand{UserID: userID})
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -190,15 +355,16 @@ func TestGenerateSummary_FetchArticlesError(t *testing.T) {
 	mockAI := new(MockAIClient)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockAI, logger, mockEventRepo)
+	service := NewService(mockRepo, mockRepo, mockAI, logger, mockEventRepo)
 
 	ctx := context.Background()
 	userID := "user-123"
 
-	mockRepo.On("FetchRecentArticles", ctx, userID, maxArticlesForSummary).
+	mockRepo.On("ListTags", ctx, userID).Return([]database.PublicTagsSelect{}, nil)
+	mockRepo.On("FetchRecentArticles", ctx, userID, "", false, maxArticlesForSummary).
 		Return(nil, errors.New("database error"))
 
-	result, err := service.GenerateSummary(ctx, userID)
+	result, err := service.GenerateSummary(ctx, models.GenerateSummaryCommand{UserID: userID})
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -214,7 +380,7 @@ func TestGenerateSummary_AIServiceError(t *testing.T) {
 	mockAI := new(MockAIClient)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockAI, logger, mockEventRepo)
+	service := NewService(mockRepo, mockRepo, mockAI, logger, mockEventRepo)
 
 	ctx := context.Background()
 	userID := "user-123"
@@ -223,13 +389,14 @@ func TestGenerateSummary_AIServiceError(t *testing.T) {
 		newTestArticle("Article 1", "Content 1"),
 	}
 
-	mockRepo.On("FetchRecentArticles", ctx, userID, maxArticlesForSummary).
+	mockRepo.On("ListTags", ctx, userID).Return([]database.PublicTagsSelect{}, nil)
+	mockRepo.On("FetchRecentArticles", ctx, userID, "", false, maxArticlesForSummary).
 		Return(articles, nil)
 
 	mockAI.On("GenerateChatCompletion", mock.Anything, mock.AnythingOfType("ai.GenerateChatCompletionOptions")).
 		Return(nil, errors.New("AI service error"))
 
-	result, err := service.GenerateSummary(ctx, userID)
+	result, err := service.GenerateSummary(ctx, models.GenerateSummaryCommand{UserID: userID})
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -245,7 +412,7 @@ func TestGenerateSummary_AIResponseEmpty(t *testing.T) {
 	mockAI := new(MockAIClient)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockAI, logger, mockEventRepo)
+	service := NewService(mockRepo, mockRepo, mockAI, logger, mockEventRepo)
 
 	ctx := context.Background()
 	userID := "user-123"
@@ -261,13 +428,14 @@ func TestGenerateSummary_AIResponseEmpty(t *testing.T) {
 		Model:   "gpt-4o-mini",
 	}
 
-	mockRepo.On("FetchRecentArticles", ctx, userID, maxArticlesForSummary).
+	mockRepo.On("ListTags", ctx, userID).Return([]database.PublicTagsSelect{}, nil)
+	mockRepo.On("FetchRecentArticles", ctx, userID, "", false, maxArticlesForSummary).
 		Return(articles, nil)
 
 	mockAI.On("GenerateChatCompletion", mock.Anything, mock.AnythingOfType("ai.GenerateChatCompletionOptions")).
 		Return(emptyResponse, nil)
 
-	result, err := service.GenerateSummary(ctx, userID)
+	result, err := service.GenerateSummary(ctx, models.GenerateSummaryCommand{UserID: userID})
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -282,7 +450,7 @@ func TestGenerateSummary_SaveSummaryError(t *testing.T) {
 	mockAI := new(MockAIClient)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockAI, logger, mockEventRepo)
+	service := NewService(mockRepo, mockRepo, mockAI, logger, mockEventRepo)
 
 	ctx := context.Background()
 	userID := "user-123"
@@ -293,16 +461,17 @@ func TestGenerateSummary_SaveSummaryError(t *testing.T) {
 
 	summaryContent := "This is a summary"
 
-	mockRepo.On("FetchRecentArticles", ctx, userID, maxArticlesForSummary).
+	mockRepo.On("ListTags", ctx, userID).Return([]database.PublicTagsSelect{}, nil)
+	mockRepo.On("FetchRecentArticles", ctx, userID, "", false, maxArticlesForSummary).
 		Return(articles, nil)
 
 	mockAI.On("GenerateChatCompletion", mock.Anything, mock.AnythingOfType("ai.GenerateChatCompletionOptions")).
 		Return(newTestAIResponse(summaryContent), nil)
 
-	mockRepo.On("SaveSummary", ctx, userID, summaryContent).
+	mockRepo.On("SaveSummary", ctx, userID, summaryContent, (*string)(nil)).
 		Return(nil, errors.New("save failed"))
 
-	result, err := service.GenerateSummary(ctx, userID)
+	result, err := service.GenerateSummary(ctx, models.GenerateSummaryCommand{UserID: userID})
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -317,7 +486,7 @@ func TestGenerateSummary_EventLogError(t *testing.T) {
 	mockAI := new(MockAIClient)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockAI, logger, mockEventRepo)
+	service := NewService(mockRepo, mockRepo, mockAI, logger, mockEventRepo)
 
 	ctx := context.Background()
 	userID := "user-123"
@@ -329,20 +498,23 @@ func TestGenerateSummary_EventLogError(t *testing.T) {
 	summaryContent := "This is a summary"
 	dbSummary := newTestSummary("summary-123", userID, summaryContent)
 
-	mockRepo.On("FetchRecentArticles", ctx, userID, maxArticlesForSummary).
+	mockRepo.On("ListTags", ctx, userID).Return([]database.PublicTagsSelect{}, nil)
+	mockRepo.On("FetchRecentArticles", ctx, userID, "", false, maxArticlesForSummary).
 		Return(articles, nil)
 
 	mockAI.On("GenerateChatCompletion", mock.Anything, mock.AnythingOfType("ai.GenerateChatCompletionOptions")).
 		Return(newTestAIResponse(summaryContent), nil)
 
-	mockRepo.On("SaveSummary", ctx, userID, summaryContent).
+	mockRepo.On("SaveSummary", ctx, userID, summaryContent, (*string)(nil)).
 		Return(dbSummary, nil)
+	mockRepo.On("AddSummarySources", ctx, "summary-123", mock.Anything).Return(nil)
+	mockRepo.On("ListSummarySources", ctx, "summary-123").Return([]models.SummarySource{}, nil)
 
 	mockEventRepo.On("RecordEvent", ctx, mock.AnythingOfType("database.PublicEventsInsert")).
 		Return(errors.New("event log failed"))
 
 	// Should not fail if event logging fails, only warn
-	result, err := service.GenerateSummary(ctx, userID)
+	result, err := service.GenerateSummary(ctx, models.GenerateSummaryCommand{UserID: userID})
 
 	require.NoError(t, err)
 	assert.NotNil(t, result)
@@ -354,7 +526,7 @@ func TestGenerateSummary_ManyArticles(t *testing.T) {
 	mockAI := new(MockAIClient)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockAI, logger, mockEventRepo)
+	service := NewService(mockRepo, mockRepo, mockAI, logger, mockEventRepo)
 
 	ctx := context.Background()
 	userID := "user-123"
@@ -369,19 +541,22 @@ func TestGenerateSummary_ManyArticles(t *testing.T) {
 	summaryContent := "Summary of many articles"
 	dbSummary := newTestSummary("summary-123", userID, summaryContent)
 
-	mockRepo.On("FetchRecentArticles", ctx, userID, maxArticlesForSummary).
+	mockRepo.On("ListTags", ctx, userID).Return([]database.PublicTagsSelect{}, nil)
+	mockRepo.On("FetchRecentArticles", ctx, userID, "", false, maxArticlesForSummary).
 		Return(articles, nil)
 
 	mockAI.On("GenerateChatCompletion", mock.Anything, mock.AnythingOfType("ai.GenerateChatCompletionOptions")).
 		Return(newTestAIResponse(summaryContent), nil)
 
-	mockRepo.On("SaveSummary", ctx, userID, summaryContent).
+	mockRepo.On("SaveSummary", ctx, userID, summaryContent, (*string)(nil)).
 		Return(dbSummary, nil)
+	mockRepo.On("AddSummarySources", ctx, "summary-123", mock.Anything).Return(nil)
+	mockRepo.On("ListSummarySources", ctx, "summary-123").Return([]models.SummarySource{}, nil)
 
 	mockEventRepo.On("RecordEvent", ctx, mock.AnythingOfType("database.PublicEventsInsert")).
 		Return(nil)
 
-	result, err := service.GenerateSummary(ctx, userID)
+	result, err := service.GenerateSummary(ctx, models.GenerateSummaryCommand{UserID: userID})
 
 	require.NoError(t, err)
 	assert.NotNil(t, result)
@@ -394,7 +569,7 @@ func TestGetLatestSummaryForUser_WithSummaryAndFeeds(t *testing.T) {
 	mockAI := new(MockAIClient)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockAI, logger, mockEventRepo)
+	service := NewService(mockRepo, mockRepo, mockAI, logger, mockEventRepo)
 
 	ctx := context.Background()
 	userID := "user-123"
@@ -403,6 +578,8 @@ func TestGetLatestSummaryForUser_WithSummaryAndFeeds(t *testing.T) {
 
 	mockRepo.On("GetLatestSummary", ctx, userID).Return(dbSummary, nil)
 	mockRepo.On("HasFeeds", ctx, userID).Return(true, nil)
+	mockRepo.On("ListTags", ctx, userID).Return([]database.PublicTagsSelect{}, nil)
+	mockRepo.On("ListSummarySources", ctx, "summary-123").Return([]models.SummarySource{}, nil)
 
 	result, err := service.GetLatestSummaryForUser(ctx, userID)
 
@@ -414,18 +591,73 @@ func TestGetLatestSummaryForUser_WithSummaryAndFeeds(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestGetLatestSummaryForUser_WithSources(t *testing.T) {
+	mockRepo := new(MockSummaryRepository)
+	service := NewService(mockRepo, mockRepo, new(MockAIClient), newTestLogger(), new(MockEventRepository))
+
+	ctx := context.Background()
+	userID := "user-123"
+
+	// Decoded from the embedded select, as returned by PostgREST
+	var dbSources []models.SummarySource
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"article_id": "article-1", "articles": {"id": "article-1", "title": "Older", "url": "https://example.com/1",
+			"published_at": "2025-01-01T08:00:00Z", "feeds": {"name": "Blog"}, "saved_articles": []}},
+		{"article_id": "article-2", "articles": {"id": "article-2", "title": "Newer", "url": "https://example.com/2",
+			"published_at": "2025-01-01T10:00:00Z", "feeds": {"name": "News"}, "saved_articles": [{"id": "saved-1"}]}}
+	]`), &dbSources))
+
+	mockRepo.On("GetLatestSummary", ctx, userID).Return(newTestSummary("summary-123", userID, "Summary"), nil)
+	mockRepo.On("HasFeeds", ctx, userID).Return(true, nil)
+	mockRepo.On("ListTags", ctx, userID).Return([]database.PublicTagsSelect{}, nil)
+	mockRepo.On("ListSummarySources", ctx, "summary-123").Return(dbSources, nil)
+
+	result, err := service.GetLatestSummaryForUser(ctx, userID)
+
+	require.NoError(t, err)
+	require.Len(t, result.Summary.Sources, 2)
+	// Newest article first, with its starred state
+	assert.Equal(t, "article-2", result.Summary.Sources[0].ArticleID)
+	assert.Equal(t, "News", result.Summary.Sources[0].FeedName)
+	assert.True(t, result.Summary.Sources[0].Starred)
+	assert.Equal(t, "article-1", result.Summary.Sources[1].ArticleID)
+	assert.False(t, result.Summary.Sources[1].Starred)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetLatestSummaryForUser_SourcesErrorStillShowsSummary(t *testing.T) {
+	mockRepo := new(MockSummaryRepository)
+	service := NewService(mockRepo, mockRepo, new(MockAIClient), newTestLogger(), new(MockEventRepository))
+
+	ctx := context.Background()
+	userID := "user-123"
+
+	mockRepo.On("GetLatestSummary", ctx, userID).Return(newTestSummary("summary-123", userID, "Summary"), nil)
+	mockRepo.On("HasFeeds", ctx, userID).Return(true, nil)
+	mockRepo.On("ListTags", ctx, userID).Return([]database.PublicTagsSelect{}, nil)
+	mockRepo.On("ListSummarySources", ctx, "summary-123").Return(nil, errors.New("db error"))
+
+	result, err := service.GetLatestSummaryForUser(ctx, userID)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Summary", result.Summary.Content)
+	assert.Empty(t, result.Summary.Sources)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestGetLatestSummaryForUser_NoSummaryWithFeeds(t *testing.T) {
 	mockRepo := new(MockSummaryRepository)
 	mockAI := new(MockAIClient)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockAI, logger, mockEventRepo)
+	service := NewService(mockRepo, mockRepo, mockAI, logger, mockEventRepo)
 
 	ctx := context.Background()
 	userID := "user-123"
 
 	mockRepo.On("GetLatestSummary", ctx, userID).Return(nil, nil)
 	mockRepo.On("HasFeeds", ctx, userID).Return(true, nil)
+	mockRepo.On("ListTags", ctx, userID).Return([]database.PublicTagsSelect{}, nil)
 
 	result, err := service.GetLatestSummaryForUser(ctx, userID)
 
@@ -441,7 +673,7 @@ func TestGetLatestSummaryForUser_WithSummaryNoFeeds(t *testing.T) {
 	mockAI := new(MockAIClient)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockAI, logger, mockEventRepo)
+	service := NewService(mockRepo, mockRepo, mockAI, logger, mockEventRepo)
 
 	ctx := context.Background()
 	userID := "user-123"
@@ -450,6 +682,8 @@ func TestGetLatestSummaryForUser_WithSummaryNoFeeds(t *testing.T) {
 
 	mockRepo.On("GetLatestSummary", ctx, userID).Return(dbSummary, nil)
 	mockRepo.On("HasFeeds", ctx, userID).Return(false, nil)
+	mockRepo.On("ListTags", ctx, userID).Return([]database.PublicTagsSelect{}, nil)
+	mockRepo.On("ListSummarySources", ctx, "summary-123").Return([]models.SummarySource{}, nil)
 
 	result, err := service.GetLatestSummaryForUser(ctx, userID)
 
@@ -465,13 +699,14 @@ func TestGetLatestSummaryForUser_NoSummaryNoFeeds(t *testing.T) {
 	mockAI := new(MockAIClient)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockAI, logger, mockEventRepo)
+	service := NewService(mockRepo, mockRepo, mockAI, logger, mockEventRepo)
 
 	ctx := context.Background()
 	userID := "user-123"
 
 	mockRepo.On("GetLatestSummary", ctx, userID).Return(nil, nil)
 	mockRepo.On("HasFeeds", ctx, userID).Return(false, nil)
+	mockRepo.On("ListTags", ctx, userID).Return([]database.PublicTagsSelect{}, nil)
 
 	result, err := service.GetLatestSummaryForUser(ctx, userID)
 
@@ -487,7 +722,7 @@ func TestGetLatestSummaryForUser_GetSummaryError(t *testing.T) {
 	mockAI := new(MockAIClient)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockAI, logger, mockEventRepo)
+	service := NewService(mockRepo, mockRepo, mockAI, logger, mockEventRepo)
 
 	ctx := context.Background()
 	userID := "user-123"
@@ -507,7 +742,7 @@ func TestGetLatestSummaryForUser_HasFeedsError(t *testing.T) {
 	mockAI := new(MockAIClient)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockAI, logger, mockEventRepo)
+	service := NewService(mockRepo, mockRepo, mockAI, logger, mockEventRepo)
 
 	ctx := context.Background()
 	userID := "user-123"
@@ -527,6 +762,159 @@ func TestGetLatestSummaryForUser_HasFeedsError(t *testing.T) {
 	assert.Equal(t, 500, serviceErr.Code)
 }
 
+// Tests for the summary archive
+func TestListSummaries_Success(t *testing.T) {
+	mockRepo := new(MockSummaryRepository)
+	service := NewService(mockRepo, mockRepo, new(MockAIClient), newTestLogger(), new(MockEventRepository))
+
+	ctx := context.Background()
+	query := models.ListSummariesQuery{UserID: "user-123", Date: "2025-11-11", Page: 2}
+
+	mockRepo.On("ListSummaries", ctx, query).Return(&ListSummariesResult{
+		Summaries: []database.PublicSummariesSelect{
+			*newTestSummary("summary-2", "user-123", "Newer"),
+			*newTestSummary("summary-1", "user-123", "Older"),
+		},
+		TotalCount: 12,
+	}, nil)
+
+	result, err := service.ListSummaries(ctx, query)
+
+	require.NoError(t, err)
+	require.Len(t, result.Summaries, 2)
+	assert.Equal(t, "summary-2", result.Summaries[0].ID)
+	assert.Equal(t, "2025-11-11", result.Date)
+	assert.False(t, result.ShowEmptyState)
+	assert.Equal(t, 2, result.Pagination.CurrentPage)
+	assert.Equal(t, 2, result.Pagination.TotalPages)
+	mockRepo.AssertExpectations(t)
+	// Sources are only loaded for a single summary
+	mockRepo.AssertNotCalled(t, "ListSummarySources")
+}
+
+func TestListSummaries_Empty(t *testing.T) {
+	mockRepo := new(MockSummaryRepository)
+	service := NewService(mockRepo, mockRepo, new(MockAIClient), newTestLogger(), new(MockEventRepository))
+
+	ctx := context.Background()
+	query := models.ListSummariesQuery{UserID: "user-123", Page: 1}
+
+	mockRepo.On("ListSummaries", ctx, query).Return(&ListSummariesResult{}, nil)
+
+	result, err := service.ListSummaries(ctx, query)
+
+	require.NoError(t, err)
+	assert.Empty(t, result.Summaries)
+	assert.True(t, result.ShowEmptyState)
+}
+
+func TestListSummaries_DatabaseError(t *testing.T) {
+	mockRepo := new(MockSummaryRepository)
+	service := NewService(mockRepo, mockRepo, new(MockAIClient), newTestLogger(), new(MockEventRepository))
+
+	ctx := context.Background()
+	query := models.ListSummariesQuery{UserID: "user-123", Page: 1}
+
+	mockRepo.On("ListSummaries", ctx, query).Return(nil, errors.New("db error"))
+
+	result, err := service.ListSummaries(ctx, query)
+
+	assert.Nil(t, result)
+	serviceErr, ok := sharederrors.AsServiceError(err)
+	require.True(t, ok, "error should be a ServiceError")
+	assert.Equal(t, 500, serviceErr.Code)
+}
+
+func TestGetSummary_WithSources(t *testing.T) {
+	mockRepo := new(MockSummaryRepository)
+	service := NewService(mockRepo, mockRepo, new(MockAIClient), newTestLogger(), new(MockEventRepository))
+
+	ctx := context.Background()
+	userID := "user-123"
+
+	var dbSources []models.SummarySource
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"article_id": "article-1", "articles": {"id": "article-1", "title": "Article", "url": "https://example.com/1",
+			"published_at": "2025-01-01T08:00:00Z", "feeds": {"name": "Blog"}, "saved_articles": []}}
+	]`), &dbSources))
+
+	mockRepo.On("FindSummary", ctx, userID, "summary-123").Return(newTestSummary("summary-123", userID, "Tuesday's digest"), nil)
+	mockRepo.On("ListSummarySources", ctx, "summary-123").Return(dbSources, nil)
+
+	result, err := service.GetSummary(ctx, userID, "summary-123")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Tuesday's digest", result.Content)
+	require.Len(t, result.Sources, 1)
+	assert.Equal(t, "article-1", result.Sources[0].ArticleID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetSummary_NotFound(t *testing.T) {
+	mockRepo := new(MockSummaryRepository)
+	service := NewService(mockRepo, mockRepo, new(MockAIClient), newTestLogger(), new(MockEventRepository))
+
+	ctx := context.Background()
+
+	mockRepo.On("FindSummary", ctx, "user-123", "summary-404").Return(nil, nil)
+
+	result, err := service.GetSummary(ctx, "user-123", "summary-404")
+
+	assert.Nil(t, result)
+	serviceErr, ok := sharederrors.AsServiceError(err)
+	require.True(t, ok, "error should be a ServiceError")
+	assert.Equal(t, 404, serviceErr.Code)
+	mockRepo.AssertNotCalled(t, "ListSummarySources")
+}
+
+func TestCompareDays_Success(t *testing.T) {
+	mockRepo := new(MockSummaryRepository)
+	service := NewService(mockRepo, mockRepo, new(MockAIClient), newTestLogger(), new(MockEventRepository))
+
+	ctx := context.Background()
+	userID := "user-123"
+
+	// The first page of every day is shown, the rest is counted
+	mockRepo.On("ListSummaries", ctx, models.ListSummariesQuery{UserID: userID, Date: "2025-11-10", Page: 1}).
+		Return(&ListSummariesResult{
+			Summaries:  []database.PublicSummariesSelect{*newTestSummary("summary-1", userID, "Monday")},
+			TotalCount: 1,
+		}, nil)
+	mockRepo.On("ListSummaries", ctx, models.ListSummariesQuery{UserID: userID, Date: "2025-11-11", Page: 1}).
+		Return(&ListSummariesResult{
+			Summaries:  []database.PublicSummariesSelect{*newTestSummary("summary-2", userID, "Tuesday")},
+			TotalCount: 13,
+		}, nil)
+
+	result, err := service.CompareDays(ctx, models.CompareSummariesQuery{UserID: userID, Left: "2025-11-10", Right: "2025-11-11"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "2025-11-10", result.Left.Date)
+	require.Len(t, result.Left.Summaries, 1)
+	assert.Equal(t, "Monday", result.Left.Summaries[0].Content)
+	assert.Zero(t, result.Left.MoreCount)
+	assert.Equal(t, "2025-11-11", result.Right.Date)
+	assert.Equal(t, 12, result.Right.MoreCount)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCompareDays_DatabaseError(t *testing.T) {
+	mockRepo := new(MockSummaryRepository)
+	service := NewService(mockRepo, mockRepo, new(MockAIClient), newTestLogger(), new(MockEventRepository))
+
+	ctx := context.Background()
+
+	mockRepo.On("ListSummaries", ctx, mock.Anything).Return(nil, errors.New("db error"))
+
+	result, err := service.CompareDays(ctx, models.CompareSummariesQuery{UserID: "user-123", Left: "2025-11-10", Right: "2025-11-11"})
+
+	assert.Nil(t, result)
+	serviceErr, ok := sharederrors.AsServiceError(err)
+	require.True(t, ok, "error should be a ServiceError")
+	assert.Equal(t, 500, serviceErr.Code)
+	mockRepo.AssertNumberOfCalls(t, "ListSummaries", 1)
+}
+
 // Tests for buildSummaryDisplayViewModel (pure function)
 func TestBuildSummaryDisplayViewModel_WithSummary(t *testing.T) {
 	dbSummary := newTestSummary("summary-123", "user-123", "Summary content")
@@ -567,7 +955,7 @@ func TestGenerateSummary_ContextTimeout(t *testing.T) {
 	mockAI := new(MockAIClient)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockAI, logger, mockEventRepo)
+	service := NewService(mockRepo, mockRepo, mockAI, logger, mockEventRepo)
 
 	ctx := context.Background()
 	userID := "user-123"
@@ -576,14 +964,15 @@ func TestGenerateSummary_ContextTimeout(t *testing.T) {
 		newTestArticle("Article 1", "Content 1"),
 	}
 
-	mockRepo.On("FetchRecentArticles", ctx, userID, maxArticlesForSummary).
+	mockRepo.On("ListTags", ctx, userID).Return([]database.PublicTagsSelect{}, nil)
+	mockRepo.On("FetchRecentArticles", ctx, userID, "", false, maxArticlesForSummary).
 		Return(articles, nil)
 
 	// Simulate AI service timing out
 	mockAI.On("GenerateChatCompletion", mock.Anything, mock.AnythingOfType("ai.GenerateChatCompletionOptions")).
 		Return(nil, errors.New("context deadline exceeded"))
 
-	result, err := service.GenerateSummary(ctx, userID)
+	result, err := service.GenerateSummary(ctx, models.GenerateSummaryCommand{UserID: userID})
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -599,7 +988,7 @@ func TestGenerateSummary_ArticlesWithNilContent(t *testing.T) {
 	mockAI := new(MockAIClient)
 	mockEventRepo := new(MockEventRepository)
 	logger := newTestLogger()
-	service := NewService(mockRepo, mockAI, logger, mockEventRepo)
+	service := NewService(mockRepo, mockRepo, mockAI, logger, mockEventRepo)
 
 	ctx := context.Background()
 	userID := "user-123"
@@ -612,19 +1001,22 @@ func TestGenerateSummary_ArticlesWithNilContent(t *testing.T) {
 	summaryContent := "Summary even with nil content"
 	dbSummary := newTestSummary("summary-123", userID, summaryContent)
 
-	mockRepo.On("FetchRecentArticles", ctx, userID, maxArticlesForSummary).
+	mockRepo.On("ListTags", ctx, userID).Return([]database.PublicTagsSelect{}, nil)
+	mockRepo.On("FetchRecentArticles", ctx, userID, "", false, maxArticlesForSummary).
 		Return(articles, nil)
 
 	mockAI.On("GenerateChatCompletion", mock.Anything, mock.AnythingOfType("ai.GenerateChatCompletionOptions")).
 		Return(newTestAIResponse(summaryContent), nil)
 
-	mockRepo.On("SaveSummary", ctx, userID, summaryContent).
+	mockRepo.On("SaveSummary", ctx, userID, summaryContent, (*string)(nil)).
 		Return(dbSummary, nil)
+	mockRepo.On("AddSummarySources", ctx, "summary-123", mock.Anything).Return(nil)
+	mockRepo.On("ListSummarySources", ctx, "summary-123").Return([]models.SummarySource{}, nil)
 
 	mockEventRepo.On("RecordEvent", ctx, mock.AnythingOfType("database.PublicEventsInsert")).
 		Return(nil)
 
-	result, err := service.GenerateSummary(ctx, userID)
+	result, err := service.GenerateSummary(ctx, models.GenerateSummaryCommand{UserID: userID})
 
 	require.NoError(t, err)
 	assert.NotNil(t, result)
@@ -0,0 +1,72 @@
+package view
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/tjanas94/vibefeeder/internal/summary/models"
+)
+
+// excerptLength limits the summary text shown on the cards of the archive (in characters)
+const excerptLength = 280
+
+// sourceTitle returns the title of a source article, falling back to its URL for untitled entries
+func sourceTitle(source models.SourceViewModel) string {
+	if strings.TrimSpace(source.Title) == "" {
+		return source.URL
+	}
+	return source.Title
+}
+
+// summaryExcerpt returns the beginning of a summary, cut at a word boundary
+func summaryExcerpt(content string) string {
+	content = strings.Join(strings.Fields(content), " ")
+	runes := []rune(content)
+	if len(runes) <= excerptLength {
+		return content
+	}
+	excerpt := string(runes[:excerptLength])
+	if i := strings.LastIndex(excerpt, " "); i > 0 {
+		excerpt = excerpt[:i]
+	}
+	return excerpt + "…"
+}
+
+// summaryURL returns the permalink of a summary
+func summaryURL(summaryID string) string {
+	return fmt.Sprintf("/summaries/%s", summaryID)
+}
+
+// summaryDay returns the day a summary was generated on, in the format of the date parameters
+func summaryDay(summary models.SummaryViewModel) string {
+	return summary.CreatedAt.UTC().Format(models.DateFormat)
+}
+
+// archiveDayURL returns the URL of the archive limited to the summaries of a day
+func archiveDayURL(date string) string {
+	return "/summaries?" + url.Values{"date": {date}}.Encode()
+}
+
+// compareURL returns the URL comparing a day with the day before
+func compareURL(date string) string {
+	return "/summaries/compare?" + url.Values{"right": {date}}.Encode()
+}
+
+// dayLabel formats a date parameter for headings, e.g. "Tuesday, Nov 11, 2025"
+func dayLabel(date string) string {
+	day, err := time.Parse(models.DateFormat, date)
+	if err != nil {
+		return date
+	}
+	return day.Format("Monday, Jan 2, 2006")
+}
+
+// moreSummariesLabel describes the summaries of a day left out of a comparison
+func moreSummariesLabel(count int) string {
+	if count == 1 {
+		return "1 more summary of this day"
+	}
+	return fmt.Sprintf("%d more summaries of this day", count)
+}
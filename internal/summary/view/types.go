@@ -13,6 +13,15 @@ type GenerateSummaryActionProps struct {
 	// ButtonSize is an optional CSS class for button sizing (e.g., "btn-sm", "btn-lg")
 	// Leave empty for default size
 	ButtonSize string
+
+	// Tags are the tags the summary can be limited to; no tag select is shown without tags
+	Tags []models.TagOptionViewModel
+
+	// SelectedTag is the ID of the preselected tag, empty for all feeds
+	SelectedTag string
+
+	// UnreadOnly preselects limiting the summary to unread articles
+	UnreadOnly bool
 }
 
 // ContentProps contains props for the Content component.
@@ -23,6 +32,11 @@ type ContentProps struct {
 	// CanGenerate indicates whether the user can generate a new summary
 	// (true if user has at least one working feed)
 	CanGenerate bool
+
+	// Tags, SelectedTag and UnreadOnly are passed to the generate action
+	Tags        []models.TagOptionViewModel
+	SelectedTag string
+	UnreadOnly  bool
 }
 
 // EmptyStateProps contains props for the EmptyState component.
@@ -30,4 +44,9 @@ type EmptyStateProps struct {
 	// CanGenerate indicates whether the user can generate a summary
 	// (true if user has at least one working feed)
 	CanGenerate bool
+
+	// Tags, SelectedTag and UnreadOnly are passed to the generate action
+	Tags        []models.TagOptionViewModel
+	SelectedTag string
+	UnreadOnly  bool
 }
@@ -0,0 +1,46 @@
+-- migration: create_websub_subscriptions_table
+-- description: creates the websub_subscriptions table to track websub (pubsubhubbub) push subscriptions for feeds
+-- tables affected: websub_subscriptions
+-- special notes: rls enabled without policies; only the service role (feed fetcher) can access subscriptions and their secrets
+
+-- create the websub_subscriptions table
+-- one subscription per feed; removed automatically when the feed is deleted
+create table websub_subscriptions (
+    feed_id uuid primary key references feeds(id) on delete cascade,
+    hub_url text not null,
+    topic_url text not null,
+    secret text not null,
+    status text not null default 'pending',
+    lease_expires_at timestamptz null,
+    last_error text null,
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now(),
+    constraint websub_subscriptions_status_check check (status in ('pending', 'active', 'denied'))
+);
+
+-- create index on lease_expires_at to find subscriptions that need renewal
+create index idx_websub_subscriptions_lease_expires_at on websub_subscriptions(lease_expires_at);
+
+-- keep updated_at current; used to throttle re-subscription attempts
+create trigger set_updated_at
+    before update on websub_subscriptions
+    for each row
+    execute function update_updated_at_column();
+
+-- enable row level security
+-- no policies are defined: subscriptions contain hmac secrets and are managed server-side only
+alter table websub_subscriptions enable row level security;
+
+-- add comment to table
+comment on table websub_subscriptions is 'websub push subscriptions for feeds that advertise a hub';
+
+-- add comments to columns
+comment on column websub_subscriptions.feed_id is 'reference to the subscribed feed';
+comment on column websub_subscriptions.hub_url is 'url of the hub advertised by the feed (link rel="hub")';
+comment on column websub_subscriptions.topic_url is 'topic url the subscription was requested for (link rel="self" or feed url)';
+comment on column websub_subscriptions.secret is 'hmac secret used by the hub to sign content distribution requests';
+comment on column websub_subscriptions.status is 'subscription state: pending (awaiting intent verification), active, denied';
+comment on column websub_subscriptions.lease_expires_at is 'when the hub lease expires; polling resumes after this time unless renewed';
+comment on column websub_subscriptions.last_error is 'reason reported by the hub or error from the last subscription request';
+comment on column websub_subscriptions.created_at is 'when the subscription was first requested';
+comment on column websub_subscriptions.updated_at is 'when the subscription was last modified';
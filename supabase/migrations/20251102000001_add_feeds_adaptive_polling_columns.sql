@@ -0,0 +1,25 @@
+-- migration: add_feeds_adaptive_polling_columns
+-- description: adds learned polling statistics to feeds table for adaptive fetch scheduling
+-- tables affected: feeds
+-- special notes: values are maintained by the background fetcher; null means not learned yet
+
+-- add poll_interval_seconds column to store the learned polling interval
+-- fetch_after is computed from this interval within configured min/max bounds
+alter table feeds
+add column poll_interval_seconds integer null;
+
+comment on column feeds.poll_interval_seconds is 'learned polling interval in seconds (adaptive scheduling)';
+
+-- add publish_cadence_seconds column to store the observed time between published items
+-- smoothed across fetches; feeds that stopped publishing drift towards long cadences
+alter table feeds
+add column publish_cadence_seconds integer null;
+
+comment on column feeds.publish_cadence_seconds is 'observed interval between published items in seconds';
+
+-- add unchanged_fetch_ratio column to track the share of fetches without new items
+-- exponentially weighted: 304 responses and fetches without new items push it towards 1
+alter table feeds
+add column unchanged_fetch_ratio double precision not null default 0;
+
+comment on column feeds.unchanged_fetch_ratio is 'weighted share of recent fetches with 304 or no new items (0-1)';
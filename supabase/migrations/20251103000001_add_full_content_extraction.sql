@@ -0,0 +1,45 @@
+-- migration: add_full_content_extraction
+-- description: adds optional full-text article extraction for feeds that only publish excerpts
+-- tables affected: feeds, articles
+-- special notes: extracted text is stored alongside the original excerpt in articles.content
+
+-- add fetch_full_content column to enable full article download per feed
+-- opt-in because it issues one extra request per new article
+alter table feeds
+add column fetch_full_content boolean not null default false;
+
+comment on column feeds.fetch_full_content is 'whether the fetcher downloads each new article and extracts its main content';
+
+-- add full_content column to store the extracted article text
+alter table articles
+add column full_content text null;
+
+comment on column articles.full_content is 'main text extracted from the article page (null if not fetched or not extractable)';
+
+-- add full_content_fetched_at column to record extraction attempts
+-- set even when no content could be extracted so pages are not downloaded again
+alter table articles
+add column full_content_fetched_at timestamptz null;
+
+comment on column articles.full_content_fetched_at is 'when full content extraction was last attempted for the article';
+
+-- create a trigger function that keeps extracted content when an article is upserted again
+-- the fetcher re-sends every feed item on each fetch without full content for already processed articles
+create or replace function preserve_article_full_content()
+returns trigger as $$
+begin
+    new.full_content = coalesce(new.full_content, old.full_content);
+    new.full_content_fetched_at = coalesce(new.full_content_fetched_at, old.full_content_fetched_at);
+    return new;
+end;
+$$ language plpgsql;
+
+comment on function preserve_article_full_content() is 'keeps previously extracted full content when an article row is updated without it';
+
+-- fires before each update of an article (including upsert conflicts)
+create trigger preserve_full_content
+    before update on articles
+    for each row
+    execute function preserve_article_full_content();
+
+comment on trigger preserve_full_content on articles is 'prevents feed re-fetches from clearing extracted full content';
@@ -0,0 +1,24 @@
+-- migration: add_articles_content_html
+-- description: stores sanitized html next to the plain text content of articles
+-- tables affected: articles
+-- special notes: articles.content now holds plain text; existing rows are normalized
+--                the next time their feed is fetched (feed items are upserted on every fetch)
+
+-- add content_html column for the allow-list sanitized article html used by article views
+alter table articles
+add column content_html text null;
+
+comment on column articles.content_html is 'sanitized article html (scripts, styles, embeds and tracking pixels removed)';
+
+comment on column articles.content is 'plain text article content or description, used for summaries';
+
+-- has_content_html: computed field of articles, lets the timeline offer reading an article inside the page
+-- without loading the html of every article of the page
+create or replace function has_content_html(public.articles)
+returns boolean
+language sql
+stable
+set search_path = ''
+as $$
+    select $1.content_html is not null and $1.content_html <> '';
+$$;
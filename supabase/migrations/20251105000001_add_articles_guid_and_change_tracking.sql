@@ -0,0 +1,68 @@
+-- migration: add_articles_guid_and_change_tracking
+-- description: identifies articles by feed item guid and tracks publisher corrections
+-- tables affected: articles
+-- special notes: existing articles get their url as guid; deduplication moves from (feed_id, url)
+--                to (feed_id, guid) so articles whose link changes are updated instead of duplicated
+
+-- add guid column holding the feed item guid/id (or the url for items without one)
+alter table articles
+add column guid text null;
+
+update articles set guid = url;
+
+alter table articles
+alter column guid set not null;
+
+comment on column articles.guid is 'feed item guid or atom id, falls back to the article url';
+
+-- add content_hash column to detect changed content without comparing full text
+alter table articles
+add column content_hash text null;
+
+comment on column articles.content_hash is 'sha-256 of the sanitized article content as published in the feed';
+
+-- add updated_at column to record when the publisher changed the article
+alter table articles
+add column updated_at timestamptz not null default now();
+
+update articles set updated_at = created_at;
+
+comment on column articles.updated_at is 'when the article title or content last changed';
+
+-- switch the deduplication key from url to guid
+alter table articles
+drop constraint unique_feed_article;
+
+alter table articles
+add constraint unique_feed_article_guid unique(feed_id, guid);
+
+-- keep url lookups per feed fast (full content extraction checks articles by url)
+create index idx_articles_feed_url on articles(feed_id, url);
+
+comment on index idx_articles_feed_url is 'optimizes article lookups by url within a feed';
+
+-- create a trigger function that bumps updated_at only when the article really changed
+-- feed items are upserted on every fetch, so the generic updated_at trigger would fire every time
+-- rows without a content hash yet (created before this migration) only record the hash
+create or replace function track_article_changes()
+returns trigger as $$
+begin
+    if new.title is distinct from old.title
+        or (old.content_hash is not null and new.content_hash is distinct from old.content_hash) then
+        new.updated_at = now();
+    else
+        new.updated_at = old.updated_at;
+    end if;
+    return new;
+end;
+$$ language plpgsql;
+
+comment on function track_article_changes() is 'sets articles.updated_at when the title or content hash changes';
+
+-- fires before each update of an article (including upsert conflicts)
+create trigger track_changes
+    before update on articles
+    for each row
+    execute function track_article_changes();
+
+comment on trigger track_changes on articles is 'tracks publisher corrections of articles';
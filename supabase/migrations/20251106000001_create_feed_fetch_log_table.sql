@@ -0,0 +1,66 @@
+-- migration: create_feed_fetch_log_table
+-- description: creates the feed_fetch_log table recording every fetch attempt of a feed
+-- tables affected: feed_fetch_log
+-- special notes: rows are written by the feed fetcher (service role) and pruned by it after
+--                FETCHER_FETCH_LOG_RETENTION; users can read the history of their own feeds
+
+-- create the feed_fetch_log table
+create table feed_fetch_log (
+    id uuid primary key default gen_random_uuid(),
+    feed_id uuid not null references feeds(id) on delete cascade,
+    status text not null,
+    http_status int null,
+    error_message text null,
+    duration_ms int not null,
+    bytes_read bigint not null default 0,
+    new_articles int not null default 0,
+    redirect_chain text[] null,
+    not_modified boolean not null default false,
+    created_at timestamptz not null default now()
+);
+
+-- composite index for the per-feed history view (latest attempts first)
+create index idx_feed_fetch_log_feed_created on feed_fetch_log(feed_id, created_at desc);
+
+-- index on created_at for pruning old entries
+create index idx_feed_fetch_log_created_at on feed_fetch_log(created_at);
+
+-- enable row level security
+alter table feed_fetch_log enable row level security;
+
+-- rls policy: allow authenticated users to view the fetch history of their feeds
+create policy "authenticated users can view fetch log of their feeds"
+on feed_fetch_log for select
+to authenticated
+using (
+    exists (
+        select 1 from feeds
+        where feeds.id = feed_fetch_log.feed_id
+        and feeds.user_id = auth.uid()
+    )
+);
+
+-- rls policy: deny anonymous users from viewing the fetch log
+create policy "anonymous users cannot view fetch log"
+on feed_fetch_log for select
+to anon
+using (false);
+
+-- note: no insert, update, or delete policies for regular users
+-- entries are managed exclusively by the feed fetcher using service role
+
+-- add comment to table
+comment on table feed_fetch_log is 'history of feed fetch attempts';
+
+-- add comments to columns
+comment on column feed_fetch_log.id is 'unique identifier for the log entry';
+comment on column feed_fetch_log.feed_id is 'reference to the fetched feed';
+comment on column feed_fetch_log.status is 'fetch decision status: success, temporary_error, permanent_error, unauthorized, too_large';
+comment on column feed_fetch_log.http_status is 'http status code of the final response (null if no response was received)';
+comment on column feed_fetch_log.error_message is 'error message of a failed attempt';
+comment on column feed_fetch_log.duration_ms is 'time spent fetching the feed in milliseconds';
+comment on column feed_fetch_log.bytes_read is 'size of the response body read from the final response';
+comment on column feed_fetch_log.new_articles is 'number of articles stored for the first time';
+comment on column feed_fetch_log.redirect_chain is 'urls requested while following redirects, ending with the final url (null without redirects)';
+comment on column feed_fetch_log.not_modified is 'whether the server answered 304 not modified';
+comment on column feed_fetch_log.created_at is 'when the fetch attempt finished';
@@ -0,0 +1,11 @@
+-- migration: add_feeds_credentials
+-- description: adds optional authorization settings for private feeds
+-- tables affected: feeds
+-- special notes: the value is encrypted by the application (aes-256-gcm) before it is stored,
+--                the database never sees plaintext passwords, tokens or header values
+
+-- add credentials_encrypted column holding basic auth, bearer token and custom request headers
+alter table feeds
+add column credentials_encrypted text null;
+
+comment on column feeds.credentials_encrypted is 'encrypted authorization settings (basic auth, bearer token, custom headers) sent with feed requests, null if the feed is public';
@@ -0,0 +1,87 @@
+-- migration: add_feeds_fetch_leases
+-- description: adds lease columns to feeds and functions that let several fetcher instances
+--              claim feeds without fetching the same feed twice
+-- tables affected: feeds
+-- special notes: a lease is owned by one scheduler instance until it is released or expires;
+--                expired leases are reclaimed by any instance. functions are meant for the
+--                feed fetcher only, so execution is restricted to the service role
+
+-- add lease columns to feeds
+alter table feeds
+    add column lease_owner text null,
+    add column lease_expires_at timestamptz null;
+
+-- claim_feeds_for_fetch: atomically leases a batch of feeds that are due for fetching
+-- rows locked by a concurrent claim are skipped instead of waited for, so two instances
+-- running at the same time get disjoint batches
+create or replace function claim_feeds_for_fetch(p_owner text, p_limit int, p_lease_seconds int)
+returns setof feeds
+language sql
+set search_path = ''
+as $$
+    with due as (
+        select id
+        from public.feeds
+        where (fetch_after is null or fetch_after <= now())
+          and (lease_expires_at is null or lease_expires_at <= now())
+        order by last_fetched_at asc nulls first
+        limit p_limit
+        for update skip locked
+    )
+    update public.feeds
+    set lease_owner = p_owner,
+        lease_expires_at = now() + make_interval(secs => p_lease_seconds)
+    from due
+    where feeds.id = due.id
+    returning feeds.*;
+$$;
+
+-- claim_feed_for_fetch: leases a single feed for an immediate fetch regardless of fetch_after
+-- returns no rows while any active lease is held on the feed, including one of the same owner,
+-- so a manual fetch or a websub push never runs alongside a fetch of the feed on the same instance
+create or replace function claim_feed_for_fetch(p_feed_id uuid, p_owner text, p_lease_seconds int)
+returns setof feeds
+language sql
+set search_path = ''
+as $$
+    update public.feeds
+    set lease_owner = p_owner,
+        lease_expires_at = now() + make_interval(secs => p_lease_seconds)
+    where id = p_feed_id
+      and (lease_owner is null or lease_expires_at <= now())
+    returning *;
+$$;
+
+-- extend_feed_lease: pushes back the expiry of a lease held by the owner
+-- returns false when the lease was lost (released or reclaimed by another instance)
+create or replace function extend_feed_lease(p_feed_id uuid, p_owner text, p_lease_seconds int)
+returns boolean
+language sql
+set search_path = ''
+as $$
+    with extended as (
+        update public.feeds
+        set lease_expires_at = now() + make_interval(secs => p_lease_seconds)
+        where id = p_feed_id
+          and lease_owner = p_owner
+        returning id
+    )
+    select exists (select 1 from extended);
+$$;
+
+-- restrict lease functions to the feed fetcher (service role)
+revoke execute on function claim_feeds_for_fetch(text, int, int) from public, anon, authenticated;
+revoke execute on function claim_feed_for_fetch(uuid, text, int) from public, anon, authenticated;
+revoke execute on function extend_feed_lease(uuid, text, int) from public, anon, authenticated;
+grant execute on function claim_feeds_for_fetch(text, int, int) to service_role;
+grant execute on function claim_feed_for_fetch(uuid, text, int) to service_role;
+grant execute on function extend_feed_lease(uuid, text, int) to service_role;
+
+-- add comments to columns
+comment on column feeds.lease_owner is 'id of the fetcher instance currently processing the feed (null when not leased)';
+comment on column feeds.lease_expires_at is 'when the current lease expires and the feed can be claimed by another instance';
+
+-- add comments to functions
+comment on function claim_feeds_for_fetch(text, int, int) is 'leases up to p_limit due feeds for p_owner using for update skip locked';
+comment on function claim_feed_for_fetch(uuid, text, int) is 'leases a single feed for an immediate fetch unless another owner holds an active lease';
+comment on function extend_feed_lease(uuid, text, int) is 'extends a lease held by p_owner; returns false if the lease was lost';
@@ -0,0 +1,19 @@
+-- migration: add_articles_media_metadata
+-- description: stores enclosures, authors, categories and the lead image of feed items
+-- tables affected: articles
+-- special notes: enclosures are kept as a jsonb array on the article row because they are
+--                always written and read together with the article; existing rows are filled
+--                the next time their feed is fetched (feed items are upserted on every fetch)
+
+-- add media and classification columns
+alter table articles
+    add column authors text[] null,
+    add column categories text[] null,
+    add column image_url text null,
+    add column enclosures jsonb null;
+
+-- add comments to columns
+comment on column articles.authors is 'author names of the feed item (null if none)';
+comment on column articles.categories is 'categories or tags of the feed item (null if none)';
+comment on column articles.image_url is 'lead image or thumbnail of the feed item (null if none)';
+comment on column articles.enclosures is 'attached media files (podcast audio, video) as an array of {url, type, length} objects (null if none)';
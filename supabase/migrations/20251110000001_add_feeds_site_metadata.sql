@@ -0,0 +1,70 @@
+-- migration: add_feeds_site_metadata
+-- description: stores channel metadata of fetched feeds, automatic feed names and cached site favicons
+-- tables affected: feeds, feed_favicons
+-- special notes: metadata and favicons are written by the feed fetcher (service role);
+--                feeds added without a name get the channel title once the feed is fetched
+
+-- add channel metadata columns (null until the first successful fetch)
+alter table feeds
+add column site_title text null,
+add column site_description text null,
+add column site_url text null,
+add column site_image_url text null,
+add column site_language text null;
+
+-- add automatic name flag; the fetcher keeps the name in sync with the channel title while it is set
+alter table feeds
+add column name_is_auto boolean not null default false;
+
+-- add favicon columns; the icon itself is stored in feed_favicons to keep feed rows small
+alter table feeds
+add column has_favicon boolean not null default false,
+add column favicon_checked_at timestamptz null;
+
+-- create the feed_favicons table holding one cached icon per feed
+create table feed_favicons (
+    feed_id uuid primary key references feeds(id) on delete cascade,
+    content_type text not null,
+    data text not null,
+    updated_at timestamptz not null default now()
+);
+
+-- enable row level security
+alter table feed_favicons enable row level security;
+
+-- rls policy: allow authenticated users to view favicons of their feeds
+create policy "authenticated users can view favicons of their feeds"
+on feed_favicons for select
+to authenticated
+using (
+    exists (
+        select 1 from feeds
+        where feeds.id = feed_favicons.feed_id
+        and feeds.user_id = auth.uid()
+    )
+);
+
+-- rls policy: deny anonymous users from viewing favicons
+create policy "anonymous users cannot view favicons"
+on feed_favicons for select
+to anon
+using (false);
+
+-- note: no insert, update, or delete policies for regular users
+-- favicons are managed exclusively by the feed fetcher using service role
+
+-- add comments
+comment on column feeds.site_title is 'channel title from the last successful fetch';
+comment on column feeds.site_description is 'channel description from the last successful fetch';
+comment on column feeds.site_url is 'website of the feed (channel link)';
+comment on column feeds.site_image_url is 'channel image or logo url';
+comment on column feeds.site_language is 'channel language code, e.g. en-us';
+comment on column feeds.name_is_auto is 'whether the feed name follows the channel title (no name entered by the user)';
+comment on column feeds.has_favicon is 'whether a favicon is cached in feed_favicons';
+comment on column feeds.favicon_checked_at is 'when the site favicon was last downloaded (null if never tried)';
+
+comment on table feed_favicons is 'site favicons cached by the feed fetcher, served by the application';
+comment on column feed_favicons.feed_id is 'reference to the feed';
+comment on column feed_favicons.content_type is 'mime type of the icon, e.g. image/x-icon or image/png';
+comment on column feed_favicons.data is 'base64 encoded icon';
+comment on column feed_favicons.updated_at is 'when the icon was downloaded';
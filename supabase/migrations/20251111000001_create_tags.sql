@@ -0,0 +1,188 @@
+-- migration: create_tags
+-- description: adds user-defined tags (folders) and the many-to-many link between feeds and tags
+-- tables affected: tags, feed_tags, summaries
+-- special notes: tags are created implicitly when assigned to a feed and deleted once no feed uses them;
+--                tag names are unique per user regardless of case
+
+-- create the tags table
+create table tags (
+    id uuid primary key default gen_random_uuid(),
+    user_id uuid not null references auth.users(id) on delete cascade,
+    name text not null check (char_length(name) between 1 and 50),
+    created_at timestamptz not null default now()
+);
+
+-- prevent duplicate tag names for the same user, "Tech" and "tech" are the same tag
+create unique index unique_user_tag_name on tags(user_id, lower(name));
+
+-- create the feed_tags table linking feeds to tags
+create table feed_tags (
+    feed_id uuid not null references feeds(id) on delete cascade,
+    tag_id uuid not null references tags(id) on delete cascade,
+    primary key (feed_id, tag_id)
+);
+
+-- create index on tag_id for filtering feeds by tag (feed_id is covered by the primary key)
+create index idx_feed_tags_tag_id on feed_tags(tag_id);
+
+-- enable row level security
+alter table tags enable row level security;
+alter table feed_tags enable row level security;
+
+-- rls policy: allow authenticated users to manage only their own tags
+create policy "authenticated users can view their own tags"
+on tags for select
+to authenticated
+using (auth.uid() = user_id);
+
+create policy "authenticated users can insert their own tags"
+on tags for insert
+to authenticated
+with check (auth.uid() = user_id);
+
+create policy "authenticated users can update their own tags"
+on tags for update
+to authenticated
+using (auth.uid() = user_id)
+with check (auth.uid() = user_id);
+
+create policy "authenticated users can delete their own tags"
+on tags for delete
+to authenticated
+using (auth.uid() = user_id);
+
+-- rls policy: deny anonymous users any access to tags
+create policy "anonymous users cannot view tags"
+on tags for select
+to anon
+using (false);
+
+-- rls policy: allow authenticated users to link their own feeds with their own tags
+create policy "authenticated users can view tags of their feeds"
+on feed_tags for select
+to authenticated
+using (
+    exists (
+        select 1 from feeds
+        where feeds.id = feed_tags.feed_id
+        and feeds.user_id = auth.uid()
+    )
+);
+
+create policy "authenticated users can tag their own feeds"
+on feed_tags for insert
+to authenticated
+with check (
+    exists (
+        select 1 from feeds
+        where feeds.id = feed_tags.feed_id
+        and feeds.user_id = auth.uid()
+    )
+    and exists (
+        select 1 from tags
+        where tags.id = feed_tags.tag_id
+        and tags.user_id = auth.uid()
+    )
+);
+
+create policy "authenticated users can untag their own feeds"
+on feed_tags for delete
+to authenticated
+using (
+    exists (
+        select 1 from feeds
+        where feeds.id = feed_tags.feed_id
+        and feeds.user_id = auth.uid()
+    )
+);
+
+-- rls policy: deny anonymous users any access to feed tags
+create policy "anonymous users cannot view feed tags"
+on feed_tags for select
+to anon
+using (false);
+
+-- set_feed_tags: assigns tags to a feed by name, creating missing tags of the calling user
+-- with p_replace the feed loses the tags missing from p_tag_names, otherwise they are kept
+-- runs with the privileges of the caller, so rls limits it to the caller's feeds and tags
+create or replace function set_feed_tags(p_feed_id uuid, p_tag_names text[], p_replace boolean)
+returns setof tags
+language plpgsql
+set search_path = ''
+as $$
+declare
+    v_user_id uuid := auth.uid();
+begin
+    insert into public.tags (user_id, name)
+    select distinct on (lower(btrim(n))) v_user_id, btrim(n)
+    from unnest(p_tag_names) as n
+    where btrim(n) <> ''
+    on conflict (user_id, lower(name)) do nothing;
+
+    if p_replace then
+        delete from public.feed_tags ft
+        where ft.feed_id = p_feed_id
+          and ft.tag_id not in (
+              select t.id from public.tags t
+              where t.user_id = v_user_id
+                and lower(t.name) in (select lower(btrim(n)) from unnest(p_tag_names) as n)
+          );
+    end if;
+
+    insert into public.feed_tags (feed_id, tag_id)
+    select p_feed_id, t.id
+    from public.tags t
+    where t.user_id = v_user_id
+      and lower(t.name) in (select lower(btrim(n)) from unnest(p_tag_names) as n)
+    on conflict do nothing;
+
+    return query
+        select t.*
+        from public.tags t
+        join public.feed_tags ft on ft.tag_id = t.id
+        where ft.feed_id = p_feed_id
+        order by lower(t.name);
+end;
+$$;
+
+revoke execute on function set_feed_tags(uuid, text[], boolean) from public, anon;
+grant execute on function set_feed_tags(uuid, text[], boolean) to authenticated;
+
+-- delete_unused_tag: removes a tag once its last feed is untagged or deleted
+create or replace function delete_unused_tag()
+returns trigger
+language plpgsql
+set search_path = ''
+as $$
+begin
+    delete from public.tags t
+    where t.id = old.tag_id
+      and not exists (select 1 from public.feed_tags ft where ft.tag_id = old.tag_id);
+    return null;
+end;
+$$;
+
+create trigger delete_unused_tag
+    after delete on feed_tags
+    for each row
+    execute function delete_unused_tag();
+
+-- remember the tag a summary was scoped to; the name is copied so the summary keeps it after the tag is gone
+alter table summaries
+add column tag_name text null;
+
+-- add comments
+comment on table tags is 'user-defined tags (folders) for organizing feeds';
+comment on column tags.id is 'unique identifier for the tag';
+comment on column tags.user_id is 'reference to the user who owns this tag';
+comment on column tags.name is 'tag name, unique per user regardless of case';
+comment on column tags.created_at is 'timestamp when the tag was created';
+
+comment on table feed_tags is 'assignment of feeds to tags, a feed can have several tags';
+comment on column feed_tags.feed_id is 'reference to the tagged feed';
+comment on column feed_tags.tag_id is 'reference to the tag';
+
+comment on function set_feed_tags(uuid, text[], boolean) is 'assigns tags to a feed by name, creating missing tags of the calling user';
+comment on trigger delete_unused_tag on feed_tags is 'deletes tags that are no longer assigned to any feed';
+
+comment on column summaries.tag_name is 'name of the tag the summary was limited to (null for all feeds)';
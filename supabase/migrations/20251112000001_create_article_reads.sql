@@ -0,0 +1,126 @@
+-- migration: create_article_reads
+-- description: adds per-user read state of articles
+-- tables affected: article_reads
+-- special notes: an article is unread while the user has no row for it;
+--                rows are removed together with the article (and so with its feed)
+
+-- create the article_reads table
+create table article_reads (
+    user_id uuid not null references auth.users(id) on delete cascade,
+    article_id uuid not null references articles(id) on delete cascade,
+    read_at timestamptz not null default now(),
+    primary key (user_id, article_id)
+);
+
+-- create index on article_id for the cascade from articles and the unread lookups per article
+create index idx_article_reads_article_id on article_reads(article_id);
+
+-- enable row level security
+alter table article_reads enable row level security;
+
+-- rls policy: allow authenticated users to manage only their own read state of articles they can see
+create policy "authenticated users can view their own read articles"
+on article_reads for select
+to authenticated
+using (auth.uid() = user_id);
+
+create policy "authenticated users can mark articles of their feeds as read"
+on article_reads for insert
+to authenticated
+with check (
+    auth.uid() = user_id
+    and exists (
+        select 1 from articles
+        join feeds on feeds.id = articles.feed_id
+        where articles.id = article_reads.article_id
+        and feeds.user_id = auth.uid()
+    )
+);
+
+create policy "authenticated users can update their own read articles"
+on article_reads for update
+to authenticated
+using (auth.uid() = user_id)
+with check (auth.uid() = user_id);
+
+create policy "authenticated users can mark their own articles as unread"
+on article_reads for delete
+to authenticated
+using (auth.uid() = user_id);
+
+-- rls policy: deny anonymous users any access to read state
+create policy "anonymous users cannot view read articles"
+on article_reads for select
+to anon
+using (false);
+
+-- count_unread_articles: counts the articles of the given feeds the calling user has not read
+-- runs with the privileges of the caller, so rls limits it to the caller's feeds
+create or replace function count_unread_articles(p_feed_ids uuid[])
+returns table (feed_id uuid, unread_count bigint)
+language sql
+stable
+set search_path = ''
+as $$
+    select a.feed_id, count(*)
+    from public.articles a
+    where a.feed_id = any(p_feed_ids)
+      and not exists (
+          select 1 from public.article_reads r
+          where r.article_id = a.id
+            and r.user_id = auth.uid()
+      )
+    group by a.feed_id;
+$$;
+
+revoke execute on function count_unread_articles(uuid[]) from public, anon;
+grant execute on function count_unread_articles(uuid[]) to authenticated;
+
+-- mark_articles_read: marks all articles of the calling user matching the filters as read
+-- every filter is optional: a feed, a tag (folder) and a published_at range (end exclusive)
+-- returns the number of articles newly marked as read
+create or replace function mark_articles_read(
+    p_feed_id uuid default null,
+    p_tag_id uuid default null,
+    p_from timestamptz default null,
+    p_to timestamptz default null
+)
+returns integer
+language plpgsql
+set search_path = ''
+as $$
+declare
+    v_user_id uuid := auth.uid();
+    v_count integer;
+begin
+    insert into public.article_reads (user_id, article_id)
+    select v_user_id, a.id
+    from public.articles a
+    join public.feeds f on f.id = a.feed_id
+    where f.user_id = v_user_id
+      and (p_feed_id is null or a.feed_id = p_feed_id)
+      and (p_tag_id is null or exists (
+          select 1 from public.feed_tags ft
+          where ft.feed_id = a.feed_id
+            and ft.tag_id = p_tag_id
+      ))
+      and (p_from is null or a.published_at >= p_from)
+      and (p_to is null or a.published_at < p_to)
+    on conflict do nothing;
+
+    get diagnostics v_count = row_count;
+    return v_count;
+end;
+$$;
+
+revoke execute on function mark_articles_read(uuid, uuid, timestamptz, timestamptz) from public, anon;
+grant execute on function mark_articles_read(uuid, uuid, timestamptz, timestamptz) to authenticated;
+
+-- add comments
+comment on table article_reads is 'articles read by a user, articles without a row are unread';
+comment on column article_reads.user_id is 'reference to the user who read the article';
+comment on column article_reads.article_id is 'reference to the read article';
+comment on column article_reads.read_at is 'timestamp when the article was marked as read';
+
+comment on function count_unread_articles(uuid[]) is 'counts unread articles of the given feeds for the calling user';
+comment on function mark_articles_read(uuid, uuid, timestamptz, timestamptz) is 'marks the articles of the calling user matching the filters as read';
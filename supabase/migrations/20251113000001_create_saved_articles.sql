@@ -0,0 +1,108 @@
+-- migration: create_saved_articles
+-- description: adds starred (saved for later) articles
+-- tables affected: saved_articles
+-- special notes: a saved article keeps a snapshot of the article, so it survives deleting the feed
+--                (article_id is set to null once the article is gone); retention must keep starred articles
+
+-- create the saved_articles table
+create table saved_articles (
+    id uuid primary key default gen_random_uuid(),
+    user_id uuid not null references auth.users(id) on delete cascade,
+    article_id uuid null references articles(id) on delete set null,
+    title text not null,
+    url text not null,
+    feed_name text not null,
+    content text null,
+    authors text[] null,
+    image_url text null,
+    published_at timestamptz not null,
+    saved_at timestamptz not null default now()
+);
+
+-- an article is starred once per user; snapshots of deleted articles (null article_id) never conflict
+create unique index unique_user_saved_article on saved_articles(user_id, article_id);
+
+-- composite index for the saved view, newest first
+create index idx_saved_articles_user_saved on saved_articles(user_id, saved_at desc);
+
+-- create index on article_id for the set null from articles and the starred lookups per article
+create index idx_saved_articles_article_id on saved_articles(article_id);
+
+-- enable row level security
+alter table saved_articles enable row level security;
+
+-- rls policy: allow authenticated users to manage only their own saved articles
+create policy "authenticated users can view their own saved articles"
+on saved_articles for select
+to authenticated
+using (auth.uid() = user_id);
+
+create policy "authenticated users can save articles of their feeds"
+on saved_articles for insert
+to authenticated
+with check (
+    auth.uid() = user_id
+    and exists (
+        select 1 from articles
+        join feeds on feeds.id = articles.feed_id
+        where articles.id = saved_articles.article_id
+        and feeds.user_id = auth.uid()
+    )
+);
+
+create policy "authenticated users can delete their own saved articles"
+on saved_articles for delete
+to authenticated
+using (auth.uid() = user_id);
+
+-- rls policy: deny anonymous users any access to saved articles
+create policy "anonymous users cannot view saved articles"
+on saved_articles for select
+to anon
+using (false);
+
+-- star_article: saves a snapshot of an article of the calling user, keeping an existing one
+-- runs with the privileges of the caller, so rls limits it to the caller's articles
+-- returns no row when the article doesn't exist or belongs to another user
+create or replace function star_article(p_article_id uuid)
+returns setof saved_articles
+language plpgsql
+set search_path = ''
+as $$
+declare
+    v_user_id uuid := auth.uid();
+begin
+    insert into public.saved_articles (user_id, article_id, title, url, feed_name, content, authors, image_url, published_at)
+    select v_user_id, a.id, a.title, a.url, f.name, coalesce(a.full_content, a.content), a.authors, a.image_url, a.published_at
+    from public.articles a
+    join public.feeds f on f.id = a.feed_id
+    where a.id = p_article_id
+      and f.user_id = v_user_id
+    on conflict (user_id, article_id) do nothing;
+
+    return query
+        select s.*
+        from public.saved_articles s
+        where s.user_id = v_user_id
+          and s.article_id = p_article_id;
+end;
+$$;
+
+revoke execute on function star_article(uuid) from public, anon;
+grant execute on function star_article(uuid) to authenticated;
+
+-- add comments
+comment on table saved_articles is 'articles starred by users, with a snapshot that outlives the article';
+comment on column saved_articles.id is 'unique identifier for the saved article';
+comment on column saved_articles.user_id is 'reference to the user who starred the article';
+comment on column saved_articles.article_id is 'reference to the starred article (null once the article is deleted)';
+comment on column saved_articles.title is 'title of the article when it was starred';
+comment on column saved_articles.url is 'url of the original article';
+comment on column saved_articles.feed_name is 'name of the feed when the article was starred';
+comment on column saved_articles.content is 'plain text content of the article when it was starred (full content if extracted)';
+comment on column saved_articles.authors is 'author names of the article (null if none)';
+comment on column saved_articles.image_url is 'lead image of the article (null if none)';
+comment on column saved_articles.published_at is 'timestamp when the article was published';
+comment on column saved_articles.saved_at is 'timestamp when the article was starred';
+
+comment on function star_article(uuid) is 'stars an article of the calling user, saving a snapshot of it';
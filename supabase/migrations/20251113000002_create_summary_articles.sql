@@ -0,0 +1,51 @@
+-- migration: create_summary_articles
+-- description: records the articles a summary was generated from, shown as its sources
+-- tables affected: summary_articles
+-- special notes: sources are listed in the summary modal, where each of them can be starred
+
+-- create the summary_articles table linking summaries to the articles they were generated from
+create table summary_articles (
+    summary_id uuid not null references summaries(id) on delete cascade,
+    article_id uuid not null references articles(id) on delete cascade,
+    primary key (summary_id, article_id)
+);
+
+-- create index on article_id for the cascade from articles (summary_id is covered by the primary key)
+create index idx_summary_articles_article_id on summary_articles(article_id);
+
+-- enable row level security
+alter table summary_articles enable row level security;
+
+-- rls policy: allow authenticated users to link their own summaries with the summarized articles
+create policy "authenticated users can view sources of their summaries"
+on summary_articles for select
+to authenticated
+using (
+    exists (
+        select 1 from summaries
+        where summaries.id = summary_articles.summary_id
+        and summaries.user_id = auth.uid()
+    )
+);
+
+create policy "authenticated users can add sources to their summaries"
+on summary_articles for insert
+to authenticated
+with check (
+    exists (
+        select 1 from summaries
+        where summaries.id = summary_articles.summary_id
+        and summaries.user_id = auth.uid()
+    )
+);
+
+-- rls policy: deny anonymous users any access to summary sources
+create policy "anonymous users cannot view summary sources"
+on summary_articles for select
+to anon
+using (false);
+
+-- add comments
+comment on table summary_articles is 'articles a summary was generated from, shown as its sources';
+comment on column summary_articles.summary_id is 'reference to the summary';
+comment on column summary_articles.article_id is 'reference to the summarized article';
@@ -0,0 +1,210 @@
+-- migration: add_articles_full_text_search
+-- description: adds full-text search over article titles and content
+-- tables affected: articles
+-- special notes: every article is indexed with the text search configuration of its feed language
+--                (feeds.site_language when the article is inserted, 'simple' for unknown languages);
+--                search_articles runs with the privileges of the caller, so rls limits it to the caller's feeds;
+--                mark_articles_read is recreated with the search of the timeline
+
+-- search_config_for_language: maps a channel language code (e.g. en-us) to a text search configuration
+-- languages without a built-in configuration are indexed with 'simple' (no stemming, no stop words)
+create or replace function search_config_for_language(p_language text)
+returns regconfig
+language sql
+immutable
+set search_path = ''
+as $$
+    select case lower(split_part(replace(coalesce(p_language, ''), '_', '-'), '-', 1))
+        when 'da' then 'pg_catalog.danish'
+        when 'de' then 'pg_catalog.german'
+        when 'en' then 'pg_catalog.english'
+        when 'es' then 'pg_catalog.spanish'
+        when 'fi' then 'pg_catalog.finnish'
+        when 'fr' then 'pg_catalog.french'
+        when 'hu' then 'pg_catalog.hungarian'
+        when 'it' then 'pg_catalog.italian'
+        when 'nb' then 'pg_catalog.norwegian'
+        when 'nl' then 'pg_catalog.dutch'
+        when 'nn' then 'pg_catalog.norwegian'
+        when 'no' then 'pg_catalog.norwegian'
+        when 'pt' then 'pg_catalog.portuguese'
+        when 'ro' then 'pg_catalog.romanian'
+        when 'ru' then 'pg_catalog.russian'
+        when 'sv' then 'pg_catalog.swedish'
+        when 'tr' then 'pg_catalog.turkish'
+        else 'pg_catalog.simple'
+    end::regconfig;
+$$;
+
+-- add the text search configuration of every article
+alter table articles
+add column search_config regconfig not null default 'pg_catalog.simple';
+
+-- backfill the configuration of existing articles from the language of their feeds
+update articles a
+set search_config = public.search_config_for_language(f.site_language)
+from feeds f
+where f.id = a.feed_id
+  and f.site_language is not null;
+
+-- add the search document: the title weighs more than the content (full content preferred over the excerpt)
+-- two-argument to_tsvector is immutable, so the configuration column can be used in a generated column
+alter table articles
+add column search_vector tsvector generated always as (
+    setweight(to_tsvector(search_config, coalesce(title, '')), 'A') ||
+    setweight(to_tsvector(search_config, coalesce(full_content, content, '')), 'B')
+) stored;
+
+-- create gin index for the full-text search
+create index idx_articles_search_vector on articles using gin(search_vector);
+
+-- set_article_search_config: sets the configuration of new articles from the language of their feed
+-- generated columns are computed after before triggers, so the search vector uses the new configuration
+create or replace function set_article_search_config()
+returns trigger
+language plpgsql
+set search_path = ''
+as $$
+begin
+    select public.search_config_for_language(f.site_language)
+    into new.search_config
+    from public.feeds f
+    where f.id = new.feed_id;
+
+    new.search_config := coalesce(new.search_config, 'pg_catalog.simple'::regconfig);
+    return new;
+end;
+$$;
+
+create trigger set_article_search_config
+before insert on articles
+for each row
+execute function set_article_search_config();
+
+-- search_articles: ranked full-text search over the articles of the calling user
+-- the query is parsed with every configuration search_config_for_language returns (websearch syntax: quotes,
+-- or, -word) and matched against the articles indexed with that configuration, so the gin index is used;
+-- every filter is optional, like mark_articles_read
+-- returns one page of ids with highlighted title and content fragments and the total number of matches
+create or replace function search_articles(
+    p_query text,
+    p_feed_id uuid default null,
+    p_tag_id uuid default null,
+    p_from timestamptz default null,
+    p_to timestamptz default null,
+    p_unread boolean default false,
+    p_limit integer default 20,
+    p_offset integer default 0
+)
+returns table (
+    id uuid,
+    rank real,
+    title_highlight text,
+    content_highlight text,
+    total_count bigint
+)
+language sql
+stable
+set search_path = ''
+as $$
+    with queries as (
+        select c.config, websearch_to_tsquery(c.config, p_query) as query
+        from unnest(array[
+            'pg_catalog.danish', 'pg_catalog.dutch', 'pg_catalog.english', 'pg_catalog.finnish',
+            'pg_catalog.french', 'pg_catalog.german', 'pg_catalog.hungarian', 'pg_catalog.italian',
+            'pg_catalog.norwegian', 'pg_catalog.portuguese', 'pg_catalog.romanian', 'pg_catalog.russian',
+            'pg_catalog.simple', 'pg_catalog.spanish', 'pg_catalog.swedish', 'pg_catalog.turkish'
+        ]::regconfig[]) as c(config)
+    ),
+    matches as (
+        select a.id, a.search_config, a.title, coalesce(a.full_content, a.content, '') as content, q.query,
+               ts_rank_cd(a.search_vector, q.query) as rank, a.published_at,
+               count(*) over () as total_count
+        from public.articles a
+        join queries q on q.config = a.search_config
+        join public.feeds f on f.id = a.feed_id
+        where f.user_id = auth.uid()
+          and a.search_vector @@ q.query
+          and (p_feed_id is null or a.feed_id = p_feed_id)
+          and (p_tag_id is null or exists (
+              select 1 from public.feed_tags ft
+              where ft.feed_id = a.feed_id
+                and ft.tag_id = p_tag_id
+          ))
+          and (p_from is null or a.published_at >= p_from)
+          and (p_to is null or a.published_at < p_to)
+          and (not p_unread or not exists (
+              select 1 from public.article_reads r
+              where r.article_id = a.id
+                and r.user_id = auth.uid()
+          ))
+        order by rank desc, a.published_at desc, a.id desc
+        limit p_limit
+        offset p_offset
+    )
+    -- highlights are computed for the returned page only, ts_headline is expensive
+    -- matches are marked with private use characters, so the application escapes the text itself
+    select m.id, m.rank,
+           ts_headline(m.search_config, m.title, m.query,
+               'HighlightAll=true, StartSel=' || chr(57344) || ', StopSel=' || chr(57345)),
+           ts_headline(m.search_config, m.content, m.query,
+               'MaxFragments=2, MaxWords=30, MinWords=10, FragmentDelimiter=" … ", StartSel=' || chr(57344) || ', StopSel=' || chr(57345)),
+           m.total_count
+    from matches m
+    order by m.rank desc, m.published_at desc, m.id desc;
+$$;
+
+revoke execute on function search_articles(text, uuid, uuid, timestamptz, timestamptz, boolean, integer, integer) from public, anon;
+grant execute on function search_articles(text, uuid, uuid, timestamptz, timestamptz, boolean, integer, integer) to authenticated;
+
+-- mark_articles_read: recreated with the search of the timeline, so marking all as read while searching
+-- only marks the found articles; the search is matched per article, the bulk update doesn't need the index
+drop function mark_articles_read(uuid, uuid, timestamptz, timestamptz);
+
+create or replace function mark_articles_read(
+    p_feed_id uuid default null,
+    p_tag_id uuid default null,
+    p_from timestamptz default null,
+    p_to timestamptz default null,
+    p_query text default null
+)
+returns integer
+language plpgsql
+set search_path = ''
+as $$
+declare
+    v_user_id uuid := auth.uid();
+    v_count integer;
+begin
+    insert into public.article_reads (user_id, article_id)
+    select v_user_id, a.id
+    from public.articles a
+    join public.feeds f on f.id = a.feed_id
+    where f.user_id = v_user_id
+      and (p_feed_id is null or a.feed_id = p_feed_id)
+      and (p_tag_id is null or exists (
+          select 1 from public.feed_tags ft
+          where ft.feed_id = a.feed_id
+            and ft.tag_id = p_tag_id
+      ))
+      and (p_from is null or a.published_at >= p_from)
+      and (p_to is null or a.published_at < p_to)
+      and (p_query is null or a.search_vector @@ websearch_to_tsquery(a.search_config, p_query))
+    on conflict do nothing;
+
+    get diagnostics v_count = row_count;
+    return v_count;
+end;
+$$;
+
+revoke execute on function mark_articles_read(uuid, uuid, timestamptz, timestamptz, text) from public, anon;
+grant execute on function mark_articles_read(uuid, uuid, timestamptz, timestamptz, text) to authenticated;
+
+-- add comments
+comment on column articles.search_config is 'text search configuration of the article, from the language of its feed';
+comment on column articles.search_vector is 'full-text search document of the title (weight a) and content (weight b)';
+
+comment on function search_config_for_language(text) is 'maps a language code to a text search configuration';
+comment on function set_article_search_config() is 'sets the text search configuration of new articles from their feed';
+comment on function search_articles(text, uuid, uuid, timestamptz, timestamptz, boolean, integer, integer) is 'ranked full-text search over the articles of the calling user';
+comment on function mark_articles_read(uuid, uuid, timestamptz, timestamptz, text) is 'marks the articles of the calling user matching the filters and search as read';
@@ -0,0 +1,285 @@
+-- migration: create_mute_rules
+-- description: adds per-user mute rules hiding matching articles from the timeline, search and summaries
+-- tables affected: mute_rules
+-- special notes: articles are matched when they are read (nothing is stored per article), so new and deleted
+--                rules apply to all articles at once; muted(articles) is a computed field, postgrest filters
+--                on it like on a column (muted=is.false); search_articles, count_unread_articles and
+--                mark_articles_read are recreated to skip muted articles, so the badges and mark all read
+--                cover the articles the timeline shows
+-- performance:   muted() runs every rule of the user against every candidate row (keyword and regex rules
+--                scan the title and content); the exact count of a timeline page evaluates it for all
+--                articles matching the filters, so the cost grows with articles x rules. it stays bounded
+--                by the few rules a user has and by article retention; users without rules pay only for
+--                an empty lookup per row. precomputing matches per article would be the next step
+
+-- is_valid_regex: checks that a pattern is a valid regular expression, invalid patterns would fail every read
+create or replace function is_valid_regex(p_pattern text)
+returns boolean
+language plpgsql
+immutable
+set search_path = ''
+as $$
+begin
+    perform '' ~ p_pattern;
+    return true;
+exception when invalid_regular_expression then
+    return false;
+end;
+$$;
+
+-- create the mute_rules table
+create table mute_rules (
+    id uuid primary key default gen_random_uuid(),
+    user_id uuid not null references auth.users(id) on delete cascade,
+    kind text not null check (kind in ('keyword', 'regex', 'author', 'category', 'domain')),
+    pattern text not null check (char_length(pattern) between 1 and 200),
+    created_at timestamptz not null default now(),
+    constraint valid_regex_pattern check (kind <> 'regex' or public.is_valid_regex(pattern))
+);
+
+-- prevent duplicate rules for the same user, patterns are matched regardless of case
+create unique index unique_user_mute_rule on mute_rules(user_id, kind, lower(pattern));
+
+-- enable row level security
+alter table mute_rules enable row level security;
+
+-- rls policy: allow authenticated users to manage only their own mute rules
+create policy "authenticated users can view their own mute rules"
+on mute_rules for select
+to authenticated
+using (auth.uid() = user_id);
+
+create policy "authenticated users can insert their own mute rules"
+on mute_rules for insert
+to authenticated
+with check (auth.uid() = user_id);
+
+create policy "authenticated users can delete their own mute rules"
+on mute_rules for delete
+to authenticated
+using (auth.uid() = user_id);
+
+-- rls policy: deny anonymous users any access to mute rules
+create policy "anonymous users cannot view mute rules"
+on mute_rules for select
+to anon
+using (false);
+
+-- mute_rule_matches: matches a single rule against the fields of an article, regardless of case
+--   keyword:  whole words or phrase in the title or content, punctuation is ignored
+--   regex:    regular expression in the title or content
+--   author:   one of the authors
+--   category: one of the categories
+--   domain:   host of the article url, or a subdomain of it
+create or replace function mute_rule_matches(
+    p_kind text,
+    p_pattern text,
+    p_title text,
+    p_content text,
+    p_authors text[],
+    p_categories text[],
+    p_url text
+)
+returns boolean
+language sql
+immutable
+set search_path = ''
+as $$
+    select case p_kind
+        when 'keyword' then strpos(
+            ' ' || regexp_replace(lower(coalesce(p_title, '') || ' ' || coalesce(p_content, '')), '[^[:alnum:]]+', ' ', 'g') || ' ',
+            ' ' || trim(regexp_replace(lower(p_pattern), '[^[:alnum:]]+', ' ', 'g')) || ' '
+        ) > 0
+        when 'regex' then (coalesce(p_title, '') || ' ' || coalesce(p_content, '')) ~* p_pattern
+        when 'author' then exists (
+            select 1 from unnest(p_authors) as author
+            where lower(author) = lower(p_pattern)
+        )
+        when 'category' then exists (
+            select 1 from unnest(p_categories) as category
+            where lower(category) = lower(p_pattern)
+        )
+        when 'domain' then (
+            select host = lower(p_pattern) or right(host, char_length(p_pattern) + 1) = '.' || lower(p_pattern)
+            from (
+                select lower(substring(p_url from '^[[:alpha:]][[:alnum:]+.-]*://(?:[^/?#@]*@)?([^/?#:]+)')) as host
+            ) as parsed
+        )
+        else false
+    end;
+$$;
+
+-- muted: computed field of articles, true when any mute rule of the calling user matches the article
+-- evaluated per row and not indexable, see the performance note above
+create or replace function muted(public.articles)
+returns boolean
+language sql
+stable
+set search_path = ''
+as $$
+    select exists (
+        select 1 from public.mute_rules r
+        where r.user_id = auth.uid()
+          and public.mute_rule_matches(r.kind, r.pattern, $1.title, $1.content, $1.authors, $1.categories, $1.url)
+    );
+$$;
+
+-- count_mute_rule_matches: counts the articles of the calling user every mute rule matches
+-- runs with the privileges of the caller, so rls limits it to the caller's rules and feeds
+create or replace function count_mute_rule_matches()
+returns table (rule_id uuid, match_count bigint)
+language sql
+stable
+set search_path = ''
+as $$
+    select r.id, count(a.id)
+    from public.mute_rules r
+    left join public.feeds f on f.user_id = r.user_id
+    left join public.articles a on a.feed_id = f.id
+        and public.mute_rule_matches(r.kind, r.pattern, a.title, a.content, a.authors, a.categories, a.url)
+    where r.user_id = auth.uid()
+    group by r.id;
+$$;
+
+revoke execute on function count_mute_rule_matches() from public, anon;
+grant execute on function count_mute_rule_matches() to authenticated;
+
+-- count_unread_articles: recreated to skip muted articles, the badges count only articles the timeline shows
+create or replace function count_unread_articles(p_feed_ids uuid[])
+returns table (feed_id uuid, unread_count bigint)
+language sql
+stable
+set search_path = ''
+as $$
+    select a.feed_id, count(*)
+    from public.articles a
+    where a.feed_id = any(p_feed_ids)
+      and not exists (
+          select 1 from public.article_reads r
+          where r.article_id = a.id
+            and r.user_id = auth.uid()
+      )
+      and not public.muted(a)
+    group by a.feed_id;
+$$;
+
+-- search_articles: recreated to skip muted articles
+create or replace function search_articles(
+    p_query text,
+    p_feed_id uuid default null,
+    p_tag_id uuid default null,
+    p_from timestamptz default null,
+    p_to timestamptz default null,
+    p_unread boolean default false,
+    p_limit integer default 20,
+    p_offset integer default 0
+)
+returns table (
+    id uuid,
+    rank real,
+    title_highlight text,
+    content_highlight text,
+    total_count bigint
+)
+language sql
+stable
+set search_path = ''
+as $$
+    with queries as (
+        select c.config, websearch_to_tsquery(c.config, p_query) as query
+        from unnest(array[
+            'pg_catalog.danish', 'pg_catalog.dutch', 'pg_catalog.english', 'pg_catalog.finnish',
+            'pg_catalog.french', 'pg_catalog.german', 'pg_catalog.hungarian', 'pg_catalog.italian',
+            'pg_catalog.norwegian', 'pg_catalog.portuguese', 'pg_catalog.romanian', 'pg_catalog.russian',
+            'pg_catalog.simple', 'pg_catalog.spanish', 'pg_catalog.swedish', 'pg_catalog.turkish'
+        ]::regconfig[]) as c(config)
+    ),
+    matches as (
+        select a.id, a.search_config, a.title, coalesce(a.full_content, a.content, '') as content, q.query,
+               ts_rank_cd(a.search_vector, q.query) as rank, a.published_at,
+               count(*) over () as total_count
+        from public.articles a
+        join queries q on q.config = a.search_config
+        join public.feeds f on f.id = a.feed_id
+        where f.user_id = auth.uid()
+          and a.search_vector @@ q.query
+          and (p_feed_id is null or a.feed_id = p_feed_id)
+          and (p_tag_id is null or exists (
+              select 1 from public.feed_tags ft
+              where ft.feed_id = a.feed_id
+                and ft.tag_id = p_tag_id
+          ))
+          and (p_from is null or a.published_at >= p_from)
+          and (p_to is null or a.published_at < p_to)
+          and (not p_unread or not exists (
+              select 1 from public.article_reads r
+              where r.article_id = a.id
+                and r.user_id = auth.uid()
+          ))
+          and not public.muted(a)
+        order by rank desc, a.published_at desc, a.id desc
+        limit p_limit
+        offset p_offset
+    )
+    -- highlights are computed for the returned page only, ts_headline is expensive
+    -- matches are marked with private use characters, so the application escapes the text itself
+    select m.id, m.rank,
+           ts_headline(m.search_config, m.title, m.query,
+               'HighlightAll=true, StartSel=' || chr(57344) || ', StopSel=' || chr(57345)),
+           ts_headline(m.search_config, m.content, m.query,
+               'MaxFragments=2, MaxWords=30, MinWords=10, FragmentDelimiter=" … ", StartSel=' || chr(57344) || ', StopSel=' || chr(57345)),
+           m.total_count
+    from matches m
+    order by m.rank desc, m.published_at desc, m.id desc;
+$$;
+
+-- mark_articles_read: recreated to skip muted articles, they are hidden from the timeline and stay unread
+create or replace function mark_articles_read(
+    p_feed_id uuid default null,
+    p_tag_id uuid default null,
+    p_from timestamptz default null,
+    p_to timestamptz default null,
+    p_query text default null
+)
+returns integer
+language plpgsql
+set search_path = ''
+as $$
+declare
+    v_user_id uuid := auth.uid();
+    v_count integer;
+begin
+    insert into public.article_reads (user_id, article_id)
+    select v_user_id, a.id
+    from public.articles a
+    join public.feeds f on f.id = a.feed_id
+    where f.user_id = v_user_id
+      and (p_feed_id is null or a.feed_id = p_feed_id)
+      and (p_tag_id is null or exists (
+          select 1 from public.feed_tags ft
+          where ft.feed_id = a.feed_id
+            and ft.tag_id = p_tag_id
+      ))
+      and (p_from is null or a.published_at >= p_from)
+      and (p_to is null or a.published_at < p_to)
+      and (p_query is null or a.search_vector @@ websearch_to_tsquery(a.search_config, p_query))
+      and not public.muted(a)
+    on conflict do nothing;
+
+    get diagnostics v_count = row_count;
+    return v_count;
+end;
+$$;
+
+-- add comments
+comment on table mute_rules is 'per-user rules hiding matching articles from the timeline, search and summaries';
+comment on column mute_rules.id is 'unique identifier for the mute rule';
+comment on column mute_rules.user_id is 'reference to the user who owns the rule';
+comment on column mute_rules.kind is 'what the rule matches: keyword, regex, author, category or domain';
+comment on column mute_rules.pattern is 'keyword, regular expression, author, category or domain to match';
+comment on column mute_rules.created_at is 'timestamp when the rule was created';
+
+comment on function is_valid_regex(text) is 'checks that a pattern is a valid regular expression';
+comment on function mute_rule_matches(text, text, text, text, text[], text[], text) is 'matches a mute rule against the fields of an article';
+comment on function muted(public.articles) is 'computed field: the article matches a mute rule of the calling user';
+comment on function count_mute_rule_matches() is 'counts the articles of the calling user every mute rule matches';
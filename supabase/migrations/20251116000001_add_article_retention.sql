@@ -0,0 +1,108 @@
+-- migration: add_article_retention
+-- description: adds per-user article retention settings and a function deleting expired articles in batches
+-- tables affected: user_settings, articles
+-- special notes: an article expires after the shorter of the server-wide retention and the retention of
+--                the feed owner (no retention keeps it forever); starred articles, sources of summaries
+--                (kept for the summary archive) and the newest articles of every feed are never deleted,
+--                the newest are still in the feed and would be fetched again as new articles; prune_articles is meant for the retention job only, so execution
+--                is restricted to the service role
+
+-- create the user_settings table, one row per user with the settings that differ from the defaults
+create table user_settings (
+    user_id uuid primary key references auth.users(id) on delete cascade,
+    article_retention_days integer null check (article_retention_days between 1 and 3650),
+    updated_at timestamptz not null default now()
+);
+
+-- keep updated_at current on every change
+create trigger set_updated_at
+    before update on user_settings
+    for each row
+    execute function update_updated_at_column();
+
+-- enable row level security
+alter table user_settings enable row level security;
+
+-- rls policy: allow authenticated users to manage only their own settings
+create policy "authenticated users can view their own settings"
+on user_settings for select
+to authenticated
+using (auth.uid() = user_id);
+
+create policy "authenticated users can insert their own settings"
+on user_settings for insert
+to authenticated
+with check (auth.uid() = user_id);
+
+create policy "authenticated users can update their own settings"
+on user_settings for update
+to authenticated
+using (auth.uid() = user_id)
+with check (auth.uid() = user_id);
+
+-- rls policy: deny anonymous users any access to settings
+create policy "anonymous users cannot view settings"
+on user_settings for select
+to anon
+using (false);
+
+-- prune_articles: deletes up to p_limit expired articles and returns how many were deleted
+-- p_max_age_days is the server-wide retention (0 leaves it to the users), p_keep_per_feed the number of
+-- newest articles kept in every feed; called repeatedly until it deletes less than p_limit, so every call
+-- is a short transaction; rows locked by a concurrent call are skipped (for update skip locked)
+create or replace function prune_articles(p_max_age_days int, p_keep_per_feed int, p_limit int)
+returns integer
+language plpgsql
+set search_path = ''
+as $$
+declare
+    v_count integer;
+begin
+    with expired as (
+        select a.id
+        from public.articles a
+        join public.feeds f on f.id = a.feed_id
+        left join public.user_settings s on s.user_id = f.user_id
+        -- least ignores nulls, without any retention the interval is null and nothing matches
+        where a.published_at < now() - make_interval(days => least(nullif(p_max_age_days, 0), s.article_retention_days))
+          and not exists (
+              select 1 from public.saved_articles sa
+              where sa.article_id = a.id
+          )
+          -- summary_articles cascades, deleting a source would remove it from archived summaries
+          and not exists (
+              select 1 from public.summary_articles sm
+              where sm.article_id = a.id
+          )
+          -- older than the p_keep_per_feed-th newest article of the feed (null for smaller feeds)
+          and a.published_at < (
+              select k.published_at
+              from public.articles k
+              where k.feed_id = a.feed_id
+              order by k.published_at desc
+              offset greatest(p_keep_per_feed, 1) - 1
+              limit 1
+          )
+        limit p_limit
+        for update of a skip locked
+    )
+    delete from public.articles a
+    using expired
+    where a.id = expired.id;
+
+    get diagnostics v_count = row_count;
+    return v_count;
+end;
+$$;
+
+-- restrict pruning to the retention job (service role)
+revoke execute on function prune_articles(int, int, int) from public, anon, authenticated;
+grant execute on function prune_articles(int, int, int) to service_role;
+
+-- add comments
+comment on table user_settings is 'per-user settings, a missing row or null column means the default';
+comment on column user_settings.user_id is 'reference to the user the settings belong to';
+comment on column user_settings.article_retention_days is 'days after publication the articles of the user are deleted (null for the server default)';
+comment on column user_settings.updated_at is 'timestamp when the settings were last changed';
+
+comment on function prune_articles(int, int, int) is 'deletes a batch of expired articles, keeping starred articles, summary sources and the newest articles of every feed';
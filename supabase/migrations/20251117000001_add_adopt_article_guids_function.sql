@@ -0,0 +1,52 @@
+-- migration: add_adopt_article_guids_function
+-- description: lets articles stored before feed item guids were tracked take over the guid of their feed item
+-- tables affected: articles
+-- special notes: add_articles_guid_and_change_tracking set guid = url on existing articles, so the first
+--                upsert of an item with a real guid would not match them and insert a duplicate;
+--                the fetcher calls adopt_article_guids before upserting, execution is restricted to the
+--                service role
+
+-- adopt_article_guids: moves the guids of feed items onto stored articles of the feed with the same url
+-- that still carry their url as guid; returns the number of adopted articles
+-- an item guid that is already stored is left alone, and every guid is adopted by one article at most,
+-- so the unique(feed_id, guid) constraint always holds
+create or replace function adopt_article_guids(p_feed_id uuid, p_urls text[], p_guids text[])
+returns integer
+language plpgsql
+set search_path = ''
+as $$
+declare
+    v_count integer;
+begin
+    with items as (
+        select distinct on (by_url.guid) by_url.url, by_url.guid
+        from (
+            select distinct on (item.url) item.url, item.guid
+            from unnest(p_urls, p_guids) as item(url, guid)
+            where item.guid <> item.url
+            order by item.url, item.guid
+        ) by_url
+        order by by_url.guid, by_url.url
+    )
+    update public.articles a
+    set guid = items.guid
+    from items
+    where a.feed_id = p_feed_id
+      and a.url = items.url
+      and a.guid = a.url
+      and not exists (
+          select 1 from public.articles known
+          where known.feed_id = p_feed_id
+            and known.guid = items.guid
+      );
+
+    get diagnostics v_count = row_count;
+    return v_count;
+end;
+$$;
+
+-- restrict the function to the feed fetcher (service role)
+revoke execute on function adopt_article_guids(uuid, text[], text[]) from public, anon, authenticated;
+grant execute on function adopt_article_guids(uuid, text[], text[]) to service_role;
+
+comment on function adopt_article_guids(uuid, text[], text[]) is 'moves feed item guids onto articles stored with their url as guid';
@@ -0,0 +1,51 @@
+-- tests of prune_articles (add_article_retention)
+begin;
+
+create extension if not exists pgtap with schema extensions;
+
+select plan(4);
+
+insert into auth.users (id, email)
+values ('00000000-0000-0000-0000-000000000001', 'retention@example.com');
+
+insert into public.feeds (id, user_id, name, url)
+values ('10000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000001', 'Feed', 'https://example.com/feed');
+
+-- three expired articles and the newest one, which is always kept
+insert into public.articles (id, feed_id, guid, title, url, published_at)
+values
+    ('20000000-0000-0000-0000-000000000001', '10000000-0000-0000-0000-000000000001', 'expired', 'Expired', 'https://example.com/expired', now() - interval '90 days'),
+    ('20000000-0000-0000-0000-000000000002', '10000000-0000-0000-0000-000000000001', 'source', 'Source', 'https://example.com/source', now() - interval '90 days'),
+    ('20000000-0000-0000-0000-000000000003', '10000000-0000-0000-0000-000000000001', 'starred', 'Starred', 'https://example.com/starred', now() - interval '90 days'),
+    ('20000000-0000-0000-0000-000000000004', '10000000-0000-0000-0000-000000000001', 'newest', 'Newest', 'https://example.com/newest', now());
+
+insert into public.summaries (id, user_id, content)
+values ('30000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000001', 'Summary');
+
+insert into public.summary_articles (summary_id, article_id)
+values ('30000000-0000-0000-0000-000000000001', '20000000-0000-0000-0000-000000000002');
+
+insert into public.saved_articles (user_id, article_id, title, url, feed_name, published_at)
+values ('00000000-0000-0000-0000-000000000001', '20000000-0000-0000-0000-000000000003', 'Starred', 'https://example.com/starred', 'Feed', now() - interval '90 days');
+
+select is(public.prune_articles(30, 1, 100), 1, 'only the expired article without references is deleted');
+
+select ok(
+    exists (select 1 from public.articles where id = '20000000-0000-0000-0000-000000000002'),
+    'a source of a summary is kept'
+);
+
+select is(
+    (select count(*)::int from public.summary_articles where summary_id = '30000000-0000-0000-0000-000000000001'),
+    1,
+    'the summary keeps its source'
+);
+
+select ok(
+    exists (select 1 from public.articles where id = '20000000-0000-0000-0000-000000000003'),
+    'a starred article is kept'
+);
+
+select * from finish();
+
+rollback;
@@ -0,0 +1,49 @@
+-- tests of the feed lease functions (add_feeds_fetch_leases)
+begin;
+
+create extension if not exists pgtap with schema extensions;
+
+select plan(5);
+
+insert into auth.users (id, email)
+values ('00000000-0000-0000-0000-000000000001', 'leases@example.com');
+
+insert into public.feeds (id, user_id, name, url)
+values ('10000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000001', 'Feed', 'https://example.com/feed');
+
+select is(
+    (select count(*)::int from public.claim_feed_for_fetch('10000000-0000-0000-0000-000000000001', 'instance-a', 300)),
+    1,
+    'a free feed is claimed'
+);
+
+select is(
+    (select count(*)::int from public.claim_feed_for_fetch('10000000-0000-0000-0000-000000000001', 'instance-a', 300)),
+    0,
+    'a second claim by the same owner returns no row while the lease is active'
+);
+
+select is(
+    (select count(*)::int from public.claim_feed_for_fetch('10000000-0000-0000-0000-000000000001', 'instance-b', 300)),
+    0,
+    'another owner cannot claim an active lease'
+);
+
+select ok(
+    public.extend_feed_lease('10000000-0000-0000-0000-000000000001', 'instance-a', 300),
+    'the owner extends its lease'
+);
+
+update public.feeds
+set lease_expires_at = now() - interval '1 second'
+where id = '10000000-0000-0000-0000-000000000001';
+
+select is(
+    (select lease_owner from public.claim_feed_for_fetch('10000000-0000-0000-0000-000000000001', 'instance-b', 300)),
+    'instance-b',
+    'an expired lease is reclaimed by another owner'
+);
+
+select * from finish();
+
+rollback;
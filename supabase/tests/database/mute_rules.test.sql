@@ -0,0 +1,53 @@
+-- tests of muted articles in the timeline, unread counts and mark all read (create_mute_rules)
+begin;
+
+create extension if not exists pgtap with schema extensions;
+
+select plan(5);
+
+insert into auth.users (id, email)
+values ('00000000-0000-0000-0000-000000000001', 'mute@example.com');
+
+insert into public.feeds (id, user_id, name, url)
+values ('10000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000001', 'Feed', 'https://example.com/feed');
+
+insert into public.articles (id, feed_id, guid, title, url, published_at)
+values
+    ('20000000-0000-0000-0000-000000000001', '10000000-0000-0000-0000-000000000001', 'kept', 'Release notes', 'https://example.com/kept', now()),
+    ('20000000-0000-0000-0000-000000000002', '10000000-0000-0000-0000-000000000001', 'muted', 'Sponsored post', 'https://example.com/muted', now());
+
+insert into public.mute_rules (user_id, kind, pattern)
+values ('00000000-0000-0000-0000-000000000001', 'keyword', 'sponsored');
+
+-- act as the user, like postgrest does
+select set_config('request.jwt.claims', '{"sub": "00000000-0000-0000-0000-000000000001", "role": "authenticated"}', true);
+set local role authenticated;
+
+select is(
+    (select count(*)::int from public.articles a where not public.muted(a)),
+    1,
+    'the timeline count (muted=is.false) excludes the muted article'
+);
+
+select is(
+    (select unread_count::int from public.count_unread_articles(array['10000000-0000-0000-0000-000000000001']::uuid[])),
+    1,
+    'the unread badge excludes the muted article'
+);
+
+select is(public.mark_articles_read(), 1, 'mark all read marks only the visible article');
+
+select ok(
+    not exists (select 1 from public.article_reads where article_id = '20000000-0000-0000-0000-000000000002'),
+    'the muted article stays unread'
+);
+
+select is(
+    (select count(*)::int from public.count_unread_articles(array['10000000-0000-0000-0000-000000000001']::uuid[])),
+    0,
+    'no unread articles are left in the badges'
+);
+
+select * from finish();
+
+rollback;